@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+)
+
+var assertCmd = &cobra.Command{
+	Use:   "assert [assertion...]",
+	Short: "Check environment invariants",
+	Long: `Evaluate assertions about the running environment and report pass/fail.
+Exits non-zero if any assertion fails, so it can gate CI smoke tests or
+close out onboarding docs.
+
+Assertions given as arguments are checked instead of the 'assertions'
+list in config.yml:
+
+  <service> running                 Released, with all pods ready
+  <service> version <op> <version>  Deployed image tag (or chart app version)
+                                    compared with >=, >, <=, <, == or !=
+  <service> returns <status>        Ingress answers with this HTTP status
+
+Examples:
+  plat assert                                  # Check the assertions in config.yml
+  plat assert "postgres running"
+  plat assert "payment-api version >= v2" "frontend returns 200"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		sources := args
+		if len(sources) == 0 {
+			sources = runtime.Base.Assertions
+		}
+		if len(sources) == 0 {
+			return fmt.Errorf("no assertions given. Pass them as arguments or list them under 'assertions' in config.yml")
+		}
+
+		var assertions []orchestrator.Assertion
+		for _, source := range sources {
+			assertion, err := orchestrator.ParseAssertion(source)
+			if err != nil {
+				return err
+			}
+			assertions = append(assertions, assertion)
+		}
+
+		// Failed assertions are a result, not a usage mistake
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		results := orch.Assert(ctx, runtime, assertions)
+
+		failed := 0
+		for _, result := range results {
+			if result.Passed {
+				fmt.Printf("✅ %s (%s)\n", result.Assertion, result.Detail)
+			} else {
+				failed++
+				fmt.Printf("❌ %s (%s)\n", result.Assertion, result.Detail)
+			}
+		}
+
+		fmt.Printf("\n%d passed, %d failed\n", len(results)-failed, failed)
+		if failed > 0 {
+			return fmt.Errorf("%d of %d assertions failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(assertCmd)
+
+	assertCmd.Flags().Duration("timeout", time.Minute, "Maximum time to spend checking")
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/settings"
+	"plat/pkg/state"
+)
+
+// bannerMaxAge is how old the cached cluster state may get before the banner
+// starts a background refresh, matching plat prompt's default
+const bannerMaxAge = 30 * time.Second
+
+// bannerPrinted keeps the banner to one line per invocation; the TUI reloads
+// the configuration through loadConfiguration
+var bannerPrinted bool
+
+// printBanner writes a one-line summary of the environment a command acts on
+// to stderr when the banner setting is on, so it never mixes with output
+// meant for pipes
+func printBanner(runtime *config.RuntimeConfig, userSettings *settings.Settings) {
+	if bannerPrinted {
+		return
+	}
+	if enabled, _ := userSettings.Bool(settings.KeyBanner); !enabled {
+		return
+	}
+	bannerPrinted = true
+	fmt.Fprintln(os.Stderr, formatBanner(runtime))
+}
+
+// formatBanner renders the environment name, mode, cluster state, service
+// counts and a hint when the config drifted from the lock file
+func formatBanner(runtime *config.RuntimeConfig) string {
+	parts := []string{runtime.Base.Name, fmt.Sprintf("%s mode", runtime.Mode)}
+	if runtime.Profile != "" {
+		parts = append(parts, "profile "+runtime.Profile)
+	}
+	parts = append(parts, bannerClusterState(runtime))
+
+	local := 0
+	for _, service := range runtime.ResolvedServices {
+		if service.IsLocal {
+			local++
+		}
+	}
+	parts = append(parts, fmt.Sprintf("%d services, %d local", len(runtime.ResolvedServices), local))
+
+	if lock, err := config.ReadLock(config.LockPath(runtime)); err == nil {
+		if _, err := lock.Apply(runtime); err != nil {
+			parts = append(parts, "⚠ drifted from "+config.LockFileName)
+		}
+	}
+
+	return "▸ " + strings.Join(parts, " · ")
+}
+
+// bannerClusterState describes the cluster from the plat prompt cache, which
+// is refreshed in the background when stale so the banner never blocks
+func bannerClusterState(runtime *config.RuntimeConfig) string {
+	status, err := state.ReadPromptStatus(runtime.ConfigDir())
+	if err != nil || status.Age() > bannerMaxAge {
+		startPromptRefresh(runtime.ConfigFile)
+	}
+	if err != nil {
+		return "cluster unknown"
+	}
+
+	age := status.Age().Round(time.Second)
+	if !status.ClusterUp {
+		return fmt.Sprintf("cluster down (checked %s ago)", age)
+	}
+	return fmt.Sprintf("cluster up, %d/%d healthy (checked %s ago)", status.Healthy, status.Total, age)
+}
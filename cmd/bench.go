@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure environment cold-start time",
+	Long: `Run repeated cold up/down cycles against a scratch cluster and report
+per-phase and per-service timings.
+
+The scratch cluster is named after the environment with a "-bench" suffix and
+is deleted after every cycle. It maps no host ports and has a kubeconfig of
+its own, so your running environment is not touched. Save a
+report with --output and pass it to --compare after a change to see the
+difference.
+
+Examples:
+  plat bench                                  # Three cycles
+  plat bench --cycles 5 --output before.json  # Save a baseline
+  plat bench --compare before.json            # Compare against the baseline`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cycles, _ := cmd.Flags().GetInt("cycles")
+		output, _ := cmd.Flags().GetString("output")
+		comparePath, _ := cmd.Flags().GetString("compare")
+
+		if cycles < 1 {
+			return fmt.Errorf("--cycles must be at least 1")
+		}
+
+		var baseline *orchestrator.BenchReport
+		if comparePath != "" {
+			var err error
+			baseline, err = orchestrator.ReadBenchReport(comparePath)
+			if err != nil {
+				return fmt.Errorf("failed to read baseline: %w", err)
+			}
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cycles)*20*time.Minute)
+		defer cancel()
+
+		fmt.Printf("⏱️  Benchmarking %s: %d cold cycle(s)...\n", runtime.Base.Name, cycles)
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		report, err := orch.Bench(ctx, runtime, cycles)
+		if err != nil {
+			return fmt.Errorf("benchmark failed: %w", err)
+		}
+
+		printBenchReport(report, baseline)
+
+		if output != "" {
+			if err := report.WriteFile(output); err != nil {
+				return fmt.Errorf("failed to save report: %w", err)
+			}
+			fmt.Printf("\n💾 Report saved to %s\n", output)
+		}
+
+		return nil
+	},
+}
+
+// printBenchReport prints phase and service timings, with deltas against a baseline
+func printBenchReport(report, baseline *orchestrator.BenchReport) {
+	phaseStats := report.PhaseStats()
+	var basePhases map[string]orchestrator.BenchStats
+	if baseline != nil {
+		basePhases = baseline.PhaseStats()
+	}
+
+	fmt.Printf("\n%-20s %10s %10s %10s", "PHASE", "MEAN", "MIN", "MAX")
+	if baseline != nil {
+		fmt.Printf(" %10s %8s", "BASELINE", "CHANGE")
+	}
+	fmt.Println()
+
+	for _, phase := range append(orchestrator.BenchPhases, "total") {
+		printBenchRow(phase, phaseStats[phase], basePhases, baseline != nil)
+	}
+
+	serviceStats := report.ServiceStats()
+	if len(serviceStats) == 0 {
+		return
+	}
+
+	var baseServices map[string]orchestrator.BenchStats
+	if baseline != nil {
+		baseServices = baseline.ServiceStats()
+	}
+
+	fmt.Printf("\n%-20s %10s %10s %10s", "SERVICE (slowest)", "MEAN", "MIN", "MAX")
+	if baseline != nil {
+		fmt.Printf(" %10s %8s", "BASELINE", "CHANGE")
+	}
+	fmt.Println()
+
+	for _, name := range report.SlowestServices() {
+		printBenchRow(name, serviceStats[name], baseServices, baseline != nil)
+	}
+}
+
+func printBenchRow(name string, stats orchestrator.BenchStats, baseline map[string]orchestrator.BenchStats, compare bool) {
+	fmt.Printf("%-20s %10s %10s %10s", name, formatBenchDuration(stats.Mean), formatBenchDuration(stats.Min), formatBenchDuration(stats.Max))
+
+	if compare {
+		base, ok := baseline[name]
+		if !ok || base.Mean == 0 {
+			fmt.Printf(" %10s %8s", "-", "-")
+		} else {
+			change := float64(stats.Mean-base.Mean) / float64(base.Mean) * 100
+			fmt.Printf(" %10s %+7.1f%%", formatBenchDuration(base.Mean), change)
+		}
+	}
+	fmt.Println()
+}
+
+func formatBenchDuration(d time.Duration) string {
+	return d.Round(100 * time.Millisecond).String()
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	benchCmd.Flags().Int("cycles", 3, "Number of cold up/down cycles")
+	benchCmd.Flags().StringP("output", "o", "", "Save the report as JSON")
+	benchCmd.Flags().String("compare", "", "Baseline report to compare against")
+}
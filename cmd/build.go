@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"plat/pkg/build"
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build [service...]",
+	Short: "Build images for services with a local source",
+	Long: `Build the images of services declared in .plat/local.yml without deploying.
+
+Images are built the same way 'plat up' builds them in local mode: from the
+source's Dockerfile and context, tagged with their content ID (dev-<id>).
+By default nothing leaves the local docker daemon; --import loads the images
+into the environment's cluster and --push publishes them to the
+configured registry.
+
+Examples:
+  plat build                         # Build every local service
+  plat build user-api                # Build one service
+  plat build --no-cache              # Rebuild without the layer cache
+  plat build --platform linux/amd64  # Build for another platform
+  plat build --import                # Make the images available to the cluster
+  plat build --push                  # Push to defaults.registry`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		platform, _ := cmd.Flags().GetString("platform")
+		importImages, _ := cmd.Flags().GetBool("import")
+		push, _ := cmd.Flags().GetBool("push")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		services, err := selectLocalServices(runtime, args)
+		if err != nil {
+			return err
+		}
+		if push && runtime.Base.Defaults.Registry == "" {
+			return fmt.Errorf("--push needs defaults.registry to be set in the config")
+		}
+
+		started := time.Now()
+		defer func() { notifyCompletion(runtime, "build", started, err) }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+		defer cancel()
+
+		engine := orchestrator.ContainerRuntime(runtime)
+		builder := build.NewBuilder(engine, verbose)
+		builder.SetNoCache(noCache)
+		builder.SetPlatform(platform)
+
+		clusters := tools.NewClusterProvider(runtime.Base.Defaults.ClusterProvider, engine)
+		cluster := orchestrator.ClusterName(runtime)
+		timings := make(map[string]time.Duration)
+		var failed []string
+
+		for _, service := range services {
+			fmt.Printf("🔨 Building %s...\n", service.Name)
+
+			result, buildErr := builder.Build(ctx, service)
+			if buildErr == nil && importImages {
+				_, buildErr = builder.Import(ctx, clusters, cluster, result.Image)
+			}
+			if buildErr == nil && push {
+				buildErr = builder.Push(ctx, result.Image, fmt.Sprintf("%s:%s", runtime.ImageRepository(service), result.Tag))
+			}
+			if buildErr != nil {
+				printError(fmt.Sprintf("%s: %v", service.Name, buildErr))
+				failed = append(failed, service.Name)
+				continue
+			}
+
+			timings[service.Name] = result.Duration
+			printSuccess(fmt.Sprintf("%s built as %s in %s", service.Name, result.Image, result.Duration.Round(100*time.Millisecond)))
+		}
+
+		if len(services) > 1 {
+			fmt.Printf("\n⏱️  Build times\n")
+			for _, service := range services {
+				if duration, ok := timings[service.Name]; ok {
+					fmt.Printf("   %-24s %s\n", service.Name, duration.Round(100*time.Millisecond))
+				} else {
+					fmt.Printf("   %-24s failed\n", service.Name)
+				}
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to build %s", strings.Join(failed, ", "))
+		}
+		return nil
+	},
+}
+
+// selectLocalServices returns the local services named in args, or all of
+// them when args is empty
+func selectLocalServices(runtime *config.RuntimeConfig, args []string) ([]*config.ResolvedService, error) {
+	local := runtime.LocalServices()
+	if len(local) == 0 {
+		return nil, fmt.Errorf("no local sources declared in .plat/local.yml")
+	}
+	if len(args) == 0 {
+		return local, nil
+	}
+
+	byName := make(map[string]*config.ResolvedService, len(local))
+	var names []string
+	for _, service := range local {
+		byName[service.Name] = service
+		names = append(names, service.Name)
+	}
+
+	requested, err := runtime.ResolveServiceNames(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*config.ResolvedService
+	for _, name := range requested {
+		service, ok := byName[name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Services with a local source: %s\n", strings.Join(names, ", "))
+			return nil, fmt.Errorf("service %q has no local source", name)
+		}
+		selected = append(selected, service)
+	}
+	return selected, nil
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+
+	buildCmd.Flags().Bool("no-cache", false, "Build without docker's layer cache")
+	buildCmd.Flags().String("platform", "", "Target platform for the build, e.g. linux/amd64")
+	buildCmd.Flags().Bool("import", false, "Import the built images into the environment's cluster")
+	buildCmd.Flags().Bool("push", false, "Push the built images to defaults.registry")
+}
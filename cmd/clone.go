@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <new-name>",
+	Short: "Duplicate the current environment under a new name",
+	Long: `Create a copy of the current environment with its own name, cluster and
+optionally namespace, so you can experiment without touching a stable setup.
+
+The clone gets the same services, values files, local sources and locked
+versions. With --copy-data the clone is started and PostgreSQL databases are
+copied from the running source environment. Its cluster then maps no host
+ports, which the source holds; reach its services with 'plat forward start'.
+
+Examples:
+  plat clone scratch                       # Write the clone to .plat-scratch/
+  plat clone scratch --namespace scratch   # Use a different namespace
+  plat clone scratch --copy-data           # Start the clone with a copy of the data
+  plat -c .plat-scratch/config.yml up      # Start a clone later`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		newName := args[0]
+		dir, _ := cmd.Flags().GetString("dir")
+		namespace, _ := cmd.Flags().GetString("namespace")
+		copyData, _ := cmd.Flags().GetBool("copy-data")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if dir == "" {
+			dir = ".plat-" + newName
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		if newName == runtime.Base.Name {
+			return fmt.Errorf("clone name must differ from the current environment name %q", newName)
+		}
+
+		pkg, err := config.BuildPackage(runtime)
+		if err != nil {
+			return fmt.Errorf("failed to read environment: %w", err)
+		}
+		if err := pkg.Rename(newName, namespace); err != nil {
+			return err
+		}
+		if err := pkg.Extract(dir, force); err != nil {
+			return fmt.Errorf("failed to write clone: %w", err)
+		}
+		if err := config.CopyEnvironmentFiles(runtime.ConfigDir(), dir); err != nil {
+			return fmt.Errorf("failed to write clone: %w", err)
+		}
+
+		cloneConfig := filepath.Join(dir, "config.yml")
+		fmt.Printf("🧬 Cloned '%s' to '%s' in %s\n", runtime.Base.Name, newName, dir)
+
+		if !copyData {
+			fmt.Printf("\nRun 'plat -c %s up' to start the clone\n", cloneConfig)
+			return nil
+		}
+
+		cloneRuntime, err := config.NewLoader(cloneConfig, runtime.Mode).Load()
+		if err != nil {
+			return fmt.Errorf("failed to load clone: %w", err)
+		}
+		// The running source holds ports 80 and 443 and the service ports
+		cloneRuntime.NoHostPorts = true
+
+		// The clone's cluster credentials go to its own kubeconfig
+		if err := tools.UseKubeconfig(cloneRuntime.KubeconfigPath()); err != nil {
+			return err
+		}
+		defer tools.UseKubeconfig(runtime.KubeconfigPath())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
+		defer cancel()
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		defer orch.WaitNotifications()
+		fmt.Printf("🚀 Starting %s...\n", newName)
+		if err := orch.Up(ctx, cloneRuntime); err != nil {
+			return fmt.Errorf("failed to start clone: %w", err)
+		}
+
+		skipped, err := orch.CopyData(ctx, runtime, cloneRuntime)
+		if err != nil {
+			return fmt.Errorf("failed to copy data: %w", err)
+		}
+		if len(skipped) > 0 {
+			printWarning(fmt.Sprintf("Data copy is only supported for PostgreSQL; skipped %v", skipped))
+		}
+
+		fmt.Printf("✅ Clone %s is running with a copy of the data\n", newName)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+
+	cloneCmd.Flags().String("dir", "", "Directory for the cloned configuration (default .plat-<new-name>)")
+	cloneCmd.Flags().String("namespace", "", "Namespace for the clone (default keeps the current namespace)")
+	cloneCmd.Flags().Bool("copy-data", false, "Start the clone and copy PostgreSQL data into it")
+	cloneCmd.Flags().BoolP("force", "f", false, "Overwrite an existing clone directory")
+}
@@ -1,55 +1,267 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"golang.org/x/term"
 	"plat/pkg/config"
+	"plat/pkg/notify"
+	"plat/pkg/orchestrator"
+	"plat/pkg/settings"
+	"plat/pkg/state"
+	"plat/pkg/tools"
 )
 
+// envStore is the state store of the environment loadConfiguration loaded,
+// installed as the process tracker. Commands record their state through it
+// so its lock covers every write.
+var envStore *state.Store
+
 // loadConfiguration loads and validates the configuration with CLI overrides
 func loadConfiguration() (*config.RuntimeConfig, error) {
+	// Flags take precedence over environment variables and user settings
+	userSettings := loadSettings()
+	modeName := mode
+	if modeName == "" {
+		modeName, _, _ = userSettings.Resolve(settings.KeyMode)
+	}
+	strictValidation := strict
+	if !strictSet {
+		strictValidation, _ = userSettings.Bool(settings.KeyStrict)
+	}
+
 	// Determine execution mode
 	execMode := config.ModeArtifact // Default mode
-	if mode != "" {
-		switch mode {
+	if modeName != "" {
+		switch modeName {
 		case "local":
 			execMode = config.ModeLocal
 		case "artifact":
 			execMode = config.ModeArtifact
 		default:
-			return nil, fmt.Errorf("invalid mode %q, must be 'local' or 'artifact'", mode)
+			return nil, withExitCode(ExitConfig, fmt.Errorf("invalid mode %q, must be 'local' or 'artifact'", modeName))
 		}
 	}
 
 	// Create loader with validation options
 	var loader *config.Loader
-	if strict {
+	if strictValidation {
 		loader = config.NewLoaderWithValidation(configPath, execMode, true)
 	} else {
 		loader = config.NewLoader(configPath, execMode)
 	}
+	loader.SetProfile(profile)
 
 	// Load configuration
 	runtime, err := loader.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load configuration: %w", err)
+		return nil, withExitCode(ExitConfig, fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	// Apply the --helm-driver override before the config is shared
+	if helmDriver != "" {
+		if helmDriver != tools.HelmDriverCLI && helmDriver != tools.HelmDriverSDK {
+			return nil, withExitCode(ExitConfig, fmt.Errorf("invalid helm driver %q, must be '%s' or '%s'", helmDriver, tools.HelmDriverCLI, tools.HelmDriverSDK))
+		}
+		base := *runtime.Base
+		defaults := *base.Defaults
+		defaults.HelmDriver = helmDriver
+		base.Defaults = &defaults
+		runtime.Base = &base
+	}
+
+	// Likewise --runtime
+	if containerRuntime != "" {
+		switch containerRuntime {
+		case tools.ContainerRuntimeDocker, tools.ContainerRuntimePodman, tools.ContainerRuntimeNerdctl:
+		default:
+			return nil, withExitCode(ExitConfig, fmt.Errorf("invalid container runtime %q, must be '%s', '%s' or '%s'", containerRuntime,
+				tools.ContainerRuntimeDocker, tools.ContainerRuntimePodman, tools.ContainerRuntimeNerdctl))
+		}
+		base := *runtime.Base
+		defaults := *base.Defaults
+		defaults.ContainerRuntime = containerRuntime
+		base.Defaults = &defaults
+		runtime.Base = &base
+	}
+
+	runtime.AllowAnyCluster = allowAnyCluster
+
+	// User settings take precedence over the config's domain
+	if domain, _, ok := userSettings.Resolve(settings.KeyDomain); ok {
+		base := *runtime.Base
+		defaults := *base.Defaults
+		defaults.Domain = domain
+		base.Defaults = &defaults
+		runtime.Base = &base
 	}
 
 	if verbose {
-		fmt.Printf("Loaded %d services in %s mode\n", len(runtime.ResolvedServices), execMode)
-		for name, service := range runtime.ResolvedServices {
+		if runtime.Profile != "" {
+			fmt.Printf("Loaded %d services in %s mode (profile %s)\n", len(runtime.ResolvedServices), execMode, runtime.Profile)
+		} else {
+			fmt.Printf("Loaded %d services in %s mode\n", len(runtime.ResolvedServices), execMode)
+		}
+		for _, service := range runtime.OrderedServices() {
 			if service.IsLocal {
-				fmt.Printf("  • %s (local: %s)\n", name, service.LocalSource.GetPath())
+				fmt.Printf("  • %s (local: %s)\n", service.Name, service.LocalSource.GetPath())
 			} else {
-				fmt.Printf("  • %s (%s)\n", name, service.Version)
+				fmt.Printf("  • %s (%s)\n", service.Name, service.Version)
 			}
 		}
 	}
 
+	tools.SetTimeouts(toolTimeouts(runtime.Base.Defaults.Timeouts))
+
+	// Keep the environment's cluster credentials out of ~/.kube/config. Set
+	// before the banner, whose status refresh queries the cluster.
+	if err := tools.UseKubeconfig(runtime.KubeconfigPath()); err != nil {
+		return nil, err
+	}
+
+	printBanner(runtime, userSettings)
+
+	// Track spawned processes so a crashed session's leftovers can be found
+	store := state.NewStore(runtime.ConfigDir())
+	envStore = store
+	tools.SetProcessTracker(store)
+	warnOrphanedProcesses(store)
+	warnAbortedDeploy(store)
+	runtime = withDebugServices(runtime, store)
+	runtime.NetworkPoliciesOff, _ = store.NetworkPoliciesOff()
+
 	return runtime, nil
 }
 
+// loadSettings reads the user's settings file. An unreadable file is
+// reported and treated as empty, so a broken file never blocks a command.
+func loadSettings() *settings.Settings {
+	userSettings, err := settings.Load()
+	if err != nil {
+		printWarning(fmt.Sprintf("Ignoring user settings: %v", err))
+		userSettings = &settings.Settings{}
+	}
+	return userSettings
+}
+
+// selectedContainerRuntime returns the container runtime for commands that
+// also work outside a project: the configured one with --runtime applied,
+// or without a config --runtime or the detected runtime
+func selectedContainerRuntime() tools.ContainerRuntime {
+	if runtime, err := loadConfiguration(); err == nil {
+		return orchestrator.ContainerRuntime(runtime)
+	}
+	return tools.NewContainerRuntime(containerRuntime, "")
+}
+
+// toolTimeouts converts defaults.timeouts, which validation already
+// checked, to the timeouts tools applies. Unset ones keep their defaults.
+func toolTimeouts(configured *config.ToolTimeouts) tools.Timeouts {
+	var timeouts tools.Timeouts
+	if configured == nil {
+		return timeouts
+	}
+	timeouts.Query, _ = time.ParseDuration(configured.Query)
+	timeouts.Mutate, _ = time.ParseDuration(configured.Mutate)
+	timeouts.Install, _ = time.ParseDuration(configured.Install)
+	timeouts.KillGrace, _ = time.ParseDuration(configured.KillGrace)
+	return timeouts
+}
+
+// withDebugServices returns the config with debug logging on for the
+// services switched to it with 'plat debug enable'
+func withDebugServices(runtime *config.RuntimeConfig, store *state.Store) *config.RuntimeConfig {
+	names, err := store.DebugServices()
+	if err != nil || len(names) == 0 {
+		return runtime
+	}
+	return withDebug(runtime, names, true)
+}
+
+// withDebug returns a copy of the config with debug logging of the named
+// services switched on or off
+func withDebug(runtime *config.RuntimeConfig, names []string, on bool) *config.RuntimeConfig {
+	changed := runtime.Clone()
+	for _, name := range names {
+		if service, ok := changed.ResolvedServices[name]; ok {
+			service.Debug = on
+		}
+	}
+	return changed
+}
+
+// warnOrphanedProcesses reports processes left behind by a previous plat run
+func warnOrphanedProcesses(store *state.Store) {
+	orphans, err := store.FindOrphans()
+	if err != nil || len(orphans) == 0 {
+		return
+	}
+
+	printWarning(fmt.Sprintf("Found %d process(es) left over from a previous plat session:", len(orphans)))
+	for _, p := range orphans {
+		fmt.Printf("   • PID %d: %s\n", p.PID, p.Command)
+	}
+	fmt.Println("   Run 'plat doctor --fix' to stop them")
+}
+
+// warnAbortedDeploy reports what a 'plat up' that died midway was doing
+func warnAbortedDeploy(store *state.Store) {
+	aborted, err := store.AbortedDeploy()
+	if err != nil || aborted == nil {
+		return
+	}
+
+	printWarning(fmt.Sprintf("The 'plat up' started %s ago (PID %d) aborted before finishing:",
+		time.Since(aborted.StartedAt).Round(time.Second), aborted.PID))
+	for _, name := range aborted.InPhase(string(orchestrator.PhaseDeploying)) {
+		fmt.Printf("   • %s was mid-deploy\n", name)
+	}
+	if pending := aborted.InPhase(state.PhasePending); len(pending) > 0 {
+		fmt.Printf("   • Not started: %s\n", strings.Join(pending, ", "))
+	}
+	fmt.Println("   Run 'plat up' to finish; it clears Helm operations left pending")
+}
+
+// notifyCompletion rings the bell and/or shows a desktop notification when a
+// long operation finishes, as configured under 'notify' in local.yml
+func notifyCompletion(runtime *config.RuntimeConfig, operation string, started time.Time, opErr error) {
+	if runtime == nil || runtime.Local == nil || runtime.Local.Notify == nil {
+		return
+	}
+
+	settings := runtime.Local.Notify
+	elapsed := time.Since(started)
+	if elapsed < settings.Threshold() {
+		return
+	}
+
+	if settings.Bell {
+		notify.Bell(os.Stderr)
+	}
+
+	if settings.Desktop {
+		title := fmt.Sprintf("plat %s finished", operation)
+		message := fmt.Sprintf("%s is ready (%s)", runtime.Base.Name, elapsed.Round(time.Second))
+		if opErr != nil {
+			title = fmt.Sprintf("plat %s failed", operation)
+			message = fmt.Sprintf("%s: %v", runtime.Base.Name, opErr)
+		} else if operation != "up" {
+			message = fmt.Sprintf("%s completed in %s", runtime.Base.Name, elapsed.Round(time.Second))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := notify.Desktop(ctx, title, message); err != nil && verbose {
+			printWarning(fmt.Sprintf("Desktop notification failed: %v", err))
+		}
+	}
+}
+
 // confirmAction prompts for confirmation if not in CI/automated mode
 func confirmAction(message string) bool {
 	if os.Getenv("CI") != "" || os.Getenv("PLAT_AUTO_CONFIRM") != "" {
@@ -80,6 +292,56 @@ func printError(message string) {
 	fmt.Printf("❌ %s\n", message)
 }
 
+// maxListedServiceErrors bounds the failed services printServiceErrors lists
+// without --verbose
+const maxListedServiceErrors = 10
+
+// printServiceErrors lists the failures of a multi-service operation on
+// stderr, grouped by category, one line each; --verbose lists every service
+// with its full error
+func printServiceErrors(err error) {
+	var failures *orchestrator.ServiceErrors
+	if !errors.As(err, &failures) {
+		return
+	}
+
+	color := term.IsTerminal(int(os.Stderr.Fd()))
+	paint := func(code, text string) string {
+		if !color {
+			return text
+		}
+		return fmt.Sprintf("\033[%sm%s\033[0m", code, text)
+	}
+
+	listed := 0
+	for _, group := range failures.Groups() {
+		if !verbose && listed >= maxListedServiceErrors {
+			break
+		}
+		fmt.Fprintln(os.Stderr, paint("1;33", fmt.Sprintf("  %s (%d)", group.Category, len(group.Errors))))
+
+		for _, failure := range group.Errors {
+			if !verbose && listed >= maxListedServiceErrors {
+				break
+			}
+			listed++
+
+			lines := strings.Split(strings.TrimSpace(failure.Err.Error()), "\n")
+			if !verbose {
+				lines = lines[:1]
+			}
+			fmt.Fprintf(os.Stderr, "    %s %s: %s\n", paint("31", "✗"), paint("1", failure.Service), lines[0])
+			for _, line := range lines[1:] {
+				fmt.Fprintf(os.Stderr, "      %s\n", line)
+			}
+		}
+	}
+
+	if hidden := len(failures.Errors) - listed; hidden > 0 {
+		fmt.Fprintf(os.Stderr, "    … and %d more (--verbose lists all)\n", hidden)
+	}
+}
+
 // printInfo prints an info message with formatting
 func printInfo(message string) {
 	if verbose {
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/settings"
 )
 
 var configCmd = &cobra.Command{
@@ -27,28 +30,46 @@ var configShowCmd = &cobra.Command{
 - Resolved service sources and versions
 - Local vs artifact execution mode
 - Applied MSC defaults
-- Service dependencies and ports`,
+- Service dependencies and ports
+
+With --explain each Helm service's resolved values are listed with the layer
+that set them: chart defaults, service values, values file, local override or
+runtime override.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		explain, _ := cmd.Flags().GetBool("explain")
+
 		runtime, err := loadConfiguration()
 		if err != nil {
 			return err
 		}
+		valuesManager := config.NewValuesManager(runtime.ConfigDir())
 
 		fmt.Printf("📋 Environment Configuration\n")
 		fmt.Printf("==========================\n\n")
 
 		fmt.Printf("Name: %s\n", runtime.Base.Name)
 		fmt.Printf("Mode: %s\n", runtime.Mode)
+		if runtime.Profile != "" {
+			fmt.Printf("Profile: %s\n", runtime.Profile)
+		}
 		fmt.Printf("Registry: %s\n", runtime.Base.Defaults.Registry)
 		fmt.Printf("Domain: %s\n", runtime.Base.Defaults.Domain)
 		fmt.Printf("Namespace: %s\n", runtime.Base.Defaults.Namespace)
+		fmt.Printf("Helm driver: %s\n", runtime.Base.Defaults.HelmDriver)
+		fmt.Printf("Container runtime: %s\n", orchestrator.ContainerRuntime(runtime).Name())
 		fmt.Printf("Services: %d\n", len(runtime.ResolvedServices))
 
 		fmt.Printf("\n🔧 Service Configuration\n")
 		fmt.Printf("========================\n")
 
-		for name, service := range runtime.ResolvedServices {
-			fmt.Printf("\n%s:\n", name)
+		for _, service := range runtime.OrderedServices() {
+			fmt.Printf("\n%s:\n", service.Name)
+			if service.DisplayName != "" {
+				fmt.Printf("  Display name: %s\n", service.DisplayName)
+			}
+			if len(service.Aliases) > 0 {
+				fmt.Printf("  Aliases: %s\n", strings.Join(service.Aliases, ", "))
+			}
 			if service.IsLocal {
 				fmt.Printf("  Source: Local (%s)\n", service.LocalSource.GetPath())
 				fmt.Printf("  Build: %s\n", service.LocalSource.GetDockerfile())
@@ -65,6 +86,14 @@ var configShowCmd = &cobra.Command{
 				fmt.Printf("\n")
 			}
 
+			if service.Manifests != "" {
+				fmt.Printf("  Manifests: %s\n", service.Manifests)
+			}
+
+			if len(service.Patches) > 0 {
+				fmt.Printf("  Patches: %d\n", len(service.Patches))
+			}
+
 			if len(service.Ports) > 0 {
 				fmt.Printf("  Ports: %v\n", service.Ports)
 			}
@@ -75,6 +104,20 @@ var configShowCmd = &cobra.Command{
 
 			if len(service.Dependencies) > 0 {
 				fmt.Printf("  Dependencies: %v\n", service.Dependencies)
+				if len(service.Inferred) > 0 {
+					fmt.Printf("  Inferred from environment: %v\n", service.Inferred)
+				}
+			}
+
+			if explain && service.Engine() == config.EngineHelm {
+				provenance, err := valuesManager.GetValueProvenance(service, runtime)
+				if err != nil {
+					return fmt.Errorf("failed to resolve values of %s: %w", service.Name, err)
+				}
+				fmt.Printf("  Values:\n")
+				for _, value := range provenance {
+					fmt.Printf("    %s: %v  (%s)\n", value.Key, value.FormatValue(), value.Source)
+				}
 			}
 		}
 
@@ -127,7 +170,11 @@ Performs comprehensive validation including:
 			fmt.Printf("  Mode: %s\n", runtime.Mode)
 		} else {
 			fmt.Printf("⚠️  Found validation issues:\n")
-			for serviceName, issues := range report {
+			for _, serviceName := range runtime.ListServices() {
+				issues, ok := report[serviceName]
+				if !ok {
+					continue
+				}
 				fmt.Printf("\n%s:\n", serviceName)
 				for _, issue := range issues {
 					fmt.Printf("  • %s\n", issue)
@@ -139,24 +186,125 @@ Performs comprehensive validation including:
 	},
 }
 
+// settingsHelp describes the settings and their precedence for the
+// config set/get/unset/list help
+var settingsHelp = func() string {
+	var help strings.Builder
+	help.WriteString("Settings are stored in ~/.config/plat/settings.yml and apply to every\n")
+	help.WriteString("project. Flags take precedence over environment variables, which take\n")
+	help.WriteString("precedence over settings, which take precedence over the project config.\n\n")
+	help.WriteString("Available settings:\n")
+	for _, key := range settings.Keys {
+		fmt.Fprintf(&help, "  %-10s %s [%s]\n", key.Name, key.Description, key.Env)
+	}
+	return strings.TrimSuffix(help.String(), "\n")
+}()
+
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
-	Short: "Set configuration values",
-	Long: `Set configuration values for persistent CLI settings.
+	Short: "Set a persistent CLI setting",
+	Long: `Set a persistent CLI setting.
+
+` + settingsHelp + `
 
-Available settings:
-  mode     - Default execution mode (local|artifact)
-  domain   - Default domain for ingress (overrides config)
-  strict   - Enable strict validation (true|false)`,
+Examples:
+  plat config set mode local
+  plat config set domain dev.local`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		key := args[0]
-		value := args[1]
+		userSettings, err := settings.Load()
+		if err != nil {
+			return err
+		}
+		if err := userSettings.Set(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := userSettings.Save(); err != nil {
+			return err
+		}
+
+		value, _ := userSettings.Get(args[0])
+		fmt.Printf("✅ Set %s = %s\n", args[0], value)
+		key, _ := settings.Lookup(args[0])
+		if _, source, _ := userSettings.Resolve(args[0]); source == settings.SourceEnv {
+			printWarning(fmt.Sprintf("$%s is set and takes precedence", key.Env))
+		}
+		return nil
+	},
+}
 
-		// TODO: Implement persistent config storage
-		fmt.Printf("Setting %s = %s\n", key, value)
-		fmt.Println("(Persistent configuration storage not yet implemented)")
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a CLI setting",
+	Long: `Print the effective value of a CLI setting, from its environment variable
+or the settings file. Prints nothing when the setting is unset.
 
+` + settingsHelp,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, ok := settings.Lookup(args[0]); !ok {
+			return &settings.UnknownKeyError{Name: args[0]}
+		}
+		userSettings, err := settings.Load()
+		if err != nil {
+			return err
+		}
+		if value, _, ok := userSettings.Resolve(args[0]); ok {
+			fmt.Println(value)
+		}
+		return nil
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a persistent CLI setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		userSettings, err := settings.Load()
+		if err != nil {
+			return err
+		}
+		removed, err := userSettings.Unset(args[0])
+		if err != nil {
+			return err
+		}
+		if !removed {
+			fmt.Printf("%s is not set\n", args[0])
+			return nil
+		}
+		if err := userSettings.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Unset %s\n", args[0])
+		return nil
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List CLI settings with their effective values",
+	Long: `List every CLI setting with its effective value and where it comes from.
+
+` + settingsHelp,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		userSettings, err := settings.Load()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Settings file: %s\n\n", userSettings.Path())
+		fmt.Printf("%-10s %-16s %s\n", "KEY", "VALUE", "SOURCE")
+		for _, key := range settings.Keys {
+			value, source, ok := userSettings.Resolve(key.Name)
+			if !ok {
+				value, source = "-", "unset"
+			} else if source == settings.SourceEnv {
+				source = "$" + key.Env
+			}
+			fmt.Printf("%-10s %-16s %s\n", key.Name, value, source)
+		}
 		return nil
 	},
 }
@@ -211,7 +359,12 @@ func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configValidateCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configExampleCmd)
+
+	configShowCmd.Flags().Bool("explain", false, "List each Helm service's resolved values with the layer that set them")
 }
 
 // createExampleConfig generates an example configuration
@@ -233,7 +386,8 @@ func createExampleConfig() interface{} {
 				},
 			},
 			map[string]interface{}{
-				"name": "postgres",
+				"name":      "postgres",
+				"protected": true,
 				"chart": map[string]interface{}{
 					"name":       "postgresql",
 					"repository": "https://charts.bitnami.com/bitnami",
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/state"
+)
+
+// debugRedeployTimeout bounds redeploying one service after switching its
+// log level
+const debugRedeployTimeout = 5 * time.Minute
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Switch debug logging of services on and off",
+	Long: `Turn on verbose logging of a service without editing config.yml. A
+service in debug mode gets DEBUG=true and LOG_LEVEL=debug in its pods, or
+its 'logLevelValue' chart value set to "debug". Only that service is
+redeployed; the switch is kept in .plat/state.json until disabled.
+
+Examples:
+  plat debug enable api          # Verbose logs from api
+  plat debug disable api         # Back to its configured level
+  plat debug list                # Show services in debug mode`,
+}
+
+var debugEnableCmd = &cobra.Command{
+	Use:   "enable <service...>",
+	Short: "Turn on debug logging and redeploy the services",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return switchDebug(cmd, args, true)
+	},
+}
+
+var debugDisableCmd = &cobra.Command{
+	Use:   "disable <service...>",
+	Short: "Turn off debug logging and redeploy the services",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return switchDebug(cmd, args, false)
+	},
+}
+
+var debugListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List services with debug logging",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, service := range runtime.OrderedServices() {
+			if !service.Debug {
+				continue
+			}
+			found = true
+			fmt.Printf("🐛 %s", service.Label())
+			if service.LogLevelValue != "" {
+				fmt.Printf(" (%s=debug)", service.LogLevelValue)
+			}
+			fmt.Println()
+		}
+		if !found {
+			fmt.Println("No services have debug logging on")
+		}
+		return nil
+	},
+}
+
+// switchDebug records debug logging of the named services as on or off and
+// redeploys each one so its pods pick up the new level
+func switchDebug(cmd *cobra.Command, args []string, on bool) error {
+	runtime, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+	serviceNames, err := runtime.ResolveServiceNames(args)
+	if err != nil {
+		return err
+	}
+
+	store := state.NewStore(runtime.ConfigDir())
+	for _, name := range serviceNames {
+		if err := store.SetDebug(name, on); err != nil {
+			return err
+		}
+	}
+
+	// Failed deploys are a result, not a usage mistake
+	cmd.SilenceUsage = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(serviceNames))*debugRedeployTimeout)
+	defer cancel()
+
+	runtime = withDebug(runtime, serviceNames, on)
+	orch := orchestrator.NewOrchestrator(verbose)
+	var failed []string
+	redeployed := 0
+	for _, name := range serviceNames {
+		service := runtime.ResolvedServices[name]
+
+		fmt.Printf("🔄 Redeploying %s with %s...\n", service.Label(), describeLogLevel(service))
+		if err := orch.StartService(ctx, runtime, name); err != nil {
+			printError(err.Error())
+			failed = append(failed, name)
+			continue
+		}
+		if on {
+			printSuccess(fmt.Sprintf("Debug logging on for %s", service.Label()))
+		} else {
+			printSuccess(fmt.Sprintf("Debug logging off for %s", service.Label()))
+		}
+		redeployed++
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	err = fmt.Errorf("%d of %d service(s) failed to redeploy: %s", len(failed), len(serviceNames), strings.Join(failed, ", "))
+	if redeployed > 0 {
+		return withExitCode(ExitPartial, err)
+	}
+	return withExitCode(ExitDeploy, err)
+}
+
+// describeLogLevel names the log level a service is deployed with
+func describeLogLevel(service *config.ResolvedService) string {
+	level := service.EffectiveLogLevel()
+	if level == "" {
+		return "its default log level"
+	}
+	return "log level " + level
+}
+
+func init() {
+	rootCmd.AddCommand(debugCmd)
+	debugCmd.AddCommand(debugEnableCmd)
+	debugCmd.AddCommand(debugDisableCmd)
+	debugCmd.AddCommand(debugListCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/deprecation"
+)
+
+// deprecations writes deprecation notices to stderr, once each, so they
+// don't mix with output meant for other tools
+var deprecations = deprecation.NewReporter(os.Stderr)
+
+// applyDeprecations hides the deprecated commands and flags from help and
+// reports their use before they run
+func applyDeprecations(root *cobra.Command) {
+	for _, d := range deprecation.All() {
+		var target *cobra.Command
+		switch d.Kind {
+		case deprecation.KindCommand:
+			target = subcommand(root, d.Name)
+			if target != nil {
+				target.Hidden = true
+			}
+		case deprecation.KindFlag:
+			target = subcommand(root, d.Command)
+			if target != nil {
+				if flag := target.Flags().Lookup(d.Name); flag != nil {
+					flag.Hidden = true
+				}
+			}
+		}
+		if target == nil {
+			continue
+		}
+
+		d, preRun := d, target.PreRunE
+		target.PreRunE = func(cmd *cobra.Command, args []string) error {
+			if d.Kind == deprecation.KindCommand || cmd.Flags().Changed(d.Name) {
+				deprecations.Report(d)
+			}
+			if preRun != nil {
+				return preRun(cmd, args)
+			}
+			return nil
+		}
+	}
+}
+
+// subcommand returns a direct subcommand of root by name
+func subcommand(root *cobra.Command, name string) *cobra.Command {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name {
+			return cmd
+		}
+	}
+	return nil
+}
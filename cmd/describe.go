@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe <service>",
+	Short: "Show everything about one service in a single report",
+	Long: `Print one report about a service: its resolved config, Helm release
+status and revision history, pods and their containers, the addresses it is
+reachable at, the secrets and config maps its pods read, and recent events.
+
+Parts that can't be read from the cluster are noted rather than failing the
+report.
+
+Examples:
+  plat describe api
+  plat describe api -o json | jq '.history'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		if output != "text" && output != "json" {
+			return fmt.Errorf("invalid output format %q, must be 'text' or 'json'", output)
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		serviceName, err := runtime.ResolveServiceName(args[0])
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		description, err := orchestrator.NewOrchestrator(verbose).Describe(ctx, runtime, serviceName)
+		if err != nil {
+			return err
+		}
+
+		if output == "json" {
+			data, err := json.MarshalIndent(description, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode description: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		printDescription(description)
+		return nil
+	},
+}
+
+// printDescription prints a service description as a sectioned report
+func printDescription(d *orchestrator.ServiceDescription) {
+	title := d.Service
+	if d.Config.DisplayName != "" {
+		title = fmt.Sprintf("%s [%s]", d.Config.DisplayName, d.Service)
+	}
+	fmt.Printf("📋 Service: %s\n", title)
+	fmt.Printf("=========================\n\n")
+
+	fmt.Printf("⚙️  Config\n")
+	if d.Config.Source != "" {
+		fmt.Printf("   Source: %s\n", d.Config.Source)
+	}
+	if d.Config.Version != "" {
+		fmt.Printf("   Version: %s\n", d.Config.Version)
+	}
+	fmt.Printf("   Engine: %s, namespace %s\n", d.Config.Engine, d.Config.Namespace)
+	if len(d.Config.Dependencies) > 0 {
+		fmt.Printf("   Depends on: %s\n", strings.Join(d.Config.Dependencies, ", "))
+	}
+	if len(d.Config.Ports) > 0 {
+		ports := make([]string, len(d.Config.Ports))
+		for i, port := range d.Config.Ports {
+			ports[i] = fmt.Sprint(port)
+		}
+		fmt.Printf("   Ports: %s\n", strings.Join(ports, ", "))
+	}
+	if d.Config.ValuesFile != "" {
+		fmt.Printf("   Values file: %s\n", d.Config.ValuesFile)
+	}
+	if len(d.Config.Values) > 0 {
+		fmt.Printf("   Values: %d top-level keys ('plat template %s --values-only' shows them)\n", len(d.Config.Values), d.Service)
+	}
+	if d.Config.EnvFrom != "" {
+		fmt.Printf("   Env from: %s\n", d.Config.EnvFrom)
+	}
+	if len(d.Config.Environment) > 0 {
+		keys := make([]string, 0, len(d.Config.Environment))
+		for key := range d.Config.Environment {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Printf("   Environment:\n")
+		for _, key := range keys {
+			fmt.Printf("     %s=%s\n", key, d.Config.Environment[key])
+		}
+	}
+
+	fmt.Printf("\n🚀 Release\n")
+	release := d.Release
+	fmt.Printf("   Status: %s %s", getStatusIcon(release.Status), release.Status)
+	if release.Revision > 0 {
+		fmt.Printf(" (revision %d)", release.Revision)
+	}
+	fmt.Println()
+	if release.Chart != "" {
+		fmt.Printf("   Chart: %s\n", release.Chart)
+	}
+	if release.Updated != "" {
+		fmt.Printf("   Updated: %s\n", release.Updated)
+	}
+	if len(d.History) > 0 {
+		fmt.Printf("   History:\n")
+		for _, revision := range d.History {
+			fmt.Printf("     %-4d %-12s %-26s %s", revision.Revision, revision.Status, revision.Updated, revision.Chart)
+			if revision.Description != "" {
+				fmt.Printf("  %s", revision.Description)
+			}
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("\n📦 Pods\n")
+	if len(d.Pods) == 0 {
+		fmt.Println("   No pods")
+	}
+	for _, pod := range d.Pods {
+		fmt.Printf("   %s %s (%s", getPodPhaseIcon(pod), pod.Name, pod.Phase)
+		if pod.Node != "" {
+			fmt.Printf(" on %s", pod.Node)
+		}
+		fmt.Printf(", %s old)\n", time.Since(pod.Created).Round(time.Second))
+		for _, container := range pod.Containers {
+			fmt.Printf("      %s\n", describeContainer(container))
+		}
+	}
+
+	fmt.Printf("\n🌐 Endpoints\n")
+	fmt.Printf("   In cluster: %s\n", d.Endpoints.DNSName)
+	if d.Endpoints.URL != "" {
+		fmt.Printf("   From host: %s\n", d.Endpoints.URL)
+	}
+	if d.Endpoints.Ingress != "" {
+		fmt.Printf("   Ingress host: %s\n", d.Endpoints.Ingress)
+	}
+	if len(d.Endpoints.Addresses) > 0 {
+		fmt.Printf("   Ready addresses: %s\n", strings.Join(d.Endpoints.Addresses, ", "))
+	} else {
+		fmt.Printf("   Ready addresses: none\n")
+	}
+
+	if len(d.Mounts) > 0 {
+		fmt.Printf("\n🔐 Secrets and Config Maps\n")
+		for _, mount := range d.Mounts {
+			fmt.Printf("   • %s\n", mount)
+		}
+	}
+
+	fmt.Printf("\n📰 Events\n")
+	if len(d.Events) == 0 {
+		fmt.Println("   No recent events")
+	}
+	for _, event := range d.Events {
+		icon := "  "
+		if event.Type == "Warning" {
+			icon = "⚠️ "
+		}
+		fmt.Printf("   %s %s  %-12s %s  %s", icon, event.LastSeen.Local().Format("15:04:05"), event.Reason, event.Object, event.Message)
+		if event.Count > 1 {
+			fmt.Printf(" (x%d)", event.Count)
+		}
+		fmt.Println()
+	}
+
+	if len(d.Errors) > 0 {
+		fmt.Println()
+		for _, message := range d.Errors {
+			printWarning(fmt.Sprintf("Couldn't read %s", message))
+		}
+	}
+}
+
+// describeContainer summarizes a container's state on one line
+func describeContainer(container tools.ContainerInfo) string {
+	name := container.Name
+	if container.Init {
+		name += " (init)"
+	}
+	state := container.State
+	if container.Reason != "" {
+		state += ": " + container.Reason
+	}
+	line := fmt.Sprintf("%s  %s, %d restart(s)  %s", name, state, container.Restarts, container.Image)
+	if container.LastTermination != "" {
+		line += fmt.Sprintf("  (last ended %s)", container.LastTermination)
+	}
+	return line
+}
+
+// getPodPhaseIcon returns an icon for a described pod: ready, starting or
+// failing
+func getPodPhaseIcon(pod tools.PodDescription) string {
+	for _, container := range pod.Containers {
+		if !container.Init && !container.Ready {
+			if container.Reason != "" && container.Reason != "ContainerCreating" {
+				return "❌"
+			}
+			return "⏳"
+		}
+	}
+	return "✅"
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+	describeCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+}
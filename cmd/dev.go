@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/watch"
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev [service...]",
+	Short: "Rebuild and redeploy local services as their source changes",
+	Long: `Watch the local sources declared in .plat/local.yml and hot-reload them.
+
+Each service is built and rolled out once at start, then again whenever a
+file in its source tree changes: the image is rebuilt, imported into the k3d
+cluster and the service's deployment rolled onto it. Press Ctrl+C to stop.
+
+config.yml, local.yml and the services' values files are watched too. When
+a change alters the resolved values of services, the changed keys are shown
+and the affected services upgraded, after asking unless --auto-upgrade.
+
+Examples:
+  plat dev                  # Watch every local service
+  plat dev user-api         # Watch one service
+  plat dev --no-initial     # Only rebuild once something changes
+  plat dev --debounce 2s    # Wait for 2s of quiet before rebuilding
+  plat dev --auto-upgrade   # Upgrade services on values changes without asking`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noInitial, _ := cmd.Flags().GetBool("no-initial")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+		autoUpgrade, _ := cmd.Flags().GetBool("auto-upgrade")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		services, err := selectLocalServices(runtime, args)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		watcher, err := watch.NewWatcher(debounce)
+		if err != nil {
+			return err
+		}
+
+		byName := make(map[string]*config.ResolvedService, len(services))
+		for _, service := range services {
+			if err := watcher.Add(service.Name, service.LocalSource.GetPath()); err != nil {
+				return err
+			}
+			byName[service.Name] = service
+			fmt.Printf("👀 Watching %s (%s)\n", service.Name, service.LocalSource.GetPath())
+		}
+
+		values, err := newValuesWatch(runtime, debounce)
+		if err != nil {
+			return err
+		}
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		if !noInitial {
+			for _, service := range services {
+				reloadService(ctx, orch, runtime, service, nil)
+			}
+		}
+
+		fmt.Println("\nWaiting for changes. Press Ctrl+C to stop.")
+
+		onError := func(err error) {
+			printWarning(fmt.Sprintf("File watcher: %v", err))
+		}
+		changes := watcher.Run(ctx, onError)
+		valuesChanges := values.watcher.Run(ctx, onError)
+		for changes != nil || valuesChanges != nil {
+			select {
+			case change, ok := <-changes:
+				if !ok {
+					changes = nil
+					continue
+				}
+				reloadService(ctx, orch, runtime, byName[change.Service], change.Paths)
+
+			case change, ok := <-valuesChanges:
+				if !ok {
+					valuesChanges = nil
+					continue
+				}
+				reloaded := values.reload(ctx, orch, change.Paths, autoUpgrade)
+				if reloaded == nil {
+					continue
+				}
+				runtime = reloaded
+				for name := range byName {
+					if service, exists := runtime.ResolvedServices[name]; exists {
+						byName[name] = service
+					}
+				}
+			}
+		}
+
+		fmt.Println("\n👋 Stopped watching")
+		return nil
+	},
+}
+
+// reloadService rebuilds and rolls one service, reporting the outcome. A
+// failed build is reported but doesn't stop watching.
+func reloadService(ctx context.Context, orch *orchestrator.Orchestrator, runtime *config.RuntimeConfig, service *config.ResolvedService, paths []string) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	switch {
+	case len(paths) == 1:
+		fmt.Printf("\n🔄 %s changed: %s\n", service.Name, relativeTo(service.LocalSource.GetPath(), paths[0]))
+	case len(paths) > 1:
+		fmt.Printf("\n🔄 %s changed: %d files\n", service.Name, len(paths))
+	default:
+		fmt.Printf("\n🔨 Building %s\n", service.Name)
+	}
+
+	started := time.Now()
+	changed, err := orch.ReloadService(ctx, runtime, service)
+	elapsed := time.Since(started).Round(100 * time.Millisecond)
+
+	switch {
+	case ctx.Err() != nil:
+		return
+	case err != nil:
+		printError(err.Error())
+	case changed:
+		printSuccess(fmt.Sprintf("%s reloaded in %s", service.Name, elapsed))
+	default:
+		printInfo(fmt.Sprintf("%s image unchanged, nothing to roll (%s)", service.Name, elapsed))
+	}
+}
+
+// valuesWatch follows the files resolved values are read from, keeping the
+// values last deployed to tell what a change alters
+type valuesWatch struct {
+	watcher  *watch.Watcher
+	resolved map[string]map[string]interface{}
+}
+
+func newValuesWatch(runtime *config.RuntimeConfig, debounce time.Duration) (*valuesWatch, error) {
+	resolved, err := config.ResolveAllValues(runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := watch.NewWatcher(debounce)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range runtime.ValuesFiles() {
+		if err := watcher.AddFile(path, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return &valuesWatch{watcher: watcher, resolved: resolved}, nil
+}
+
+// reload reloads the configuration after its files changed, shows which
+// values changed and upgrades the affected services, asking first unless
+// autoUpgrade. It returns the reloaded configuration, or nil when it could
+// not be loaded or the upgrade was declined.
+func (v *valuesWatch) reload(ctx context.Context, orch *orchestrator.Orchestrator, paths []string, autoUpgrade bool) *config.RuntimeConfig {
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	for _, path := range paths {
+		fmt.Printf("\n📝 %s changed\n", relativeTo(".", path))
+	}
+
+	runtime, err := loadConfiguration()
+	if err != nil {
+		printError(fmt.Sprintf("Configuration not reloaded: %v", err))
+		return nil
+	}
+	resolved, err := config.ResolveAllValues(runtime)
+	if err != nil {
+		printError(fmt.Sprintf("Configuration not reloaded: %v", err))
+		return nil
+	}
+
+	changes := config.CompareResolvedValues(v.resolved, resolved)
+	if len(changes) == 0 {
+		printInfo("No resolved values changed")
+		v.resolved = resolved
+		return runtime
+	}
+
+	for _, change := range changes {
+		fmt.Printf("~ %s:\n", change.Service)
+		for _, value := range change.Changes {
+			switch {
+			case value.Old == nil:
+				fmt.Printf("    + %s: %v\n", value.Key, value.New)
+			case value.New == nil:
+				fmt.Printf("    - %s: %v\n", value.Key, value.Old)
+			default:
+				fmt.Printf("    ~ %s: %v → %v\n", value.Key, value.Old, value.New)
+			}
+		}
+	}
+
+	if !autoUpgrade && !confirmAction(fmt.Sprintf("Upgrade %d service(s)?", len(changes))) {
+		fmt.Println("Not upgraded; the next change is compared against the deployed values")
+		return nil
+	}
+
+	for _, change := range changes {
+		fmt.Printf("⬆️  Upgrading %s\n", change.Service)
+		if err := orch.StartService(ctx, runtime, change.Service); err != nil {
+			printError(err.Error())
+			continue
+		}
+		printSuccess(fmt.Sprintf("%s upgraded", change.Service))
+	}
+
+	v.resolved = resolved
+	return runtime
+}
+
+// relativeTo shortens a changed path for display
+func relativeTo(root, path string) string {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(abs, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+
+	devCmd.Flags().Bool("no-initial", false, "Skip the build at start; only rebuild on changes")
+	devCmd.Flags().Duration("debounce", watch.DefaultDebounce, "Quiet period after a change before rebuilding")
+	devCmd.Flags().Bool("auto-upgrade", false, "Upgrade services whose values change without asking")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"plat/pkg/orchestrator"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [service...]",
+	Short: "Show what 'plat up' would change",
+	Long: `Render each service's manifests from its resolved values and diff them
+against what is live in the cluster, like helm diff, to preview what 'plat up'
+will change. Helm services are compared with their release's manifests,
+services deployed with kubectl are compared with kubectl diff. Services that
+are not deployed yet are shown in full.
+
+Without arguments every service is compared. Diffs are colored when writing
+to a terminal.
+
+Examples:
+  plat diff              # Diff every service
+  plat diff api worker   # Diff some services
+  plat diff -o json      # Machine-readable output`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		if output != "text" && output != "json" {
+			return fmt.Errorf("invalid output format %q, must be 'text' or 'json'", output)
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		serviceNames := runtime.ListServices()
+		if len(args) > 0 {
+			if serviceNames, err = runtime.ResolveServiceNames(args); err != nil {
+				return err
+			}
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(serviceNames))*time.Minute)
+		defer cancel()
+
+		diffs, err := orchestrator.NewOrchestrator(verbose).Diff(ctx, runtime, serviceNames)
+		if err != nil {
+			return err
+		}
+
+		if output == "json" {
+			data, err := json.MarshalIndent(diffs, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode diff: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		color := term.IsTerminal(int(os.Stdout.Fd()))
+		changed := 0
+		for _, diff := range diffs {
+			switch diff.Change {
+			case orchestrator.DiffUnchanged:
+				if verbose {
+					fmt.Printf("✓ %s is up to date\n", diff.Service)
+				}
+				continue
+			case orchestrator.DiffInstall:
+				fmt.Printf("➕ %s would be installed\n", diff.Service)
+			default:
+				fmt.Printf("~ %s would change\n", diff.Service)
+			}
+			printDiff(diff.Diff, color)
+			changed++
+		}
+
+		if changed == 0 {
+			fmt.Printf("✅ No changes: the cluster matches %d service(s)\n", len(diffs))
+		} else {
+			fmt.Printf("\n%d of %d service(s) would change. Run 'plat up' to apply.\n", changed, len(diffs))
+		}
+		return nil
+	},
+}
+
+// printDiff prints a unified diff, coloring added, removed and hunk lines
+func printDiff(diff string, color bool) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		code := ""
+		switch {
+		case !color:
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			code = "1"
+		case strings.HasPrefix(line, "@@"):
+			code = "36"
+		case strings.HasPrefix(line, "+"):
+			code = "32"
+		case strings.HasPrefix(line, "-"):
+			code = "31"
+		}
+		if code == "" {
+			fmt.Println(line)
+		} else {
+			fmt.Printf("\033[%sm%s\033[0m\n", code, line)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+}
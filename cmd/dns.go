@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/netconfig"
+)
+
+var dnsCmd = &cobra.Command{
+	Use:   "dns",
+	Short: "Make service hosts like frontend.platform.local resolve locally",
+	Long: `Ingress hosts such as frontend.platform.local don't resolve out of the
+box. 'plat dns setup' makes them resolve to the local cluster, in one of two
+ways:
+
+  hosts      Add a line per service to /etc/hosts. Works everywhere; run it
+             again after adding services.
+  resolver   Have dnsmasq answer for the whole domain, routed to it with
+             /etc/resolver on macOS or systemd-resolved on Linux. Needs
+             dnsmasq installed; new services resolve without re-running it.
+
+Files outside your reach are written with sudo, which asks for your password.
+'plat dns teardown' removes what setup wrote and 'plat doctor' checks that
+the hosts resolve.
+
+Examples:
+  plat dns setup                    # /etc/hosts entries
+  plat dns setup --method resolver  # dnsmasq for *.platform.local
+  plat dns teardown`,
+}
+
+var dnsSetupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Make the environment's service hosts resolve to the cluster",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		methodName, _ := cmd.Flags().GetString("method")
+		method, err := netconfig.ParseMethod(methodName)
+		if err != nil {
+			return err
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		domain := runtime.Base.Defaults.Domain
+		hosts := netconfig.Hosts(runtime)
+		if domain == "" {
+			return fmt.Errorf("defaults.domain is not set; services are reached on localhost ports")
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		written, err := netconfig.NewManager().Setup(ctx, method, runtime.Base.Name, domain, hosts)
+		for _, path := range written {
+			fmt.Printf("📝 Wrote %s\n", path)
+		}
+		if err != nil {
+			return err
+		}
+
+		if method == netconfig.MethodResolver {
+			fmt.Printf("🔄 Restart dnsmasq to apply: %s\n", netconfig.ReloadHint())
+			return nil
+		}
+		reportResolution(ctx, hosts)
+		return nil
+	},
+}
+
+var dnsTeardownCmd = &cobra.Command{
+	Use:   "teardown",
+	Short: "Remove the DNS configuration 'plat dns setup' wrote",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		changed, err := netconfig.NewManager().Teardown(ctx, runtime.Base.Name, runtime.Base.Defaults.Domain)
+		for _, path := range changed {
+			fmt.Printf("🗑️  Cleaned up %s\n", path)
+		}
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			fmt.Println("No DNS configuration to remove")
+		}
+		return nil
+	},
+}
+
+// reportResolution prints whether each host resolves to the local cluster
+func reportResolution(ctx context.Context, hosts []string) {
+	for _, result := range netconfig.CheckResolution(ctx, hosts) {
+		switch {
+		case result.OK():
+			fmt.Printf("   ✅ %s\n", result.Host)
+		case result.Err != nil:
+			fmt.Printf("   ❌ %s doesn't resolve\n", result.Host)
+		default:
+			fmt.Printf("   ❌ %s resolves to %v, not this machine\n", result.Host, result.Addresses)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(dnsCmd)
+	dnsCmd.AddCommand(dnsSetupCmd)
+	dnsCmd.AddCommand(dnsTeardownCmd)
+
+	dnsSetupCmd.Flags().String("method", string(netconfig.MethodHosts), "How hosts resolve: 'hosts' (/etc/hosts entries) or 'resolver' (dnsmasq stub)")
+}
@@ -3,8 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"plat/pkg/netconfig"
+	"plat/pkg/state"
 	"plat/pkg/tools"
 )
 
@@ -16,8 +19,12 @@ var doctorCmd = &cobra.Command{
 This command checks:
 - k3d installation and version
 - Helm installation and version  
-- Docker daemon status
-- System resources`,
+- Container runtime (docker, podman or nerdctl) and engine status
+- Processes left over from crashed plat sessions
+- Service hosts (frontend.platform.local) resolving to this machine
+- System resources
+
+Use --fix to stop leftover processes.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 
@@ -46,21 +53,28 @@ This command checks:
 
 		// Terraform removed from toolchain - k3d + Helm only
 
-		// Check docker
-		fmt.Print("Checking docker... ")
-		if err := tools.ValidateCommand("docker"); err != nil {
+		// Check the container runtime and its engine
+		engine := selectedContainerRuntime()
+		fmt.Printf("Checking %s... ", engine.Name())
+		if version, err := engine.Validate(ctx); err != nil {
 			fmt.Printf("❌ %v\n", err)
 		} else {
-			// Test docker daemon connectivity
-			executor := tools.NewProcessExecutor()
-			cmd := tools.Command{Name: "docker", Args: []string{"info", "--format", "{{.ServerVersion}}"}}
-			if result, err := executor.Execute(ctx, cmd); err != nil {
-				fmt.Printf("❌ Docker daemon not running\n")
-			} else {
-				fmt.Printf("✅ Docker daemon running (v%s)\n", result.Stdout)
-			}
+			fmt.Printf("✅ %s engine running (v%s)\n", engine.Name(), version)
 		}
 
+		// Check for processes orphaned by a crashed session
+		fmt.Print("Checking for orphaned processes... ")
+		fix, _ := cmd.Flags().GetBool("fix")
+		checkOrphanedProcesses(state.NewStore(".plat"), fix)
+
+		// Check that service hosts resolve to the cluster
+		fmt.Print("Checking service host resolution... ")
+		checkHostResolution(ctx)
+
+		// Check that browsers accept the ingress certificates
+		fmt.Print("Checking local CA... ")
+		checkLocalCA()
+
 		fmt.Println()
 		fmt.Println("💡 Install missing tools:")
 		fmt.Println("  k3d: https://k3d.io/stable/#installation")
@@ -70,6 +84,75 @@ This command checks:
 	},
 }
 
+// checkOrphanedProcesses lists (and optionally stops) leftover processes
+func checkOrphanedProcesses(store *state.Store, fix bool) {
+	orphans, err := store.FindOrphans()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	if len(orphans) == 0 {
+		fmt.Println("✅ None")
+		return
+	}
+
+	fmt.Printf("⚠️  %d found\n", len(orphans))
+	for _, p := range orphans {
+		fmt.Printf("   • PID %d: %s %s\n", p.PID, p.Command, strings.Join(p.Args, " "))
+	}
+
+	if !fix {
+		fmt.Println("   Run 'plat doctor --fix' to stop them")
+		return
+	}
+
+	if errs := store.KillOrphans(orphans); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Printf("   ❌ %v\n", err)
+		}
+		return
+	}
+	fmt.Println("   ✅ Stopped leftover processes")
+}
+
+// checkHostResolution reports service hosts that don't resolve to this
+// machine
+func checkHostResolution(ctx context.Context) {
+	runtime, err := loadConfiguration()
+	if err != nil {
+		fmt.Println("⏭️  Skipped (no configuration)")
+		return
+	}
+	hosts := netconfig.Hosts(runtime)
+	if len(hosts) == 0 {
+		fmt.Println("✅ Not needed (no defaults.domain)")
+		return
+	}
+
+	var failed []netconfig.Resolution
+	for _, result := range netconfig.CheckResolution(ctx, hosts) {
+		if !result.OK() {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) == 0 {
+		fmt.Printf("✅ %d host(s) resolve\n", len(hosts))
+		return
+	}
+
+	fmt.Printf("⚠️  %d of %d host(s) don't resolve to this machine\n", len(failed), len(hosts))
+	for _, result := range failed {
+		if result.Err != nil {
+			fmt.Printf("   • %s\n", result.Host)
+		} else {
+			fmt.Printf("   • %s (resolves to %s)\n", result.Host, strings.Join(result.Addresses, ", "))
+		}
+	}
+	fmt.Println("   Run 'plat dns setup' to fix")
+}
+
 func init() {
 	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().Bool("fix", false, "Stop processes left over from crashed plat sessions")
 }
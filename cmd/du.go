@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+)
+
+var duCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show disk used by plat-managed resources",
+	Long: `Report the disk used by the environment's k3d cluster, the images built
+for its local services, the local registry and its .plat directory, with how
+much of it can be reclaimed and how.
+
+Examples:
+  plat du
+  plat du -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		if output != "table" && output != "json" {
+			return fmt.Errorf("invalid output format %q, must be 'table' or 'json'", output)
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		usage, err := orchestrator.NewOrchestrator(verbose).DiskUsage(ctx, runtime)
+		if err != nil {
+			return err
+		}
+
+		if output == "json" {
+			data, err := json.MarshalIndent(usage, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode disk usage: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("💾 Disk usage: %s\n\n", runtime.Base.Name)
+		fmt.Printf("%-32s %10s %12s\n", "RESOURCE", "SIZE", "RECLAIMABLE")
+		for _, entry := range usage.Entries {
+			fmt.Printf("%-32s %10s %12s\n", entry.Name, formatBytes(entry.Size), formatBytes(entry.Reclaimable))
+		}
+		size, reclaimable := usage.Total()
+		fmt.Printf("%-32s %10s %12s\n", "Total", formatBytes(size), formatBytes(reclaimable))
+
+		var hints []orchestrator.DiskUsageEntry
+		for _, entry := range usage.Entries {
+			if entry.Hint != "" {
+				hints = append(hints, entry)
+			}
+		}
+		if len(hints) > 0 {
+			fmt.Printf("\nTo reclaim space:\n")
+			for _, entry := range hints {
+				fmt.Printf("  • %s: %s\n", entry.Name, entry.Hint)
+			}
+		}
+		return nil
+	},
+}
+
+// formatBytes formats a size with decimal units, as docker prints them
+func formatBytes(size int64) string {
+	const unit = 1000
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	value, suffix := float64(size), ""
+	for _, s := range []string{"kB", "MB", "GB", "TB"} {
+		value /= unit
+		suffix = s
+		if value < unit {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f%s", value, suffix)
+}
+
+func init() {
+	rootCmd.AddCommand(duCmd)
+	duCmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+)
+
+var envFileCmd = &cobra.Command{
+	Use:   "env-file <service>",
+	Short: "Export environment variables for running a service natively",
+	Long: `Emit the environment a service needs to run natively (e.g. in your IDE)
+against the rest of the cluster.
+
+The output contains the service's configured environment, its PORT, and
+host/URL/port variables for each dependency (or every service with --all).
+
+Examples:
+  plat env-file user-api > .env            # dotenv format
+  plat env-file user-api --format shell    # export statements
+  eval "$(plat env-file user-api --format shell)"
+  plat env-file user-api --all -o .env     # Include every other service`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		outputPath, _ := cmd.Flags().GetString("output")
+		all, _ := cmd.Flags().GetBool("all")
+
+		if format != "dotenv" && format != "shell" {
+			return fmt.Errorf("invalid format %q, must be 'dotenv' or 'shell'", format)
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		serviceName, err := runtime.ResolveServiceName(args[0])
+		if err != nil {
+			return err
+		}
+		vars, err := runtime.NativeEnv(serviceName, all)
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if outputPath != "" {
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", outputPath, err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		writeEnvVars(out, vars, format)
+
+		if outputPath != "" {
+			fmt.Printf("Wrote %d variables to %s\n", len(vars), outputPath)
+		}
+
+		return nil
+	},
+}
+
+// writeEnvVars writes variables in dotenv or shell export format
+func writeEnvVars(out io.Writer, vars []config.EnvVar, format string) {
+	for _, v := range vars {
+		if format == "shell" {
+			fmt.Fprintf(out, "export %s='%s'\n", v.Key, strings.ReplaceAll(v.Value, "'", `'\''`))
+		} else {
+			fmt.Fprintf(out, "%s=%q\n", v.Key, v.Value)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(envFileCmd)
+
+	envFileCmd.Flags().String("format", "dotenv", "Output format: dotenv or shell")
+	envFileCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+	envFileCmd.Flags().Bool("all", false, "Include connection details for every service, not just dependencies")
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"plat/pkg/orchestrator"
+)
+
+// followEvents prints the orchestrator's events of the given types as they
+// happen, one line each. The returned function stops following once every
+// event published before it was called is printed.
+func followEvents(orch *orchestrator.Orchestrator, types ...orchestrator.EventType) func() {
+	wanted := make(map[orchestrator.EventType]bool, len(types))
+	for _, eventType := range types {
+		wanted[eventType] = true
+	}
+
+	events, unsubscribe := orch.Subscribe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range events {
+			if wanted[event.Type] {
+				printEvent(event)
+			}
+		}
+	}()
+
+	return func() {
+		unsubscribe()
+		<-done
+	}
+}
+
+// printEvent prints an orchestration event as one line
+func printEvent(event orchestrator.Event) {
+	switch event.Type {
+	case orchestrator.EventClusterCreating:
+		fmt.Printf("🏗️  %s...\n", event)
+	case orchestrator.EventIngressInstalling:
+		fmt.Printf("🚦 %s...\n", event)
+	case orchestrator.EventServiceDeploying:
+		fmt.Printf("🚀 %s...\n", event)
+	case orchestrator.EventServiceRemoving:
+		fmt.Printf("🧹 %s...\n", event)
+	case orchestrator.EventInstallSlow:
+		fmt.Printf("⏳ %s\n", event)
+	case orchestrator.EventClusterReady, orchestrator.EventIngressReady, orchestrator.EventServiceReady, orchestrator.EventServiceRemoved:
+		fmt.Printf("✅ %s\n", event)
+	case orchestrator.EventError:
+		fmt.Printf("❌ %s\n", event)
+	default:
+		fmt.Printf("   %s\n", event)
+	}
+}
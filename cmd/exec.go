@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"plat/pkg/tools"
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec <service> [-- command...]",
+	Short: "Open a shell or run a command in a service pod",
+	Long: `Run a command in a running pod of a service, with an interactive terminal
+when plat is run from one. Without a command, opens /bin/sh.
+
+The newest running pod of the service is used. Like 'plat logs', this goes
+through the Kubernetes API, so kubectl does not need to be installed.
+
+Examples:
+  plat exec api                         # Interactive shell
+  plat exec api -- env                  # Run a single command
+  plat exec postgres -- psql -U postgres
+  plat exec api --container sidecar     # Shell in another container`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		command := args[1:]
+		if len(command) == 0 {
+			command = []string{"/bin/sh"}
+		}
+		container, _ := cmd.Flags().GetString("container")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		serviceName, err := runtime.ResolveServiceName(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		namespace := runtime.Base.Defaults.Namespace
+		pod, err := runningPod(ctx, namespace, serviceName)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Printf("Executing %v in pod %s\n", command, pod)
+		}
+
+		// The remote command reports its own failures
+		cmd.SilenceUsage = true
+
+		opts := tools.ExecOptions{
+			Container: container,
+			Stdin:     os.Stdin,
+			Stdout:    os.Stdout,
+			Stderr:    os.Stderr,
+		}
+
+		stdinFd := int(os.Stdin.Fd())
+		if !term.IsTerminal(stdinFd) {
+			return tools.Exec(ctx, namespace, pod, command, opts)
+		}
+
+		// Interactive: pass keystrokes (including Ctrl+C) through to the pod
+		stop()
+		oldState, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("failed to set up terminal: %w", err)
+		}
+		defer term.Restore(stdinFd, oldState)
+
+		resize := make(chan tools.TerminalSize, 1)
+		stopResize := watchTerminalSize(int(os.Stdout.Fd()), resize)
+		defer stopResize()
+
+		opts.TTY = true
+		opts.Resize = resize
+		return tools.Exec(context.Background(), namespace, pod, command, opts)
+	},
+}
+
+// runningPod returns the newest running pod of a service
+func runningPod(ctx context.Context, namespace, serviceName string) (string, error) {
+	pods, err := tools.ListPods(ctx, namespace, fmt.Sprintf("app.kubernetes.io/instance=%s", serviceName))
+	if err != nil {
+		return "", err
+	}
+
+	// ListPods is oldest first
+	for i := len(pods) - 1; i >= 0; i-- {
+		if pods[i].Phase == "Running" {
+			return pods[i].Name, nil
+		}
+	}
+	return "", fmt.Errorf("no running pods found for service '%s'. Is the service deployed? Run 'plat status' to check", serviceName)
+}
+
+// terminalSize reads the size of a terminal
+func terminalSize(fd int) (tools.TerminalSize, bool) {
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return tools.TerminalSize{}, false
+	}
+	return tools.TerminalSize{Width: uint16(width), Height: uint16(height)}, true
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.Flags().String("container", "", "Container name (for multi-container pods)")
+}
@@ -0,0 +1,45 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"plat/pkg/tools"
+)
+
+// watchTerminalSize sends the terminal's size now and whenever it changes,
+// until the returned function is called
+func watchTerminalSize(fd int, sizes chan<- tools.TerminalSize) func() {
+	if size, ok := terminalSize(fd); ok {
+		sizes <- size
+	}
+
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-winch:
+				if size, ok := terminalSize(fd); ok {
+					select {
+					case sizes <- size:
+					case <-done:
+						return
+					}
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(winch)
+		close(done)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package cmd
+
+import "plat/pkg/tools"
+
+// watchTerminalSize sends the terminal's size once; Windows consoles have no
+// resize signal
+func watchTerminalSize(fd int, sizes chan<- tools.TerminalSize) func() {
+	if size, ok := terminalSize(fd); ok {
+		sizes <- size
+	}
+	return func() {}
+}
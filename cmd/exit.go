@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"os/exec"
+
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+)
+
+// Exit codes, so wrapper scripts can branch on the kind of failure. Every
+// command reports through ExitCode; new failure kinds get a new code rather
+// than reusing one.
+const (
+	ExitOK           = 0
+	ExitError        = 1 // Any other failure, including usage mistakes
+	ExitConfig       = 2 // The configuration is invalid or names an unknown service
+	ExitPrerequisite = 3 // A required tool (k3d, helm, docker) is missing
+	ExitCluster      = 4 // The cluster could not be created, started, deleted or reached
+	ExitDeploy       = 5 // No service was deployed
+	ExitPartial      = 6 // Some services were deployed before others failed
+)
+
+// exitError tags an error with the exit code it should end plat with
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode tags an error with an exit code; nil stays nil
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode returns the exit code for an error returned by Execute. Errors
+// tagged by the command take precedence over the orchestrator's failure
+// kinds, which take precedence over what the error wraps.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+
+	switch orchestrator.FailureKindOf(err) {
+	case orchestrator.FailurePrerequisite:
+		return ExitPrerequisite
+	case orchestrator.FailureCluster:
+		return ExitCluster
+	case orchestrator.FailureDeploy:
+		return ExitDeploy
+	case orchestrator.FailurePartial:
+		return ExitPartial
+	}
+
+	var unknownService *config.UnknownServiceError
+	var unknownKey *config.UnknownKeyError
+	var validation config.ValidationErrors
+	if errors.As(err, &unknownService) || errors.As(err, &unknownKey) || errors.As(err, &validation) {
+		return ExitConfig
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return ExitPrerequisite
+	}
+	return ExitError
+}
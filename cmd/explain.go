@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [key]",
+	Short: "Document a configuration key",
+	Long: `Document a key of .plat/config.yml or .plat/local.yml: its type, default,
+description and an example, plus the keys it holds. Without a key the
+top-level keys of both files are listed.
+
+Keys are dotted paths; lists and maps are entered without naming an element.
+
+Examples:
+  plat explain                           # Top-level keys
+  plat explain services                  # Service definition keys
+  plat explain services.chart.repository # One key
+  plat explain defaults.readyTimeout`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			printKeyList(config.TopLevelKeys(), true)
+			return nil
+		}
+
+		// Unknown keys come with suggestions; usage wouldn't help
+		cmd.SilenceUsage = true
+		doc, err := config.ExplainKey(args[0])
+		if err != nil {
+			return err
+		}
+		printKeyDoc(doc)
+		return nil
+	},
+}
+
+// printKeyDoc prints a key's documentation, example and keys
+func printKeyDoc(doc *config.KeyDoc) {
+	fmt.Printf("%s (%s)\n", doc.Path, doc.Type)
+	fmt.Printf("File: .plat/%s\n", doc.File)
+	if doc.Default != "" {
+		fmt.Printf("Default: %s\n", doc.Default)
+	}
+	if doc.Description != "" {
+		fmt.Printf("\n  %s\n", doc.Description)
+	}
+	if doc.Shorthand != "" {
+		fmt.Printf("  May also be %s in place of the object.\n", doc.Shorthand)
+	}
+
+	fmt.Printf("\nExample:\n")
+	for _, line := range strings.Split(doc.Example, "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+
+	if len(doc.Keys) > 0 {
+		fmt.Printf("\nKeys:\n")
+		printKeyList(doc.Keys, false)
+	}
+}
+
+// printKeyList prints one line per key with its type and description
+func printKeyList(keys []config.KeyDoc, showFile bool) {
+	nameWidth, typeWidth := 0, 0
+	for _, key := range keys {
+		nameWidth = max(nameWidth, len(lastKey(key.Path)))
+		typeWidth = max(typeWidth, len(key.Type))
+	}
+
+	file := ""
+	for _, key := range keys {
+		if showFile && key.File != file {
+			if file != "" {
+				fmt.Println()
+			}
+			file = key.File
+			fmt.Printf(".plat/%s:\n", file)
+		}
+		fmt.Printf("  %-*s  %-*s  %s\n", nameWidth, lastKey(key.Path), typeWidth, key.Type, key.Description)
+	}
+}
+
+// lastKey returns the last key of a dotted path
+func lastKey(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
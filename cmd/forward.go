@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+	"plat/pkg/forward"
+	"plat/pkg/state"
+)
+
+var forwardCmd = &cobra.Command{
+	Use:   "forward",
+	Short: "Manage port-forwards to services",
+	Long: `Forward local ports to services that have no Ingress.
+
+Forwards use the ports declared for each service in config.yml (the same
+port locally and on the pod) unless --port is given. When a pod is deleted,
+restarted or replaced by a rollout, the forward moves to a new ready pod.
+
+Examples:
+  plat forward start postgres               # Forward until Ctrl+C
+  plat forward start postgres redis -d      # Forward in the background
+  plat forward start api --port 8080:80     # Local 8080 to pod port 80
+  plat forward list                         # Show running forwards
+  plat forward stop                         # Stop all background forwards`,
+}
+
+var forwardStartCmd = &cobra.Command{
+	Use:   "start <service...>",
+	Short: "Start forwarding ports to services",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		detach, _ := cmd.Flags().GetBool("detach")
+		background, _ := cmd.Flags().GetBool("background")
+		portFlags, _ := cmd.Flags().GetStringSlice("port")
+
+		if len(portFlags) > 0 && len(args) > 1 {
+			return fmt.Errorf("--port can only be used when forwarding a single service")
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		services, err := runtime.ResolveServiceNames(args)
+		if err != nil {
+			return err
+		}
+
+		ports := make(map[string][]forward.Port, len(services))
+		for _, serviceName := range services {
+			ports[serviceName], err = forwardPorts(runtime.ResolvedServices[serviceName], portFlags)
+			if err != nil {
+				return err
+			}
+		}
+
+		store := state.NewStore(runtime.ConfigDir())
+		if detach {
+			return startDetachedForwards(runtime, store, services, portFlags)
+		}
+		return runForwards(runtime, store, services, ports, background)
+	},
+}
+
+var forwardStopCmd = &cobra.Command{
+	Use:   "stop [service...]",
+	Short: "Stop port-forwards (all when no service is given)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		store := state.NewStore(runtime.ConfigDir())
+		forwards, err := store.Forwards()
+		if err != nil {
+			return fmt.Errorf("failed to read forwards: %w", err)
+		}
+
+		services, err := runtime.ResolveServiceNames(args)
+		if err != nil {
+			return err
+		}
+		wanted := make(map[string]bool, len(services))
+		for _, serviceName := range services {
+			wanted[serviceName] = true
+		}
+
+		stopped := 0
+		for _, f := range forwards {
+			if len(args) > 0 && !wanted[f.Service] {
+				continue
+			}
+			delete(wanted, f.Service)
+			if err := store.StopForward(f); err != nil {
+				printError(err.Error())
+				continue
+			}
+			stopped++
+			fmt.Printf("⏹️  Stopped forward for %s (PID %d)\n", f.Service, f.PID)
+		}
+
+		for serviceName := range wanted {
+			printWarning(fmt.Sprintf("%s is not forwarded", serviceName))
+		}
+		if stopped == 0 && len(args) == 0 {
+			fmt.Println("No forwards running")
+		}
+		return nil
+	},
+}
+
+var forwardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List running port-forwards",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		forwards, err := state.NewStore(runtime.ConfigDir()).Forwards()
+		if err != nil {
+			return fmt.Errorf("failed to read forwards: %w", err)
+		}
+
+		if len(forwards) == 0 {
+			fmt.Println("No forwards running")
+			return nil
+		}
+
+		fmt.Printf("%-24s %-20s %-8s %s\n", "SERVICE", "PORTS", "PID", "UPTIME")
+		for _, f := range forwards {
+			fmt.Printf("%-24s %-20s %-8d %s\n", f.Service, strings.Join(f.Ports, ","), f.PID, f.Uptime().Round(time.Second))
+		}
+		return nil
+	},
+}
+
+// forwardPorts returns the ports to forward for a service: the --port
+// overrides if given, otherwise the service's declared ports
+func forwardPorts(service *config.ResolvedService, overrides []string) ([]forward.Port, error) {
+	var ports []forward.Port
+	for _, value := range overrides {
+		port, err := forward.ParsePort(value)
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) > 0 {
+		return ports, nil
+	}
+
+	for _, p := range service.Ports {
+		ports = append(ports, forward.Port{Local: p, Remote: p})
+	}
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("service '%s' declares no ports. Add 'ports' to its config or pass --port", service.Name)
+	}
+	return ports, nil
+}
+
+// runForwards keeps the forwards up until interrupted, recording them in the
+// state file so 'plat forward list/stop' can find them from other terminals
+func runForwards(runtime *config.RuntimeConfig, store *state.Store, services []string, ports map[string][]forward.Port, background bool) error {
+	if background {
+		// Outlive the terminal that started us
+		signal.Ignore(syscall.SIGHUP)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	defer store.ForwardsStopped()
+	for _, serviceName := range services {
+		specs := make([]string, len(ports[serviceName]))
+		for i, port := range ports[serviceName] {
+			specs[i] = port.String()
+		}
+		if err := store.ForwardStarted(serviceName, specs); err != nil {
+			return err
+		}
+	}
+
+	manager := forward.NewManager(runtime.Base.Defaults.Namespace)
+	manager.SetEventHandler(func(event forward.Event) {
+		stamp := time.Now().Format("15:04:05")
+		switch event.State {
+		case forward.StateActive:
+			for _, port := range ports[event.Service] {
+				fmt.Printf("%s 🔌 %s: localhost:%d → %s:%d\n", stamp, event.Service, port.Local, event.Pod, port.Remote)
+			}
+		case forward.StateRetrying:
+			fmt.Printf("%s ⚠️  %s: %v, retrying\n", stamp, event.Service, event.Err)
+		}
+	})
+
+	for _, serviceName := range services {
+		if err := manager.Start(ctx, serviceName, ports[serviceName]); err != nil {
+			manager.StopAll()
+			return err
+		}
+	}
+
+	if !background {
+		fmt.Println("Press Ctrl+C to stop forwarding")
+	}
+	<-ctx.Done()
+	manager.StopAll()
+	return nil
+}
+
+// startDetachedForwards starts one background 'plat forward start' process
+// per service, logging to .plat/logs/forward-<service>.log
+func startDetachedForwards(runtime *config.RuntimeConfig, store *state.Store, services []string, portFlags []string) error {
+	running, err := store.Forwards()
+	if err != nil {
+		return fmt.Errorf("failed to read forwards: %w", err)
+	}
+	for _, f := range running {
+		for _, serviceName := range services {
+			if f.Service == serviceName {
+				return fmt.Errorf("%s is already forwarded by PID %d, stop it with 'plat forward stop %s'", serviceName, f.PID, serviceName)
+			}
+		}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate plat executable: %w", err)
+	}
+	configFile, err := filepath.Abs(runtime.ConfigFile)
+	if err != nil {
+		configFile = runtime.ConfigFile
+	}
+
+	logDir := filepath.Join(runtime.ConfigDir(), "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	for _, serviceName := range services {
+		logPath := filepath.Join(logDir, fmt.Sprintf("forward-%s.log", serviceName))
+		logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open forward log: %w", err)
+		}
+
+		childArgs := []string{"forward", "start", serviceName, "--background", "-c", configFile}
+		for _, port := range portFlags {
+			childArgs = append(childArgs, "--port", port)
+		}
+
+		child := exec.Command(executable, childArgs...)
+		child.Stdout = logFile
+		child.Stderr = logFile
+		err = child.Start()
+		logFile.Close()
+		if err != nil {
+			return fmt.Errorf("failed to start forward for %s: %w", serviceName, err)
+		}
+
+		fmt.Printf("🔌 Forwarding %s in the background (PID %d, log: %s)\n", serviceName, child.Process.Pid, logPath)
+		child.Process.Release()
+	}
+
+	fmt.Println("Run 'plat forward list' to check them and 'plat forward stop' to end them")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(forwardCmd)
+	forwardCmd.AddCommand(forwardStartCmd)
+	forwardCmd.AddCommand(forwardStopCmd)
+	forwardCmd.AddCommand(forwardListCmd)
+
+	forwardStartCmd.Flags().BoolP("detach", "d", false, "Run the forwards in the background")
+	forwardStartCmd.Flags().StringSlice("port", nil, "Port to forward as <port> or <local>:<remote> (repeatable; overrides config)")
+	forwardStartCmd.Flags().Bool("background", false, "Run as a detached forward process")
+	forwardStartCmd.Flags().MarkHidden("background")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 	"plat/pkg/config"
+	"plat/pkg/settings"
 )
 
 var initCmd = &cobra.Command{
@@ -32,6 +33,11 @@ Templates:
 		}
 
 		template, _ := cmd.Flags().GetString("template")
+		if !cmd.Flags().Changed("template") {
+			if value, _, ok := loadSettings().Resolve(settings.KeyTemplate); ok {
+				template = value
+			}
+		}
 		force, _ := cmd.Flags().GetBool("force")
 		scanLocal, _ := cmd.Flags().GetBool("scan-local")
 
@@ -211,6 +217,15 @@ func createPlatGitignore(_ string) error {
 	gitignoreContent := `# Plat local configuration
 .plat/local.yml
 .plat/.platconfig
+.plat/state.json
+.plat/state.json.lock
+.plat/*.tmp
+.plat/schedule.log
+.plat/prompt.json
+.plat/snapshots.json
+.plat/logs/
+.plat/kubeconfig
+.plat/certs/
 `
 
 	gitignorePath := ".gitignore"
@@ -1,39 +1,82 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"plat/pkg/logbuf"
+	"plat/pkg/logmux"
+	"plat/pkg/tools"
 )
 
+// logsMergeWindow is how long lines of different services are held so they
+// can be printed in timestamp order
+const logsMergeWindow = 250 * time.Millisecond
+
+// logsPrefixColors are the ANSI colors services are told apart by
+var logsPrefixColors = []string{"36", "32", "33", "35", "34", "96", "92", "93", "95", "94"}
+
 var logsCmd = &cobra.Command{
-	Use:   "logs <service>",
-	Short: "View logs for a service",
-	Long: `View logs from a deployed service in the MSC development environment.
+	Use:   "logs <service>... | --all",
+	Short: "View logs for one or more services",
+	Long: `View logs from deployed services in the MSC development environment.
+
+Logs are read through the Kubernetes API, so kubectl does not need to be installed.
 
-This command uses kubectl logs under the hood to stream logs from the service pods.
+With several services, or --all, their logs are streamed concurrently and
+interleaved by timestamp, each line prefixed with its service. --since,
+--tail and --follow apply to each service's stream; services without pods
+are skipped with a warning.
 
 Examples:
   plat logs postgres           # View postgres logs
   plat logs postgres -f        # Follow/tail postgres logs
   plat logs postgres --tail 50 # Show last 50 lines
-  plat logs postgres --since 5m # Show logs from last 5 minutes`,
-	Args: cobra.ExactArgs(1),
+  plat logs postgres --since 5m # Show logs from last 5 minutes
+  plat logs api -f --save api.log  # Also keep the last lines in a file
+  plat logs api worker -f      # Follow two services, prefixed [api] and [worker]
+  plat logs --all --since 10m  # Every service's recent logs`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		if all && len(args) > 0 {
+			return fmt.Errorf("--all can't be combined with service names")
+		}
+		if !all && len(args) == 0 {
+			return fmt.Errorf("requires at least 1 service name, or --all")
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serviceName := args[0]
-
 		// Load configuration to validate service exists
 		runtime, err := loadConfiguration()
 		if err != nil {
 			return err
 		}
 
-		// Check if service exists
-		if _, exists := runtime.ResolvedServices[serviceName]; !exists {
-			return fmt.Errorf("service '%s' not found in configuration", serviceName)
+		// Check the services exist
+		all, _ := cmd.Flags().GetBool("all")
+		services := runtime.ListServices()
+		if !all {
+			services = nil
+			for _, arg := range args {
+				serviceName, err := runtime.ResolveServiceName(arg)
+				if err != nil {
+					return err
+				}
+				if !slices.Contains(services, serviceName) {
+					services = append(services, serviceName)
+				}
+			}
 		}
 
 		// Get flags
@@ -42,74 +85,185 @@ Examples:
 		since, _ := cmd.Flags().GetString("since")
 		previous, _ := cmd.Flags().GetBool("previous")
 		container, _ := cmd.Flags().GetString("container")
+		savePath, _ := cmd.Flags().GetString("save")
+		maxLines, _ := cmd.Flags().GetInt("max-lines")
+		if maxLines <= 0 {
+			maxLines = runtime.Local.LogMaxLines()
+		}
 
-		namespace := runtime.Base.Defaults.Namespace
-
-		// Build kubectl logs command
-		kubectlArgs := []string{"logs"}
-
-		// Find pod for the service
-		// Most Helm charts create pods with the release name as prefix
-		podSelector := fmt.Sprintf("-l app.kubernetes.io/instance=%s", serviceName)
-		kubectlArgs = append(kubectlArgs, podSelector)
+		opts := tools.LogOptions{
+			Follow:    follow,
+			TailLines: tailLines,
+			Previous:  previous,
+			Container: container,
+		}
+		if since != "" {
+			opts.Since, err = time.ParseDuration(since)
+			if err != nil {
+				return fmt.Errorf("invalid --since duration '%s': %w", since, err)
+			}
+		}
 
-		// Add namespace
-		kubectlArgs = append(kubectlArgs, "-n", namespace)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
 
-		// Add optional flags
-		if follow {
-			kubectlArgs = append(kubectlArgs, "-f")
+		namespace := runtime.Base.Defaults.Namespace
+		if all || len(services) > 1 {
+			return streamServicesLogs(ctx, namespace, services, opts, savePath, maxLines)
 		}
+		serviceName := services[0]
 
-		if tailLines > 0 {
-			kubectlArgs = append(kubectlArgs, "--tail", fmt.Sprintf("%d", tailLines))
+		selector := serviceLogsSelector(serviceName)
+		if verbose {
+			fmt.Printf("Streaming logs for pods matching %s in namespace %s\n", selector, namespace)
 		}
 
-		if since != "" {
-			kubectlArgs = append(kubectlArgs, "--since", since)
+		reader, err := tools.StreamLogs(ctx, namespace, selector, opts)
+		if errors.Is(err, tools.ErrNoPods) {
+			return fmt.Errorf("no pods found for service '%s'. Is the service deployed? Run 'plat status' to check", serviceName)
 		}
+		if err != nil {
+			return fmt.Errorf("failed to get logs: %w", err)
+		}
+		defer reader.Close()
 
-		if previous {
-			kubectlArgs = append(kubectlArgs, "--previous")
+		if savePath == "" {
+			if _, err := io.Copy(os.Stdout, reader); err != nil && ctx.Err() == nil {
+				return fmt.Errorf("failed to read logs: %w", err)
+			}
+			return nil
 		}
 
-		if container != "" {
-			kubectlArgs = append(kubectlArgs, "-c", container)
+		// Keep the most recent lines in memory and write them when the stream ends
+		lines := logbuf.New(maxLines)
+		buffered := bufio.NewReader(reader)
+		for {
+			line, err := buffered.ReadString('\n')
+			if line != "" {
+				fmt.Print(line)
+				lines.Add(strings.TrimRight(line, "\r\n"))
+			}
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					printError(fmt.Sprintf("failed to read logs: %v", err))
+				}
+				break
+			}
 		}
 
+		return saveLogs(savePath, lines)
+	},
+}
+
+// serviceLogsSelector selects a service's pods; most Helm charts label pods
+// with the release name
+func serviceLogsSelector(service string) string {
+	return fmt.Sprintf("app.kubernetes.io/instance=%s", service)
+}
+
+// streamServicesLogs streams several services' logs concurrently, printing
+// their lines in timestamp order behind a colored [service] prefix
+func streamServicesLogs(ctx context.Context, namespace string, services []string, opts tools.LogOptions, savePath string, maxLines int) error {
+	// Timestamps order the lines; they are stripped again when printed
+	opts.Timestamps = true
+
+	streams := make(map[string]io.ReadCloser, len(services))
+	var streaming []string
+	for _, service := range services {
 		if verbose {
-			fmt.Printf("Running: kubectl %v\n", kubectlArgs)
+			fmt.Printf("Streaming logs for pods matching %s in namespace %s\n", serviceLogsSelector(service), namespace)
+		}
+		reader, err := tools.StreamLogs(ctx, namespace, serviceLogsSelector(service), opts)
+		if errors.Is(err, tools.ErrNoPods) {
+			printWarning(fmt.Sprintf("No pods found for service '%s', skipping", service))
+			continue
 		}
+		if err != nil {
+			for _, opened := range streams {
+				opened.Close()
+			}
+			return fmt.Errorf("failed to get logs of %s: %w", service, err)
+		}
+		streams[service] = reader
+		streaming = append(streaming, service)
+	}
+	if len(streams) == 0 {
+		return fmt.Errorf("no pods found for any of the services. Are they deployed? Run 'plat status' to check")
+	}
 
-		// Execute kubectl logs with streaming output
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
+	mux := logmux.Merge(streams, logsMergeWindow)
+	defer mux.Close()
+	go func() {
+		<-ctx.Done()
+		mux.Close()
+	}()
 
-		kubectlCmd := exec.CommandContext(ctx, "kubectl", kubectlArgs...)
-		kubectlCmd.Stdout = os.Stdout
-		kubectlCmd.Stderr = os.Stderr
-		kubectlCmd.Stdin = os.Stdin
+	width := 0
+	for _, service := range streaming {
+		width = max(width, len(service))
+	}
+	color := term.IsTerminal(int(os.Stdout.Fd()))
 
-		if err := kubectlCmd.Run(); err != nil {
-			// Check if no pods were found
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				if exitErr.ExitCode() == 1 {
-					return fmt.Errorf("no pods found for service '%s'. Is the service deployed? Run 'plat status' to check", serviceName)
-				}
+	var lines *logbuf.Ring
+	if savePath != "" {
+		lines = logbuf.New(maxLines)
+	}
+	for batch := range mux.Batches() {
+		for _, line := range batch {
+			text := line.Text
+			if !line.Time.IsZero() {
+				_, text, _ = strings.Cut(text, " ")
+			}
+			prefix := fmt.Sprintf("%-*s", width+2, "["+line.Source+"]")
+			if color {
+				code := logsPrefixColors[slices.Index(streaming, line.Source)%len(logsPrefixColors)]
+				fmt.Printf("\033[%sm%s\033[0m %s\n", code, prefix, text)
+			} else {
+				fmt.Printf("%s %s\n", prefix, text)
+			}
+			if lines != nil {
+				lines.Add(prefix + " " + text)
 			}
-			return fmt.Errorf("failed to get logs: %w", err)
 		}
+	}
+	if err := mux.Err(); err != nil && ctx.Err() == nil {
+		printError(fmt.Sprintf("failed to read logs: %v", err))
+	}
 
+	if lines == nil {
 		return nil
-	},
+	}
+	return saveLogs(savePath, lines)
+}
+
+// saveLogs writes the retained log lines to a file, noting any lines that
+// were dropped to stay within the retention limit
+func saveLogs(path string, lines *logbuf.Ring) error {
+	var b strings.Builder
+	if dropped := lines.Dropped(); dropped > 0 {
+		fmt.Fprintf(&b, "# %d earlier lines truncated, kept the last %d (raise with --max-lines)\n", dropped, lines.Len())
+	}
+	lines.Each(func(line string) {
+		b.WriteString(line)
+		b.WriteString("\n")
+	})
+
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to save logs: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "💾 Saved %d lines to %s\n", lines.Len(), path)
+	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(logsCmd)
 
 	logsCmd.Flags().BoolP("follow", "f", false, "Follow/stream logs")
-	logsCmd.Flags().Int("tail", 100, "Number of lines to show from the end of the logs")
+	logsCmd.Flags().Bool("all", false, "Show the logs of every service")
+	logsCmd.Flags().Int("tail", 100, "Number of lines to show from the end of the logs (-1 for all)")
 	logsCmd.Flags().String("since", "", "Show logs since duration (e.g., 5m, 1h)")
 	logsCmd.Flags().BoolP("previous", "p", false, "Show logs from previous container instance")
 	logsCmd.Flags().String("container", "", "Container name (for multi-container pods)")
+	logsCmd.Flags().String("save", "", "Also write the logs to this file when the stream ends")
+	logsCmd.Flags().Int("max-lines", 0, "Lines kept for --save; older lines are dropped (default logs.maxLines in local.yml, or 10000)")
 }
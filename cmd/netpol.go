@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+	"plat/pkg/state"
+)
+
+var netpolCmd = &cobra.Command{
+	Use:   "netpol",
+	Short: "Show or toggle the NetworkPolicies isolating services",
+	Long: `With defaults.networkPolicies on, 'plat up' denies traffic between the
+environment's pods except along the dependency graph, like a production
+cluster would: a service accepts connections from the services depending on
+it and from the ingress controller. A missing dependency then fails locally
+instead of after deploying to production.
+
+'plat netpol disable' removes the policies while debugging and keeps them off
+for later 'plat up' runs until 'plat netpol enable'. Only ingress is
+restricted; egress, such as to external APIs, stays open.
+
+Examples:
+  plat netpol show               # Who may connect to each service
+  plat netpol show --manifests   # The NetworkPolicies applied
+  plat netpol disable            # Open all traffic while debugging
+  plat netpol enable             # Isolate services again`,
+}
+
+var netpolShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "List which services may connect to each service",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifests, _ := cmd.Flags().GetBool("manifests")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		if manifests {
+			out, err := orch.NetworkPolicyManifests(runtime)
+			if err != nil {
+				return err
+			}
+			fmt.Print(out)
+			return nil
+		}
+
+		switch {
+		case runtime.NetworkPoliciesEnabled():
+			fmt.Println("🔒 Network policies: on")
+		case runtime.Base.Defaults.NetworkPolicies:
+			fmt.Println("🔓 Network policies: off ('plat netpol enable' to turn them back on)")
+		default:
+			fmt.Println("🔓 Network policies: off (set defaults.networkPolicies: true to turn them on)")
+		}
+		fmt.Println()
+
+		fmt.Printf("%-24s %s\n", "SERVICE", "ACCEPTS FROM")
+		for _, rule := range orchestrator.NetworkRules(runtime) {
+			from := append([]string(nil), rule.From...)
+			if rule.Ingress {
+				from = append(from, "ingress")
+			}
+			if len(from) == 0 {
+				from = []string{"-"}
+			}
+			fmt.Printf("%-24s %s\n", rule.Service, strings.Join(from, ", "))
+		}
+		return nil
+	},
+}
+
+var netpolDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Remove the NetworkPolicies and keep them off while debugging",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		store := state.NewStore(runtime.ConfigDir())
+		if err := store.SetNetworkPoliciesOff(true); err != nil {
+			return fmt.Errorf("failed to record network policies as disabled: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		if err := orchestrator.NewOrchestrator(verbose).RemoveNetworkPolicies(ctx, runtime); err != nil {
+			return withExitCode(ExitDeploy, err)
+		}
+		fmt.Println("🔓 Network policies removed; traffic between services is open until 'plat netpol enable'")
+		return nil
+	},
+}
+
+var netpolEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Apply the NetworkPolicies again after 'plat netpol disable'",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		store := state.NewStore(runtime.ConfigDir())
+		if err := store.SetNetworkPoliciesOff(false); err != nil {
+			return fmt.Errorf("failed to record network policies as enabled: %w", err)
+		}
+		runtime.NetworkPoliciesOff = false
+
+		if !runtime.NetworkPoliciesEnabled() {
+			fmt.Println("Network policies aren't configured; set defaults.networkPolicies: true in config.yml to turn them on")
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		if err := orchestrator.NewOrchestrator(verbose).ApplyNetworkPolicies(ctx, runtime); err != nil {
+			return withExitCode(ExitDeploy, err)
+		}
+		fmt.Println("🔒 Network policies applied; services only accept traffic from their dependents")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(netpolCmd)
+	netpolCmd.AddCommand(netpolShowCmd)
+	netpolCmd.AddCommand(netpolDisableCmd)
+	netpolCmd.AddCommand(netpolEnableCmd)
+
+	netpolShowCmd.Flags().Bool("manifests", false, "Print the NetworkPolicy manifests instead of the rules")
+}
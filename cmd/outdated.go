@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"plat/pkg/charts"
+	"plat/pkg/config"
+	"plat/pkg/images"
+)
+
+// upgrade is a newer version available for a service's chart or image
+type upgrade struct {
+	Service string `json:"service"`
+	Kind    string `json:"kind"` // chart or image
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+}
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List services with newer chart or image versions available",
+	Long: `Compare the pinned chart and image versions in config.yml with the
+latest releases in their repositories and list the available upgrades.
+
+Chart versions are checked for services that pin chart.version, against the
+repository's index.yaml or an OCI registry's tags. Image versions are checked
+for registry images of the microservice chart with a version pinned.
+Pre-release versions are never suggested.
+
+With --update the new versions are written to config.yml, keeping its
+comments; run 'plat up' to deploy them. CUE and Jsonnet configs are not
+rewritten; the versions to set are printed instead.
+
+Examples:
+  plat outdated             # List available upgrades
+  plat outdated --update    # Bump versions in config.yml
+  plat outdated -o json     # Machine-readable output`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		if output != "text" && output != "json" {
+			return fmt.Errorf("invalid output format %q, must be 'text' or 'json'", output)
+		}
+		update, _ := cmd.Flags().GetBool("update")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		upgrades, failures := findUpgrades(ctx, runtime)
+		for _, failure := range failures {
+			if output == "json" {
+				fmt.Fprintf(os.Stderr, "⚠️  %s\n", failure)
+			} else {
+				printWarning(failure)
+			}
+		}
+
+		if output == "json" {
+			data, err := json.MarshalIndent(upgrades, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode upgrades: %w", err)
+			}
+			fmt.Println(string(data))
+		} else if len(upgrades) == 0 && len(failures) > 0 {
+			fmt.Println("No upgrades found for the versions that could be checked")
+		} else if len(upgrades) == 0 {
+			fmt.Println("✓ All pinned charts and images are up to date")
+		} else {
+			fmt.Printf("%-24s %-6s %-16s %s\n", "SERVICE", "KIND", "CURRENT", "LATEST")
+			for _, u := range upgrades {
+				fmt.Printf("%-24s %-6s %-16s %s\n", u.Service, u.Kind, u.Current, u.Latest)
+			}
+		}
+
+		if !update || len(upgrades) == 0 {
+			return nil
+		}
+
+		// CUE and Jsonnet sources can't be rewritten like YAML
+		if config.RenderedConfig(runtime.ConfigFile) {
+			out := os.Stdout
+			if output == "json" {
+				out = os.Stderr // Keep stdout parseable
+			}
+			fmt.Fprintf(out, "⚠️  %s is rendered, so --update can't rewrite it; set these versions in it:\n", filepath.Base(runtime.ConfigFile))
+			for _, u := range upgrades {
+				fmt.Fprintf(out, "   • %s %s: %s → %s\n", u.Service, u.Kind, u.Current, u.Latest)
+			}
+			return nil
+		}
+
+		bumps := make([]config.VersionBump, 0, len(upgrades))
+		for _, u := range upgrades {
+			bumps = append(bumps, config.VersionBump{Service: u.Service, Chart: u.Kind == "chart", Version: u.Latest})
+		}
+		skipped, err := config.BumpVersions(runtime.ConfigFile, bumps)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", runtime.ConfigFile, err)
+		}
+		for _, name := range skipped {
+			printWarning(fmt.Sprintf("%s's version isn't set in %s; update it where it's configured", name, filepath.Base(runtime.ConfigFile)))
+		}
+		if updated := len(bumps) - len(skipped); updated > 0 {
+			fmt.Printf("📝 Updated %d version(s) in %s; run 'plat up' to deploy them\n", updated, filepath.Base(runtime.ConfigFile))
+		}
+		return nil
+	},
+}
+
+// findUpgrades checks every service's pinned chart and image versions,
+// returning the upgrades found and a message for each check that failed
+func findUpgrades(ctx context.Context, runtime *config.RuntimeConfig) ([]upgrade, []string) {
+	checker := charts.NewChecker()
+	tags := images.NewDigestResolver()
+
+	upgrades := []upgrade{}
+	var failures []string
+	for _, service := range runtime.OrderedServices() {
+		if service.Engine() == config.EngineHelm && service.Chart.Version != "" {
+			ref, err := runtime.ResolveChart(service)
+			if err == nil && ref.RepoURL != "" {
+				versions, err := checker.Versions(ctx, ref)
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("Couldn't check %s's chart: %v", service.Name, err))
+				} else if newer, ok := images.NewerVersion(service.Chart.Version, versions); ok {
+					upgrades = append(upgrades, upgrade{Service: service.Name, Kind: "chart", Current: service.Chart.Version, Latest: newer})
+				}
+			}
+		}
+
+		if service.IsLocal || !service.IsMicroserviceChart() || !isPinnedVersion(service.Version) {
+			continue
+		}
+		available, err := tags.ListTags(ctx, runtime.ImageRepository(service))
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("Couldn't check %s's image: %v", service.Name, err))
+			continue
+		}
+		if newer, ok := images.NewerVersion(service.Version, available); ok {
+			upgrades = append(upgrades, upgrade{Service: service.Name, Kind: "image", Current: service.Version, Latest: newer})
+		}
+	}
+	return upgrades, failures
+}
+
+// isPinnedVersion reports whether an image version is a release version
+// rather than a moving tag like latest, so newer releases can be looked up
+func isPinnedVersion(version string) bool {
+	_, ok := images.CompareVersions(version, version)
+	return ok
+}
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+	outdatedCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	outdatedCmd.Flags().Bool("update", false, "Write the new versions to config.yml")
+}
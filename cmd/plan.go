@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"plat/pkg/orchestrator"
+)
+
+// planVerbs phrases plan actions for printPlan
+var planVerbs = map[string]string{
+	orchestrator.ActionEnsureRegistry: "Start local registry",
+	orchestrator.ActionCreateCluster:  "Create cluster",
+	orchestrator.ActionBuild:          "Build",
+	orchestrator.ActionInstall:        "Install",
+	orchestrator.ActionUpgrade:        "Upgrade",
+	orchestrator.ActionApply:          "Apply",
+	orchestrator.ActionWait:           "Wait for",
+	orchestrator.ActionUninstall:      "Uninstall",
+	orchestrator.ActionDeleteManifest: "Delete",
+	orchestrator.ActionDeleteVolumes:  "Delete data volumes of",
+	orchestrator.ActionKeep:           "Keep",
+	orchestrator.ActionDeleteCluster:  "Delete cluster",
+	orchestrator.ActionKeepCluster:    "Keep cluster",
+}
+
+// printPlan prints a dry run's plan as numbered stages
+func printPlan(operation string, plan *orchestrator.Plan) {
+	fmt.Printf("🔍 Dry run: nothing is changed. 'plat %s' would:\n", operation)
+	if len(plan.Stages) == 0 {
+		fmt.Println("\n  Nothing to do")
+		return
+	}
+
+	for i, stage := range plan.Stages {
+		fmt.Printf("\n%d. %s\n", i+1, stage.Name)
+		for _, step := range stage.Steps {
+			verb, ok := planVerbs[step.Action]
+			if !ok {
+				verb = step.Action
+			}
+			if step.Detail != "" {
+				fmt.Printf("   • %s %s (%s)\n", verb, step.Target, step.Detail)
+			} else {
+				fmt.Printf("   • %s %s\n", verb, step.Target)
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/state"
+	"plat/pkg/tools"
+)
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print a short environment status for shell prompts",
+	Long: `Print a one-line environment summary for embedding in shell prompts.
+
+The status is read from a cache in .plat/prompt.json so the command returns
+in a few milliseconds. When the cache is older than --max-age, the cached
+value is printed and a refresh runs in the background. Nothing is printed
+until the first refresh completes or outside a plat project.
+
+Format placeholders: {env}, {cluster} (up/down), {healthy}, {total},
+{counts} (healthy/total, or "down") and {icon}.
+
+Examples:
+  plat prompt                                  # platform-backend ● 3/4
+  plat prompt --format '{env}:{healthy}/{total}'
+  plat prompt --refresh                        # Query the cluster now
+
+  # starship (~/.config/starship.toml)
+  [custom.plat]
+  command = "plat prompt"
+  when = "test -d .plat"
+
+  # powerlevel10k (~/.p10k.zsh)
+  function prompt_plat() { p10k segment -t "$(plat prompt)" }`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		maxAge, _ := cmd.Flags().GetDuration("max-age")
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		configFile := configPath
+		if configFile == "" {
+			found, err := config.FindConfigFile()
+			if err != nil {
+				return nil // Not in a plat project; print nothing
+			}
+			configFile = found
+		}
+		configDir := filepath.Dir(configFile)
+
+		if refresh {
+			status, err := refreshPromptStatus(configFile)
+			if err != nil {
+				return err
+			}
+			fmt.Println(formatPromptStatus(format, status))
+			return nil
+		}
+
+		// Fast path: never query the cluster from the prompt itself
+		status, err := state.ReadPromptStatus(configDir)
+		if err != nil || status.Age() > maxAge {
+			startPromptRefresh(configFile)
+		}
+		if err == nil {
+			fmt.Println(formatPromptStatus(format, status))
+		}
+		return nil
+	},
+}
+
+// refreshPromptStatus queries the environment and updates the prompt cache
+func refreshPromptStatus(configFile string) (*state.PromptStatus, error) {
+	configDir := filepath.Dir(configFile)
+	defer state.ReleasePromptRefresh(configDir)
+
+	runtime, err := config.NewLoader(configFile, config.ModeArtifact).Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	// Query the environment's cluster, not the user's current context
+	if err := tools.UseKubeconfig(runtime.KubeconfigPath()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	orch := orchestrator.NewOrchestrator(false)
+	orch.SetCrashNotifications(false)
+
+	envStatus, err := orch.Status(ctx, runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &state.PromptStatus{
+		Environment: runtime.Base.Name,
+		ClusterUp:   envStatus.Cluster != nil && envStatus.Cluster.Status == "running",
+		Total:       len(envStatus.Services),
+		CheckedAt:   time.Now(),
+	}
+	for _, service := range envStatus.Services {
+		if service.Status == "deployed" && (service.Deployment == nil || service.Deployment.Ready) {
+			status.Healthy++
+		}
+	}
+
+	if err := state.WritePromptStatus(configDir, status); err != nil {
+		return nil, fmt.Errorf("failed to write prompt cache: %w", err)
+	}
+	// Refreshes keep the history of 'plat status --diff' going between checks
+	state.RecordSnapshot(configDir, snapshotOf(envStatus))
+	return status, nil
+}
+
+// startPromptRefresh refreshes the prompt cache in a detached process unless
+// a refresh is already running
+func startPromptRefresh(configFile string) {
+	configDir := filepath.Dir(configFile)
+	if !state.ClaimPromptRefresh(configDir) {
+		return
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		state.ReleasePromptRefresh(configDir)
+		return
+	}
+	absConfig, err := filepath.Abs(configFile)
+	if err != nil {
+		absConfig = configFile
+	}
+
+	refresh := exec.Command(executable, "prompt", "--refresh", "-c", absConfig)
+	if err := refresh.Start(); err != nil {
+		state.ReleasePromptRefresh(configDir)
+		return
+	}
+	refresh.Process.Release()
+}
+
+// formatPromptStatus renders the status with the --format placeholders
+func formatPromptStatus(format string, status *state.PromptStatus) string {
+	cluster, icon := "down", "○"
+	if status.ClusterUp {
+		cluster, icon = "up", "●"
+		if status.Healthy < status.Total {
+			icon = "◐"
+		}
+	}
+
+	counts := "down"
+	if status.ClusterUp {
+		counts = fmt.Sprintf("%d/%d", status.Healthy, status.Total)
+	}
+
+	return strings.NewReplacer(
+		"{env}", status.Environment,
+		"{cluster}", cluster,
+		"{healthy}", strconv.Itoa(status.Healthy),
+		"{total}", strconv.Itoa(status.Total),
+		"{icon}", icon,
+		"{counts}", counts,
+	).Replace(format)
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+
+	promptCmd.Flags().String("format", "{env} {icon} {counts}", "Output format")
+	promptCmd.Flags().Duration("max-age", 30*time.Second, "Refresh the cached status in the background when older than this")
+	promptCmd.Flags().Bool("refresh", false, "Query the environment now and update the cache")
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/registry"
+	"plat/pkg/tools"
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage the local image registry",
+	Long: `Inspect and clean up the registry plat runs for local builds.
+
+'plat up' creates the registry (` + registry.Name + `, at ` + registry.HostAddress() + `) when
+any service builds from a local source, and clusters pull those images from it.
+
+Examples:
+  plat registry list              # List images and tags
+  plat registry prune --dry-run   # Show what prune would delete
+  plat registry prune             # Delete images no pod is running`,
+}
+
+var registryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the images in the local registry",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		reg, err := runningRegistry(ctx, selectedContainerRuntime())
+		if err != nil {
+			return err
+		}
+
+		repositories, err := reg.Repositories(ctx)
+		if err != nil {
+			return err
+		}
+		if len(repositories) == 0 {
+			fmt.Println("The local registry is empty")
+			return nil
+		}
+
+		fmt.Printf("📦 Local registry %s\n\n", registry.HostAddress())
+		for _, repository := range repositories {
+			tags, err := reg.Tags(ctx, repository)
+			if err != nil {
+				return err
+			}
+			sort.Strings(tags)
+			fmt.Printf("  • %s: %s\n", repository, strings.Join(tags, ", "))
+		}
+		return nil
+	},
+}
+
+var registryPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete images the environment's pods are not running",
+	Long: `Delete every image in the local registry that no pod of the current
+environment is running, then free their layers. The newest build of each
+service (its "` + config.DefaultLocalTag + `" tag) is kept.
+
+Examples:
+  plat registry prune --dry-run   # Show what would be deleted
+  plat registry prune`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		reg, err := runningRegistry(ctx, orchestrator.ContainerRuntime(runtime))
+		if err != nil {
+			return err
+		}
+
+		inUse, err := runningDigests(ctx, runtime)
+		if err != nil {
+			return err
+		}
+
+		repositories, err := reg.Repositories(ctx)
+		if err != nil {
+			return err
+		}
+
+		deleted := 0
+		for _, repository := range repositories {
+			tags, err := reg.Tags(ctx, repository)
+			if err != nil {
+				return err
+			}
+
+			// Tags sharing a manifest are deleted together
+			stale := make(map[string][]string)
+			keep := make(map[string]bool)
+			for _, tag := range tags {
+				digest, err := reg.Digest(ctx, repository, tag)
+				if err != nil {
+					return err
+				}
+				if tag == config.DefaultLocalTag || inUse[digest] {
+					keep[digest] = true
+					continue
+				}
+				stale[digest] = append(stale[digest], tag)
+			}
+
+			for digest, staleTags := range stale {
+				if keep[digest] {
+					continue
+				}
+				fmt.Printf("🗑️  %s:%s\n", repository, strings.Join(staleTags, ", "))
+				if !dryRun {
+					if err := reg.DeleteManifest(ctx, repository, digest); err != nil {
+						return err
+					}
+				}
+				deleted++
+			}
+		}
+
+		if deleted == 0 {
+			fmt.Println("Nothing to prune")
+			return nil
+		}
+		if dryRun {
+			printInfo(fmt.Sprintf("Would delete %d image(s)", deleted))
+			return nil
+		}
+
+		if err := reg.GarbageCollect(ctx); err != nil {
+			return err
+		}
+		printSuccess(fmt.Sprintf("Deleted %d image(s)", deleted))
+		return nil
+	},
+}
+
+// runningRegistry returns the local registry, failing when it isn't running
+func runningRegistry(ctx context.Context, engine tools.ContainerRuntime) (*registry.Registry, error) {
+	reg := registry.New(engine, verbose)
+	status, err := reg.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !status.Running {
+		return nil, fmt.Errorf("the local registry is not running; 'plat up' starts it when a service builds from a local source")
+	}
+	return reg, nil
+}
+
+// runningDigests collects the digests of the images the environment's pods
+// are running
+func runningDigests(ctx context.Context, runtime *config.RuntimeConfig) (map[string]bool, error) {
+	digests := make(map[string]bool)
+	for name := range runtime.ResolvedServices {
+		images, err := tools.GetPodImages(ctx, name, runtime.Base.Defaults.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range images {
+			if _, digest, ok := strings.Cut(image, "@"); ok {
+				digests[digest] = true
+			}
+		}
+	}
+	return digests, nil
+}
+
+func init() {
+	rootCmd.AddCommand(registryCmd)
+	registryCmd.AddCommand(registryListCmd)
+	registryCmd.AddCommand(registryPruneCmd)
+
+	registryPruneCmd.Flags().Bool("dry-run", false, "Show what would be deleted without deleting it")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+)
+
+var restartCmd = &cobra.Command{
+	Use:   "restart <service...>",
+	Short: "Restart services with a rolling update",
+	Long: `Restart services the way 'kubectl rollout restart' does: new pods start
+and old pods keep serving until their replacements are ready. Waits for each
+rollout to finish, printing progress as replicas are replaced. A service that
+is not deployed yet is installed instead.
+
+Services restart one at a time, in dependency order. When one fails, the
+others are still restarted.
+
+Examples:
+  plat restart api                # Restart one service
+  plat restart api worker         # Restart several
+  plat restart api --timeout 2m   # Give up waiting after two minutes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		serviceNames, err := runtime.ResolveServiceNames(args)
+		if err != nil {
+			return err
+		}
+		selected := make(map[string]bool, len(serviceNames))
+		for _, name := range serviceNames {
+			selected[name] = true
+		}
+
+		// Failed restarts are a result, not a usage mistake
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(serviceNames))*(timeout+time.Minute))
+		defer cancel()
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		orch.SetRolloutTimeout(timeout)
+
+		var failed []string
+		restarted := 0
+		for _, service := range runtime.OrderedServices() {
+			if !selected[service.Name] {
+				continue
+			}
+
+			fmt.Printf("🔄 Restarting %s...\n", service.Label())
+			stopFollowing := followEvents(orch, orchestrator.EventProgress)
+			err := orch.RestartService(ctx, runtime, service.Name)
+			stopFollowing()
+			if err != nil {
+				printError(err.Error())
+				failed = append(failed, service.Name)
+				continue
+			}
+			printSuccess(fmt.Sprintf("%s restarted", service.Label()))
+			restarted++
+		}
+
+		if len(failed) == 0 {
+			return nil
+		}
+		err = fmt.Errorf("%d of %d service(s) failed to restart: %s", len(failed), len(serviceNames), strings.Join(failed, ", "))
+		if restarted > 0 {
+			return withExitCode(ExitPartial, err)
+		}
+		return withExitCode(ExitDeploy, err)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restartCmd)
+	restartCmd.Flags().Duration("timeout", orchestrator.DefaultRolloutTimeout, "Maximum time to wait for each rollout")
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+	"plat/pkg/state"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <service> [revision]",
+	Short: "Roll a service back to an earlier Helm revision",
+	Long: `Roll a service's Helm release back to an earlier revision. Without a
+revision it returns to the last revision that deployed successfully before
+the current one; 'plat describe <service>' lists the release history.
+
+'plat up --auto-rollback' does the same automatically for services that fail
+the readiness check after a deploy. 'plat status' shows a rolled back service's
+previous revision and the reason, until it is deployed again.
+
+Examples:
+  plat rollback api          # Back to the last good revision
+  plat rollback api 3        # Back to revision 3
+  plat rollback api --reason "bad migration"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		revision := 0
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid revision %q, must be a positive number", args[1])
+			}
+			revision = n
+		}
+		reason, _ := cmd.Flags().GetString("reason")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		serviceName, err := runtime.ResolveServiceName(args[0])
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		rollback, err := orch.RollbackService(ctx, runtime, serviceName, revision, reason)
+		if err != nil {
+			return withExitCode(ExitDeploy, err)
+		}
+
+		store := state.NewStore(runtime.ConfigDir())
+		if err := recordRollbacks(store, orch.Rollbacks()); err != nil {
+			printWarning(fmt.Sprintf("Failed to record the rollback: %v", err))
+		}
+
+		fmt.Printf("⏪ Rolled %s back from revision %d to %d\n", runtime.ResolvedServices[serviceName].Label(), rollback.From, rollback.To)
+		return nil
+	},
+}
+
+// recordRollbacks keeps rollbacks in the state file for 'plat status'
+func recordRollbacks(store *state.Store, rollbacks []orchestrator.Rollback) error {
+	records := make([]state.RollbackRecord, 0, len(rollbacks))
+	for _, r := range rollbacks {
+		records = append(records, state.RollbackRecord{
+			Service:   r.Service,
+			From:      r.From,
+			To:        r.To,
+			Revision:  r.Revision,
+			Reason:    r.Reason,
+			Automatic: r.Automatic,
+			Time:      r.Time,
+		})
+	}
+	return store.RecordRollbacks(records)
+}
+
+// loadRollbacks returns the recorded rollbacks, by service
+func loadRollbacks(store *state.Store) map[string]orchestrator.Rollback {
+	records, err := store.Rollbacks()
+	if err != nil {
+		return nil
+	}
+
+	rollbacks := make(map[string]orchestrator.Rollback, len(records))
+	for name, r := range records {
+		rollbacks[name] = orchestrator.Rollback{
+			Service:   r.Service,
+			From:      r.From,
+			To:        r.To,
+			Revision:  r.Revision,
+			Reason:    r.Reason,
+			Automatic: r.Automatic,
+			Time:      r.Time,
+		}
+	}
+	return rollbacks
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+	rollbackCmd.Flags().String("reason", "", "Why the service is rolled back, shown by 'plat status'")
+}
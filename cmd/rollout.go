@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+)
+
+var rolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Inspect service rollouts",
+	Long: `Inspect rolling updates of deployed services.
+
+Examples:
+  plat rollout status api                # Wait for the api rollout to finish
+  plat rollout status api --timeout 2m   # Give up after two minutes`,
+}
+
+var rolloutStatusCmd = &cobra.Command{
+	Use:   "status <service>",
+	Short: "Wait for a service rollout to complete",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		serviceName, err := runtime.ResolveServiceName(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout+30*time.Second)
+		defer cancel()
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		err = orch.RolloutStatus(ctx, runtime, serviceName, timeout, func(message string) {
+			fmt.Printf("   %s\n", message)
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ %s rolled out\n", serviceName)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rolloutCmd)
+	rolloutCmd.AddCommand(rolloutStatusCmd)
+
+	rolloutStatusCmd.Flags().Duration("timeout", orchestrator.DefaultRolloutTimeout, "Maximum time to wait for the rollout")
+}
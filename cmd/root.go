@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"plat/pkg/logging"
 	"plat/pkg/ui"
 )
 
@@ -13,6 +14,17 @@ var (
 	configPath string
 	mode       string
 	strict     bool
+	strictSet  bool // --strict was given, so user settings don't apply
+	helmDriver string
+	profile    string
+
+	containerRuntime string
+	allowAnyCluster  bool
+
+	logLevel  string
+	logFormat string
+	logFile   string
+	closeLog  = func() error { return nil }
 )
 
 var rootCmd = &cobra.Command{
@@ -35,26 +47,65 @@ Features:
 			return cmd.Help()
 		}
 
+		if demoMode, _ := cmd.Flags().GetBool("demo"); demoMode {
+			return ui.RunDemo()
+		}
+
 		// If no subcommand provided, launch TUI
 		runtime, err := loadConfiguration()
 		if err != nil {
 			return err
 		}
-		return ui.RunTUI(runtime)
+		return ui.RunTUI(runtime, envStore, loadConfiguration)
 	},
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	applyDeprecations(rootCmd)
+	defer func() { closeLog() }()
+	err := rootCmd.Execute()
+	printServiceErrors(err)
+	return err
+}
+
+// setupLogging builds the shared logger from the --log-* flags. Without
+// --log-level, --verbose logs at info and everything else at warn.
+func setupLogging(cmd *cobra.Command) error {
+	level := logLevel
+	if !cmd.Flags().Changed("log-level") && verbose {
+		level = "info"
+	}
+
+	logger, closeFn, err := logging.New(logging.Options{Level: level, Format: logFormat, File: logFile})
+	if err != nil {
+		return withExitCode(ExitConfig, err)
+	}
+	logging.SetDefault(logger)
+	closeLog = closeFn
+	return nil
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "Config file (default is .plat/config.yml)")
 	rootCmd.PersistentFlags().StringVarP(&mode, "mode", "m", "", "Execution mode: 'local' or 'artifact' (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&helmDriver, "helm-driver", "", "Helm driver: 'cli' (helm binary) or 'sdk' (built in); overrides defaults.helmDriver")
+	rootCmd.PersistentFlags().StringVar(&containerRuntime, "runtime", "", "Container runtime: 'docker', 'podman' or 'nerdctl'; overrides defaults.containerRuntime")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "Profile from the config's profiles section to run (e.g. 'minimal')")
 	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "Enable strict validation (fail on warnings)")
+	rootCmd.PersistentFlags().BoolVar(&allowAnyCluster, "allow-any-cluster", false, "Modify the cluster of the active kube context even if it isn't the environment's local cluster")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "Log level: debug, info, warn or error (--verbose implies info)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logging.FormatText, "Log format: text or json")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs to a file instead of stderr")
+	rootCmd.Flags().Bool("demo", false, "Run the TUI against synthetic data, without docker or k3d")
+
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		strictSet = cmd.Flags().Changed("strict")
+
+		if err := setupLogging(cmd); err != nil {
+			return err
+		}
 
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
 		if verbose {
 			fmt.Printf("plat v%s\n", rootCmd.Version)
 			if configPath != "" {
@@ -64,5 +115,6 @@ func init() {
 				fmt.Printf("Mode override: %s\n", mode)
 			}
 		}
+		return nil
 	}
 }
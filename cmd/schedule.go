@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/schedule"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Start the environment automatically at a set time",
+	Long: `Register a user-level launchd (macOS) or systemd (Linux) timer that runs
+'plat up' before you sit down, so the environment is warm when you start work.
+
+The job runs 'plat up --quiet' with the PATH of the shell that installed it,
+so reinstall after moving k3d, helm or kubectl. Output of scheduled runs is
+written to .plat/schedule.log.
+
+Examples:
+  plat schedule install --at 08:30              # Weekdays at 08:30
+  plat schedule install --at 07:45 --every-day  # Including weekends
+  plat schedule list                            # Show installed schedules
+  plat schedule remove                          # Remove this environment's schedule`,
+}
+
+var scheduleInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install a daily 'plat up' for this environment",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		at, _ := cmd.Flags().GetString("at")
+		everyDay, _ := cmd.Flags().GetBool("every-day")
+
+		hour, minute, err := schedule.ParseTime(at)
+		if err != nil {
+			return err
+		}
+		at = fmt.Sprintf("%02d:%02d", hour, minute)
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		scheduler, err := schedule.New()
+		if err != nil {
+			return err
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to locate plat binary: %w", err)
+		}
+		configFile, err := filepath.Abs(runtime.ConfigFile)
+		if err != nil {
+			return err
+		}
+
+		entry := schedule.Entry{
+			Environment: runtime.Base.Name,
+			At:          at,
+			Weekdays:    !everyDay,
+			Executable:  executable,
+			ConfigFile:  configFile,
+			LogFile:     filepath.Join(filepath.Dir(configFile), "schedule.log"),
+			SearchPath:  os.Getenv("PATH"),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := scheduler.Install(ctx, entry); err != nil {
+			return fmt.Errorf("failed to install schedule: %w", err)
+		}
+
+		days := "weekdays"
+		if everyDay {
+			days = "every day"
+		}
+		fmt.Printf("⏰ '%s' will start %s at %s (%s)\n", entry.Environment, days, at, scheduler.Name())
+		fmt.Printf("   Log: %s\n", entry.LogFile)
+		return nil
+	},
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove [environment]",
+	Short: "Remove a scheduled 'plat up'",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var environment string
+		if len(args) > 0 {
+			environment = args[0]
+		} else {
+			runtime, err := loadConfiguration()
+			if err != nil {
+				return err
+			}
+			environment = runtime.Base.Name
+		}
+
+		scheduler, err := schedule.New()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := scheduler.Remove(ctx, environment); err != nil {
+			return err
+		}
+
+		fmt.Printf("🗑️  Removed schedule for '%s'\n", environment)
+		return nil
+	},
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled environments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scheduler, err := schedule.New()
+		if err != nil {
+			return err
+		}
+
+		entries, err := scheduler.List()
+		if err != nil {
+			return fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		if len(entries) == 0 {
+			fmt.Println("No schedules installed")
+			return nil
+		}
+
+		fmt.Printf("%-24s %-6s %-10s %s\n", "ENVIRONMENT", "AT", "DAYS", "CONFIG")
+		for _, entry := range entries {
+			days := "every day"
+			if entry.Weekdays {
+				days = "weekdays"
+			}
+			fmt.Printf("%-24s %-6s %-10s %s\n", entry.Environment, entry.At, days, entry.ConfigFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleInstallCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+
+	scheduleInstallCmd.Flags().String("at", "08:30", "Local time of day to start the environment (HH:MM)")
+	scheduleInstallCmd.Flags().Bool("every-day", false, "Also run on weekends")
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
+)
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage service secrets stored in Kubernetes",
+	Long: `Store sensitive environment values in a Kubernetes Secret per service
+instead of config.yml. Services with 'envFrom: secret' load every key of
+their secret as an environment variable.
+
+Examples:
+  plat secrets set api STRIPE_KEY=sk_test_123   # Set one or more keys
+  plat secrets set api DB_PASSWORD=x --restart  # Set and restart the service
+  plat secrets list api                         # List the keys
+  plat secrets get api STRIPE_KEY               # Print a value
+  plat secrets rm api STRIPE_KEY                # Remove a key`,
+}
+
+var secretsSetCmd = &cobra.Command{
+	Use:   "set <service> KEY=VALUE...",
+	Short: "Set secret values for a service",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		restart, _ := cmd.Flags().GetBool("restart")
+
+		updates := make(map[string][]byte, len(args)-1)
+		for _, arg := range args[1:] {
+			key, value, ok := strings.Cut(arg, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("invalid secret %q, expected KEY=VALUE", arg)
+			}
+			updates[key] = []byte(value)
+		}
+
+		runtime, service, err := loadSecretService(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		if err := orchestrator.CheckClusterTarget(ctx, runtime); err != nil {
+			return err
+		}
+		data, err := readSecret(ctx, runtime, service)
+		if err != nil {
+			return err
+		}
+		for key, value := range updates {
+			data[key] = value
+		}
+		if err := tools.UpdateSecret(ctx, config.SecretName(service.Name), runtime.Base.Defaults.Namespace, data); err != nil {
+			return err
+		}
+		fmt.Printf("🔐 Set %d secret value(s) for %s\n", len(updates), service.Name)
+
+		if service.EnvFrom != config.EnvFromSecret {
+			printWarning(fmt.Sprintf("%s does not load its secret; add 'envFrom: %s' to it in config.yml", service.Name, config.EnvFromSecret))
+		}
+		return restartForSecrets(ctx, runtime, service, restart)
+	},
+}
+
+var secretsGetCmd = &cobra.Command{
+	Use:   "get <service> <KEY>",
+	Short: "Print a secret value",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, service, err := loadSecretService(args[0])
+		if err != nil {
+			return err
+		}
+
+		data, err := readSecret(context.Background(), runtime, service)
+		if err != nil {
+			return err
+		}
+		value, ok := data[args[1]]
+		if !ok {
+			return fmt.Errorf("%s has no secret %s", service.Name, args[1])
+		}
+		fmt.Println(string(value))
+		return nil
+	},
+}
+
+var secretsListCmd = &cobra.Command{
+	Use:   "list <service>",
+	Short: "List the secret keys of a service",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, service, err := loadSecretService(args[0])
+		if err != nil {
+			return err
+		}
+
+		data, err := readSecret(context.Background(), runtime, service)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			fmt.Printf("No secrets set for %s\n", service.Name)
+			return nil
+		}
+
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Println(key)
+		}
+		return nil
+	},
+}
+
+var secretsRmCmd = &cobra.Command{
+	Use:     "rm <service> <KEY...>",
+	Aliases: []string{"remove"},
+	Short:   "Remove secret values from a service",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		restart, _ := cmd.Flags().GetBool("restart")
+
+		runtime, service, err := loadSecretService(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		if err := orchestrator.CheckClusterTarget(ctx, runtime); err != nil {
+			return err
+		}
+		data, err := readSecret(ctx, runtime, service)
+		if err != nil {
+			return err
+		}
+		for _, key := range args[1:] {
+			if _, ok := data[key]; !ok {
+				return fmt.Errorf("%s has no secret %s", service.Name, key)
+			}
+			delete(data, key)
+		}
+
+		// The secret goes away with its last key
+		name, namespace := config.SecretName(service.Name), runtime.Base.Defaults.Namespace
+		if len(data) == 0 {
+			err = tools.DeleteSecret(ctx, name, namespace)
+		} else {
+			err = tools.UpdateSecret(ctx, name, namespace, data)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🗑️  Removed %d secret value(s) from %s\n", len(args)-1, service.Name)
+
+		return restartForSecrets(ctx, runtime, service, restart)
+	},
+}
+
+// loadSecretService loads the configuration and resolves the service whose
+// secrets are managed
+func loadSecretService(name string) (*config.RuntimeConfig, *config.ResolvedService, error) {
+	runtime, err := loadConfiguration()
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceName, err := runtime.ResolveServiceName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return runtime, runtime.ResolvedServices[serviceName], nil
+}
+
+// readSecret returns the secret values of a service; a service without a
+// secret has none
+func readSecret(ctx context.Context, runtime *config.RuntimeConfig, service *config.ResolvedService) (map[string][]byte, error) {
+	data, err := tools.GetSecret(ctx, config.SecretName(service.Name), runtime.Base.Defaults.Namespace)
+	if tools.IsNotFound(err) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = map[string][]byte{}
+	}
+	return data, nil
+}
+
+// restartForSecrets restarts a service so its pods see changed secrets, or
+// tells the user how to
+func restartForSecrets(ctx context.Context, runtime *config.RuntimeConfig, service *config.ResolvedService, restart bool) error {
+	if service.EnvFrom != config.EnvFromSecret {
+		return nil
+	}
+	if !restart {
+		fmt.Printf("   Running pods keep the old values until restarted (use --restart)\n")
+		return nil
+	}
+	if err := orchestrator.NewOrchestrator(verbose).RestartService(ctx, runtime, service.Name); err != nil {
+		return err
+	}
+	fmt.Printf("🔄 Restarted %s\n", service.Name)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(secretsCmd)
+	secretsCmd.AddCommand(secretsSetCmd)
+	secretsCmd.AddCommand(secretsGetCmd)
+	secretsCmd.AddCommand(secretsListCmd)
+	secretsCmd.AddCommand(secretsRmCmd)
+
+	secretsSetCmd.Flags().Bool("restart", false, "Restart the service so its pods pick up the new values")
+	secretsRmCmd.Flags().Bool("restart", false, "Restart the service so its pods pick up the change")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Export or import a shareable environment package",
+	Long: `Share an exact environment spec with teammates as a single file.
+
+The package bundles config.yml, referenced values files, and the pinned
+service and chart versions, which import writes to lock.yml. Values files
+must live inside the config directory. Local source paths are
+machine-specific and are listed by name only.
+
+Examples:
+  plat share export env.platpkg    # Bundle the current environment
+  plat share import env.platpkg    # Recreate it in this directory`,
+}
+
+var shareExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Bundle the current environment into a package file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		pkg, err := config.BuildPackage(runtime)
+		if err != nil {
+			return fmt.Errorf("failed to build package: %w", err)
+		}
+
+		if err := pkg.WriteFile(args[0]); err != nil {
+			return fmt.Errorf("failed to write package: %w", err)
+		}
+
+		fmt.Printf("📦 Exported environment '%s' to %s\n", pkg.Name, args[0])
+		fmt.Printf("   Services: %d, values files: %d\n", len(pkg.Pins), len(pkg.ValuesFiles))
+		if len(pkg.LocalSources) > 0 {
+			printWarning(fmt.Sprintf("Local sources are not bundled: %v", pkg.LocalSources))
+		}
+
+		return nil
+	},
+}
+
+var shareImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Recreate an environment from a package file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		dir, _ := cmd.Flags().GetString("dir")
+
+		pkg, err := config.ReadPackage(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read package: %w", err)
+		}
+
+		if err := pkg.Extract(dir, force); err != nil {
+			return fmt.Errorf("failed to import package: %w", err)
+		}
+
+		fmt.Printf("📦 Imported environment '%s' into %s\n", pkg.Name, dir)
+		fmt.Printf("\nPinned versions (written to %s):\n", filepath.Join(dir, config.LockFileName))
+		for _, pin := range pkg.Pins {
+			fmt.Printf("  • %s: %s", pin.Name, pin.Version)
+			if pin.Chart != "" {
+				fmt.Printf(" (chart %s", pin.Chart)
+				if pin.ChartVersion != "" {
+					fmt.Printf(" %s", pin.ChartVersion)
+				}
+				fmt.Printf(")")
+			}
+			fmt.Println()
+		}
+
+		if len(pkg.LocalSources) > 0 {
+			fmt.Printf("\nThe exporter ran these services from local sources: %v\n", pkg.LocalSources)
+			fmt.Println("Declare them in .plat/local.yml to reproduce local development.")
+		}
+
+		fmt.Println("\nRun 'plat up --frozen' to start the environment at these versions,")
+		fmt.Println("or 'plat up' if the package carries no image digests to pin")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shareCmd)
+	shareCmd.AddCommand(shareExportCmd)
+	shareCmd.AddCommand(shareImportCmd)
+
+	shareImportCmd.Flags().BoolP("force", "f", false, "Overwrite existing configuration")
+	shareImportCmd.Flags().String("dir", ".plat", "Directory to write the configuration into")
+}
@@ -2,12 +2,17 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 	"plat/pkg/orchestrator"
+	"plat/pkg/state"
+	"plat/pkg/tools"
 )
 
 var statusCmd = &cobra.Command{
@@ -19,12 +24,39 @@ Shows information about:
 • k3d cluster status and health
 • Helm service deployment status
 • Service access URLs and ports
-• Local vs artifact execution mode`,
+• Restart counts, flagging services that restarted since the last status
+• Local vs artifact execution mode
+
+Use --output json or yaml for scripts and editor integrations.
+
+Every status check (including the background refresh of 'plat prompt') keeps
+a snapshot of the environment for a day, at most one a minute. --diff lists
+what changed since a while ago: services appearing and disappearing, status
+and readiness transitions, version and revision changes and restarts.
+
+Examples:
+  plat status
+  plat status --detailed
+  plat status --diff 1h     # What changed in the last hour
+  plat status -o json | jq '.services[] | select(.ready | not) | .name'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		detailed, _ := cmd.Flags().GetBool("detailed")
+		output, _ := cmd.Flags().GetString("output")
+		if output != "table" && output != "json" && output != "yaml" {
+			return fmt.Errorf("invalid output format %q, must be 'table', 'json' or 'yaml'", output)
+		}
+		diff, _ := cmd.Flags().GetString("diff")
+		var window time.Duration
+		if diff != "" {
+			var err error
+			window, err = time.ParseDuration(diff)
+			if err != nil || window <= 0 {
+				return fmt.Errorf("invalid --diff duration %q, e.g. 10m or 1h", diff)
+			}
+		}
 
 		// Load configuration
 		runtime, err := loadConfiguration()
@@ -34,19 +66,162 @@ Shows information about:
 
 		// Create orchestrator and get status
 		orch := orchestrator.NewOrchestrator(verbose)
+		defer orch.WaitNotifications()
+
+		// Compare restarts with the previous run to flag flapping services
+		store := state.NewStore(runtime.ConfigDir())
+		orch.SetCrashLedger(store)
+		if restarts, err := store.Restarts(); err == nil {
+			orch.SetRestartCounts(restarts)
+		}
+		orch.SetRollbacks(loadRollbacks(store))
 
 		status, err := orch.Status(ctx, runtime)
 		if err != nil {
 			return fmt.Errorf("failed to get environment status: %w", err)
 		}
 
-		// Display status
-		displayEnvironmentStatus(status, detailed)
+		if err := store.RecordRestarts(orch.RestartCounts()); err != nil && verbose {
+			printWarning(fmt.Sprintf("Failed to record restart counts: %v", err))
+		}
+
+		// Read the history before this status joins it
+		snapshot := snapshotOf(status)
+		history, err := state.ReadSnapshots(runtime.ConfigDir())
+		if err != nil && verbose {
+			printWarning(fmt.Sprintf("Failed to read status history: %v", err))
+		}
+		if err := state.RecordSnapshot(runtime.ConfigDir(), snapshot); err != nil && verbose {
+			printWarning(fmt.Sprintf("Failed to record status snapshot: %v", err))
+		}
+
+		if diff != "" {
+			cmd.SilenceUsage = true
+			return displayStatusDiff(history, snapshot, window, output)
+		}
+
+		switch output {
+		case "json":
+			data, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode status: %w", err)
+			}
+			fmt.Println(string(data))
+		case "yaml":
+			data, err := yaml.Marshal(status)
+			if err != nil {
+				return fmt.Errorf("failed to encode status: %w", err)
+			}
+			fmt.Print(string(data))
+		default:
+			displayEnvironmentStatus(status, detailed)
+			displayTunnels(store)
+		}
 
 		return nil
 	},
 }
 
+// snapshotOf reduces a status to what the status history keeps
+func snapshotOf(status *orchestrator.EnvironmentStatus) state.Snapshot {
+	snapshot := state.Snapshot{
+		Time:     time.Now(),
+		Services: make(map[string]state.ServiceSnapshot, len(status.Services)),
+	}
+	if status.Cluster != nil {
+		snapshot.Cluster = status.Cluster.Status
+	}
+	for name, service := range status.Services {
+		s := state.ServiceSnapshot{
+			Status:   service.Status,
+			Version:  service.Version,
+			Ready:    service.Ready,
+			Revision: service.Revision,
+		}
+		if service.Deployment != nil {
+			s.Restarts = service.Deployment.Restarts
+		}
+		snapshot.Services[name] = s
+	}
+	return snapshot
+}
+
+// statusDiff is the output of 'plat status --diff'
+type statusDiff struct {
+	Since   time.Time              `json:"since" yaml:"since"`
+	Changes []state.SnapshotChange `json:"changes" yaml:"changes"`
+}
+
+// displayStatusDiff prints what changed between the snapshot taken window
+// ago, or the oldest one kept, and the current one
+func displayStatusDiff(history []state.Snapshot, current state.Snapshot, window time.Duration, output string) error {
+	past, ok := state.SnapshotAt(history, current.Time.Add(-window))
+	if !ok {
+		return fmt.Errorf("no status history yet; it is recorded by each 'plat status', so try again later")
+	}
+	diff := statusDiff{Since: past.Time, Changes: state.DiffSnapshots(past, current)}
+	if diff.Changes == nil {
+		diff.Changes = []state.SnapshotChange{}
+	}
+
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode status diff: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(diff)
+		if err != nil {
+			return fmt.Errorf("failed to encode status diff: %w", err)
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+
+	age := current.Time.Sub(past.Time).Round(time.Second)
+	if age < window-time.Minute {
+		printWarning(fmt.Sprintf("The status history only goes back %s", age))
+	}
+	if len(diff.Changes) == 0 {
+		fmt.Printf("✓ Nothing changed since %s (%s ago)\n", past.Time.Format("15:04:05"), age)
+		return nil
+	}
+
+	fmt.Printf("🕰️  Changes since %s (%s ago)\n", past.Time.Format("15:04:05"), age)
+	for _, change := range diff.Changes {
+		fmt.Printf("   %s\n", describeChange(change))
+	}
+	return nil
+}
+
+// describeChange describes a status change on one line
+func describeChange(change state.SnapshotChange) string {
+	switch change.Field {
+	case "cluster":
+		return fmt.Sprintf("cluster: %s → %s", change.From, change.To)
+	case "added":
+		return fmt.Sprintf("+ %s appeared (%s)", change.Service, change.To)
+	case "removed":
+		return fmt.Sprintf("- %s disappeared (was %s)", change.Service, change.From)
+	case "ready":
+		if change.To == "true" {
+			return fmt.Sprintf("%s: became ready", change.Service)
+		}
+		return fmt.Sprintf("%s: stopped being ready", change.Service)
+	case "restarts":
+		from, _ := strconv.Atoi(change.From)
+		to, _ := strconv.Atoi(change.To)
+		return fmt.Sprintf("%s: restarted %d time(s)", change.Service, to-from)
+	case "status":
+		return fmt.Sprintf("%s: %s → %s", change.Service, change.From, change.To)
+	default:
+		return fmt.Sprintf("%s: %s %s → %s", change.Service, change.Field, change.From, change.To)
+	}
+}
+
 func displayEnvironmentStatus(status *orchestrator.EnvironmentStatus, detailed bool) {
 	fmt.Printf("📊 Environment Status: %s\n", status.Name)
 	fmt.Printf("=========================\n\n")
@@ -76,9 +251,14 @@ func displayEnvironmentStatus(status *orchestrator.EnvironmentStatus, detailed b
 		return
 	}
 
-	for serviceName, service := range status.Services {
+	for _, serviceName := range status.ServiceNames() {
+		service := status.Services[serviceName]
 		statusIcon := getStatusIcon(service.Status)
-		fmt.Printf("   %s %s", statusIcon, serviceName)
+		if service.DisplayName != "" {
+			fmt.Printf("   %s %s [%s]", statusIcon, service.DisplayName, serviceName)
+		} else {
+			fmt.Printf("   %s %s", statusIcon, serviceName)
+		}
 
 		if service.Version != "" {
 			fmt.Printf(" (%s)", service.Version)
@@ -98,14 +278,31 @@ func displayEnvironmentStatus(status *orchestrator.EnvironmentStatus, detailed b
 				fmt.Printf(" - %s", service.Deployment.PodsReady)
 			} else {
 				fmt.Printf(" - %s", service.Deployment.PodsReady)
-				if service.Deployment.Reason != "" {
+				if len(service.Deployment.Pods) > 1 {
+					fmt.Printf(" (%s)", service.Deployment.Summary)
+				} else if service.Deployment.Reason != "" {
 					fmt.Printf(" (%s)", service.Deployment.Reason)
 				}
 			}
+			if service.Deployment.Flapping {
+				fmt.Printf(" ⚠️  flapping (%d restarts)", service.Deployment.Restarts)
+			}
 		}
 
 		fmt.Println()
 
+		if rollback := service.Rollback; rollback != nil {
+			how := "Rolled back"
+			if rollback.Automatic {
+				how = "Rolled back automatically"
+			}
+			fmt.Printf("      ⏪ %s from revision %d to %d (%s ago)", how, rollback.From, rollback.To, time.Since(rollback.Time).Round(time.Second))
+			if rollback.Reason != "" {
+				fmt.Printf(": %s", rollback.Reason)
+			}
+			fmt.Println()
+		}
+
 		if detailed {
 			if service.Chart != "" {
 				fmt.Printf("      Chart: %s\n", service.Chart)
@@ -116,14 +313,28 @@ func displayEnvironmentStatus(status *orchestrator.EnvironmentStatus, detailed b
 			if len(service.Ports) > 0 {
 				fmt.Printf("      Ports: %v\n", service.Ports)
 			}
+			if service.DNSName != "" {
+				fmt.Printf("      DNS: %s\n", service.DNSName)
+			}
 			if service.Updated != "" {
 				fmt.Printf("      Updated: %s\n", service.Updated)
 			}
+			if service.Revision > 0 {
+				fmt.Printf("      Revision: %d\n", service.Revision)
+			}
 			// Show detailed deployment info in detailed mode
 			if service.Deployment != nil {
 				fmt.Printf("      Deployment:\n")
 				fmt.Printf("        Phase: %s\n", service.Deployment.Phase)
 				fmt.Printf("        Containers: %s\n", service.Deployment.PodsReady)
+				fmt.Printf("        Pods ready: %s\n", service.Deployment.Replicas)
+				if len(service.Deployment.Pods) > 1 {
+					fmt.Printf("        Pods: %s\n", service.Deployment.Summary)
+					for _, pod := range service.Deployment.Pods {
+						fmt.Printf("          %s\n", describePod(pod))
+					}
+				}
+				fmt.Printf("        Restarts: %d\n", service.Deployment.Restarts)
 				fmt.Printf("        State: %s\n", service.Deployment.ContainerState)
 				if service.Deployment.Reason != "" {
 					fmt.Printf("        Reason: %s\n", service.Deployment.Reason)
@@ -161,6 +372,28 @@ func displayEnvironmentStatus(status *orchestrator.EnvironmentStatus, detailed b
 	}
 }
 
+// describePod describes one pod of a service on one line
+func describePod(pod tools.PodDetail) string {
+	line := fmt.Sprintf("%s %s", getPodIcon(pod), pod.Name)
+	if pod.Reason != "" {
+		line += " " + pod.Reason
+	}
+	if pod.Restarts > 0 {
+		line += fmt.Sprintf(" (%d restarts)", pod.Restarts)
+	}
+	return line
+}
+
+func getPodIcon(pod tools.PodDetail) string {
+	if pod.Ready {
+		return "✅"
+	}
+	if pod.ContainerState == "running" {
+		return "⏳"
+	}
+	return "❌"
+}
+
 func getStatusIcon(status string) string {
 	switch strings.ToLower(status) {
 	case "running", "deployed":
@@ -200,4 +433,6 @@ func init() {
 	rootCmd.AddCommand(statusCmd)
 
 	statusCmd.Flags().Bool("detailed", false, "Show detailed status information")
+	statusCmd.Flags().StringP("output", "o", "table", "Output format: table, json or yaml")
+	statusCmd.Flags().String("diff", "", "Show what changed since this long ago (e.g. 10m, 1h) instead of the status")
 }
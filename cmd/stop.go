@@ -16,19 +16,30 @@ var downCmd = &cobra.Command{
 	
 This command will:
 • Undeploy all Helm services in dependency order
+• Keep services marked 'protected: true' unless --include-protected is set
+• Delete persistent volumes of services with 'dataRetention: delete'
 • Optionally delete the k3d cluster
+
+With --dry-run the releases, volumes and cluster that would be removed are
+printed in the order they would go, and nothing is changed.
 • Clean up resources while preserving configuration
 
 Examples:
-  plat down              # Stop services, keep cluster
-  plat down --cluster    # Stop services and delete cluster
-  plat down --confirm    # Skip confirmation prompt`,
-	RunE: func(cmd *cobra.Command, args []string) error {
+  plat down                       # Stop services, keep cluster
+  plat down --cluster             # Stop services and delete cluster
+  plat down --include-protected   # Also remove protected services
+  plat down --purge-data          # Also wipe every service's volumes
+  plat down --cluster --dry-run   # Show what would be removed, in order
+  plat down --confirm             # Skip confirmation prompt`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
 		deleteCluster, _ := cmd.Flags().GetBool("cluster")
 		skipConfirm, _ := cmd.Flags().GetBool("confirm")
+		includeProtected, _ := cmd.Flags().GetBool("include-protected")
+		purgeData, _ := cmd.Flags().GetBool("purge-data")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 		// Load configuration
 		runtime, err := loadConfiguration()
@@ -36,12 +47,32 @@ Examples:
 			return err
 		}
 
+		// Create orchestrator and stop environment
+		orch := orchestrator.NewOrchestrator(verbose)
+		orch.SetIncludeProtected(includeProtected)
+		orch.SetPurgeData(purgeData)
+
+		if dryRun {
+			plan, err := orch.PlanDown(ctx, runtime, deleteCluster)
+			if err != nil {
+				return err
+			}
+			printPlan("down", plan)
+			return nil
+		}
+
 		// Confirmation prompt
 		if !skipConfirm {
 			message := "Stop all services"
 			if deleteCluster {
 				message = "Stop all services and delete cluster"
 			}
+			if protected := runtime.ProtectedServices(); includeProtected && len(protected) > 0 {
+				message += fmt.Sprintf(", including protected services %v", protected)
+			}
+			if purgeData {
+				message += " and permanently delete their data volumes"
+			}
 
 			if !confirmAction(message + "?") {
 				fmt.Println("Operation cancelled")
@@ -49,10 +80,13 @@ Examples:
 			}
 		}
 
-		// Create orchestrator and stop environment
-		orch := orchestrator.NewOrchestrator(verbose)
+		started := time.Now()
+		defer func() { notifyCompletion(runtime, "down", started, err) }()
 
-		if err := orch.Down(ctx, runtime, deleteCluster); err != nil {
+		stopFollowing := followEvents(orch, orchestrator.EventServiceRemoving, orchestrator.EventServiceRemoved)
+		err = orch.Down(ctx, runtime, deleteCluster)
+		stopFollowing()
+		if err != nil {
 			return fmt.Errorf("environment shutdown failed: %w", err)
 		}
 
@@ -60,12 +94,10 @@ Examples:
 	},
 }
 
-// Legacy stop command for compatibility
+// Legacy stop command for compatibility, deprecated in pkg/deprecation
 var stopCmd = &cobra.Command{
-	Use:        "stop",
-	Short:      "Stop the development environment (alias for 'down')",
-	Hidden:     true, // Hide from help but keep for compatibility
-	Deprecated: "use 'plat down' instead",
+	Use:   "stop",
+	Short: "Stop the development environment (alias for 'down')",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Forward to down command
 		return downCmd.RunE(cmd, args)
@@ -78,8 +110,14 @@ func init() {
 
 	downCmd.Flags().Bool("cluster", false, "Also delete the k3d cluster")
 	downCmd.Flags().Bool("confirm", false, "Skip confirmation prompt")
+	downCmd.Flags().Bool("include-protected", false, "Also remove services marked protected")
+	downCmd.Flags().Bool("purge-data", false, "Delete persistent volumes of all removed services")
+	downCmd.Flags().Bool("dry-run", false, "Print what would be removed, in order, without removing anything")
 
 	// Legacy flags for stop command
 	stopCmd.Flags().Bool("cluster", false, "Also delete the k3d cluster")
 	stopCmd.Flags().Bool("confirm", false, "Skip confirmation prompt")
+	stopCmd.Flags().Bool("include-protected", false, "Also remove services marked protected")
+	stopCmd.Flags().Bool("purge-data", false, "Delete persistent volumes of all removed services")
+	stopCmd.Flags().Bool("dry-run", false, "Print what would be removed, in order, without removing anything")
 }
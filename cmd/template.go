@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"plat/pkg/orchestrator"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template <service>",
+	Short: "Print a service's resolved values and rendered manifests",
+	Long: `Print exactly what 'plat up' would deploy for a service: its Helm values
+merged from chart defaults, service values, the values file and local and
+runtime overrides, followed by the manifests rendered from them. Nothing is
+installed, so chart and value changes can be reviewed offline of the cluster.
+
+With --show-sources each value is annotated with the layer that set it.
+Services deployed from manifests or kustomize have no values; only their
+manifests are printed.
+
+Examples:
+  plat template api                  # Values and manifests
+  plat template api --values-only    # Merged values only
+  plat template api --show-sources   # Annotate each value with its layer`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		valuesOnly, _ := cmd.Flags().GetBool("values-only")
+		showSources, _ := cmd.Flags().GetBool("show-sources")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		template, err := orchestrator.NewOrchestrator(verbose).Template(ctx, runtime, args[0], valuesOnly)
+		if err != nil {
+			return err
+		}
+
+		if template.Values != nil {
+			var sources map[string]string
+			if showSources {
+				sources = template.Sources
+			}
+			data, err := yaml.Marshal(valuesNode(template.Values, sources, ""))
+			if err != nil {
+				return fmt.Errorf("failed to encode values: %w", err)
+			}
+			fmt.Printf("# Resolved values for %s\n", template.Service)
+			fmt.Print(string(data))
+		}
+
+		if template.Manifests != "" {
+			manifests := template.Manifests
+			if !strings.HasPrefix(manifests, "---") {
+				manifests = "---\n" + manifests
+			}
+			fmt.Print(manifests)
+			if !strings.HasSuffix(manifests, "\n") {
+				fmt.Println()
+			}
+		}
+		return nil
+	},
+}
+
+// valuesNode builds a YAML mapping of values with sorted keys. When sources
+// are given, each leaf is annotated with the layer that set it.
+func valuesNode(values map[string]interface{}, sources map[string]string, prefix string) *yaml.Node {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+		var valueNode *yaml.Node
+		if nested, isMap := values[key].(map[string]interface{}); isMap && len(nested) > 0 {
+			valueNode = valuesNode(nested, sources, path)
+		} else {
+			valueNode = &yaml.Node{}
+			if err := valueNode.Encode(values[key]); err != nil {
+				valueNode = &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(values[key])}
+			}
+			if source, ok := sources[path]; ok {
+				// Scalars carry the comment on their line; lists and maps
+				// start on the next one, so annotate the key instead
+				if valueNode.Kind == yaml.ScalarNode || len(valueNode.Content) == 0 {
+					valueNode.LineComment = source
+				} else {
+					keyNode.LineComment = source
+				}
+			}
+		}
+		node.Content = append(node.Content, keyNode, valueNode)
+	}
+	return node
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.Flags().Bool("values-only", false, "Print the merged values without rendering manifests")
+	templateCmd.Flags().Bool("show-sources", false, "Annotate each value with the layer that set it")
+}
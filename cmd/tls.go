@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/certs"
+)
+
+var tlsCmd = &cobra.Command{
+	Use:   "tls",
+	Short: "Manage the local CA that signs ingress certificates",
+	Long: `With defaults.tls on, 'plat up' serves every service at
+https://<service>.<domain> with a certificate from a local certificate
+authority, created on first use. Browsers accept the certificates once the
+CA is trusted, which 'plat tls trust' does for the system trust store.
+
+The CA lives in the plat directory of your user config directory, or is
+mkcert's when mkcert has created one ($CAROOT is honoured too), so a CA
+already installed with 'mkcert -install' is trusted everywhere it is,
+Firefox included.
+
+Examples:
+  plat tls status   # Where the CA is and whether it is trusted
+  plat tls trust    # Install the CA in the system trust store`,
+}
+
+var tlsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the local CA and whether the system trusts it",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := certs.DefaultDir()
+		if err != nil {
+			return err
+		}
+
+		if runtime, err := loadConfiguration(); err == nil && !runtime.TLSEnabled() {
+			fmt.Println("🔓 TLS is off; set defaults.tls: true in config.yml to serve services over HTTPS")
+		}
+
+		ca, err := certs.LoadCA(dir)
+		if err != nil {
+			fmt.Printf("No local CA in %s yet; 'plat up' or 'plat tls trust' creates it\n", dir)
+			return nil
+		}
+
+		fmt.Printf("CA:       %s\n", ca.CertPath())
+		fmt.Printf("Expires:  %s\n", ca.Cert.NotAfter.Format("2006-01-02"))
+		if ca.Trusted() {
+			fmt.Println("Trusted:  ✅ yes")
+		} else {
+			fmt.Println("Trusted:  ❌ no, run 'plat tls trust'")
+		}
+		return nil
+	},
+}
+
+var tlsTrustCmd = &cobra.Command{
+	Use:   "trust",
+	Short: "Install the local CA in the system trust store",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := certs.DefaultDir()
+		if err != nil {
+			return err
+		}
+		ca, created, err := certs.LoadOrCreateCA(dir)
+		if err != nil {
+			return fmt.Errorf("failed to load the local CA: %w", err)
+		}
+		if created {
+			fmt.Printf("🔑 Created a local CA in %s\n", dir)
+		}
+
+		cmd.SilenceUsage = true
+
+		if ca.Trusted() {
+			fmt.Println("✓ The local CA is already trusted")
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		if err := ca.Trust(ctx); err != nil {
+			return err
+		}
+		fmt.Println("🔒 Installed the local CA in the system trust store; restart your browser to pick it up")
+		return nil
+	},
+}
+
+// checkLocalCA reports whether the CA signing ingress certificates is trusted
+func checkLocalCA() {
+	runtime, err := loadConfiguration()
+	if err != nil {
+		fmt.Println("⏭️  Skipped (no configuration)")
+		return
+	}
+	if !runtime.TLSEnabled() {
+		fmt.Println("✅ Not needed (defaults.tls is off)")
+		return
+	}
+
+	dir, err := certs.DefaultDir()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	ca, err := certs.LoadCA(dir)
+	switch {
+	case err != nil:
+		fmt.Println("⚠️  No local CA yet; 'plat up' creates it")
+	case ca.Trusted():
+		fmt.Println("✅ Trusted")
+	default:
+		fmt.Println("⚠️  Not trusted; browsers will warn about service certificates")
+		fmt.Println("   Run 'plat tls trust' to fix")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(tlsCmd)
+	tlsCmd.AddCommand(tlsStatusCmd)
+	tlsCmd.AddCommand(tlsTrustCmd)
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/settings"
+	"plat/pkg/state"
+	"plat/pkg/tunnel"
+)
+
+// defaultTunnelTTL is how long a tunnel stays open unless --ttl says otherwise
+const defaultTunnelTTL = time.Hour
+
+var tunnelCmd = &cobra.Command{
+	Use:   "tunnel <service>",
+	Short: "Expose a service publicly over a temporary tunnel",
+	Long: `Expose a service's ingress at a temporary public URL, for demos, pair
+debugging or testing from a phone. The tunnel runs through cloudflared, ngrok
+or tailscale funnel, whichever is installed first, unless --provider or the
+'tunnel' setting picks one.
+
+The tunnel closes on Ctrl+C or after --ttl (0 keeps it open until
+interrupted). While open it is listed by 'plat status'.
+
+Anyone with the URL can reach the service: don't expose services holding
+data you wouldn't share.
+
+Examples:
+  plat tunnel frontend                      # Open for an hour
+  plat tunnel api --provider ngrok          # Use ngrok
+  plat tunnel frontend --ttl 15m            # Close after 15 minutes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerName, _ := cmd.Flags().GetString("provider")
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		if ttl < 0 {
+			return fmt.Errorf("--ttl must not be negative")
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		serviceName, err := runtime.ResolveServiceName(args[0])
+		if err != nil {
+			return err
+		}
+		domain := runtime.Base.Defaults.Domain
+		if domain == "" {
+			return fmt.Errorf("%s has no ingress to expose; set defaults.domain in config.yml", serviceName)
+		}
+
+		if providerName == "" {
+			providerName, _, _ = loadSettings().Resolve(settings.KeyTunnel)
+		}
+		var provider tunnel.Provider
+		if providerName != "" {
+			provider, err = tunnel.Lookup(providerName)
+		} else {
+			provider, err = tunnel.Detect()
+		}
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		host := fmt.Sprintf("%s.%s", serviceName, domain)
+		fmt.Printf("🌍 Opening a tunnel to %s through %s...\n", host, provider.Name())
+		t, err := tunnel.Open(ctx, provider, host)
+		if err != nil {
+			return fmt.Errorf("failed to open tunnel: %w", err)
+		}
+		defer t.Close()
+
+		var expiresAt time.Time
+		if ttl > 0 {
+			expiresAt = time.Now().Add(ttl)
+		}
+		store := state.NewStore(runtime.ConfigDir())
+		if err := store.TunnelStarted(serviceName, provider.Name(), t.URL, expiresAt); err != nil {
+			return err
+		}
+		defer store.TunnelsStopped()
+
+		fmt.Printf("🔗 %s is public at %s\n", serviceName, t.URL)
+		if ttl > 0 {
+			fmt.Printf("   Closes at %s; press Ctrl+C to close it sooner\n", expiresAt.Format("15:04"))
+		} else {
+			fmt.Println("   Press Ctrl+C to close it")
+		}
+
+		var expired <-chan time.Time
+		if ttl > 0 {
+			timer := time.NewTimer(ttl)
+			defer timer.Stop()
+			expired = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n⏹️  Tunnel closed")
+		case <-expired:
+			fmt.Printf("⏹️  Tunnel closed after %s\n", ttl)
+		case err := <-t.Done():
+			return fmt.Errorf("tunnel closed unexpectedly: %w", err)
+		}
+		return nil
+	},
+}
+
+// displayTunnels lists the open tunnels below the status output
+func displayTunnels(store *state.Store) {
+	tunnels, err := store.Tunnels()
+	if err != nil || len(tunnels) == 0 {
+		return
+	}
+
+	fmt.Printf("\n🌍 Public Tunnels\n")
+	for _, t := range tunnels {
+		line := fmt.Sprintf("   %s → %s (%s", t.Service, t.URL, t.Provider)
+		if !t.ExpiresAt.IsZero() {
+			line += fmt.Sprintf(", closes in %s", time.Until(t.ExpiresAt).Round(time.Second))
+		}
+		fmt.Println(line + ")")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(tunnelCmd)
+	tunnelCmd.Flags().String("provider", "", "Tunnel provider: "+strings.Join(tunnel.Names(), ", ")+" (default: the first installed)")
+	tunnelCmd.Flags().Duration("ttl", defaultTunnelTTL, "Close the tunnel after this long; 0 keeps it open until interrupted")
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 
 	"plat/pkg/config"
 	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
 )
 
 var upCmd = &cobra.Command{
@@ -21,13 +23,21 @@ This command will:
 • Create/start the k3d cluster with MSC defaults
 • Deploy services using Helm with resolved values
 • Handle service dependencies automatically
+• Ask before deploying into a namespace holding releases or workloads it
+  didn't deploy (--allow-foreign skips the question)
 • Set up ingress for local access
 
 Examples:
   plat up                     # Start all services
   plat up frontend user-api   # Start specific services only
-  plat up --mode local        # Force local development mode`,
-	RunE: func(cmd *cobra.Command, args []string) error {
+  plat up --mode local        # Force local development mode
+  plat up --profile minimal   # Start the services of the 'minimal' profile
+  plat up --frozen            # Deploy strictly from .plat/lock.yml
+  plat up --no-wait && plat wait --for all=ready
+  plat up --auto-rollback     # Roll back services that don't become ready
+  plat up --open              # Open services marked openOnUp once they respond
+  plat up --dry-run           # Print the ordered plan without changing anything`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 		defer cancel()
 
@@ -37,10 +47,22 @@ Examples:
 			return err
 		}
 
+		// Foreign resources are checked against every configured service,
+		// not only the ones this run deploys
+		configured := runtime
+
 		// Filter to specific services if requested
+		if services, _ := cmd.Flags().GetString("services"); services != "" {
+			for _, name := range strings.Split(services, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					args = append(args, name)
+				}
+			}
+		}
 		if len(args) > 0 {
-			if err := filterRuntimeServices(runtime, args); err != nil {
-				return fmt.Errorf("service filtering failed: %w", err)
+			runtime, err = runtime.Filter(args)
+			if err != nil {
+				return withExitCode(ExitConfig, fmt.Errorf("service filtering failed: %w", err))
 			}
 
 			if verbose {
@@ -48,52 +70,256 @@ Examples:
 			}
 		}
 
+		// Pin services to the lock file when frozen
+		frozen, _ := cmd.Flags().GetBool("frozen")
+		lockPath := config.LockPath(runtime)
+
+		var lock *config.Lock
+		if frozen {
+			lock, err = config.ReadLock(lockPath)
+			if err != nil {
+				return withExitCode(ExitConfig, fmt.Errorf("--frozen requires a lock file: %w", err))
+			}
+			runtime, err = lock.Apply(runtime)
+			if err != nil {
+				return withExitCode(ExitConfig, err)
+			}
+		}
+
 		// Create orchestrator and validate prerequisites
 		orch := orchestrator.NewOrchestrator(verbose)
+		defer orch.WaitNotifications()
+
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+		orch.SetNoWait(noWait)
+
+		autoRollback, _ := cmd.Flags().GetBool("auto-rollback")
+		orch.SetAutoRollback(autoRollback)
+
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		orch.SetQuiet(quiet)
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			plan, err := orch.PlanUp(ctx, runtime)
+			if err != nil {
+				return err
+			}
+			printPlan("up", plan)
+			return nil
+		}
+
+		started := time.Now()
+		defer func() { notifyCompletion(runtime, "up", started, err) }()
 
 		printInfo("Validating prerequisites...")
-		if err := orch.ValidatePrerequisites(ctx); err != nil {
+		if err := orch.ValidatePrerequisites(ctx, runtime); err != nil {
 			return fmt.Errorf("prerequisite validation failed: %w", err)
 		}
 
+		allowForeign, _ := cmd.Flags().GetBool("allow-foreign")
+		namespace, err := resolveForeignResources(ctx, orch, configured, allowForeign)
+		if err != nil {
+			return err
+		}
+		if namespace == "" {
+			fmt.Println("Operation cancelled")
+			return nil
+		}
+		runtime = withNamespace(runtime, namespace)
+
+		// Record each service's progress as it happens, so a run that dies
+		// midway can be reported and the Helm operations it left pending
+		// cleared by the next one
+		store := envStore
+		if aborted, _ := store.AbortedDeploy(); aborted != nil {
+			orch.SetInterruptedServices(aborted.InPhase(string(orchestrator.PhaseDeploying)))
+		}
+		if err := store.BeginDeploy(runtime.ListServices()); err != nil {
+			printWarning(fmt.Sprintf("Failed to record deploy progress: %v", err))
+		}
+		orch.SetPhaseRecorder(func(name string, phase orchestrator.DeployPhase) {
+			store.RecordPhase(name, string(phase))
+		})
+
 		// Start the environment
-		if err := orch.Up(ctx, runtime); err != nil {
+		stopFollowing := func() {}
+		if !quiet {
+			stopFollowing = followEvents(orch, orchestrator.EventClusterCreating, orchestrator.EventClusterReady,
+				orchestrator.EventIngressInstalling, orchestrator.EventIngressReady,
+				orchestrator.EventServiceDeploying, orchestrator.EventInstallSlow, orchestrator.EventServiceReady)
+		}
+		err = orch.Up(ctx, runtime)
+		stopFollowing()
+		// A run cut short by its timeout may leave operations pending like
+		// one that died, so its record is kept for the next run
+		if ctx.Err() == nil {
+			store.EndDeploy()
+		}
+		if rollbackErr := recordRollbacks(store, orch.Rollbacks()); rollbackErr != nil {
+			printWarning(fmt.Sprintf("Failed to record rollbacks: %v", rollbackErr))
+		}
+		if err != nil {
 			return fmt.Errorf("environment startup failed: %w", err)
 		}
 
+		if err := updateLockFile(ctx, orch, runtime, lock, lockPath); err != nil {
+			return err
+		}
+
+		if open, _ := cmd.Flags().GetBool("open"); open {
+			openEntryServices(ctx, orch, runtime)
+		}
+
 		return nil
 	},
 }
 
-// filterRuntimeServices filters the runtime configuration to only include specified services
-func filterRuntimeServices(runtime *config.RuntimeConfig, serviceNames []string) error {
-	// Create a set of requested services
-	requested := make(map[string]bool)
-	for _, name := range serviceNames {
-		requested[name] = true
-	}
+// resolveForeignResources checks the environment's namespace for Helm releases
+// and workloads plat didn't deploy and asks whether to deploy alongside them,
+// switch to another namespace or abort. It returns the namespace to deploy
+// to, or "" to abort. Without a terminal to ask (CI, PLAT_AUTO_CONFIRM) or
+// with --allow-foreign it warns and proceeds.
+func resolveForeignResources(ctx context.Context, orch *orchestrator.Orchestrator, runtime *config.RuntimeConfig, allow bool) (string, error) {
+	for {
+		foreign, err := orch.FindForeignResources(ctx, runtime)
+		if err != nil {
+			printWarning(fmt.Sprintf("Could not check namespace %s for foreign resources: %v", runtime.Base.Defaults.Namespace, err))
+			return runtime.Base.Defaults.Namespace, nil
+		}
+		if foreign.Empty() {
+			return foreign.Namespace, nil
+		}
+
+		printWarning(fmt.Sprintf("Namespace %s already holds resources this environment didn't deploy:", foreign.Namespace))
+		for _, release := range foreign.Releases {
+			fmt.Printf("   • Helm release %s\n", release)
+		}
+		for _, workload := range foreign.Workloads {
+			fmt.Printf("   • %s\n", workload)
+		}
 
-	// Check all requested services exist
-	for name := range requested {
-		if _, exists := runtime.ResolvedServices[name]; !exists {
-			return fmt.Errorf("service '%s' not found in configuration", name)
+		if allow || os.Getenv("CI") != "" || os.Getenv("PLAT_AUTO_CONFIRM") != "" {
+			fmt.Println("   Deploying alongside them")
+			return foreign.Namespace, nil
+		}
+
+		fmt.Print("Proceed (p), use another namespace (n) or abort (a)? [a]: ")
+		var response string
+		fmt.Scanln(&response)
+
+		switch strings.ToLower(strings.TrimSpace(response)) {
+		case "p", "proceed":
+			return foreign.Namespace, nil
+		case "n", "namespace":
+			fmt.Print("Namespace: ")
+			var namespace string
+			fmt.Scanln(&namespace)
+			if namespace = strings.TrimSpace(namespace); namespace == "" {
+				return "", nil
+			}
+			fmt.Printf("   Set defaults.namespace: %s in config.yml to keep using it\n", namespace)
+			runtime = withNamespace(runtime, namespace)
+		default:
+			return "", nil
 		}
 	}
+}
+
+// withNamespace returns the runtime config deploying to another namespace
+func withNamespace(runtime *config.RuntimeConfig, namespace string) *config.RuntimeConfig {
+	if runtime.Base.Defaults.Namespace == namespace {
+		return runtime
+	}
+	changed := *runtime
+	base := *runtime.Base
+	defaults := *base.Defaults
+	defaults.Namespace = namespace
+	base.Defaults = &defaults
+	changed.Base = &base
+	return &changed
+}
+
+// openEntryServices waits for the services marked openOnUp to answer and
+// opens their URLs in the browser. Failures are warnings; the environment
+// itself is already up.
+func openEntryServices(ctx context.Context, orch *orchestrator.Orchestrator, runtime *config.RuntimeConfig) {
+	entries := runtime.EntryServices()
+	if len(entries) == 0 {
+		printWarning("No services have 'openOnUp: true'; nothing to open")
+		return
+	}
+
+	// Wait for the ingress to route traffic; a browser opened earlier would
+	// land on a 503 page
+	condition := orchestrator.ConditionReachable
+	if runtime.Base.Defaults.Domain == "" {
+		condition = orchestrator.ConditionReady
+	}
 
-	// Filter resolved services
-	filteredServices := make(map[string]*config.ResolvedService)
-	for name, service := range runtime.ResolvedServices {
-		if requested[name] {
-			filteredServices[name] = service
+	var conditions []orchestrator.WaitCondition
+	for _, service := range entries {
+		conditions = append(conditions, orchestrator.WaitCondition{Target: service.Name, Condition: condition})
+	}
+
+	printInfo("Waiting for entry services before opening the browser...")
+	waitCtx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+	if err := orch.Wait(waitCtx, runtime, conditions, 2*time.Second); err != nil {
+		printWarning(fmt.Sprintf("Entry services not ready, opening anyway: %v", err))
+	}
+
+	for _, service := range entries {
+		url, ok := runtime.ServiceURL(service)
+		if !ok {
+			printWarning(fmt.Sprintf("%s has no domain or port to open", service.Name))
+			continue
 		}
+		if err := tools.OpenURL(ctx, url); err != nil {
+			printWarning(err.Error())
+			continue
+		}
+		fmt.Printf("🌐 Opened %s\n", url)
+	}
+}
+
+// updateLockFile records what was deployed in the lock file. When a frozen
+// lock is supplied the deployment is verified against it instead.
+func updateLockFile(ctx context.Context, orch *orchestrator.Orchestrator, runtime *config.RuntimeConfig, frozen *config.Lock, lockPath string) error {
+	current, err := orch.GenerateLock(ctx, runtime)
+	if err != nil {
+		printWarning(fmt.Sprintf("Failed to generate lock file: %v", err))
+		return nil
+	}
+
+	if frozen != nil {
+		return frozen.VerifyImages(current)
+	}
+
+	// Merge into the existing lock so partial ups keep other services pinned
+	lock, err := config.ReadLock(lockPath)
+	if err != nil {
+		lock = config.NewLock()
+	}
+	lock.Merge(current)
+
+	if err := lock.WriteFile(lockPath); err != nil {
+		printWarning(fmt.Sprintf("Failed to write lock file: %v", err))
+		return nil
 	}
 
-	runtime.ResolvedServices = filteredServices
+	printSuccess(fmt.Sprintf("Updated %s", lockPath))
 	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(upCmd)
 
-	upCmd.Flags().StringP("services", "s", "", "Comma-separated list of services to start (deprecated: use args)")
+	upCmd.Flags().StringP("services", "s", "", "Comma-separated list of services to start")
+	upCmd.Flags().Bool("frozen", false, "Deploy strictly from .plat/lock.yml and fail on drift")
+	upCmd.Flags().Bool("no-wait", false, "Return once releases are installed without waiting for readiness")
+	upCmd.Flags().Bool("allow-foreign", false, "Deploy into a namespace holding resources plat didn't deploy without asking")
+	upCmd.Flags().Bool("dry-run", false, "Print the cluster, builds and deploys that would run, in order, without running them")
+	upCmd.Flags().Bool("auto-rollback", false, "Roll back services that fail the readiness check to their last successful revision")
+	upCmd.Flags().Bool("open", false, "Open services marked openOnUp in the browser once they are reachable")
+	upCmd.Flags().BoolP("quiet", "q", false, "Print only warnings and errors, e.g. for scheduled runs")
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var urlsCmd = &cobra.Command{
+	Use:   "urls [service...]",
+	Short: "Print the addresses services are reachable at",
+	Long: `Print the URL each service is reachable at from the host, or with
+--internal the DNS name (and ports) other pods reach it at inside the
+cluster, for use in service configuration.
+
+Examples:
+  plat urls                        # Host URLs of all services
+  plat urls --internal             # In-cluster DNS names
+  plat urls payment-api --internal # payment-api.default.svc.cluster.local:8080`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		internal, _ := cmd.Flags().GetBool("internal")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		selected := make(map[string]bool)
+		if len(args) > 0 {
+			names, err := runtime.ResolveServiceNames(args)
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				selected[name] = true
+			}
+		}
+
+		for _, service := range runtime.OrderedServices() {
+			if len(selected) > 0 && !selected[service.Name] {
+				continue
+			}
+
+			if !internal {
+				if url, ok := runtime.ServiceURL(service); ok {
+					fmt.Printf("%s\t%s\n", service.Name, url)
+				}
+				continue
+			}
+
+			name := runtime.ServiceDNSName(service)
+			if len(service.Ports) == 0 {
+				fmt.Printf("%s\t%s\n", service.Name, name)
+				continue
+			}
+			addresses := make([]string, len(service.Ports))
+			for i, port := range service.Ports {
+				addresses[i] = fmt.Sprintf("%s:%d", name, port)
+			}
+			fmt.Printf("%s\t%s\n", service.Name, strings.Join(addresses, " "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(urlsCmd)
+	urlsCmd.Flags().Bool("internal", false, "Print in-cluster DNS names instead of host URLs")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/config"
+)
+
+var valuesCmd = &cobra.Command{
+	Use:   "values",
+	Short: "Inspect resolved Helm values",
+}
+
+var valuesSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Write or check golden files of each service's resolved values",
+	Long: `Resolve the Helm values of every service and write them to golden files,
+one per service, under .plat/snapshots/values. Commit the files; with --check
+the resolved values are compared against them instead, so CI catches
+unintended changes to value resolution after upgrading plat or editing
+defaults and values files.
+
+Examples:
+  plat values snapshot            # Write (or update) the golden files
+  plat values snapshot --check    # Fail if resolved values differ from them`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		check, _ := cmd.Flags().GetBool("check")
+		dir, _ := cmd.Flags().GetString("dir")
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		if dir == "" {
+			dir = filepath.Join(runtime.ConfigDir(), config.ValuesSnapshotDir)
+		}
+
+		snapshots, err := config.NewValuesManager(runtime.ConfigDir()).RenderValuesSnapshots(runtime)
+		if err != nil {
+			return err
+		}
+
+		if !check {
+			if err := config.WriteValuesSnapshots(dir, snapshots); err != nil {
+				return err
+			}
+			fmt.Printf("📸 Wrote values snapshots for %d services to %s\n", len(snapshots), dir)
+			return nil
+		}
+
+		changes, err := config.CompareValuesSnapshots(runtime, dir, snapshots)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			fmt.Printf("✅ Resolved values match the snapshots for %d services\n", len(snapshots))
+			return nil
+		}
+
+		for _, change := range changes {
+			switch change.Kind {
+			case config.SnapshotAdded:
+				fmt.Printf("+ %s: no snapshot\n", change.Service)
+			case config.SnapshotRemoved:
+				fmt.Printf("- %s: snapshot for a service no longer in the configuration\n", change.Service)
+			case config.SnapshotChanged:
+				fmt.Printf("~ %s:\n", change.Service)
+				for _, value := range change.Changes {
+					switch {
+					case value.Old == nil:
+						fmt.Printf("    + %s: %v\n", value.Key, value.New)
+					case value.New == nil:
+						fmt.Printf("    - %s: %v\n", value.Key, value.Old)
+					default:
+						fmt.Printf("    ~ %s: %v → %v\n", value.Key, value.Old, value.New)
+					}
+				}
+			}
+		}
+
+		// A mismatch is a result, not a usage mistake
+		cmd.SilenceUsage = true
+		return fmt.Errorf("resolved values differ from %d snapshot(s). Run 'plat values snapshot' to accept the changes", len(changes))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(valuesCmd)
+	valuesCmd.AddCommand(valuesSnapshotCmd)
+
+	valuesSnapshotCmd.Flags().Bool("check", false, "Compare resolved values with the snapshots instead of writing them")
+	valuesSnapshotCmd.Flags().String("dir", "", "Snapshot directory (default .plat/snapshots/values)")
+}
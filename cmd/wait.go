@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"plat/pkg/orchestrator"
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait --for <target>=<condition>",
+	Short: "Wait until services reach a condition",
+	Long: `Block until every condition is satisfied or the timeout expires.
+
+Conditions:
+  <service>=ready       All pods of the service are ready
+  <service>=reachable   The service ingress answers HTTP requests
+  <job>=complete        The Kubernetes job completed successfully
+  all=ready             Every configured service is ready
+
+Examples:
+  plat up --no-wait && plat wait --for all=ready
+  plat wait --for postgres=ready --for frontend=reachable --timeout 2m
+  plat wait --for db-migrate=complete`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		forValues, _ := cmd.Flags().GetStringArray("for")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		if len(forValues) == 0 {
+			return fmt.Errorf("at least one --for condition is required")
+		}
+
+		var conditions []orchestrator.WaitCondition
+		for _, value := range forValues {
+			cond, err := orchestrator.ParseWaitCondition(value)
+			if err != nil {
+				return err
+			}
+			conditions = append(conditions, cond)
+		}
+
+		runtime, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		orch := orchestrator.NewOrchestrator(verbose)
+		return orch.Wait(ctx, runtime, conditions, interval)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+
+	waitCmd.Flags().StringArray("for", nil, "Condition to wait for (<target>=ready|reachable|complete), repeatable")
+	waitCmd.Flags().Duration("timeout", 5*time.Minute, "Maximum time to wait")
+	waitCmd.Flags().Duration("interval", 2*time.Second, "Polling interval")
+}
@@ -0,0 +1,184 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/registry"
+	"plat/pkg/tools"
+)
+
+// Builder builds the images of services with a local source and makes them
+// available to the environment's cluster: pushed to the local registry when
+// the cluster pulls from it, otherwise imported into the cluster nodes
+// (pullPolicy: Never)
+type Builder struct {
+	docker   *DockerProvider
+	registry *registry.Registry
+	verbose  bool
+	noCache  bool
+	platform string
+	output   io.Writer // Build output; defaults to stdout when verbose
+}
+
+// Result describes a built image
+type Result struct {
+	Image    string // repository:tag of the content-tagged image
+	Tag      string
+	Duration time.Duration
+	Registry string // Registry pods pull the image from; empty when imported
+}
+
+// NewBuilder creates a new builder using a container runtime
+func NewBuilder(runtime tools.ContainerRuntime, verbose bool) *Builder {
+	return &Builder{
+		docker:   NewDockerProvider(runtime),
+		registry: registry.New(runtime, verbose),
+		verbose:  verbose,
+	}
+}
+
+// SetNoCache builds without docker's layer cache
+func (b *Builder) SetNoCache(noCache bool) {
+	b.noCache = noCache
+}
+
+// SetPlatform builds for a target platform such as linux/amd64
+func (b *Builder) SetPlatform(platform string) {
+	b.platform = platform
+}
+
+// SetOutput streams docker build output to w
+func (b *Builder) SetOutput(w io.Writer) {
+	b.output = w
+}
+
+// Build builds a local service's image and tags it with a tag unique to its
+// content. Deploying that tag rather than a fixed "dev" tag makes every
+// rebuild roll the pods.
+func (b *Builder) Build(ctx context.Context, service *config.ResolvedService) (*Result, error) {
+	if service.LocalSource == nil {
+		return nil, fmt.Errorf("service %s has no local source", service.Name)
+	}
+
+	opts, err := buildOptions(service)
+	if err != nil {
+		return nil, err
+	}
+	opts.NoCache = b.noCache
+	opts.Platform = b.platform
+	opts.Output = b.output
+	if opts.Output == nil && b.verbose {
+		opts.Output = os.Stdout
+	}
+	if b.verbose {
+		fmt.Printf("🔨 Building %s from %s\n", opts.Image, opts.Context)
+	}
+
+	started := time.Now()
+	if err := b.docker.BuildImage(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	id, err := b.docker.ImageID(ctx, opts.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := contentTag(id)
+	image := fmt.Sprintf("%s:%s", service.Name, tag)
+	if err := b.docker.TagImage(ctx, opts.Image, image); err != nil {
+		return nil, err
+	}
+
+	return &Result{Image: image, Tag: tag, Duration: time.Since(started)}, nil
+}
+
+// Import makes built images available to a cluster of the given provider.
+// Clusters that pull from the local registry get them pushed there, and the
+// registry's in-cluster address is returned; others get them imported into
+// their nodes.
+func (b *Builder) Import(ctx context.Context, clusters tools.ClusterProvider, cluster string, images ...string) (string, error) {
+	status, err := b.registry.Status(ctx)
+	if err == nil && status.Running && status.AttachedTo(cluster) {
+		for _, image := range images {
+			if err := b.Push(ctx, image, registry.HostAddress()+"/"+image); err != nil {
+				return "", err
+			}
+		}
+		return registry.ClusterAddress(), nil
+	}
+
+	if b.verbose {
+		fmt.Printf("📥 Importing %s into %s\n", strings.Join(images, ", "), cluster)
+	}
+	return "", clusters.ImportImages(ctx, cluster, images)
+}
+
+// Push tags a built image as target and pushes it to its registry
+func (b *Builder) Push(ctx context.Context, image, target string) error {
+	if err := b.docker.TagImage(ctx, image, target); err != nil {
+		return err
+	}
+	if b.verbose {
+		fmt.Printf("📤 Pushing %s\n", target)
+	}
+	return b.docker.PushImage(ctx, target)
+}
+
+// BuildService builds a local service's image and makes it available to
+// the cluster with Import. The "dev" tag goes along, for charts of the
+// service's own that reference it.
+func (b *Builder) BuildService(ctx context.Context, service *config.ResolvedService, clusters tools.ClusterProvider, cluster string) (*Result, error) {
+	result, err := b.Build(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Registry, err = b.Import(ctx, clusters, cluster, result.Image, fmt.Sprintf("%s:%s", service.Name, config.DefaultLocalTag))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// buildOptions resolves a service's local source into docker build options.
+// The dockerfile and context are relative to the source path.
+func buildOptions(service *config.ResolvedService) (BuildOptions, error) {
+	source := service.LocalSource
+
+	root, err := filepath.Abs(source.GetPath())
+	if err != nil {
+		return BuildOptions{}, fmt.Errorf("invalid local source path for %s: %w", service.Name, err)
+	}
+	if _, err := os.Stat(root); err != nil {
+		return BuildOptions{}, fmt.Errorf("local source for %s not found: %w", service.Name, err)
+	}
+
+	dockerfile := filepath.Join(root, source.GetDockerfile())
+	if _, err := os.Stat(dockerfile); err != nil {
+		return BuildOptions{}, fmt.Errorf("no Dockerfile for %s at %s", service.Name, dockerfile)
+	}
+
+	return BuildOptions{
+		Image:      fmt.Sprintf("%s:%s", service.Name, config.DefaultLocalTag),
+		Context:    filepath.Join(root, source.GetContext()),
+		Dockerfile: dockerfile,
+	}, nil
+}
+
+// contentTag derives the tag for an image ID: the default local tag plus
+// the first 12 hex characters of the ID
+func contentTag(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return fmt.Sprintf("%s-%s", config.DefaultLocalTag, id)
+}
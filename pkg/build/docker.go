@@ -0,0 +1,121 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+// Timeouts for docker operations; builds can pull base images and compile
+const (
+	buildTimeout = 20 * time.Minute
+	pushTimeout  = 10 * time.Minute
+	tagTimeout   = 30 * time.Second
+)
+
+// BuildOptions describes a docker image build
+type BuildOptions struct {
+	Image      string // Tag to give the built image (repository:tag)
+	Context    string // Build context directory
+	Dockerfile string // Dockerfile path
+	NoCache    bool   // Build without the layer cache
+	Platform   string // Target platform, e.g. linux/amd64
+	Output     io.Writer
+}
+
+// DockerProvider builds and tags images with the docker CLI, or the CLI of
+// another container runtime taking the same arguments
+type DockerProvider struct {
+	executor tools.ProcessExecutor
+	runtime  tools.ContainerRuntime
+}
+
+// NewDockerProvider creates a new docker provider using a container runtime
+func NewDockerProvider(runtime tools.ContainerRuntime) *DockerProvider {
+	return &DockerProvider{
+		executor: tools.NewProcessExecutor(),
+		runtime:  runtime,
+	}
+}
+
+// BuildImage builds an image from a Dockerfile. Build output is streamed to
+// opts.Output when set.
+func (d *DockerProvider) BuildImage(ctx context.Context, opts BuildOptions) error {
+	args := []string{"build", "-t", opts.Image, "-f", opts.Dockerfile}
+	if opts.NoCache {
+		args = append(args, "--no-cache")
+	}
+	if opts.Platform != "" {
+		args = append(args, "--platform", opts.Platform)
+	}
+	args = append(args, opts.Context)
+
+	cmd := tools.Command{
+		Name:    d.runtime.Name(),
+		Args:    args,
+		Env:     d.runtime.Env(),
+		Timeout: buildTimeout,
+	}
+
+	if opts.Output != nil {
+		if err := d.executor.Stream(ctx, cmd, opts.Output); err != nil {
+			return fmt.Errorf("failed to build image %s: %w", opts.Image, err)
+		}
+		return nil
+	}
+
+	if _, err := d.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to build image %s: %w", opts.Image, err)
+	}
+	return nil
+}
+
+// TagImage adds the target tag to an existing image
+func (d *DockerProvider) TagImage(ctx context.Context, source, target string) error {
+	cmd := tools.Command{
+		Name:    d.runtime.Name(),
+		Args:    []string{"tag", source, target},
+		Env:     d.runtime.Env(),
+		Timeout: tagTimeout,
+	}
+
+	if _, err := d.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to tag %s as %s: %w", source, target, err)
+	}
+	return nil
+}
+
+// PushImage pushes an image to its registry
+func (d *DockerProvider) PushImage(ctx context.Context, image string) error {
+	cmd := tools.Command{
+		Name:    d.runtime.Name(),
+		Args:    []string{"push", image},
+		Env:     d.runtime.Env(),
+		Timeout: pushTimeout,
+	}
+
+	if _, err := d.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to push image %s: %w", image, err)
+	}
+	return nil
+}
+
+// ImageID returns the content ID of an image (sha256:...)
+func (d *DockerProvider) ImageID(ctx context.Context, image string) (string, error) {
+	cmd := tools.Command{
+		Name:    d.runtime.Name(),
+		Args:    []string{"image", "inspect", "--format", "{{.Id}}", image},
+		Env:     d.runtime.Env(),
+		Timeout: tagTimeout,
+	}
+
+	result, err := d.executor.Execute(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect image %s: %w", image, err)
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
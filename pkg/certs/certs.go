@@ -0,0 +1,318 @@
+// Package certs issues certificates for ingress hosts, such as
+// frontend.platform.local, from a local certificate authority. The CA is
+// stored like mkcert's, so a CA mkcert created and installed is reused.
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+)
+
+const (
+	// caCertFile and caKeyFile are mkcert's names for the CA files
+	caCertFile = "rootCA.pem"
+	caKeyFile  = "rootCA-key.pem"
+
+	caValidity = 10 * 365 * 24 * time.Hour
+
+	// certValidity stays under the 825 days macOS accepts for server
+	// certificates
+	certValidity = 820 * 24 * time.Hour
+
+	// renewBefore is how long before expiry a host certificate is replaced
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// CA is a local certificate authority
+type CA struct {
+	Dir  string
+	Cert *x509.Certificate
+
+	certPEM []byte
+	key     crypto.Signer
+}
+
+// DefaultDir returns where the CA is kept: $CAROOT when set, as for mkcert,
+// then mkcert's own directory when it holds a CA, otherwise the plat
+// directory of the user config directory (~/.config/plat/ca on Linux)
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("CAROOT"); dir != "" {
+		return dir, nil
+	}
+	if dir := mkcertDir(); dir != "" {
+		if _, err := os.Stat(filepath.Join(dir, caKeyFile)); err == nil {
+			return dir, nil
+		}
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user config directory: %w", err)
+	}
+	return filepath.Join(dir, "plat", "ca"), nil
+}
+
+// mkcertDir returns mkcert's default CA directory on this system
+func mkcertDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(home, "Library", "Application Support", "mkcert")
+	case "windows":
+		return filepath.Join(os.Getenv("LocalAppData"), "mkcert")
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "mkcert")
+	}
+	return filepath.Join(home, ".local", "share", "mkcert")
+}
+
+// CertPath returns the path of the CA certificate, to install in trust stores
+func (ca *CA) CertPath() string {
+	return filepath.Join(ca.Dir, caCertFile)
+}
+
+// LoadCA reads the CA in dir. The error wraps fs.ErrNotExist when there is
+// none.
+func LoadCA(dir string) (*CA, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, caCertFile))
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, caKeyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := parseCert(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate in %s: %w", dir, err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("invalid CA key in %s", dir)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA key in %s: %w", dir, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA key type in %s", dir)
+	}
+
+	return &CA{Dir: dir, Cert: cert, certPEM: certPEM, key: signer}, nil
+}
+
+// LoadOrCreateCA reads the CA in dir, creating one when there is none. It
+// reports whether the CA was created; a new CA must be trusted before
+// browsers accept its certificates.
+func LoadOrCreateCA(dir string) (*CA, bool, error) {
+	ca, err := LoadCA(dir)
+	if err == nil {
+		return ca, false, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, err
+	}
+
+	ca, err = createCA(dir)
+	if err != nil {
+		return nil, false, err
+	}
+	return ca, true, nil
+}
+
+// createCA generates a CA and writes it to dir, the key readable by the user
+// only
+func createCA(dir string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"plat development CA"},
+			OrganizationalUnit: []string{owner()},
+			CommonName:         "plat " + owner(),
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create CA directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, caKeyFile), keyPEM, 0400); err != nil {
+		return nil, fmt.Errorf("failed to write CA key: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, caCertFile), certPEM, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write CA certificate: %w", err)
+	}
+
+	return &CA{Dir: dir, Cert: cert, certPEM: certPEM, key: key}, nil
+}
+
+// Issue creates a server certificate for the hosts, returning the
+// certificate and its key in PEM
+func (ca *CA) Issue(hosts []string) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"plat development certificate"},
+			OrganizationalUnit: []string{owner()},
+		},
+		DNSNames:    hosts,
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// HostCert returns a certificate for host, reusing the one cached in dir
+// while it was issued by this CA and is not about to expire. New
+// certificates are written to dir as <host>.pem and <host>-key.pem.
+func (ca *CA) HostCert(dir, host string) (certPEM, keyPEM []byte, err error) {
+	certPath := filepath.Join(dir, host+".pem")
+	keyPath := filepath.Join(dir, host+"-key.pem")
+
+	certPEM, certErr := os.ReadFile(certPath)
+	keyPEM, keyErr := os.ReadFile(keyPath)
+	if certErr == nil && keyErr == nil && ca.valid(certPEM, host) {
+		return certPEM, keyPEM, nil
+	}
+
+	certPEM, keyPEM, err = ca.Issue([]string{host})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate directory: %w", err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return nil, nil, fmt.Errorf("failed to write key for %s: %w", host, err)
+	}
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		return nil, nil, fmt.Errorf("failed to write certificate for %s: %w", host, err)
+	}
+	return certPEM, keyPEM, nil
+}
+
+// valid reports whether a certificate was issued by this CA for host and
+// stays valid for a while
+func (ca *CA) valid(certPEM []byte, host string) bool {
+	cert, err := parseCert(certPEM)
+	if err != nil || !slices.Contains(cert.DNSNames, host) {
+		return false
+	}
+	if time.Until(cert.NotAfter) < renewBefore {
+		return false
+	}
+	return cert.CheckSignatureFrom(ca.Cert) == nil
+}
+
+// Trusted reports whether the system trusts the CA, so browsers using the
+// system store accept its certificates
+func (ca *CA) Trusted() bool {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return false
+	}
+	_, err = ca.Cert.Verify(x509.VerifyOptions{Roots: pool})
+	return err == nil
+}
+
+// parseCert decodes a PEM certificate
+func parseCert(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// randomSerial returns a random 128-bit certificate serial number
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
+
+// owner names who the certificates belong to, as user@host
+func owner() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		name += "@" + host
+	}
+	return name
+}
@@ -0,0 +1,61 @@
+package certs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+// sudoTimeout leaves time to type a password at sudo's prompt
+const sudoTimeout = 2 * time.Minute
+
+// trustStore is a Linux distribution's directory of extra CA certificates
+// and the command rebuilding the system bundle from it
+type trustStore struct {
+	dir    string
+	update []string
+}
+
+// trustStores are the system trust stores of Debian, Fedora and Arch based
+// distributions
+var trustStores = []trustStore{
+	{dir: "/usr/local/share/ca-certificates", update: []string{"update-ca-certificates"}},
+	{dir: "/etc/pki/ca-trust/source/anchors", update: []string{"update-ca-trust", "extract"}},
+	{dir: "/etc/ca-certificates/trust-source/anchors", update: []string{"trust", "extract-compat"}},
+}
+
+// Trust installs the CA in the system trust store with sudo, which asks for
+// a password on the terminal. Firefox keeps its own store and isn't covered.
+func (ca *CA) Trust(ctx context.Context) error {
+	executor := tools.NewProcessExecutor()
+	sudo := func(args ...string) error {
+		if _, err := executor.Execute(ctx, tools.Command{Name: "sudo", Args: args, Timeout: sudoTimeout}); err != nil {
+			return fmt.Errorf("sudo %s failed: %w", strings.Join(args, " "), err)
+		}
+		return nil
+	}
+
+	if runtime.GOOS == "darwin" {
+		return sudo("security", "add-trusted-cert", "-d", "-r", "trustRoot", "-k", "/Library/Keychains/System.keychain", ca.CertPath())
+	}
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("installing the CA isn't supported on %s; add %s to the system trust store", runtime.GOOS, ca.CertPath())
+	}
+
+	for _, store := range trustStores {
+		if info, err := os.Stat(store.dir); err != nil || !info.IsDir() {
+			continue
+		}
+		if err := sudo("cp", ca.CertPath(), filepath.Join(store.dir, "plat-rootCA.crt")); err != nil {
+			return err
+		}
+		return sudo(store.update...)
+	}
+	return fmt.Errorf("no system trust store found; add %s to it manually", ca.CertPath())
+}
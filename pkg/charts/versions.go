@@ -0,0 +1,103 @@
+// Package charts looks up the chart versions Helm repositories offer
+package charts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"plat/pkg/config"
+	"plat/pkg/images"
+)
+
+// Checker lists the published versions of charts, reading each repository's
+// index once
+type Checker struct {
+	client *http.Client
+	tags   *images.DigestResolver
+
+	mu      sync.Mutex
+	indexes map[string]map[string][]string // Chart versions by chart, by repository URL
+}
+
+// NewChecker creates a version checker
+func NewChecker() *Checker {
+	return &Checker{
+		client:  &http.Client{Timeout: 30 * time.Second},
+		tags:    images.NewDigestResolver(),
+		indexes: make(map[string]map[string][]string),
+	}
+}
+
+// Versions returns the versions of a chart in its repository: from
+// index.yaml for http(s) repositories, from the registry's tags for OCI ones
+func (c *Checker) Versions(ctx context.Context, ref config.ChartRef) ([]string, error) {
+	switch {
+	case strings.HasPrefix(ref.RepoURL, "oci://"):
+		repository := strings.TrimSuffix(strings.TrimPrefix(ref.RepoURL, "oci://"), "/") + "/" + ref.Chart
+		return c.tags.ListTags(ctx, repository)
+	case strings.HasPrefix(ref.RepoURL, "http://"), strings.HasPrefix(ref.RepoURL, "https://"):
+		index, err := c.index(ctx, ref.RepoURL)
+		if err != nil {
+			return nil, err
+		}
+		versions, ok := index[ref.Chart]
+		if !ok {
+			return nil, fmt.Errorf("chart %s not found in %s", ref.Chart, ref.RepoURL)
+		}
+		return versions, nil
+	default:
+		return nil, fmt.Errorf("chart %s has no repository to check", ref)
+	}
+}
+
+// index returns the chart versions listed in a repository's index.yaml
+func (c *Checker) index(ctx context.Context, repoURL string) (map[string][]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if index, ok := c.indexes[repoURL]; ok {
+		return index, nil
+	}
+
+	indexURL := strings.TrimSuffix(repoURL, "/") + "/index.yaml"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", indexURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", indexURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", indexURL, err)
+	}
+	var file struct {
+		Entries map[string][]struct {
+			Version string `yaml:"version"`
+		} `yaml:"entries"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", indexURL, err)
+	}
+
+	index := make(map[string][]string, len(file.Entries))
+	for chart, entries := range file.Entries {
+		for _, entry := range entries {
+			index[chart] = append(index[chart], entry.Version)
+		}
+	}
+	c.indexes[repoURL] = index
+	return index, nil
+}
@@ -1,50 +1,331 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"path"
+	"time"
+)
 
 // BaseConfig represents the main .plat/config.yml structure
 type BaseConfig struct {
-	APIVersion string          `yaml:"apiVersion"`
-	Kind       string          `yaml:"kind"`
-	Name       string          `yaml:"name"`
-	Services   []Service       `yaml:"services"`
-	Defaults   *DefaultsConfig `yaml:"defaults,omitempty"`
+	APIVersion string          `yaml:"apiVersion"`         // Config format version, "plat/v1"
+	Kind       string          `yaml:"kind"`               // Always "Environment"
+	Name       string          `yaml:"name"`               // Environment name, also used for the cluster
+	Services   []Service       `yaml:"services"`           // Services to deploy: a name, or a full definition
+	Defaults   *DefaultsConfig `yaml:"defaults,omitempty"` // Settings shared by every service
+
+	Notifications []NotificationHook `yaml:"notifications,omitempty"` // Webhooks fired on environment events
+	ImagePolicy   *ImagePolicy       `yaml:"imagePolicy,omitempty"`   // Image hooks run before deploy
+	Repositories  []ChartRepository  `yaml:"repositories,omitempty"`  // Chart repositories services reference by alias
+	Assertions    []string           `yaml:"assertions,omitempty"`    // Checked by 'plat assert', e.g. "postgres running"
+
+	Profiles map[string]Profile `yaml:"profiles,omitempty"` // Variants selected with --profile
+}
+
+// Profile is a variant of the environment, such as "minimal" or
+// "frontend-only", selected with --profile
+type Profile struct {
+	Services []string                          `yaml:"services,omitempty"` // Services to run, plus their dependencies; empty means all
+	Values   map[string]map[string]interface{} `yaml:"values,omitempty"`   // Helm value overrides, keyed by service
+}
+
+// ImagePolicy configures image pre-processing hooks run before deploy
+type ImagePolicy struct {
+	ResolveDigests   bool   `yaml:"resolveDigests,omitempty"`   // Translate tags into registry digests
+	VerifySignatures bool   `yaml:"verifySignatures,omitempty"` // Verify cosign signatures
+	CosignKey        string `yaml:"cosignKey,omitempty"`        // Public key path; keyless if empty
+
+	// Keyless verification accepts only certificates issued to this
+	// identity by this OIDC issuer; both are required without cosignKey
+	CertificateIdentity   string `yaml:"certificateIdentity,omitempty"`   // Signer identity, e.g. a CI workflow URL or an email address
+	CertificateOIDCIssuer string `yaml:"certificateOidcIssuer,omitempty"` // Issuer of the signer's identity, e.g. https://token.actions.githubusercontent.com
+}
+
+// NotificationHook defines a webhook fired on selected environment events
+type NotificationHook struct {
+	Name   string   `yaml:"name,omitempty"`   // Shown when the hook fails
+	Type   string   `yaml:"type"`             // slack or webhook
+	URL    string   `yaml:"url"`              // Endpoint receiving the POST
+	Events []string `yaml:"events,omitempty"` // Empty means all events
+}
+
+// NotificationEvents lists the event names a notification hook can subscribe to
+var NotificationEvents = []string{
+	"up.finished",
+	"service.crashed",
 }
 
 // LocalConfig represents the .plat/local.yml structure
 type LocalConfig struct {
-	LocalSources map[string]LocalSource `yaml:"local_sources"`
+	LocalSources map[string]LocalSource `yaml:"local_sources"`    // Repositories built instead of pulling, keyed by service
+	Notify       *CompletionNotify      `yaml:"notify,omitempty"` // Notifications when long operations finish
+	Logs         *LogSettings           `yaml:"logs,omitempty"`   // Log history kept in memory
+}
+
+// LogSettings configures how much log history plat keeps in memory
+type LogSettings struct {
+	MaxLines int `yaml:"maxLines,omitempty"` // Lines kept by the TUI log view and 'plat logs --save' (default 10000)
+}
+
+// DefaultLogMaxLines is how many log lines are kept when logs.maxLines is unset
+const DefaultLogMaxLines = 10000
+
+// LogMaxLines returns how many log lines to keep; older lines are dropped
+func (l *LocalConfig) LogMaxLines() int {
+	if l == nil || l.Logs == nil || l.Logs.MaxLines <= 0 {
+		return DefaultLogMaxLines
+	}
+	return l.Logs.MaxLines
+}
+
+// CompletionNotify configures personal notifications when long operations
+// (up, down, build) finish, so developers can tab away while they run
+type CompletionNotify struct {
+	Desktop bool   `yaml:"desktop,omitempty"` // Show an OS notification
+	Bell    bool   `yaml:"bell,omitempty"`    // Ring the terminal bell
+	After   string `yaml:"after,omitempty"`   // Only notify for operations taking at least this long (default 30s)
+}
+
+// DefaultNotifyAfter is the minimum operation duration that triggers a notification
+const DefaultNotifyAfter = 30 * time.Second
+
+// Threshold returns the minimum duration an operation must take to notify
+func (n *CompletionNotify) Threshold() time.Duration {
+	if n.After == "" {
+		return DefaultNotifyAfter
+	}
+	threshold, err := time.ParseDuration(n.After)
+	if err != nil {
+		return DefaultNotifyAfter
+	}
+	return threshold
 }
 
 // DefaultsConfig contains MSC-specific default settings
 type DefaultsConfig struct {
-	Registry  string `yaml:"registry,omitempty"`
-	Domain    string `yaml:"domain,omitempty"`
-	Namespace string `yaml:"namespace,omitempty"`
-	Chart     string `yaml:"chart,omitempty"`
+	Registry  string `yaml:"registry,omitempty"`  // Registry service images are pulled from (default msc-registry.minitab.com)
+	Domain    string `yaml:"domain,omitempty"`    // Domain of service hosts (default platform.local)
+	Namespace string `yaml:"namespace,omitempty"` // Namespace services deploy into (default default)
+	Chart     string `yaml:"chart,omitempty"`     // Chart of services declared by name only (default microservice)
+
+	HelmDriver        string `yaml:"helmDriver,omitempty"`        // "cli" (helm binary, default) or "sdk" (Helm Go SDK)
+	ClusterProvider   string `yaml:"clusterProvider,omitempty"`   // "k3d" (default), "kind" or "minikube"
+	ContainerRuntime  string `yaml:"containerRuntime,omitempty"`  // "docker", "podman" or "nerdctl"; detected when empty
+	ContainerSocket   string `yaml:"containerSocket,omitempty"`   // Engine socket path; the runtime's default when empty
+	ReadyTimeout      string `yaml:"readyTimeout,omitempty"`      // How long 'plat up' waits for pods to become ready (default 5m)
+	IngressController string `yaml:"ingressController,omitempty"` // "nginx" (default), "traefik", or "none" to bring your own
+
+	Timeouts *ToolTimeouts `yaml:"timeouts,omitempty"` // How long plat waits on helm, kubectl and the cluster tools
+
+	DisableDependencyInference bool `yaml:"disableDependencyInference,omitempty"` // Only deploy in the declared dependency order
+	NetworkPolicies            bool `yaml:"networkPolicies,omitempty"`            // Deny traffic between services except along dependencies, like production
+	TLS                        bool `yaml:"tls,omitempty"`                        // Serve ingress hosts over HTTPS with certificates from a local CA
+}
+
+// ToolTimeouts limits how long plat waits on the external tools it runs,
+// for slow machines or networks. Empty fields keep the defaults.
+type ToolTimeouts struct {
+	Query     string `yaml:"query,omitempty"`     // Read-only lookups such as status and list (default 30s)
+	Mutate    string `yaml:"mutate,omitempty"`    // Uninstalls, repository updates, cluster deletion (default 2m)
+	Install   string `yaml:"install,omitempty"`   // Chart installs, including helm's wait for resources, and cluster creation (default 6m)
+	KillGrace string `yaml:"killGrace,omitempty"` // How long a timed-out tool gets to exit before it is killed (default 5s)
 }
 
-// RuntimeConfig represents the resolved configuration at runtime
+// DefaultReadyTimeout is how long 'plat up' waits for a service's pods to
+// become ready when no readyTimeout is configured
+const DefaultReadyTimeout = 5 * time.Minute
+
+// RuntimeConfig represents the resolved configuration at runtime. It is
+// shared across goroutines (the TUI refreshes status while operations run),
+// so it must not be mutated after Load; derive changed configs with Clone
+// or Filter instead.
 type RuntimeConfig struct {
-	Base             *BaseConfig
-	Local            *LocalConfig
-	Mode             ExecutionMode
-	ResolvedServices map[string]*ResolvedService
-	Timestamp        time.Time
+	ConfigFile         string // Path of the loaded config.yml
+	Base               *BaseConfig
+	Local              *LocalConfig
+	Mode               ExecutionMode
+	ResolvedServices   map[string]*ResolvedService
+	ServiceOrder       []string // Service names in config declaration order
+	Profile            string   // Active profile, if any
+	Timestamp          time.Time
+	AllowAnyCluster    bool              // Skip checking that operations target the environment's local cluster
+	NetworkPoliciesOff bool              // Network policies switched off for debugging with 'plat netpol disable'
+	LockedAddons       map[string]string // Cluster addon versions 'plat up --frozen' installs, from the lock file
+	NoHostPorts        bool              // Create the cluster without host port mappings, to run next to the environment (bench, clones)
+	Kubeconfig         string            // Kubeconfig of the cluster; empty means KubeconfigFile in the config directory
+	unfiltered         *RuntimeConfig    // Configuration Filter narrowed down, if any
+}
+
+// Unfiltered returns the configuration of the whole environment, before
+// Filter narrowed it down to some services
+func (r *RuntimeConfig) Unfiltered() *RuntimeConfig {
+	if r.unfiltered != nil {
+		return r.unfiltered
+	}
+	return r
+}
+
+// NetworkPoliciesEnabled reports whether 'plat up' isolates services with
+// NetworkPolicies: configured with defaults.networkPolicies and not switched
+// off
+func (r *RuntimeConfig) NetworkPoliciesEnabled() bool {
+	return r.Base.Defaults.NetworkPolicies && !r.NetworkPoliciesOff
+}
+
+// TLSEnabled reports whether ingress hosts are served over HTTPS: configured
+// with defaults.tls and with a domain for the hosts
+func (r *RuntimeConfig) TLSEnabled() bool {
+	return r.Base.Defaults.TLS && r.Base.Defaults.Domain != ""
+}
+
+// Ingress controllers plat installs into the cluster
+const (
+	IngressNginx   = "nginx"
+	IngressTraefik = "traefik"
+	IngressNone    = "none" // Installed by the user, or by the cluster provider
+)
+
+// IngressClass returns the ingress class service Ingresses use: the
+// installed controller's, or nginx when the user brings their own
+func (r *RuntimeConfig) IngressClass() string {
+	if r.Base.Defaults.IngressController == IngressTraefik {
+		return IngressTraefik
+	}
+	return IngressNginx
+}
+
+// IngressScheme returns the scheme of URLs on ingress hosts
+func (r *RuntimeConfig) IngressScheme() string {
+	if r.TLSEnabled() {
+		return "https"
+	}
+	return "http"
 }
 
 // ResolvedService is a service with all overrides and defaults applied
 type ResolvedService struct {
-	Name         string
-	Version      string
-	IsLocal      bool
-	LocalSource  *LocalSource
-	Chart        ServiceChart
-	Values       map[string]interface{}
-	ValuesFile   string
-	Ports        []int
-	Environment  map[string]string
-	Dependencies []string
+	Name          string
+	Version       string
+	IsLocal       bool
+	LocalSource   *LocalSource
+	Chart         ServiceChart
+	Values        map[string]interface{}
+	ValuesFile    string
+	Ports         []int
+	Environment   map[string]string
+	Dependencies  []string
+	Inferred      []string // Dependencies added from environment references, not declared
+	ImageDigest   string   // Registry digest pinned by image hooks (sha256:...)
+	LocalTag      string   // Tag of the image built from the local source
+	LocalRegistry string   // Registry the local image is pulled from; empty when imported into the cluster
+	Protected     bool     // Holds long-lived state; kept by 'plat down' by default
+	DataRetention string   // DataRetentionKeep or DataRetentionDelete
+	Patches       []ManifestPatch
+	Manifests     string          // Manifests directory, relative to the config directory
+	Kustomize     string          // Kustomize overlay directory, relative to the config directory
+	OpenOnUp      bool            // Entry point opened in the browser by 'plat up --open'
+	ReadyTimeout  time.Duration   // How long 'plat up' waits for the pods to become ready
+	Readiness     *ReadinessProbe // Checked before dependent services deploy; HTTP probes have Port set
+	DisplayName   string          // Human-friendly name; empty means Name
+	Aliases       []string        // Short names accepted in place of Name
+	EnvFrom       string          // EnvFromSecret, or empty
+	LogLevel      string          // Log level passed to the pods; empty leaves the service's default
+	Debug         bool            // Debug logging, from the config or 'plat debug enable'
+	LogLevelValue string          // Chart value path taking the log level; empty uses env vars
+}
+
+// DefaultLocalTag is the tag local services deploy when no image was built
+const DefaultLocalTag = "dev"
+
+// LocalImageTag returns the tag of the service's locally built image
+func (s *ResolvedService) LocalImageTag() string {
+	if s.LocalTag != "" {
+		return s.LocalTag
+	}
+	return DefaultLocalTag
+}
+
+// Label returns the name to show for the service: its display name if set
+func (s *ResolvedService) Label() string {
+	if s.DisplayName != "" {
+		return s.DisplayName
+	}
+	return s.Name
+}
+
+// EffectiveLogLevel returns the log level the service's pods run with:
+// "debug" when debugging, else the configured level
+func (s *ResolvedService) EffectiveLogLevel() string {
+	if s.Debug {
+		return "debug"
+	}
+	return s.LogLevel
+}
+
+// PodEnvironment returns the environment variables set in the service's
+// pods: its configured environment plus LOG_LEVEL and DEBUG, unless the
+// level goes to a chart value instead. Configured variables take precedence.
+func (s *ResolvedService) PodEnvironment() map[string]string {
+	level := s.EffectiveLogLevel()
+	if level == "" || s.LogLevelValue != "" {
+		return s.Environment
+	}
+	env := map[string]string{"LOG_LEVEL": level}
+	if s.Debug {
+		env["DEBUG"] = "true"
+	}
+	for key, value := range s.Environment {
+		env[key] = value
+	}
+	return env
+}
+
+// LocalImageRepository returns the repository of the service's locally
+// built image, as pods reference it
+func (s *ResolvedService) LocalImageRepository() string {
+	if s.LocalRegistry != "" {
+		return s.LocalRegistry + "/" + s.Name
+	}
+	return s.Name
+}
+
+// Engine returns how the service is deployed: EngineHelm, EngineManifests or EngineKustomize
+func (s *ResolvedService) Engine() string {
+	switch {
+	case s.Kustomize != "":
+		return EngineKustomize
+	case s.Manifests != "":
+		return EngineManifests
+	default:
+		return EngineHelm
+	}
+}
+
+// AppliesManifests reports whether the service is deployed by rendering
+// manifests and applying them with kubectl rather than installing a Helm release
+func (s *ResolvedService) AppliesManifests() bool {
+	return s.Engine() != EngineHelm || len(s.Patches) > 0
+}
+
+// DeletesData reports whether 'plat down' should remove the service's volumes
+func (s *ResolvedService) DeletesData() bool {
+	return s.DataRetention == DataRetentionDelete
+}
+
+// IsMicroserviceChart reports whether the service is deployed with the MSC
+// microservice chart, whose image plat manages, whatever repository it comes
+// from ("microservice", "msc/microservice"). Third-party charts (postgresql,
+// redis, etc.) have their own image configuration.
+func (s *ResolvedService) IsMicroserviceChart() bool {
+	if s.Engine() != EngineHelm {
+		return false
+	}
+	return path.Base(s.Chart.Name) == "microservice"
+}
+
+// ImageRepository returns the registry repository for a non-local service image
+func (r *RuntimeConfig) ImageRepository(service *ResolvedService) string {
+	return fmt.Sprintf("%s/%s", r.Base.Defaults.Registry, service.Name)
 }
 
 // ExecutionMode defines how services should be executed
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VersionBump is a new version for a service in config.yml: its image
+// version, or its chart version when Chart is set
+type VersionBump struct {
+	Service string
+	Chart   bool
+	Version string
+}
+
+// BumpVersions writes new versions into a config file, keeping its comments
+// and key order. It returns the services whose version isn't set in the
+// file's services list, such as services declared by name only, which are
+// left unchanged.
+func BumpVersions(path string, bumps []VersionBump) (skipped []string, err error) {
+	if RenderedConfig(path) {
+		return nil, fmt.Errorf("%s is rendered by an external tool and can't be rewritten", filepath.Base(path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s is not a YAML mapping", path)
+	}
+
+	entries := make(map[string]*yaml.Node)
+	if services := mappingValue(doc.Content[0], "services"); services != nil && services.Kind == yaml.SequenceNode {
+		for _, entry := range services.Content {
+			if entry.Kind != yaml.MappingNode {
+				continue
+			}
+			if name := mappingValue(entry, "name"); name != nil {
+				entries[name.Value] = entry
+			}
+		}
+	}
+
+	for _, bump := range bumps {
+		entry := entries[bump.Service]
+		if entry == nil {
+			skipped = append(skipped, bump.Service)
+			continue
+		}
+		if !bump.Chart {
+			setMappingValue(entry, "version", bump.Version)
+			continue
+		}
+		chart := mappingValue(entry, "chart")
+		if chart == nil || chart.Kind != yaml.MappingNode {
+			skipped = append(skipped, bump.Service)
+			continue
+		}
+		setMappingValue(chart, "version", bump.Version)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	encoder.Close()
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+	return skipped, nil
+}
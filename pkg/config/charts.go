@@ -0,0 +1,123 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChartRepository is a named Helm chart repository declared in config.yml
+type ChartRepository struct {
+	Name string `yaml:"name"` // Alias used as the helm repo name and in chart refs
+	URL  string `yaml:"url"`  // http(s):// index URL or oci:// registry
+}
+
+// ChartRef is a fully qualified chart reference
+type ChartRef struct {
+	RepoName string // Helm repository name to add; empty for OCI and local charts
+	RepoURL  string // Repository URL; empty for local charts
+	Chart    string // Chart name, or the path of a local chart
+}
+
+// String returns the reference helm install/template accepts
+func (c ChartRef) String() string {
+	switch {
+	case c.RepoName != "":
+		return c.RepoName + "/" + c.Chart
+	case strings.HasPrefix(c.RepoURL, "oci://"):
+		return strings.TrimSuffix(c.RepoURL, "/") + "/" + c.Chart
+	default:
+		return c.Chart
+	}
+}
+
+// ResolveChart fully qualifies a service's chart reference. The chart
+// repository may be a declared alias or a URL; URLs matching a declared
+// alias use it, others get a stable name derived from the URL so two
+// repositories never share a helm repo name.
+func (r *RuntimeConfig) ResolveChart(service *ResolvedService) (ChartRef, error) {
+	chart := service.Chart
+
+	if chart.Repository == "" {
+		// Local chart paths are used as-is
+		if strings.HasPrefix(chart.Name, ".") || strings.HasPrefix(chart.Name, "/") {
+			return ChartRef{Chart: chart.Name}, nil
+		}
+
+		// alias/chart form
+		if prefix, name, ok := strings.Cut(chart.Name, "/"); ok {
+			if repo, found := r.chartRepository(prefix); found {
+				return ChartRef{RepoName: repo.Name, RepoURL: repo.URL, Chart: name}, nil
+			}
+			// A repository the user configured in helm directly
+			return ChartRef{Chart: chart.Name}, nil
+		}
+
+		return ChartRef{}, fmt.Errorf("chart '%s' of service %s needs a repository. Either:\n  • Add a 'repository' field to the service chart (an alias from 'repositories' or a URL)\n  • Use 'alias/chart' format with an alias declared under 'repositories'%s\n  • Provide a local chart path",
+			chart.Name, service.Name, r.repositoryHint())
+	}
+
+	if strings.Contains(chart.Name, "/") {
+		return ChartRef{}, fmt.Errorf("chart '%s' of service %s sets a repository, so the chart name must not contain '/'", chart.Name, service.Name)
+	}
+
+	// Declared alias
+	if repo, found := r.chartRepository(chart.Repository); found {
+		return ChartRef{RepoName: repo.Name, RepoURL: repo.URL, Chart: chart.Name}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(chart.Repository, "oci://"):
+		return ChartRef{RepoURL: chart.Repository, Chart: chart.Name}, nil
+	case strings.HasPrefix(chart.Repository, "http://"), strings.HasPrefix(chart.Repository, "https://"):
+		for _, repo := range r.Base.Repositories {
+			if sameRepositoryURL(repo.URL, chart.Repository) {
+				return ChartRef{RepoName: repo.Name, RepoURL: repo.URL, Chart: chart.Name}, nil
+			}
+		}
+		return ChartRef{RepoName: derivedRepositoryName(chart.Repository), RepoURL: chart.Repository, Chart: chart.Name}, nil
+	default:
+		return ChartRef{}, fmt.Errorf("unknown chart repository %q for service %s; declare it under 'repositories' or use a URL%s",
+			chart.Repository, service.Name, r.repositoryHint())
+	}
+}
+
+// chartRepository looks up a declared repository alias
+func (r *RuntimeConfig) chartRepository(name string) (ChartRepository, bool) {
+	for _, repo := range r.Base.Repositories {
+		if repo.Name == name {
+			return repo, true
+		}
+	}
+	return ChartRepository{}, false
+}
+
+// repositoryHint lists the declared aliases for error messages
+func (r *RuntimeConfig) repositoryHint() string {
+	if len(r.Base.Repositories) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(r.Base.Repositories))
+	for _, repo := range r.Base.Repositories {
+		names = append(names, repo.Name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("\n  (declared repositories: %s)", strings.Join(names, ", "))
+}
+
+// derivedRepositoryName names an undeclared repository URL. The hash keeps
+// different URLs with the same host apart.
+func derivedRepositoryName(url string) string {
+	sum := sha256.Sum256([]byte(normalizeRepositoryURL(url)))
+	return "plat-" + hex.EncodeToString(sum[:])[:10]
+}
+
+func sameRepositoryURL(a, b string) bool {
+	return normalizeRepositoryURL(a) == normalizeRepositoryURL(b)
+}
+
+func normalizeRepositoryURL(url string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(url)), "/")
+}
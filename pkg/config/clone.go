@@ -0,0 +1,108 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rename rewrites the packaged config so it describes an environment with a
+// different name (and therefore cluster) and optionally a different namespace.
+// Comments and key order in the config are preserved.
+func (p *EnvironmentPackage) Rename(name, namespace string) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(p.Config), &doc); err != nil {
+		return fmt.Errorf("failed to parse packaged config: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("packaged config is not a YAML mapping")
+	}
+
+	root := doc.Content[0]
+	setMappingValue(root, "name", name)
+
+	if namespace != "" {
+		defaults := mappingValue(root, "defaults")
+		if defaults == nil {
+			defaults = &yaml.Node{Kind: yaml.MappingNode}
+			root.Content = append(root.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "defaults"},
+				defaults,
+			)
+		}
+		setMappingValue(defaults, "namespace", namespace)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	encoder.Close()
+
+	p.Name = name
+	p.Config = buf.String()
+	return nil
+}
+
+// CopyEnvironmentFiles copies machine-local files that are not part of a
+// package (local.yml and the lock file) from one config directory to another.
+// Missing files are skipped.
+func CopyEnvironmentFiles(fromDir, toDir string) error {
+	for _, name := range []string{"local.yml", LockFileName} {
+		src, err := os.Open(filepath.Join(fromDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		dst, err := os.Create(filepath.Join(toDir, name))
+		if err != nil {
+			src.Close()
+			return err
+		}
+
+		_, err = io.Copy(dst, src)
+		src.Close()
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping node
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets a scalar value in a mapping node, adding the key if needed
+func setMappingValue(mapping *yaml.Node, key, value string) {
+	if node := mappingValue(mapping, key); node != nil {
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!str"
+		node.Value = value
+		node.Content = nil
+		return
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+	)
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnvVar is a single environment variable assignment
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// EnvVarPrefix converts a service name into an environment variable prefix
+// (payment-api -> PAYMENT_API)
+func EnvVarPrefix(serviceName string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(serviceName))
+}
+
+// NativeEnv builds the environment a service needs to run natively (outside
+// the cluster) while talking to the rest of the environment. Connection
+// details are emitted for the service's dependencies, or for every other
+// service when all is true.
+func (r *RuntimeConfig) NativeEnv(serviceName string, all bool) ([]EnvVar, error) {
+	service, exists := r.ResolvedServices[serviceName]
+	if !exists {
+		return nil, &UnknownServiceError{Name: serviceName}
+	}
+
+	var vars []EnvVar
+
+	// The service's own configured environment
+	environment := service.PodEnvironment()
+	keys := make([]string, 0, len(environment))
+	for key := range environment {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		vars = append(vars, EnvVar{Key: key, Value: environment[key]})
+	}
+
+	if len(service.Ports) > 0 {
+		vars = append(vars, EnvVar{Key: "PORT", Value: fmt.Sprintf("%d", service.Ports[0])})
+	}
+
+	// Connection details for the services it talks to
+	targets := service.Dependencies
+	if all {
+		targets = nil
+		for _, name := range r.ListServices() {
+			if name != serviceName {
+				targets = append(targets, name)
+			}
+		}
+	}
+	targets = append([]string(nil), targets...)
+	sort.Strings(targets)
+
+	domain := r.Base.Defaults.Domain
+	for _, name := range targets {
+		target, exists := r.ResolvedServices[name]
+		if !exists {
+			continue
+		}
+
+		prefix := EnvVarPrefix(name)
+		if domain != "" {
+			host := fmt.Sprintf("%s.%s", name, domain)
+			vars = append(vars,
+				EnvVar{Key: prefix + "_HOST", Value: host},
+				EnvVar{Key: prefix + "_URL", Value: r.IngressScheme() + "://" + host},
+			)
+		}
+
+		// Service ports are mapped through the k3d load balancer to localhost
+		if len(target.Ports) > 0 {
+			vars = append(vars,
+				EnvVar{Key: prefix + "_PORT", Value: fmt.Sprintf("%d", target.Ports[0])},
+				EnvVar{Key: prefix + "_LOCAL_URL", Value: fmt.Sprintf("http://localhost:%d", target.Ports[0])},
+			)
+		}
+	}
+
+	return vars, nil
+}
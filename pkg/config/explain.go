@@ -0,0 +1,384 @@
+package config
+
+//go:generate go run ./internal/fielddocs
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Config files a key can belong to
+const (
+	ConfigFileBase  = "config.yml"
+	ConfigFileLocal = "local.yml"
+)
+
+// KeyDoc documents a config key for 'plat explain'
+type KeyDoc struct {
+	Path        string   // Dotted key path, e.g. "services.chart.repository"
+	File        string   // ConfigFileBase or ConfigFileLocal
+	Type        string   // e.g. "string", "list of object"
+	Description string   // From the comments of the config structs
+	Default     string   // Empty when the key has no default
+	Example     string   // YAML snippet setting the key
+	Shorthand   string   // What a plain string in place of the object means
+	Keys        []KeyDoc // Keys of an object, without their own keys
+}
+
+// UnknownKeyError is returned for a path that matches no config key
+type UnknownKeyError struct {
+	Path        string
+	Suggestions []string // Keys valid where the path went wrong, closest first
+}
+
+func (e *UnknownKeyError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("unknown config key '%s'; did you mean %s?", e.Path, quoteAlternatives(e.Suggestions))
+	}
+	return fmt.Sprintf("unknown config key '%s'", e.Path)
+}
+
+// unionTypes are types that also accept a plain string, described by it
+var unionTypes = map[reflect.Type]string{
+	reflect.TypeOf(Service{}):     "a service name",
+	reflect.TypeOf(LocalSource{}): "a repository path",
+}
+
+var (
+	defaultPattern       = regexp.MustCompile(`\(default:? ([^)]+)\)`)
+	defaultChoicePattern = regexp.MustCompile(`"?([\w.-]+)"? \(default\)`)
+	examplePattern       = regexp.MustCompile(`e\.g\. "([^"]+)"`)
+)
+
+// keyStep is a key of a path, with the struct field it names
+type keyStep struct {
+	name  string
+	field reflect.StructField
+}
+
+// TopLevelKeys documents the keys of config.yml and local.yml
+func TopLevelKeys() []KeyDoc {
+	var keys []KeyDoc
+	for _, root := range configRoots() {
+		for _, step := range yamlFields(root.typ) {
+			keys = append(keys, describeKey(root, []keyStep{step}))
+		}
+	}
+	return keys
+}
+
+// ExplainKey documents the config key at a dotted path, such as
+// "services.chart.repository". Lists and maps are entered without naming
+// an element, although "profiles.minimal.services" names a profile.
+func ExplainKey(path string) (*KeyDoc, error) {
+	names := strings.Split(strings.Trim(path, "."), ".")
+	for _, root := range configRoots() {
+		if _, ok := yamlField(root.typ, names[0]); !ok {
+			continue
+		}
+		steps, err := resolveKeyPath(root.typ, names, path)
+		if err != nil {
+			return nil, err
+		}
+		doc := describeKey(root, steps)
+		for _, step := range yamlFields(elemType(steps[len(steps)-1].field.Type)) {
+			doc.Keys = append(doc.Keys, describeKey(root, append(steps[:len(steps):len(steps)], step)))
+		}
+		return &doc, nil
+	}
+
+	var candidates []string
+	for _, key := range TopLevelKeys() {
+		candidates = append(candidates, key.Path)
+	}
+	return nil, &UnknownKeyError{Path: path, Suggestions: suggestKeys(names[0], candidates)}
+}
+
+type configRoot struct {
+	file string
+	typ  reflect.Type
+}
+
+func configRoots() []configRoot {
+	return []configRoot{
+		{ConfigFileBase, reflect.TypeOf(BaseConfig{})},
+		{ConfigFileLocal, reflect.TypeOf(LocalConfig{})},
+	}
+}
+
+// resolveKeyPath follows the names through the structs from root
+func resolveKeyPath(root reflect.Type, names []string, path string) ([]keyStep, error) {
+	var steps []keyStep
+	current := root
+	for i := 0; i < len(names); i++ {
+		name := names[i]
+		step, ok := yamlField(current, name)
+		if !ok {
+			// A map key, e.g. a profile or service name
+			if i > 0 && isMap(steps[len(steps)-1].field.Type) && i+1 < len(names) {
+				continue
+			}
+			var candidates []string
+			for _, field := range yamlFields(current) {
+				candidates = append(candidates, field.name)
+			}
+			prefix := strings.Join(names[:i], ".")
+			suggestions := suggestKeys(name, candidates)
+			for j := range suggestions {
+				suggestions[j] = strings.TrimPrefix(prefix+"."+suggestions[j], ".")
+			}
+			return nil, &UnknownKeyError{Path: path, Suggestions: suggestions}
+		}
+		steps = append(steps, step)
+		current = elemType(step.field.Type)
+	}
+	return steps, nil
+}
+
+// describeKey documents the key the steps lead to, without its keys
+func describeKey(root configRoot, steps []keyStep) KeyDoc {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.name
+	}
+
+	last := steps[len(steps)-1]
+	description := fieldDoc(root, steps)
+	doc := KeyDoc{
+		Path:        strings.Join(names, "."),
+		File:        root.file,
+		Type:        typeName(last.field.Type),
+		Description: description,
+		Default:     defaultValue(description),
+		Shorthand:   unionTypes[elemType(last.field.Type)],
+	}
+	doc.Example = exampleYAML(steps, doc)
+	return doc
+}
+
+// fieldDoc returns the comment of the key's field, or of its type
+func fieldDoc(root configRoot, steps []keyStep) string {
+	owner := root.typ
+	if len(steps) > 1 {
+		owner = elemType(steps[len(steps)-2].field.Type)
+	}
+
+	last := steps[len(steps)-1]
+	if doc := configDocs[owner.Name()+"."+last.field.Name]; doc != "" {
+		return doc
+	}
+	if elem := elemType(last.field.Type); elem.Kind() == reflect.Struct {
+		return configDocs[elem.Name()]
+	}
+	return ""
+}
+
+// defaultValue extracts a default from a comment, e.g. "(default 5m)" or
+// "keep (default) or delete"
+func defaultValue(description string) string {
+	if match := defaultPattern.FindStringSubmatch(description); match != nil {
+		return strings.Trim(match[1], `"`)
+	}
+	if match := defaultChoicePattern.FindStringSubmatch(description); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// exampleYAML sets the key in a snippet nesting it under its parents
+func exampleYAML(steps []keyStep, doc KeyDoc) string {
+	var b strings.Builder
+	prefix, indent := "", ""
+	for i, step := range steps {
+		b.WriteString(prefix + step.name + ":")
+		if i == len(steps)-1 {
+			break
+		}
+		b.WriteString("\n")
+
+		switch step.field.Type.Kind() {
+		case reflect.Slice:
+			prefix, indent = indent+"  - ", indent+"    "
+		case reflect.Map:
+			b.WriteString(indent + "  <name>:\n")
+			prefix, indent = indent+"    ", indent+"    "
+		default:
+			prefix, indent = indent+"  ", indent+"  "
+		}
+	}
+
+	last := steps[len(steps)-1]
+	t := last.field.Type
+	switch {
+	case elemType(t).Kind() == reflect.Struct:
+		fields := yamlFields(elemType(t))
+		childIndent := indent + "  "
+		switch t.Kind() {
+		case reflect.Slice:
+			b.WriteString("\n" + indent + "  - ")
+			childIndent = indent + "    "
+		case reflect.Map:
+			b.WriteString("\n" + indent + "  <name>:\n" + indent + "    ")
+			childIndent = indent + "    "
+		default:
+			b.WriteString("\n" + childIndent)
+		}
+		first := true
+		for _, field := range fields {
+			if strings.HasPrefix(configDocs[elemType(t).Name()+"."+field.field.Name], "Deprecated") {
+				continue
+			}
+			if !first {
+				b.WriteString("\n" + childIndent)
+			}
+			first = false
+			b.WriteString(field.name + ": " + placeholder(field.field.Type, ""))
+		}
+	case t.Kind() == reflect.Slice:
+		b.WriteString("\n" + indent + "  - " + placeholder(t.Elem(), exampleValue(doc.Description)))
+	case t.Kind() == reflect.Map:
+		b.WriteString("\n" + indent + "  <name>: " + placeholder(t.Elem(), ""))
+	default:
+		value := doc.Default
+		if value == "" {
+			value = exampleValue(doc.Description)
+		}
+		b.WriteString(" " + placeholder(t, value))
+	}
+	return b.String()
+}
+
+// exampleValue extracts an example from a comment, e.g. `e.g. "pay"`
+func exampleValue(description string) string {
+	if match := examplePattern.FindStringSubmatch(description); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// placeholder returns value, or a placeholder for a value of type t
+func placeholder(t reflect.Type, value string) string {
+	if value != "" {
+		return value
+	}
+	switch elemType(t).Kind() {
+	case reflect.Bool:
+		return "true"
+	case reflect.Struct:
+		if t.Kind() == reflect.Slice {
+			return "[...]"
+		}
+		return "{...}"
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		return "[...]"
+	case reflect.Map:
+		return "{...}"
+	}
+	return "<" + typeName(t) + ">"
+}
+
+// typeName describes a type in config terms, e.g. "list of string"
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeName(t.Elem())
+	case reflect.Slice:
+		return "list of " + typeName(t.Elem())
+	case reflect.Map:
+		return "map of " + typeName(t.Key()) + " to " + typeName(t.Elem())
+	case reflect.Interface:
+		return "any"
+	case reflect.Struct:
+		if _, ok := unionTypes[t]; ok {
+			return "string or object"
+		}
+		return "object"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// elemType returns the struct a key's value holds, entering pointers,
+// lists and maps
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+func isMap(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map
+}
+
+// yamlFields returns the keys of a struct, in declaration order
+func yamlFields(t reflect.Type) []keyStep {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var steps []keyStep
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		steps = append(steps, keyStep{name: name, field: field})
+	}
+	return steps
+}
+
+func yamlField(t reflect.Type, name string) (keyStep, bool) {
+	for _, step := range yamlFields(t) {
+		if step.name == name {
+			return step, true
+		}
+	}
+	return keyStep{}, false
+}
+
+// suggestKeys returns the candidates within a few edits of name, closest
+// first
+func suggestKeys(name string, candidates []string) []string {
+	key := strings.ToLower(name)
+	limit := max(2, len(key)/3)
+
+	type suggestion struct {
+		key      string
+		distance int
+	}
+	var suggestions []suggestion
+	for _, candidate := range candidates {
+		distance := levenshtein(key, strings.ToLower(candidate))
+		if len(key) >= 3 && strings.HasPrefix(strings.ToLower(candidate), key) {
+			distance = 1
+		}
+		if distance <= limit {
+			suggestions = append(suggestions, suggestion{candidate, distance})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	var keys []string
+	for _, s := range suggestions {
+		if len(keys) == maxSuggestions {
+			break
+		}
+		keys = append(keys, s.key)
+	}
+	return keys
+}
@@ -0,0 +1,141 @@
+// Code generated by go run ./internal/fielddocs; DO NOT EDIT.
+
+package config
+
+// configDocs holds the comments of the config structs and their fields,
+// keyed by "Type" and "Type.Field"
+var configDocs = map[string]string{
+	"BaseConfig":                                "BaseConfig represents the main .plat/config.yml structure",
+	"BaseConfig.APIVersion":                     "Config format version, \"plat/v1\"",
+	"BaseConfig.Assertions":                     "Checked by 'plat assert', e.g. \"postgres running\"",
+	"BaseConfig.Defaults":                       "Settings shared by every service",
+	"BaseConfig.ImagePolicy":                    "Image hooks run before deploy",
+	"BaseConfig.Kind":                           "Always \"Environment\"",
+	"BaseConfig.Name":                           "Environment name, also used for the cluster",
+	"BaseConfig.Notifications":                  "Webhooks fired on environment events",
+	"BaseConfig.Profiles":                       "Variants selected with --profile",
+	"BaseConfig.Repositories":                   "Chart repositories services reference by alias",
+	"BaseConfig.Services":                       "Services to deploy: a name, or a full definition",
+	"ChartRef":                                  "ChartRef is a fully qualified chart reference",
+	"ChartRepository":                           "ChartRepository is a named Helm chart repository declared in config.yml",
+	"ChartRepository.Name":                      "Alias used as the helm repo name and in chart refs",
+	"ChartRepository.URL":                       "http(s):// index URL or oci:// registry",
+	"CompletionNotify":                          "CompletionNotify configures personal notifications when long operations (up, down, build) finish, so developers can tab away while they run",
+	"CompletionNotify.After":                    "Only notify for operations taking at least this long (default 30s)",
+	"CompletionNotify.Bell":                     "Ring the terminal bell",
+	"CompletionNotify.Desktop":                  "Show an OS notification",
+	"ConfigValidator":                           "ConfigValidator handles configuration validation",
+	"DefaultsConfig":                            "DefaultsConfig contains MSC-specific default settings",
+	"DefaultsConfig.Chart":                      "Chart of services declared by name only (default microservice)",
+	"DefaultsConfig.ClusterProvider":            "\"k3d\" (default), \"kind\" or \"minikube\"",
+	"DefaultsConfig.ContainerRuntime":           "\"docker\", \"podman\" or \"nerdctl\"; detected when empty",
+	"DefaultsConfig.ContainerSocket":            "Engine socket path; the runtime's default when empty",
+	"DefaultsConfig.DisableDependencyInference": "Only deploy in the declared dependency order",
+	"DefaultsConfig.Domain":                     "Domain of service hosts (default platform.local)",
+	"DefaultsConfig.HelmDriver":                 "\"cli\" (helm binary, default) or \"sdk\" (Helm Go SDK)",
+	"DefaultsConfig.IngressController":          "\"nginx\" (default), \"traefik\", or \"none\" to bring your own",
+	"DefaultsConfig.Namespace":                  "Namespace services deploy into (default default)",
+	"DefaultsConfig.NetworkPolicies":            "Deny traffic between services except along dependencies, like production",
+	"DefaultsConfig.ReadyTimeout":               "How long 'plat up' waits for pods to become ready (default 5m)",
+	"DefaultsConfig.Registry":                   "Registry service images are pulled from (default msc-registry.minitab.com)",
+	"DefaultsConfig.TLS":                        "Serve ingress hosts over HTTPS with certificates from a local CA",
+	"DefaultsConfig.Timeouts":                   "How long plat waits on helm, kubectl and the cluster tools",
+	"EnvVar":                                    "EnvVar is a single environment variable assignment",
+	"EnvironmentPackage":                        "EnvironmentPackage is a single-file, shareable snapshot of an environment spec",
+	"EnvironmentPackage.Config":                 "Rendered config.yml contents",
+	"EnvironmentPackage.LocalSources":           "Services the exporter ran from local sources; the importer must declare their own local.yml entries to reproduce them",
+	"EnvironmentPackage.ValuesFiles":            "Relative path -> contents",
+	"ImagePolicy":                               "ImagePolicy configures image pre-processing hooks run before deploy",
+	"ImagePolicy.CertificateIdentity":           "Signer identity, e.g. a CI workflow URL or an email address",
+	"ImagePolicy.CertificateOIDCIssuer":         "Issuer of the signer's identity, e.g. https://token.actions.githubusercontent.com",
+	"ImagePolicy.CosignKey":                     "Public key path; keyless if empty",
+	"ImagePolicy.ResolveDigests":                "Translate tags into registry digests",
+	"ImagePolicy.VerifySignatures":              "Verify cosign signatures",
+	"KeyDoc":                                    "KeyDoc documents a config key for 'plat explain'",
+	"Loader":                                    "Loader handles configuration loading and merging",
+	"LocalConfig":                               "LocalConfig represents the .plat/local.yml structure",
+	"LocalConfig.LocalSources":                  "Repositories built instead of pulling, keyed by service",
+	"LocalConfig.Logs":                          "Log history kept in memory",
+	"LocalConfig.Notify":                        "Notifications when long operations finish",
+	"LocalSource":                               "LocalSource represents a local source definition with union type support",
+	"LocalSource.Chart":                         "Chart directory, relative to the repository (default chart)",
+	"LocalSource.Context":                       "Build context, relative to the repository (default .)",
+	"LocalSource.Dockerfile":                    "Dockerfile, relative to the repository (default Dockerfile)",
+	"LocalSource.LocalPath":                     "Repository checkout",
+	"Lock":                                      "Lock captures the exact artifacts an environment was deployed with",
+	"Lock.Addons":                               "Cluster addon release (e.g. ingress-nginx) -> chart version",
+	"LockedService":                             "LockedService records what was actually deployed for a service",
+	"LockedService.Digest":                      "Digest of the image plat sets, pinned by image hooks or the running pods",
+	"LockedService.Images":                      "Image references with digests",
+	"LogSettings":                               "LogSettings configures how much log history plat keeps in memory",
+	"LogSettings.MaxLines":                      "Lines kept by the TUI log view and 'plat logs --save' (default 10000)",
+	"ManifestPatch":                             "ManifestPatch is a kustomize-style patch applied to a service's rendered manifests. The patch is either a strategic merge patch (a partial resource) or a list of JSON6902 operations, which require a target.",
+	"ManifestPatch.Patch":                       "Strategic merge patch or JSON6902 operations, as YAML",
+	"ManifestPatch.Target":                      "Resources patched; required for JSON6902 patches",
+	"NotificationHook":                          "NotificationHook defines a webhook fired on selected environment events",
+	"NotificationHook.Events":                   "Empty means all events",
+	"NotificationHook.Name":                     "Shown when the hook fails",
+	"NotificationHook.Type":                     "slack or webhook",
+	"NotificationHook.URL":                      "Endpoint receiving the POST",
+	"PatchTarget":                               "PatchTarget selects the resources a patch applies to",
+	"PatchTarget.Group":                         "API group, e.g. \"apps\"",
+	"PatchTarget.Kind":                          "Resource kind, e.g. \"Deployment\"",
+	"PatchTarget.LabelSelector":                 "Label selector, e.g. \"app=web\"",
+	"PatchTarget.Name":                          "Resource name",
+	"PatchTarget.Namespace":                     "Resource namespace",
+	"PatchTarget.Version":                       "API version, e.g. \"v1\"",
+	"PinnedService":                             "PinnedService records the exact versions a service was exported with",
+	"PinnedService.Digest":                      "Image digest from the exporter's lock file, if it matches",
+	"Profile":                                   "Profile is a variant of the environment, such as \"minimal\" or \"frontend-only\", selected with --profile",
+	"Profile.Services":                          "Services to run, plus their dependencies; empty means all",
+	"Profile.Values":                            "Helm value overrides, keyed by service",
+	"ReadinessProbe":                            "ReadinessProbe checks that a service actually serves, beyond its pods reporting ready. Exactly one of HTTP, TCP and Exec is set.",
+	"ReadinessProbe.Exec":                       "Command that must exit 0 in the pod",
+	"ReadinessProbe.HTTP":                       "Path that must answer with a status below 400",
+	"ReadinessProbe.Port":                       "Port of HTTP probes (default: the service's first port)",
+	"ReadinessProbe.TCP":                        "Port that must accept connections",
+	"ResolvedService":                           "ResolvedService is a service with all overrides and defaults applied",
+	"RuntimeConfig":                             "RuntimeConfig represents the resolved configuration at runtime. It is shared across goroutines (the TUI refreshes status while operations run), so it must not be mutated after Load; derive changed configs with Clone or Filter instead.",
+	"Service":                                   "Service represents a service definition with union type support",
+	"Service.Aliases":                           "Short names CLI commands accept, e.g. \"pay\"",
+	"Service.Chart":                             "Helm chart installed for the service",
+	"Service.DataRetention":                     "keep (default) or delete PVCs on 'plat down'",
+	"Service.Debug":                             "Set DEBUG=true and LOG_LEVEL=debug in the pods",
+	"Service.Dependencies":                      "Services deployed and ready before this one",
+	"Service.DisplayName":                       "Shown in the TUI and status output",
+	"Service.EnvFrom":                           "\"secret\": load env vars set with 'plat secrets set'",
+	"Service.Environment":                       "Environment variables set in the pods",
+	"Service.Kustomize":                         "Kustomize overlay deployed instead of a chart",
+	"Service.LogLevel":                          "Set as LOG_LEVEL in the pods, e.g. \"warn\"",
+	"Service.LogLevelValue":                     "Chart value set to the log level instead of LOG_LEVEL and DEBUG, e.g. \"logging.level\"",
+	"Service.Manifests":                         "Directory of plain YAML deployed instead of a chart",
+	"Service.OpenOnUp":                          "Opened in the browser by 'plat up --open'",
+	"Service.Patches":                           "Applied to rendered manifests",
+	"Service.Ports":                             "Ports the service listens on; the first is exposed",
+	"Service.Protected":                         "Skipped by 'plat down' unless --include-protected",
+	"Service.Readiness":                         "Must pass before dependents deploy",
+	"Service.ReadyTimeout":                      "Overrides defaults.readyTimeout",
+	"Service.ServiceName":                       "Service and Helm release name",
+	"Service.Values":                            "Helm values, over the chart's defaults",
+	"Service.ValuesFile":                        "Helm values file, relative to the config directory",
+	"Service.Version":                           "Image tag deployed (default latest)",
+	"ServiceChart":                              "ServiceChart defines Helm chart specification",
+	"ServiceChart.Name":                         "Chart name, \"alias/name\", an oci:// reference or a local path",
+	"ServiceChart.Repository":                   "Repository URL or alias from repositories",
+	"ServiceChart.Version":                      "Chart version; the latest when empty",
+	"ServiceValuesChange":                       "ServiceValuesChange is a Helm service whose resolved values differ between two loads of the config",
+	"ToolTimeouts":                              "ToolTimeouts limits how long plat waits on the external tools it runs, for slow machines or networks. Empty fields keep the defaults.",
+	"ToolTimeouts.Install":                      "Chart installs, including helm's wait for resources, and cluster creation (default 6m)",
+	"ToolTimeouts.KillGrace":                    "How long a timed-out tool gets to exit before it is killed (default 5s)",
+	"ToolTimeouts.Mutate":                       "Uninstalls, repository updates, cluster deletion (default 2m)",
+	"ToolTimeouts.Query":                        "Read-only lookups such as status and list (default 30s)",
+	"UnknownKeyError":                           "UnknownKeyError is returned for a path that matches no config key",
+	"UnknownServiceError":                       "UnknownServiceError is returned for a name that matches no configured service",
+	"ValidationError":                           "ValidationError represents a configuration validation error",
+	"ValueChange":                               "ValueChange is one differing key, as a dotted path",
+	"ValueProvenance":                           "ValueProvenance is a leaf key of a service's resolved values and the layer that set it",
+	"ValuesLayer":                               "ValuesLayer is one named set of values merged into a service's values",
+	"ValuesManager":                             "ValuesManager handles Helm values resolution and merging",
+	"ValuesSnapshotChange":                      "ValuesSnapshotChange is a service whose resolved values no longer match its golden file",
+	"VersionBump":                               "VersionBump is a new version for a service in config.yml: its image version, or its chart version when Chart is set",
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// inferDependencies adds a dependency on every service a service's
+// environment refers to, by host name (DATABASE_URL=postgres://u@postgres:5432)
+// or by the variables 'plat env-file' writes (${PAYMENT_API_URL}). Edges that
+// would close a dependency cycle are skipped: the configured dependencies
+// already decide the order between those services.
+func (r *RuntimeConfig) inferDependencies() {
+	if r.Base.Defaults.DisableDependencyInference {
+		return
+	}
+
+	for _, service := range r.OrderedServices() {
+		for _, target := range r.referencedServices(service) {
+			if containsString(service.Dependencies, target) || r.dependsOn(target, service.Name) {
+				continue
+			}
+			// Copy before appending; the slice is shared with the base config
+			service.Dependencies = append(append([]string(nil), service.Dependencies...), target)
+			service.Inferred = append(service.Inferred, target)
+		}
+	}
+}
+
+// referencedServices returns the other services a service's environment
+// refers to, sorted by name
+func (r *RuntimeConfig) referencedServices(service *ResolvedService) []string {
+	var referenced []string
+	for _, name := range r.ListServices() {
+		if name == service.Name {
+			continue
+		}
+		for _, value := range service.Environment {
+			if referencesService(value, name) {
+				referenced = append(referenced, name)
+				break
+			}
+		}
+	}
+	sort.Strings(referenced)
+	return referenced
+}
+
+// dependsOn reports whether a service depends on another, directly or
+// through other services
+func (r *RuntimeConfig) dependsOn(from, to string) bool {
+	visited := make(map[string]bool)
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		if name == to {
+			return true
+		}
+		if visited[name] {
+			return false
+		}
+		visited[name] = true
+		if service, exists := r.ResolvedServices[name]; exists {
+			for _, dep := range service.Dependencies {
+				if visit(dep) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return visit(from)
+}
+
+// referencesService reports whether an environment value refers to a
+// service: its name as the host of an address (payment-api:8080,
+// http://payment-api, u@payment-api.default.svc, a, b lists), or a
+// ${NAME_...} variable. URL schemes and path segments don't count.
+func referencesService(value, name string) bool {
+	prefix := EnvVarPrefix(name) + "_"
+	if strings.Contains(value, "${"+prefix) || strings.Contains(value, "$"+prefix) {
+		return true
+	}
+
+	for offset := 0; ; {
+		index := strings.Index(value[offset:], name)
+		if index == -1 {
+			return false
+		}
+		start := offset + index
+		end := start + len(name)
+		offset = start + 1
+
+		if !startsHost(value[:start]) {
+			continue
+		}
+		if end < len(value) && (isHostChar(value[end]) || value[end] == '_') {
+			continue
+		}
+		if strings.HasPrefix(value[end:], "://") {
+			continue
+		}
+		return true
+	}
+}
+
+// startsHost reports whether a host name can start after a prefix
+func startsHost(prefix string) bool {
+	if prefix == "" || strings.HasSuffix(prefix, "//") {
+		return true
+	}
+	switch prefix[len(prefix)-1] {
+	case '@', ',', ' ', '=':
+		return true
+	}
+	return false
+}
+
+// isHostChar reports whether a byte can be part of a host name label
+func isHostChar(c byte) bool {
+	return c == '-' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Command fielddocs generates the documentation 'plat explain' shows from
+// the comments of the config structs. Run it with go generate in
+// pkg/config.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// output is the generated file, written next to the parsed sources
+const output = "fielddocs_gen.go"
+
+func main() {
+	docs, err := collectDocs(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := render(docs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(output, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// collectDocs returns the comments of the structs in dir and of their
+// fields with a yaml tag, keyed by "Type" and "Type.Field"
+func collectDocs(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	skip := func(info os.FileInfo) bool {
+		return info.Name() != output && !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	packages, err := parser.ParseDir(fset, dir, skip, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]string)
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok || !typeSpec.Name.IsExported() {
+						continue
+					}
+
+					doc := typeSpec.Doc
+					if doc == nil {
+						doc = gen.Doc
+					}
+					if text := commentText(doc); text != "" {
+						docs[typeSpec.Name.Name] = text
+					}
+					collectFieldDocs(docs, typeSpec.Name.Name, structType)
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+// collectFieldDocs records the comments of a struct's yaml fields, the
+// trailing comment if there is one and the comment above otherwise
+func collectFieldDocs(docs map[string]string, typeName string, structType *ast.StructType) {
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		if name, _, _ := strings.Cut(reflect.StructTag(tag).Get("yaml"), ","); name == "" || name == "-" {
+			continue
+		}
+
+		text := commentText(field.Comment)
+		if text == "" {
+			text = commentText(field.Doc)
+		}
+		if text == "" {
+			continue
+		}
+		for _, name := range field.Names {
+			docs[typeName+"."+name.Name] = text
+		}
+	}
+}
+
+// commentText joins a comment's lines into one sentence
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
+
+func render(docs map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(docs))
+	for key := range docs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by go run ./internal/fielddocs; DO NOT EDIT.\n\n")
+	b.WriteString("package config\n\n")
+	b.WriteString("// configDocs holds the comments of the config structs and their fields,\n")
+	b.WriteString("// keyed by \"Type\" and \"Type.Field\"\n")
+	b.WriteString("var configDocs = map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\t%q: %q,\n", key, docs[key])
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -4,23 +4,33 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"plat/pkg/clock"
+	"plat/pkg/fsys"
 )
 
-// DefaultConfigPaths are the standard locations to look for config files
+// DefaultConfigPaths are the standard locations to look for config files.
+// CUE and Jsonnet sources are rendered to YAML before validation.
 var DefaultConfigPaths = []string{
 	".plat/config.yml",
 	".plat/config.yaml",
+	".plat/config.cue",
+	".plat/config.jsonnet",
 }
 
 // Loader handles configuration loading and merging
 type Loader struct {
 	configPath string
 	mode       ExecutionMode
+	profile    string
 	validator  *ConfigValidator
+	fs         fsys.FS
+	clock      clock.Clock
 }
 
 // NewLoader creates a new configuration loader
@@ -29,6 +39,8 @@ func NewLoader(configPath string, mode ExecutionMode) *Loader {
 		configPath: configPath,
 		mode:       mode,
 		validator:  NewConfigValidator("", false), // Will be updated with actual config dir
+		fs:         fsys.OS{},
+		clock:      clock.Real{},
 	}
 }
 
@@ -38,15 +50,34 @@ func NewLoaderWithValidation(configPath string, mode ExecutionMode, strict bool)
 		configPath: configPath,
 		mode:       mode,
 		validator:  NewConfigValidator("", strict),
+		fs:         fsys.OS{},
+		clock:      clock.Real{},
 	}
 }
 
+// SetFS sets the file system config files are read from, for the loader and
+// its validator
+func (l *Loader) SetFS(fs fsys.FS) {
+	l.fs = fs
+	l.validator.SetFS(fs)
+}
+
+// SetClock sets the clock that stamps loaded configs
+func (l *Loader) SetClock(c clock.Clock) {
+	l.clock = c
+}
+
+// SetProfile selects a profile from the config's profiles section
+func (l *Loader) SetProfile(name string) {
+	l.profile = name
+}
+
 // Load loads and merges configuration from files
 func (l *Loader) Load() (*RuntimeConfig, error) {
 	// Find config file if not specified
 	configFile := l.configPath
 	if configFile == "" {
-		found, err := l.findConfigFile()
+		found, err := findConfigFile(l.fs)
 		if err != nil {
 			return nil, err
 		}
@@ -84,11 +115,12 @@ func (l *Loader) Load() (*RuntimeConfig, error) {
 
 	// Create runtime config
 	runtime := &RuntimeConfig{
+		ConfigFile:       configFile,
 		Base:             baseConfig,
 		Local:            localConfig,
 		Mode:             l.mode,
 		ResolvedServices: make(map[string]*ResolvedService),
-		Timestamp:        time.Now(),
+		Timestamp:        l.clock.Now(),
 	}
 
 	// Resolve services
@@ -101,13 +133,27 @@ func (l *Loader) Load() (*RuntimeConfig, error) {
 		return nil, fmt.Errorf("invalid runtime configuration: %w", err)
 	}
 
+	// Order services after the services their environment refers to
+	runtime.inferDependencies()
+
+	// Narrow the environment to the selected profile
+	if l.profile != "" {
+		if err := runtime.applyProfile(l.profile); err != nil {
+			return nil, err
+		}
+	}
+
 	return runtime, nil
 }
 
-// findConfigFile looks for config file in standard locations
-func (l *Loader) findConfigFile() (string, error) {
+// FindConfigFile looks for config file in standard locations
+func FindConfigFile() (string, error) {
+	return findConfigFile(fsys.OS{})
+}
+
+func findConfigFile(fs fsys.FS) (string, error) {
 	for _, path := range DefaultConfigPaths {
-		if _, err := os.Stat(path); err == nil {
+		if _, err := fs.Stat(path); err == nil {
 			return path, nil
 		}
 	}
@@ -118,7 +164,7 @@ func (l *Loader) findConfigFile() (string, error) {
 
 // loadBaseConfig loads the base configuration file
 func (l *Loader) loadBaseConfig(path string) (*BaseConfig, error) {
-	data, err := os.ReadFile(path)
+	data, err := readConfigData(l.fs, path)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +190,15 @@ func (l *Loader) loadBaseConfig(path string) (*BaseConfig, error) {
 	if config.Defaults.Chart == "" {
 		config.Defaults.Chart = "microservice"
 	}
+	if config.Defaults.HelmDriver == "" {
+		config.Defaults.HelmDriver = "cli"
+	}
+	if config.Defaults.ClusterProvider == "" {
+		config.Defaults.ClusterProvider = "k3d"
+	}
+	if config.Defaults.IngressController == "" {
+		config.Defaults.IngressController = IngressNginx
+	}
 
 	return &config, nil
 }
@@ -151,15 +206,15 @@ func (l *Loader) loadBaseConfig(path string) (*BaseConfig, error) {
 // loadLocalConfig loads the local configuration file
 func (l *Loader) loadLocalConfig(configDir string) (*LocalConfig, error) {
 	localPath := filepath.Join(configDir, "local.yml")
-	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+	if _, err := l.fs.Stat(localPath); os.IsNotExist(err) {
 		// Try .yaml extension
 		localPath = filepath.Join(configDir, "local.yaml")
-		if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		if _, err := l.fs.Stat(localPath); os.IsNotExist(err) {
 			return nil, fmt.Errorf("local config file not found")
 		}
 	}
 
-	data, err := os.ReadFile(localPath)
+	data, err := l.fs.ReadFile(localPath)
 	if err != nil {
 		return nil, err
 	}
@@ -185,10 +240,12 @@ func (l *Loader) resolveServices(runtime *RuntimeConfig) error {
 		serviceName := service.GetName()
 
 		resolved := &ResolvedService{
-			Name:         serviceName,
-			Version:      service.GetVersion(),
-			Environment:  make(map[string]string),
-			Dependencies: []string{},
+			Name:          serviceName,
+			Version:       service.GetVersion(),
+			Environment:   make(map[string]string),
+			Dependencies:  []string{},
+			DataRetention: DataRetentionKeep,
+			ReadyTimeout:  parseReadyTimeout(runtime.Base.Defaults.ReadyTimeout, DefaultReadyTimeout),
 		}
 
 		// Copy base service configuration
@@ -199,6 +256,28 @@ func (l *Loader) resolveServices(runtime *RuntimeConfig) error {
 			resolved.Ports = service.Ports
 			resolved.Environment = service.Environment
 			resolved.Dependencies = service.Dependencies
+			resolved.Protected = service.Protected
+			resolved.Patches = service.Patches
+			resolved.Manifests = service.Manifests
+			resolved.Kustomize = service.Kustomize
+			resolved.OpenOnUp = service.OpenOnUp
+			resolved.DisplayName = service.DisplayName
+			resolved.Aliases = service.Aliases
+			resolved.EnvFrom = service.EnvFrom
+			resolved.LogLevel = service.LogLevel
+			resolved.Debug = service.Debug
+			resolved.LogLevelValue = service.LogLevelValue
+			if service.DataRetention != "" {
+				resolved.DataRetention = service.DataRetention
+			}
+			resolved.ReadyTimeout = parseReadyTimeout(service.ReadyTimeout, resolved.ReadyTimeout)
+			if service.Readiness != nil {
+				probe := *service.Readiness
+				if probe.HTTP != "" && probe.Port == 0 && len(service.Ports) > 0 {
+					probe.Port = service.Ports[0]
+				}
+				resolved.Readiness = &probe
+			}
 		} else {
 			// Apply defaults for simple form
 			if runtime.Base.Defaults != nil && runtime.Base.Defaults.Chart != "" {
@@ -217,24 +296,296 @@ func (l *Loader) resolveServices(runtime *RuntimeConfig) error {
 		}
 
 		runtime.ResolvedServices[serviceName] = resolved
+		runtime.ServiceOrder = append(runtime.ServiceOrder, serviceName)
 	}
 
 	return nil
 }
 
+// applyProfile restricts the resolved services to a profile's services and
+// their dependencies, and merges the profile's value overrides into them
+func (r *RuntimeConfig) applyProfile(name string) error {
+	profile, exists := r.Base.Profiles[name]
+	if !exists {
+		available := make([]string, 0, len(r.Base.Profiles))
+		for profileName := range r.Base.Profiles {
+			available = append(available, profileName)
+		}
+		sort.Strings(available)
+		if len(available) == 0 {
+			return fmt.Errorf("profile '%s' not found: the configuration defines no profiles", name)
+		}
+		return fmt.Errorf("profile '%s' not found (available: %s)", name, strings.Join(available, ", "))
+	}
+
+	if len(profile.Services) > 0 {
+		// Dependencies come along so the profile can always be deployed
+		included := make(map[string]bool)
+		var include func(string)
+		include = func(serviceName string) {
+			if included[serviceName] {
+				return
+			}
+			included[serviceName] = true
+			for _, dep := range r.ResolvedServices[serviceName].Dependencies {
+				include(dep)
+			}
+		}
+		for _, serviceName := range profile.Services {
+			include(serviceName)
+		}
+
+		var order []string
+		for _, serviceName := range r.ServiceOrder {
+			if included[serviceName] {
+				order = append(order, serviceName)
+			} else {
+				delete(r.ResolvedServices, serviceName)
+			}
+		}
+		r.ServiceOrder = order
+	}
+
+	for serviceName, overrides := range profile.Values {
+		service, exists := r.ResolvedServices[serviceName]
+		if !exists {
+			continue
+		}
+		// Copy first; the service's values are shared with the base config
+		values := copyValues(service.Values)
+		if values == nil {
+			values = make(map[string]interface{})
+		}
+		mergeValues(values, overrides)
+		service.Values = values
+	}
+
+	r.Profile = name
+	return nil
+}
+
+// ConfigDir returns the directory containing the loaded config file
+func (r *RuntimeConfig) ConfigDir() string {
+	if r.ConfigFile == "" {
+		return ".plat"
+	}
+	return filepath.Dir(r.ConfigFile)
+}
+
+// KubeconfigFile is the environment's own kubeconfig, in the config
+// directory, so plat never touches the user's ~/.kube/config
+const KubeconfigFile = "kubeconfig"
+
+// KubeconfigPath returns the absolute path of the environment's kubeconfig
+func (r *RuntimeConfig) KubeconfigPath() string {
+	path := r.Kubeconfig
+	if path == "" {
+		path = filepath.Join(r.ConfigDir(), KubeconfigFile)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// ProtectedServices returns the sorted names of services marked protected
+func (r *RuntimeConfig) ProtectedServices() []string {
+	var names []string
+	for name, service := range r.ResolvedServices {
+		if service.Protected {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolvePath resolves a path from the config against the config directory
+func (r *RuntimeConfig) ResolvePath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(r.ConfigDir(), path)
+}
+
+// ServiceURL returns the address a service is reachable at from the host:
+// its ingress host when a domain is configured, otherwise its first port on
+// localhost. It returns false if the service has neither.
+func (r *RuntimeConfig) ServiceURL(service *ResolvedService) (string, bool) {
+	domain := ""
+	if r.Base.Defaults != nil {
+		domain = r.Base.Defaults.Domain
+	}
+
+	port := 80
+	if len(service.Ports) > 0 {
+		port = service.Ports[0]
+	}
+
+	switch {
+	case domain != "" && port != 80:
+		return fmt.Sprintf("http://%s.%s:%d", service.Name, domain, port), true
+	case domain != "":
+		return fmt.Sprintf("%s://%s.%s", r.IngressScheme(), service.Name, domain), true
+	case len(service.Ports) > 0:
+		return fmt.Sprintf("http://localhost:%d", port), true
+	default:
+		return "", false
+	}
+}
+
+// ServiceDNSName returns the name pods reach a service at inside the
+// cluster. Charts name the Kubernetes Service after the helm release, which
+// is the service name.
+func (r *RuntimeConfig) ServiceDNSName(service *ResolvedService) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local", service.Name, r.Base.Defaults.Namespace)
+}
+
+// EntryServices returns the services marked openOnUp, in config order
+func (r *RuntimeConfig) EntryServices() []*ResolvedService {
+	var entries []*ResolvedService
+	for _, service := range r.OrderedServices() {
+		if service.OpenOnUp {
+			entries = append(entries, service)
+		}
+	}
+	return entries
+}
+
 // GetService returns a resolved service by name
 func (r *RuntimeConfig) GetService(name string) (*ResolvedService, bool) {
 	service, exists := r.ResolvedServices[name]
 	return service, exists
 }
 
-// ListServices returns all service names
+// ListServices returns all service names in config declaration order, so
+// output and index-based selection are stable between runs
 func (r *RuntimeConfig) ListServices() []string {
 	names := make([]string, 0, len(r.ResolvedServices))
+	seen := make(map[string]bool, len(r.ResolvedServices))
+	for _, name := range r.ServiceOrder {
+		if _, exists := r.ResolvedServices[name]; exists && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	// Services added without an order entry follow alphabetically
+	var rest []string
 	for name := range r.ResolvedServices {
-		names = append(names, name)
+		if !seen[name] {
+			rest = append(rest, name)
+		}
 	}
-	return names
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// OrderedServices returns the resolved services in ListServices order
+func (r *RuntimeConfig) OrderedServices() []*ResolvedService {
+	names := r.ListServices()
+	services := make([]*ResolvedService, 0, len(names))
+	for _, name := range names {
+		services = append(services, r.ResolvedServices[name])
+	}
+	return services
+}
+
+// LocalServices returns the services with a local source declared in
+// local.yml, in config order, marked local whatever the execution mode
+func (r *RuntimeConfig) LocalServices() []*ResolvedService {
+	var services []*ResolvedService
+	for _, service := range r.OrderedServices() {
+		source, ok := r.Local.LocalSources[service.Name]
+		if !ok {
+			continue
+		}
+		local := service.Clone()
+		local.IsLocal = true
+		local.LocalSource = &source
+		services = append(services, local)
+	}
+	return services
+}
+
+// Clone returns a copy of the runtime configuration whose resolved services
+// can be modified without affecting r. Base and Local are shared; they are
+// never modified after load.
+func (r *RuntimeConfig) Clone() *RuntimeConfig {
+	clone := *r
+	clone.ServiceOrder = append([]string(nil), r.ServiceOrder...)
+	clone.ResolvedServices = make(map[string]*ResolvedService, len(r.ResolvedServices))
+	for name, service := range r.ResolvedServices {
+		clone.ResolvedServices[name] = service.Clone()
+	}
+	return &clone
+}
+
+// Filter returns a copy of the runtime configuration containing only the
+// named services, which may be given by alias. It fails if any name is not
+// configured.
+func (r *RuntimeConfig) Filter(names []string) (*RuntimeConfig, error) {
+	names, err := r.ResolveServiceNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		requested[name] = true
+	}
+
+	filtered := *r
+	filtered.unfiltered = r.Unfiltered()
+	filtered.ResolvedServices = make(map[string]*ResolvedService, len(names))
+	filtered.ServiceOrder = nil
+	for _, name := range r.ListServices() {
+		if requested[name] {
+			filtered.ResolvedServices[name] = r.ResolvedServices[name].Clone()
+			filtered.ServiceOrder = append(filtered.ServiceOrder, name)
+		}
+	}
+	return &filtered, nil
+}
+
+// parseReadyTimeout parses a configured ready timeout, falling back when it
+// is unset (invalid values are reported by validation)
+func parseReadyTimeout(value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return fallback
+	}
+	return timeout
+}
+
+// Clone returns a deep copy of the resolved service
+func (s *ResolvedService) Clone() *ResolvedService {
+	clone := *s
+	clone.Values = copyValues(s.Values)
+	clone.Ports = append([]int(nil), s.Ports...)
+	clone.Dependencies = append([]string(nil), s.Dependencies...)
+	clone.Inferred = append([]string(nil), s.Inferred...)
+	clone.Patches = append([]ManifestPatch(nil), s.Patches...)
+	clone.Aliases = append([]string(nil), s.Aliases...)
+	if s.Environment != nil {
+		clone.Environment = make(map[string]string, len(s.Environment))
+		for key, value := range s.Environment {
+			clone.Environment[key] = value
+		}
+	}
+	if s.LocalSource != nil {
+		source := *s.LocalSource
+		clone.LocalSource = &source
+	}
+	if s.Readiness != nil {
+		probe := *s.Readiness
+		probe.Exec = append([]string(nil), s.Readiness.Exec...)
+		clone.Readiness = &probe
+	}
+	return &clone
 }
 
 // FilterServices returns services matching the given names
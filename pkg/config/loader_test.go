@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"plat/pkg/fsys"
+)
+
+const testConfig = `apiVersion: plat/v1
+kind: Environment
+name: race
+defaults:
+  chart: microservice
+  registry: registry.example.com
+repositories:
+  - name: example
+    url: https://charts.example.com
+services:
+  - name: api
+    version: 1.2.0
+    values:
+      env:
+        LOG_FORMAT: json
+      resources:
+        limits:
+          memory: 1Gi
+  - name: web
+    dependencies: [api]
+    values:
+      replicaCount: 2
+  - name: postgres
+    chart:
+      name: postgresql
+      repository: example
+      version: 13.2.0
+`
+
+// loadTestConfig loads testConfig from an in-memory file system
+func loadTestConfig(t *testing.T) *RuntimeConfig {
+	t.Helper()
+
+	mem := fsys.NewMem(func() time.Time { return time.Unix(0, 0) })
+	if err := mem.MkdirAll(".plat", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.WriteFile(".plat/config.yml", []byte(testConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := NewLoader(".plat/config.yml", ModeArtifact)
+	loader.SetFS(mem)
+	runtime, err := loader.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	return runtime
+}
+
+// TestFilterAndCloneConcurrently filters, clones and modifies copies of a
+// runtime config from many goroutines while others read the original, as the
+// TUI does while status refreshes run. Run with -race.
+func TestFilterAndCloneConcurrently(t *testing.T) {
+	runtime := loadTestConfig(t)
+	want := runtime.Clone()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			filtered, err := runtime.Filter([]string{"web"})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			filtered.ResolvedServices["web"].Debug = true
+			filtered.ResolvedServices["web"].Values["replicaCount"] = 3
+			if names := filtered.Unfiltered().ListServices(); len(names) != 3 {
+				t.Errorf("unfiltered config has %d services, want 3", len(names))
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			clone := runtime.Clone()
+			clone.ResolvedServices["api"].ImageDigest = fmt.Sprintf("sha256:%d", i)
+			clone.ResolvedServices["api"].Aliases = append(clone.ResolvedServices["api"].Aliases, "a")
+			clone.ServiceOrder = append(clone.ServiceOrder, "extra")
+		}(i)
+		go func() {
+			defer wg.Done()
+			for _, service := range runtime.OrderedServices() {
+				_ = service.Values["replicaCount"]
+				_ = service.Debug
+				_ = service.ImageDigest
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !reflect.DeepEqual(runtime.ServiceOrder, want.ServiceOrder) {
+		t.Errorf("service order changed to %v, want %v", runtime.ServiceOrder, want.ServiceOrder)
+	}
+	for name, service := range runtime.ResolvedServices {
+		if !reflect.DeepEqual(service, want.ResolvedServices[name]) {
+			t.Errorf("service %s was modified through a copy", name)
+		}
+	}
+}
+
+// TestResolveValuesConcurrently resolves values of the same services from
+// many goroutines, as parallel deploys of a level do, and checks the config's
+// values are never written through. Run with -race.
+func TestResolveValuesConcurrently(t *testing.T) {
+	runtime := loadTestConfig(t)
+	want := runtime.Clone()
+	vm := NewValuesManager(".plat")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		for _, name := range runtime.ListServices() {
+			wg.Add(1)
+			go func(service *ResolvedService) {
+				defer wg.Done()
+				values, err := vm.ResolveValues(service, runtime)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				vm.ApplyOverrides(values, map[string]interface{}{
+					"env":       map[string]interface{}{"LOG_FORMAT": "text"},
+					"resources": map[string]interface{}{"limits": map[string]interface{}{"memory": "2Gi"}},
+				})
+			}(runtime.ResolvedServices[name])
+		}
+	}
+	wg.Wait()
+
+	for name, service := range runtime.ResolvedServices {
+		if !reflect.DeepEqual(service.Values, want.ResolvedServices[name].Values) {
+			t.Errorf("values of %s were modified: %v", name, service.Values)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileName is the lock file written next to config.yml
+const LockFileName = "lock.yml"
+
+// Lock captures the exact artifacts an environment was deployed with
+type Lock struct {
+	APIVersion  string            `yaml:"apiVersion"`
+	Kind        string            `yaml:"kind"`
+	GeneratedAt time.Time         `yaml:"generatedAt"`
+	Services    []LockedService   `yaml:"services"`
+	Addons      map[string]string `yaml:"addons,omitempty"` // Cluster addon release (e.g. ingress-nginx) -> chart version
+}
+
+// LockedService records what was actually deployed for a service
+type LockedService struct {
+	Name         string   `yaml:"name"`
+	Version      string   `yaml:"version"`
+	Chart        string   `yaml:"chart"`
+	Repository   string   `yaml:"repository,omitempty"`
+	ChartVersion string   `yaml:"chartVersion"`
+	Digest       string   `yaml:"digest,omitempty"` // Digest of the image plat sets, pinned by image hooks or the running pods
+	Images       []string `yaml:"images,omitempty"` // Image references with digests
+}
+
+// NewLock creates an empty lock
+func NewLock() *Lock {
+	return &Lock{
+		APIVersion:  "plat/v1",
+		Kind:        "Lock",
+		GeneratedAt: time.Now().UTC(),
+		Addons:      make(map[string]string),
+	}
+}
+
+// LockPath returns the lock file location for the runtime configuration
+func LockPath(runtime *RuntimeConfig) string {
+	return filepath.Join(runtime.ConfigDir(), LockFileName)
+}
+
+// ReadLock loads a lock file
+func ReadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file: %w", err)
+	}
+	if lock.Kind != "Lock" {
+		return nil, fmt.Errorf("unsupported lock kind %q, expected 'Lock'", lock.Kind)
+	}
+	if lock.Addons == nil {
+		lock.Addons = make(map[string]string)
+	}
+
+	return &lock, nil
+}
+
+// WriteFile writes the lock to path
+func (l *Lock) WriteFile(path string) error {
+	sort.Slice(l.Services, func(i, j int) bool {
+		return l.Services[i].Name < l.Services[j].Name
+	})
+
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to encode lock file: %w", err)
+	}
+
+	header := "# Generated by plat. Do not edit by hand; run 'plat up' to refresh.\n"
+	return os.WriteFile(path, append([]byte(header), data...), 0644)
+}
+
+// GetService returns the locked entry for a service
+func (l *Lock) GetService(name string) (*LockedService, bool) {
+	for i := range l.Services {
+		if l.Services[i].Name == name {
+			return &l.Services[i], true
+		}
+	}
+	return nil, false
+}
+
+// SetService adds or replaces the locked entry for a service
+func (l *Lock) SetService(entry LockedService) {
+	if existing, ok := l.GetService(entry.Name); ok {
+		*existing = entry
+		return
+	}
+	l.Services = append(l.Services, entry)
+}
+
+// Merge copies every service and addon entry from other into the lock
+func (l *Lock) Merge(other *Lock) {
+	for _, entry := range other.Services {
+		l.SetService(entry)
+	}
+	for name, version := range other.Addons {
+		l.Addons[name] = version
+	}
+	l.GeneratedAt = other.GeneratedAt
+}
+
+// Apply returns a copy of the runtime configuration with services pinned to
+// the locked chart versions and image digests, and cluster addons to their
+// locked versions, so every helm call deploys what the lock records. It
+// fails if a service is missing from the lock, its configuration drifted,
+// or the lock holds no digest for an image plat sets. Images chosen by
+// other charts are fixed by the chart version and checked by VerifyImages.
+func (l *Lock) Apply(runtime *RuntimeConfig) (*RuntimeConfig, error) {
+	var drift []string
+
+	pinned := runtime.Clone()
+	for _, name := range pinned.ListServices() {
+		service := pinned.ResolvedServices[name]
+
+		locked, ok := l.GetService(name)
+		if !ok {
+			drift = append(drift, fmt.Sprintf("%s: not present in lock file", name))
+			continue
+		}
+
+		if locked.Chart != service.Chart.Name {
+			drift = append(drift, fmt.Sprintf("%s: chart %q does not match locked chart %q", name, service.Chart.Name, locked.Chart))
+		}
+		if service.Chart.Version != "" && service.Chart.Version != locked.ChartVersion {
+			drift = append(drift, fmt.Sprintf("%s: chart version %s does not match locked %s", name, service.Chart.Version, locked.ChartVersion))
+		}
+		if service.Version != locked.Version {
+			drift = append(drift, fmt.Sprintf("%s: version %s does not match locked %s", name, service.Version, locked.Version))
+		}
+
+		if !service.IsLocal && service.IsMicroserviceChart() {
+			switch {
+			case locked.Digest == "":
+				drift = append(drift, fmt.Sprintf("%s: no image digest locked; run 'plat up' without --frozen to record it", name))
+			case service.ImageDigest != "" && service.ImageDigest != locked.Digest:
+				drift = append(drift, fmt.Sprintf("%s: image digest %s does not match locked %s", name, service.ImageDigest, locked.Digest))
+			}
+		}
+
+		service.Chart.Version = locked.ChartVersion
+		service.ImageDigest = locked.Digest
+	}
+	pinned.LockedAddons = l.Addons
+
+	if len(drift) > 0 {
+		sort.Strings(drift)
+		return nil, fmt.Errorf("configuration drifted from %s:\n  - %s", LockFileName, strings.Join(drift, "\n  - "))
+	}
+
+	return pinned, nil
+}
+
+// VerifyImages compares deployed image digests against the lock
+func (l *Lock) VerifyImages(current *Lock) error {
+	var drift []string
+
+	for _, entry := range current.Services {
+		locked, ok := l.GetService(entry.Name)
+		if !ok || len(locked.Images) == 0 {
+			continue
+		}
+
+		want := append([]string(nil), locked.Images...)
+		got := append([]string(nil), entry.Images...)
+		sort.Strings(want)
+		sort.Strings(got)
+
+		if strings.Join(want, ",") != strings.Join(got, ",") {
+			drift = append(drift, fmt.Sprintf("%s: images %v do not match locked %v", entry.Name, got, want))
+		}
+	}
+
+	if len(drift) > 0 {
+		sort.Strings(drift)
+		return fmt.Errorf("deployed images drifted from %s:\n  - %s", LockFileName, strings.Join(drift, "\n  - "))
+	}
+
+	return nil
+}
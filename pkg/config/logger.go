@@ -0,0 +1,31 @@
+package config
+
+import (
+	"log/slog"
+	"sync"
+
+	"plat/pkg/logging"
+)
+
+var (
+	loggerMu  sync.RWMutex
+	configLog *slog.Logger // nil uses logging.Default()
+)
+
+// SetLogger sets the logger configuration loading and validation report
+// to, instead of the default one
+func SetLogger(logger *slog.Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	configLog = logger
+}
+
+// logger returns the logger configuration code reports to
+func logger() *slog.Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	if configLog == nil {
+		return logging.Default()
+	}
+	return configLog
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is how many similar names a not-found error suggests
+const maxSuggestions = 3
+
+// UnknownServiceError is returned for a name that matches no configured
+// service
+type UnknownServiceError struct {
+	Name        string
+	Suggestions []string // Similar service names, closest first
+}
+
+func (e *UnknownServiceError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("service '%s' not found in configuration; did you mean %s?", e.Name, quoteAlternatives(e.Suggestions))
+	}
+	return fmt.Sprintf("service '%s' not found in configuration", e.Name)
+}
+
+// ResolveServiceName returns the configured name of a service given its
+// name or one of its aliases. Case and separators are ignored, so
+// "PaymentApi" finds payment-api. Unknown names fail with suggestions of
+// similar service names.
+func (r *RuntimeConfig) ResolveServiceName(name string) (string, error) {
+	if _, exists := r.ResolvedServices[name]; exists {
+		return name, nil
+	}
+
+	services := r.OrderedServices()
+	for _, service := range services {
+		for _, alias := range service.Aliases {
+			if alias == name {
+				return service.Name, nil
+			}
+		}
+	}
+
+	// Loose matches only count when they are unambiguous
+	key := normalizeServiceName(name)
+	var matches []string
+	for _, service := range services {
+		for _, candidate := range serviceNames(service) {
+			if normalizeServiceName(candidate) == key {
+				matches = append(matches, service.Name)
+				break
+			}
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	return "", &UnknownServiceError{Name: name, Suggestions: r.suggestServiceNames(name)}
+}
+
+// ResolveServiceNames resolves names and aliases with ResolveServiceName
+func (r *RuntimeConfig) ResolveServiceNames(names []string) ([]string, error) {
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		serviceName, err := r.ResolveServiceName(name)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, serviceName)
+	}
+	return resolved, nil
+}
+
+// suggestServiceNames returns the services whose name or alias is within a
+// few edits of name or starts with it, closest first
+func (r *RuntimeConfig) suggestServiceNames(name string) []string {
+	key := normalizeServiceName(name)
+	// Allow roughly one typo per three characters, and at least two
+	limit := max(2, len(key)/3)
+
+	type suggestion struct {
+		service  string
+		distance int
+	}
+	var suggestions []suggestion
+	for _, service := range r.OrderedServices() {
+		best := -1
+		for _, candidate := range serviceNames(service) {
+			normalized := normalizeServiceName(candidate)
+			distance := levenshtein(key, normalized)
+			if len(key) >= 3 && strings.HasPrefix(normalized, key) {
+				distance = 1 // An abbreviation, e.g. "payment" for payment-api
+			}
+			if best < 0 || distance < best {
+				best = distance
+			}
+		}
+		if best <= limit {
+			suggestions = append(suggestions, suggestion{service.Name, best})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	var names []string
+	for _, s := range suggestions {
+		if len(names) == maxSuggestions {
+			break
+		}
+		names = append(names, s.service)
+	}
+	return names
+}
+
+// serviceNames returns the names a service can be referred to by
+func serviceNames(service *ResolvedService) []string {
+	return append([]string{service.Name}, service.Aliases...)
+}
+
+// normalizeServiceName lowercases a name and drops separators, so
+// "PaymentApi", "payment_api" and "payment-api" compare equal
+func normalizeServiceName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r != '-' && r != '_' && r != '.' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+// quoteAlternatives quotes names and joins them, e.g. "'a', 'b' or 'c'"
+func quoteAlternatives(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
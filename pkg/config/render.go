@@ -0,0 +1,54 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"plat/pkg/fsys"
+	"plat/pkg/tools"
+)
+
+// renderers maps config file extensions to the external tool that renders
+// them into YAML/JSON the loader understands
+var renderers = map[string]tools.Command{
+	".cue":     {Name: "cue", Args: []string{"export", "--out", "yaml"}},
+	".jsonnet": {Name: "jsonnet"},
+}
+
+// RenderedConfig reports whether a config file is evaluated by an external
+// tool (CUE, Jsonnet), so plat can read it but not write it back
+func RenderedConfig(path string) bool {
+	_, ok := renderers[filepath.Ext(path)]
+	return ok
+}
+
+// readConfigData returns the YAML (or JSON) document for a config file,
+// evaluating CUE and Jsonnet sources with their respective tools
+func readConfigData(fs fsys.FS, path string) ([]byte, error) {
+	renderer, ok := renderers[filepath.Ext(path)]
+	if !ok {
+		return fs.ReadFile(path)
+	}
+
+	if err := tools.ValidateCommand(renderer.Name); err != nil {
+		return nil, fmt.Errorf("rendering %s requires %s: %w", filepath.Base(path), renderer.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := tools.Command{
+		Name: renderer.Name,
+		Args: append(append([]string{}, renderer.Args...), filepath.Base(path)),
+		Dir:  filepath.Dir(path),
+	}
+
+	result, err := tools.NewProcessExecutor().Execute(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s with %s: %w", path, renderer.Name, err)
+	}
+
+	return []byte(result.Stdout), nil
+}
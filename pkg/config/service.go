@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,21 +13,103 @@ type Service struct {
 	Name string `yaml:"-"`
 
 	// For complex form: full service configuration
-	ServiceName  string                 `yaml:"name,omitempty"`
-	Version      string                 `yaml:"version,omitempty"`
-	Chart        ServiceChart           `yaml:"chart,omitempty"`
-	Values       map[string]interface{} `yaml:"values,omitempty"`
-	ValuesFile   string                 `yaml:"values_file,omitempty"`
-	Ports        []int                  `yaml:"ports,omitempty"`
-	Environment  map[string]string      `yaml:"environment,omitempty"`
-	Dependencies []string               `yaml:"dependencies,omitempty"`
+	ServiceName   string                 `yaml:"name,omitempty"`          // Service and Helm release name
+	Version       string                 `yaml:"version,omitempty"`       // Image tag deployed (default latest)
+	Chart         ServiceChart           `yaml:"chart,omitempty"`         // Helm chart installed for the service
+	Values        map[string]interface{} `yaml:"values,omitempty"`        // Helm values, over the chart's defaults
+	ValuesFile    string                 `yaml:"values_file,omitempty"`   // Helm values file, relative to the config directory
+	Ports         []int                  `yaml:"ports,omitempty"`         // Ports the service listens on; the first is exposed
+	Environment   map[string]string      `yaml:"environment,omitempty"`   // Environment variables set in the pods
+	Dependencies  []string               `yaml:"dependencies,omitempty"`  // Services deployed and ready before this one
+	Protected     bool                   `yaml:"protected,omitempty"`     // Skipped by 'plat down' unless --include-protected
+	DataRetention string                 `yaml:"dataRetention,omitempty"` // keep (default) or delete PVCs on 'plat down'
+	Patches       []ManifestPatch        `yaml:"patches,omitempty"`       // Applied to rendered manifests
+	Manifests     string                 `yaml:"manifests,omitempty"`     // Directory of plain YAML deployed instead of a chart
+	Kustomize     string                 `yaml:"kustomize,omitempty"`     // Kustomize overlay deployed instead of a chart
+	OpenOnUp      bool                   `yaml:"openOnUp,omitempty"`      // Opened in the browser by 'plat up --open'
+	ReadyTimeout  string                 `yaml:"readyTimeout,omitempty"`  // Overrides defaults.readyTimeout
+	Readiness     *ReadinessProbe        `yaml:"readiness,omitempty"`     // Must pass before dependents deploy
+	DisplayName   string                 `yaml:"displayName,omitempty"`   // Shown in the TUI and status output
+	Aliases       []string               `yaml:"aliases,omitempty"`       // Short names CLI commands accept, e.g. "pay"
+	EnvFrom       string                 `yaml:"envFrom,omitempty"`       // "secret": load env vars set with 'plat secrets set'
+	LogLevel      string                 `yaml:"logLevel,omitempty"`      // Set as LOG_LEVEL in the pods, e.g. "warn"
+	Debug         bool                   `yaml:"debug,omitempty"`         // Set DEBUG=true and LOG_LEVEL=debug in the pods
+	LogLevelValue string                 `yaml:"logLevelValue,omitempty"` // Chart value set to the log level instead of LOG_LEVEL and DEBUG, e.g. "logging.level"
 }
 
+// EnvFromSecret loads a service's environment from the secret managed by
+// 'plat secrets'
+const EnvFromSecret = "secret"
+
+// SecretName returns the name of the Kubernetes Secret holding the values
+// set with 'plat secrets' for a service
+func SecretName(serviceName string) string {
+	return "plat-secrets-" + serviceName
+}
+
+// ReadinessProbe checks that a service actually serves, beyond its pods
+// reporting ready. Exactly one of HTTP, TCP and Exec is set.
+type ReadinessProbe struct {
+	HTTP string   `yaml:"http,omitempty"` // Path that must answer with a status below 400
+	TCP  int      `yaml:"tcp,omitempty"`  // Port that must accept connections
+	Exec []string `yaml:"exec,omitempty"` // Command that must exit 0 in the pod
+	Port int      `yaml:"port,omitempty"` // Port of HTTP probes (default: the service's first port)
+}
+
+// String describes the probe, e.g. "tcp 5432"
+func (p *ReadinessProbe) String() string {
+	switch {
+	case p.HTTP != "":
+		return fmt.Sprintf("http :%d%s", p.Port, p.HTTP)
+	case p.TCP != 0:
+		return fmt.Sprintf("tcp %d", p.TCP)
+	default:
+		return "exec " + strings.Join(p.Exec, " ")
+	}
+}
+
+// Deploy engines a service can use
+const (
+	EngineHelm      = "helm"      // Helm chart installed as a release
+	EngineManifests = "manifests" // Plain YAML directory applied with kubectl
+	EngineKustomize = "kustomize" // Kustomize overlay built and applied with kubectl
+)
+
+// ManifestPatch is a kustomize-style patch applied to a service's rendered
+// manifests. The patch is either a strategic merge patch (a partial resource)
+// or a list of JSON6902 operations, which require a target.
+type ManifestPatch struct {
+	Target *PatchTarget `yaml:"target,omitempty"` // Resources patched; required for JSON6902 patches
+	Patch  string       `yaml:"patch"`            // Strategic merge patch or JSON6902 operations, as YAML
+}
+
+// PatchTarget selects the resources a patch applies to
+type PatchTarget struct {
+	Group         string `yaml:"group,omitempty"`         // API group, e.g. "apps"
+	Version       string `yaml:"version,omitempty"`       // API version, e.g. "v1"
+	Kind          string `yaml:"kind,omitempty"`          // Resource kind, e.g. "Deployment"
+	Name          string `yaml:"name,omitempty"`          // Resource name
+	Namespace     string `yaml:"namespace,omitempty"`     // Resource namespace
+	LabelSelector string `yaml:"labelSelector,omitempty"` // Label selector, e.g. "app=web"
+}
+
+// IsJSON6902 reports whether the patch is a list of JSON6902 operations
+func (p ManifestPatch) IsJSON6902() bool {
+	trimmed := strings.TrimSpace(p.Patch)
+	return strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "- op:") || strings.HasPrefix(trimmed, "- {")
+}
+
+// Data retention policies for a service's persistent volumes
+const (
+	DataRetentionKeep   = "keep"
+	DataRetentionDelete = "delete"
+)
+
 // ServiceChart defines Helm chart specification
 type ServiceChart struct {
-	Name       string `yaml:"name"`
-	Repository string `yaml:"repository,omitempty"`
-	Version    string `yaml:"version,omitempty"`
+	Name       string `yaml:"name"`                 // Chart name, "alias/name", an oci:// reference or a local path
+	Repository string `yaml:"repository,omitempty"` // Repository URL or alias from repositories
+	Version    string `yaml:"version,omitempty"`    // Chart version; the latest when empty
 }
 
 func (sc ServiceChart) FullName() string {
@@ -74,10 +157,10 @@ type LocalSource struct {
 	Path string `yaml:"-"`
 
 	// For complex form: full local source configuration
-	LocalPath  string `yaml:"path,omitempty"`
-	Dockerfile string `yaml:"dockerfile,omitempty"`
-	Context    string `yaml:"context,omitempty"`
-	Chart      string `yaml:"chart,omitempty"`
+	LocalPath  string `yaml:"path,omitempty"`       // Repository checkout
+	Dockerfile string `yaml:"dockerfile,omitempty"` // Dockerfile, relative to the repository (default Dockerfile)
+	Context    string `yaml:"context,omitempty"`    // Build context, relative to the repository (default .)
+	Chart      string `yaml:"chart,omitempty"`      // Chart directory, relative to the repository (default chart)
 }
 
 // UnmarshalYAML implements custom unmarshaling for local sources
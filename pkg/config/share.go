@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"plat/pkg/fsys"
+)
+
+// EnvironmentPackage is a single-file, shareable snapshot of an environment spec
+type EnvironmentPackage struct {
+	APIVersion  string            `yaml:"apiVersion"`
+	Kind        string            `yaml:"kind"`
+	Name        string            `yaml:"name"`
+	CreatedAt   time.Time         `yaml:"createdAt"`
+	Config      string            `yaml:"config"`                // Rendered config.yml contents
+	ValuesFiles map[string]string `yaml:"valuesFiles,omitempty"` // Relative path -> contents
+	Pins        []PinnedService   `yaml:"pins"`
+	// Services the exporter ran from local sources; the importer must
+	// declare their own local.yml entries to reproduce them
+	LocalSources []string `yaml:"localSources,omitempty"`
+}
+
+// PinnedService records the exact versions a service was exported with
+type PinnedService struct {
+	Name         string `yaml:"name"`
+	Version      string `yaml:"version"`
+	Chart        string `yaml:"chart,omitempty"`
+	ChartVersion string `yaml:"chartVersion,omitempty"`
+	Digest       string `yaml:"digest,omitempty"` // Image digest from the exporter's lock file, if it matches
+}
+
+// BuildPackage bundles the loaded environment into a shareable package
+func BuildPackage(runtime *RuntimeConfig) (*EnvironmentPackage, error) {
+	configData, err := readConfigData(fsys.OS{}, runtime.ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	pkg := &EnvironmentPackage{
+		APIVersion:  "plat/v1",
+		Kind:        "EnvironmentPackage",
+		Name:        runtime.Base.Name,
+		CreatedAt:   time.Now().UTC(),
+		Config:      string(configData),
+		ValuesFiles: make(map[string]string),
+	}
+
+	configDir := filepath.Dir(runtime.ConfigFile)
+
+	// Digests come from the lock file, when it still matches the config
+	lock, err := ReadLock(LockPath(runtime))
+	if err != nil {
+		lock = NewLock()
+	}
+
+	for _, name := range runtime.ListServices() {
+		service := runtime.ResolvedServices[name]
+
+		pin := PinnedService{
+			Name:         name,
+			Version:      service.Version,
+			Chart:        service.Chart.Name,
+			ChartVersion: service.Chart.Version,
+		}
+		if locked, ok := lock.GetService(name); ok && locked.Version == pin.Version && locked.Chart == pin.Chart {
+			if pin.ChartVersion == "" {
+				pin.ChartVersion = locked.ChartVersion
+			}
+			if pin.ChartVersion == locked.ChartVersion {
+				pin.Digest = locked.Digest
+			}
+		}
+		pkg.Pins = append(pkg.Pins, pin)
+
+		if service.IsLocal {
+			pkg.LocalSources = append(pkg.LocalSources, name)
+		}
+
+		if service.ValuesFile == "" {
+			continue
+		}
+		if !sharablePath(service.ValuesFile) {
+			return nil, fmt.Errorf("service %s uses values file %s outside %s, which cannot be shared; move it into the config directory", name, service.ValuesFile, configDir)
+		}
+
+		data, err := os.ReadFile(filepath.Join(configDir, service.ValuesFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file for %s: %w", name, err)
+		}
+		pkg.ValuesFiles[filepath.ToSlash(service.ValuesFile)] = string(data)
+	}
+
+	return pkg, nil
+}
+
+// WriteFile writes the package to path
+func (p *EnvironmentPackage) WriteFile(path string) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode package: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadPackage loads a package previously written with WriteFile
+func ReadPackage(path string) (*EnvironmentPackage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkg EnvironmentPackage
+	if err := yaml.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package: %w", err)
+	}
+
+	if pkg.Kind != "EnvironmentPackage" {
+		return nil, fmt.Errorf("unsupported package kind %q, expected 'EnvironmentPackage'", pkg.Kind)
+	}
+	if pkg.APIVersion != "plat/v1" {
+		return nil, fmt.Errorf("unsupported package apiVersion %q, expected 'plat/v1'", pkg.APIVersion)
+	}
+
+	return &pkg, nil
+}
+
+// Extract recreates the packaged config and values files inside configDir,
+// and writes the pinned versions to its lock file
+func (p *EnvironmentPackage) Extract(configDir string, force bool) error {
+	configPath := filepath.Join(configDir, "config.yml")
+	if _, err := os.Stat(configPath); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", configPath)
+	}
+
+	// Validate every path before writing anything
+	for relPath := range p.ValuesFiles {
+		if !sharablePath(relPath) {
+			return fmt.Errorf("package contains unsafe values file path %q", relPath)
+		}
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", configDir, err)
+	}
+
+	if err := os.WriteFile(configPath, []byte(p.Config), 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	for relPath, contents := range p.ValuesFiles {
+		target := filepath.Join(configDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", relPath, err)
+		}
+		if err := os.WriteFile(target, []byte(contents), 0644); err != nil {
+			return fmt.Errorf("failed to write values file %s: %w", relPath, err)
+		}
+	}
+
+	if err := p.Lock().WriteFile(filepath.Join(configDir, LockFileName)); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	return nil
+}
+
+// Lock returns a lock file pinning services to the packaged versions.
+// Addons are not packaged and stay unpinned until the next 'plat up'.
+func (p *EnvironmentPackage) Lock() *Lock {
+	lock := NewLock()
+	lock.GeneratedAt = p.CreatedAt
+	for _, pin := range p.Pins {
+		lock.SetService(LockedService{
+			Name:         pin.Name,
+			Version:      pin.Version,
+			Chart:        pin.Chart,
+			ChartVersion: pin.ChartVersion,
+			Digest:       pin.Digest,
+		})
+	}
+	return lock
+}
+
+// sharablePath reports whether a values file path stays inside the config
+// directory, so it can be exported and extracted again
+func sharablePath(relPath string) bool {
+	if filepath.IsAbs(relPath) {
+		return false
+	}
+	clean := filepath.ToSlash(filepath.Clean(relPath))
+	return clean != ".." && !strings.HasPrefix(clean, "../")
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValuesSnapshotDir is where values snapshots are kept, relative to the
+// config directory
+const ValuesSnapshotDir = "snapshots/values"
+
+// Snapshot change kinds
+const (
+	SnapshotAdded   = "added"   // Service has no golden file yet
+	SnapshotRemoved = "removed" // Golden file for a service no longer in the config
+	SnapshotChanged = "changed" // Resolved values differ from the golden file
+)
+
+// ValuesSnapshotChange is a service whose resolved values no longer match
+// its golden file
+type ValuesSnapshotChange struct {
+	Service string
+	Kind    string
+	Changes []ValueChange // Differing keys, for changed snapshots
+}
+
+// ValueChange is one differing key, as a dotted path
+type ValueChange struct {
+	Key string
+	Old interface{} // nil if the key was added
+	New interface{} // nil if the key was removed
+}
+
+// RenderValuesSnapshots resolves the values of every Helm service and
+// renders each as YAML, keyed by service name
+func (vm *ValuesManager) RenderValuesSnapshots(runtime *RuntimeConfig) (map[string][]byte, error) {
+	snapshots := make(map[string][]byte)
+	for _, service := range runtime.OrderedServices() {
+		if service.Engine() != EngineHelm {
+			continue
+		}
+
+		values, err := vm.ResolveValues(service, runtime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve values for %s: %w", service.Name, err)
+		}
+
+		// Round-trip through YAML so snapshots compare like values read back from disk
+		data, err := yaml.Marshal(values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode values for %s: %w", service.Name, err)
+		}
+		header := fmt.Sprintf("# Resolved values for %s, written by 'plat values snapshot'\n", service.Name)
+		snapshots[service.Name] = append([]byte(header), data...)
+	}
+	return snapshots, nil
+}
+
+// WriteValuesSnapshots replaces the golden files in dir. Files of services
+// that are no longer snapshotted are removed.
+func WriteValuesSnapshots(dir string, snapshots map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	existing, err := readValuesSnapshots(dir)
+	if err != nil {
+		return err
+	}
+	for service := range existing {
+		if _, keep := snapshots[service]; !keep {
+			if err := os.Remove(snapshotPath(dir, service)); err != nil {
+				return fmt.Errorf("failed to remove snapshot for %s: %w", service, err)
+			}
+		}
+	}
+
+	for service, data := range snapshots {
+		if err := os.WriteFile(snapshotPath(dir, service), data, 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot for %s: %w", service, err)
+		}
+	}
+	return nil
+}
+
+// CompareValuesSnapshots compares rendered snapshots with the golden files
+// in dir and returns the differences in config order. Golden files of
+// services no longer in the config come last, sorted by name.
+func CompareValuesSnapshots(runtime *RuntimeConfig, dir string, snapshots map[string][]byte) ([]ValuesSnapshotChange, error) {
+	golden, err := readValuesSnapshots(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []ValuesSnapshotChange
+	for _, service := range runtime.ListServices() {
+		data, rendered := snapshots[service]
+		if !rendered {
+			continue
+		}
+
+		goldenData, exists := golden[service]
+		if !exists {
+			changes = append(changes, ValuesSnapshotChange{Service: service, Kind: SnapshotAdded})
+			continue
+		}
+
+		var before, after map[string]interface{}
+		if err := yaml.Unmarshal(goldenData, &before); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot for %s: %w", service, err)
+		}
+		if err := yaml.Unmarshal(data, &after); err != nil {
+			return nil, fmt.Errorf("failed to parse values for %s: %w", service, err)
+		}
+
+		if !ValuesEqual(before, after) {
+			var valueChanges []ValueChange
+			diffValueKeys("", before, after, &valueChanges)
+			changes = append(changes, ValuesSnapshotChange{Service: service, Kind: SnapshotChanged, Changes: valueChanges})
+		}
+	}
+
+	var removed []string
+	for service := range golden {
+		if _, exists := snapshots[service]; !exists {
+			removed = append(removed, service)
+		}
+	}
+	sort.Strings(removed)
+	for _, service := range removed {
+		changes = append(changes, ValuesSnapshotChange{Service: service, Kind: SnapshotRemoved})
+	}
+
+	return changes, nil
+}
+
+// readValuesSnapshots reads the golden files in dir, keyed by service. A
+// missing directory has no snapshots.
+func readValuesSnapshots(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	snapshots := make(map[string][]byte)
+	for _, entry := range entries {
+		service, isSnapshot := strings.CutSuffix(entry.Name(), ".yaml")
+		if entry.IsDir() || !isSnapshot {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot for %s: %w", service, err)
+		}
+		snapshots[service] = data
+	}
+	return snapshots, nil
+}
+
+func snapshotPath(dir, service string) string {
+	return filepath.Join(dir, service+".yaml")
+}
+
+// diffValueKeys collects the keys that differ between two values maps,
+// descending into nested maps. Lists are compared as a whole.
+func diffValueKeys(prefix string, before, after map[string]interface{}, changes *[]ValueChange) {
+	keys := make(map[string]bool, len(before)+len(after))
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		beforeValue, afterValue := before[key], after[key]
+		beforeMap, beforeIsMap := beforeValue.(map[string]interface{})
+		afterMap, afterIsMap := afterValue.(map[string]interface{})
+		if beforeIsMap && afterIsMap {
+			diffValueKeys(path, beforeMap, afterMap, changes)
+			continue
+		}
+
+		if !reflect.DeepEqual(normalizeValue(beforeValue), normalizeValue(afterValue)) {
+			*changes = append(*changes, ValueChange{Key: path, Old: beforeValue, New: afterValue})
+		}
+	}
+}
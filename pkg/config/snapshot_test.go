@@ -0,0 +1,107 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenSnapshotDir holds the resolved values of testConfig. Run
+// 'go test ./pkg/config -run TestValuesSnapshotsGolden -update' to accept
+// an intended change to value resolution.
+var goldenSnapshotDir = filepath.Join("testdata", ValuesSnapshotDir)
+
+// TestValuesSnapshotsGolden renders the resolved values of testConfig and
+// compares them with the golden files, so changes to defaults or merging
+// show up in review
+func TestValuesSnapshotsGolden(t *testing.T) {
+	runtime := loadTestConfig(t)
+
+	snapshots, err := NewValuesManager(runtime.ConfigDir()).RenderValuesSnapshots(runtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *updateGolden {
+		if err := WriteValuesSnapshots(goldenSnapshotDir, snapshots); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes, err := CompareValuesSnapshots(runtime, goldenSnapshotDir, snapshots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, change := range changes {
+		t.Errorf("%s: snapshot %s %+v", change.Service, change.Kind, change.Changes)
+	}
+	if len(changes) > 0 {
+		t.Log("run with -update to accept the changes")
+	}
+}
+
+// TestCompareValuesSnapshots checks the kinds of changes and that they are
+// reported in config order, with removed services last
+func TestCompareValuesSnapshots(t *testing.T) {
+	runtime := loadTestConfig(t)
+	vm := NewValuesManager(runtime.ConfigDir())
+
+	snapshots, err := vm.RenderValuesSnapshots(runtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := WriteValuesSnapshots(dir, snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(snapshotPath(dir, "api")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(snapshotPath(dir, "retired"), []byte("replicaCount: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	edited := runtime.Clone()
+	edited.ResolvedServices["web"].Values["replicaCount"] = 3
+	edited.ResolvedServices["web"].Values["debug"] = true
+	snapshots, err = vm.RenderValuesSnapshots(edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := CompareValuesSnapshots(edited, dir, snapshots)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ValuesSnapshotChange{
+		{Service: "api", Kind: SnapshotAdded},
+		{Service: "web", Kind: SnapshotChanged, Changes: []ValueChange{
+			{Key: "debug", New: true},
+			{Key: "replicaCount", Old: 2, New: 3},
+		}},
+		{Service: "retired", Kind: SnapshotRemoved},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %+v, want %+v", changes, want)
+	}
+
+	if err := WriteValuesSnapshots(dir, snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(snapshotPath(dir, "retired")); !os.IsNotExist(err) {
+		t.Errorf("snapshot of a removed service was kept: %v", err)
+	}
+	changes, err = CompareValuesSnapshots(edited, dir, snapshots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes after writing snapshots = %+v, want none", changes)
+	}
+}
@@ -5,7 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"time"
+
+	"plat/pkg/fsys"
 )
 
 // ValidationError represents a configuration validation error
@@ -45,6 +50,7 @@ func (e ValidationErrors) Error() string {
 type ConfigValidator struct {
 	configDir string
 	strict    bool // Enable strict validation (fail on warnings)
+	fs        fsys.FS
 }
 
 // NewConfigValidator creates a new configuration validator
@@ -52,9 +58,15 @@ func NewConfigValidator(configDir string, strict bool) *ConfigValidator {
 	return &ConfigValidator{
 		configDir: configDir,
 		strict:    strict,
+		fs:        fsys.OS{},
 	}
 }
 
+// SetFS sets the file system paths are checked against
+func (cv *ConfigValidator) SetFS(fs fsys.FS) {
+	cv.fs = fs
+}
+
 // ValidateBaseConfig validates the base configuration
 func (cv *ConfigValidator) ValidateBaseConfig(config *BaseConfig) error {
 	var errors ValidationErrors
@@ -127,6 +139,11 @@ func (cv *ConfigValidator) ValidateBaseConfig(config *BaseConfig) error {
 				errors = append(errors, serviceErrors...)
 			}
 		}
+
+		// Aliases must name exactly one service
+		if aliasErrors := cv.validateAliases(config.Services, serviceNames); len(aliasErrors) > 0 {
+			errors = append(errors, aliasErrors...)
+		}
 	}
 
 	// Validate defaults
@@ -136,6 +153,30 @@ func (cv *ConfigValidator) ValidateBaseConfig(config *BaseConfig) error {
 		}
 	}
 
+	// Validate the image policy
+	if config.ImagePolicy != nil {
+		if policyErrors := cv.validateImagePolicy(config.ImagePolicy); len(policyErrors) > 0 {
+			errors = append(errors, policyErrors...)
+		}
+	}
+
+	// Validate chart repository aliases
+	if repoErrors := cv.validateRepositories(config.Repositories); len(repoErrors) > 0 {
+		errors = append(errors, repoErrors...)
+	}
+
+	// Validate profiles
+	if profileErrors := cv.validateProfiles(config); len(profileErrors) > 0 {
+		errors = append(errors, profileErrors...)
+	}
+
+	// Validate notification hooks
+	for i, hook := range config.Notifications {
+		if hookErrors := cv.validateNotificationHook(&hook, i); len(hookErrors) > 0 {
+			errors = append(errors, hookErrors...)
+		}
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -160,6 +201,24 @@ func (cv *ConfigValidator) ValidateLocalConfig(config *LocalConfig) error {
 		}
 	}
 
+	if config.Logs != nil && config.Logs.MaxLines < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "logs.maxLines",
+			Value:   fmt.Sprintf("%d", config.Logs.MaxLines),
+			Message: "must be a positive number of lines",
+		})
+	}
+
+	if config.Notify != nil && config.Notify.After != "" {
+		if threshold, err := time.ParseDuration(config.Notify.After); err != nil || threshold < 0 {
+			errors = append(errors, ValidationError{
+				Field:   "notify.after",
+				Value:   config.Notify.After,
+				Message: "must be a non-negative duration such as 30s or 2m",
+			})
+		}
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -182,9 +241,9 @@ func (cv *ConfigValidator) ValidateRuntimeConfig(runtime *RuntimeConfig) error {
 		}
 	}
 
-	// Validate resolved services
-	for name, service := range runtime.ResolvedServices {
-		if serviceErrors := cv.validateResolvedService(service, name, runtime); len(serviceErrors) > 0 {
+	// Validate resolved services, in config order
+	for _, service := range runtime.OrderedServices() {
+		if serviceErrors := cv.validateResolvedService(service, service.Name, runtime); len(serviceErrors) > 0 {
 			errors = append(errors, serviceErrors...)
 		}
 	}
@@ -255,7 +314,7 @@ func (cv *ConfigValidator) validateService(service *Service, index int) Validati
 			errors = append(errors, ValidationError{
 				Field:   fmt.Sprintf("%s.environment[%s]", prefix, key),
 				Value:   key,
-				Message: "potentially sensitive value detected - consider using secrets",
+				Message: fmt.Sprintf("potentially sensitive value detected - store it with 'plat secrets set %s %s=...' and set envFrom: secret", serviceName, key),
 			})
 		}
 	}
@@ -271,13 +330,109 @@ func (cv *ConfigValidator) validateService(service *Service, index int) Validati
 		}
 	}
 
+	if service.ReadyTimeout != "" && !isPositiveDuration(service.ReadyTimeout) {
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".readyTimeout",
+			Value:   service.ReadyTimeout,
+			Message: "must be a positive duration such as 90s or 5m",
+		})
+	}
+
+	if service.Readiness != nil {
+		errors = append(errors, cv.validateReadinessProbe(service, prefix)...)
+	}
+
+	if service.EnvFrom != "" && service.EnvFrom != EnvFromSecret {
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".envFrom",
+			Value:   service.EnvFrom,
+			Message: fmt.Sprintf("unsupported source, expected '%s'", EnvFromSecret),
+		})
+	}
+
+	if service.LogLevelValue != "" && slices.Contains(strings.Split(service.LogLevelValue, "."), "") {
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".logLevelValue",
+			Value:   service.LogLevelValue,
+			Message: "must be a dotted values path such as logging.level",
+		})
+	}
+
+	// Validate data retention policy
+	switch service.DataRetention {
+	case "", DataRetentionKeep, DataRetentionDelete:
+	default:
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".dataRetention",
+			Value:   service.DataRetention,
+			Message: "data retention must be 'keep' or 'delete'",
+		})
+	}
+
+	// Validate the deploy engine: at most one of chart, manifests and kustomize
+	engines := 0
+	for _, set := range []bool{service.Chart.Name != "", service.Manifests != "", service.Kustomize != ""} {
+		if set {
+			engines++
+		}
+	}
+	if engines > 1 {
+		errors = append(errors, ValidationError{
+			Field:   prefix,
+			Value:   serviceName,
+			Message: "only one of chart, manifests and kustomize can be set",
+		})
+	}
+
+	if service.Manifests != "" && !cv.dirExists(service.Manifests) {
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".manifests",
+			Value:   service.Manifests,
+			Message: "manifests directory does not exist",
+		})
+	}
+
+	if service.Kustomize != "" {
+		if !cv.dirExists(service.Kustomize) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".kustomize",
+				Value:   service.Kustomize,
+				Message: "kustomize directory does not exist",
+			})
+		} else if !cv.hasKustomization(service.Kustomize) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".kustomize",
+				Value:   service.Kustomize,
+				Message: "directory has no kustomization.yaml",
+			})
+		}
+	}
+
+	// Validate manifest patches
+	for i, patch := range service.Patches {
+		field := fmt.Sprintf("%s.patches[%d]", prefix, i)
+		if strings.TrimSpace(patch.Patch) == "" {
+			errors = append(errors, ValidationError{
+				Field:   field + ".patch",
+				Message: "patch cannot be empty",
+			})
+			continue
+		}
+		if patch.IsJSON6902() && (patch.Target == nil || patch.Target.Kind == "") {
+			errors = append(errors, ValidationError{
+				Field:   field + ".target",
+				Message: "JSON6902 patches require a target with at least a kind",
+			})
+		}
+	}
+
 	// Validate values file path
 	if service.ValuesFile != "" {
 		valuesPath := service.ValuesFile
 		if !filepath.IsAbs(valuesPath) {
 			valuesPath = filepath.Join(cv.configDir, valuesPath)
 		}
-		if _, err := os.Stat(valuesPath); os.IsNotExist(err) {
+		if _, err := cv.fs.Stat(valuesPath); os.IsNotExist(err) {
 			errors = append(errors, ValidationError{
 				Field:   prefix + ".values_file",
 				Value:   service.ValuesFile,
@@ -315,7 +470,7 @@ func (cv *ConfigValidator) validateLocalSource(source *LocalSource, name string)
 	}
 
 	// Check if path exists
-	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+	if _, err := cv.fs.Stat(absPath); os.IsNotExist(err) {
 		errors = append(errors, ValidationError{
 			Field:   prefix + ".path",
 			Value:   sourcePath,
@@ -325,7 +480,7 @@ func (cv *ConfigValidator) validateLocalSource(source *LocalSource, name string)
 
 	// Validate dockerfile exists
 	dockerfilePath := filepath.Join(absPath, source.GetDockerfile())
-	if _, err := os.Stat(dockerfilePath); os.IsNotExist(err) {
+	if _, err := cv.fs.Stat(dockerfilePath); os.IsNotExist(err) {
 		if cv.strict {
 			errors = append(errors, ValidationError{
 				Field:   prefix + ".dockerfile",
@@ -334,13 +489,13 @@ func (cv *ConfigValidator) validateLocalSource(source *LocalSource, name string)
 			})
 		} else {
 			// Just a warning in non-strict mode
-			fmt.Printf("Warning: Dockerfile not found at %s\n", dockerfilePath)
+			logger().Warn("dockerfile not found", "path", dockerfilePath)
 		}
 	}
 
 	// Validate chart directory exists if using local charts
 	chartPath := filepath.Join(absPath, source.GetChart())
-	if _, err := os.Stat(chartPath); os.IsNotExist(err) {
+	if _, err := cv.fs.Stat(chartPath); os.IsNotExist(err) {
 		if cv.strict {
 			errors = append(errors, ValidationError{
 				Field:   prefix + ".chart",
@@ -348,8 +503,139 @@ func (cv *ConfigValidator) validateLocalSource(source *LocalSource, name string)
 				Message: "chart directory does not exist",
 			})
 		} else {
-			fmt.Printf("Warning: Chart directory not found at %s\n", chartPath)
+			logger().Warn("chart directory not found", "path", chartPath)
+		}
+	}
+
+	return errors
+}
+
+// validateAliases checks that service aliases are valid names that don't
+// shadow a service or another service's alias
+func (cv *ConfigValidator) validateAliases(services []Service, serviceNames map[string]bool) ValidationErrors {
+	var errors ValidationErrors
+	owners := make(map[string]string)
+
+	for i, service := range services {
+		for j, alias := range service.Aliases {
+			field := fmt.Sprintf("services[%d].aliases[%d]", i, j)
+			switch owner, taken := owners[alias]; {
+			case !cv.isValidServiceName(alias):
+				errors = append(errors, ValidationError{
+					Field:   field,
+					Value:   alias,
+					Message: "invalid alias format",
+				})
+			case serviceNames[alias]:
+				errors = append(errors, ValidationError{
+					Field:   field,
+					Value:   alias,
+					Message: "alias is the name of a service",
+				})
+			case taken && owner != service.GetName():
+				errors = append(errors, ValidationError{
+					Field:   field,
+					Value:   alias,
+					Message: fmt.Sprintf("alias is already used by %s", owner),
+				})
+			}
+			owners[alias] = service.GetName()
+		}
+	}
+
+	return errors
+}
+
+// validateProfiles checks that profiles only refer to configured services
+func (cv *ConfigValidator) validateProfiles(config *BaseConfig) ValidationErrors {
+	var errors ValidationErrors
+	services := make(map[string]bool, len(config.Services))
+	for _, service := range config.Services {
+		services[service.GetName()] = true
+	}
+
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		profile := config.Profiles[name]
+		prefix := fmt.Sprintf("profiles[%s]", name)
+
+		if !cv.isValidKubernetesSafeName(name) {
+			errors = append(errors, ValidationError{
+				Field:   prefix,
+				Value:   name,
+				Message: "profile name must be lowercase alphanumeric and hyphens",
+			})
+		}
+		for i, service := range profile.Services {
+			if !services[service] {
+				errors = append(errors, ValidationError{
+					Field:   fmt.Sprintf("%s.services[%d]", prefix, i),
+					Value:   service,
+					Message: "service not found in configuration",
+				})
+			}
+		}
+		for service := range profile.Values {
+			if !services[service] {
+				errors = append(errors, ValidationError{
+					Field:   prefix + ".values",
+					Value:   service,
+					Message: "service not found in configuration",
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// validateRepositories validates chart repository aliases
+func (cv *ConfigValidator) validateRepositories(repos []ChartRepository) ValidationErrors {
+	var errors ValidationErrors
+	byName := make(map[string]string)
+	byURL := make(map[string]string)
+
+	for i, repo := range repos {
+		prefix := fmt.Sprintf("repositories[%d]", i)
+
+		if !cv.isValidKubernetesSafeName(repo.Name) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   repo.Name,
+				Message: "repository name must be lowercase alphanumeric and hyphens",
+			})
+		}
+		if !strings.HasPrefix(repo.URL, "http://") && !strings.HasPrefix(repo.URL, "https://") && !strings.HasPrefix(repo.URL, "oci://") {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".url",
+				Value:   repo.URL,
+				Message: "repository URL must start with http://, https:// or oci://",
+			})
 		}
+
+		if url, exists := byName[repo.Name]; exists && !sameRepositoryURL(url, repo.URL) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   repo.Name,
+				Message: fmt.Sprintf("repository name is already used for %s; pick a different alias", url),
+			})
+		}
+		byName[repo.Name] = repo.URL
+
+		normalized := normalizeRepositoryURL(repo.URL)
+		if other, exists := byURL[normalized]; exists && other != repo.Name {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".url",
+				Value:   repo.URL,
+				Message: fmt.Sprintf("URL is already declared as '%s'; use one alias per repository", other),
+			})
+		}
+		byURL[normalized] = repo.Name
 	}
 
 	return errors
@@ -392,6 +678,193 @@ func (cv *ConfigValidator) validateDefaults(defaults *DefaultsConfig) Validation
 		}
 	}
 
+	// Validate helm driver
+	if defaults.HelmDriver != "" && defaults.HelmDriver != "cli" && defaults.HelmDriver != "sdk" {
+		errors = append(errors, ValidationError{
+			Field:   "defaults.helmDriver",
+			Value:   defaults.HelmDriver,
+			Message: "must be 'cli' or 'sdk'",
+		})
+	}
+
+	switch defaults.ClusterProvider {
+	case "", "k3d", "kind", "minikube":
+	default:
+		errors = append(errors, ValidationError{
+			Field:   "defaults.clusterProvider",
+			Value:   defaults.ClusterProvider,
+			Message: "must be 'k3d', 'kind' or 'minikube'",
+		})
+	}
+
+	switch defaults.IngressController {
+	case "", IngressNginx, IngressTraefik, IngressNone:
+	default:
+		errors = append(errors, ValidationError{
+			Field:   "defaults.ingressController",
+			Value:   defaults.IngressController,
+			Message: "must be 'nginx', 'traefik' or 'none'",
+		})
+	}
+
+	switch defaults.ContainerRuntime {
+	case "", "docker", "podman", "nerdctl":
+	default:
+		errors = append(errors, ValidationError{
+			Field:   "defaults.containerRuntime",
+			Value:   defaults.ContainerRuntime,
+			Message: "must be 'docker', 'podman' or 'nerdctl'",
+		})
+	}
+
+	if defaults.ReadyTimeout != "" && !isPositiveDuration(defaults.ReadyTimeout) {
+		errors = append(errors, ValidationError{
+			Field:   "defaults.readyTimeout",
+			Value:   defaults.ReadyTimeout,
+			Message: "must be a positive duration such as 90s or 5m",
+		})
+	}
+
+	if timeouts := defaults.Timeouts; timeouts != nil {
+		for _, timeout := range []struct{ field, value string }{
+			{"query", timeouts.Query},
+			{"mutate", timeouts.Mutate},
+			{"install", timeouts.Install},
+			{"killGrace", timeouts.KillGrace},
+		} {
+			if timeout.value != "" && !isPositiveDuration(timeout.value) {
+				errors = append(errors, ValidationError{
+					Field:   "defaults.timeouts." + timeout.field,
+					Value:   timeout.value,
+					Message: "must be a positive duration such as 90s or 5m",
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// validateReadinessProbe validates a service's readiness probe
+func (cv *ConfigValidator) validateReadinessProbe(service *Service, prefix string) ValidationErrors {
+	var errors ValidationErrors
+	probe := service.Readiness
+	field := prefix + ".readiness"
+
+	kinds := 0
+	for _, set := range []bool{probe.HTTP != "", probe.TCP != 0, len(probe.Exec) > 0} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return append(errors, ValidationError{
+			Field:   field,
+			Value:   service.GetName(),
+			Message: "set exactly one of http, tcp and exec",
+		})
+	}
+
+	if probe.HTTP != "" {
+		if !strings.HasPrefix(probe.HTTP, "/") {
+			errors = append(errors, ValidationError{
+				Field:   field + ".http",
+				Value:   probe.HTTP,
+				Message: "must be a path starting with /",
+			})
+		}
+		if probe.Port == 0 && len(service.Ports) == 0 {
+			errors = append(errors, ValidationError{
+				Field:   field + ".port",
+				Value:   service.GetName(),
+				Message: "http probes need a port when the service declares no ports",
+			})
+		}
+	}
+
+	for _, port := range []struct {
+		name  string
+		value int
+	}{{"tcp", probe.TCP}, {"port", probe.Port}} {
+		if port.value < 0 || port.value > 65535 {
+			errors = append(errors, ValidationError{
+				Field:   field + "." + port.name,
+				Value:   fmt.Sprintf("%d", port.value),
+				Message: "port must be between 1 and 65535",
+			})
+		}
+	}
+
+	return errors
+}
+
+// isPositiveDuration reports whether value parses as a duration above zero
+func isPositiveDuration(value string) bool {
+	d, err := time.ParseDuration(value)
+	return err == nil && d > 0
+}
+
+// validateImagePolicy checks that keyless signature verification knows
+// which signer to accept, as cosign requires
+func (cv *ConfigValidator) validateImagePolicy(policy *ImagePolicy) ValidationErrors {
+	var errors ValidationErrors
+	if !policy.VerifySignatures || policy.CosignKey != "" {
+		return errors
+	}
+
+	if policy.CertificateIdentity == "" {
+		errors = append(errors, ValidationError{
+			Field:   "imagePolicy.certificateIdentity",
+			Message: "required for keyless signature verification (or set cosignKey to verify with a public key)",
+		})
+	}
+	if policy.CertificateOIDCIssuer == "" {
+		errors = append(errors, ValidationError{
+			Field:   "imagePolicy.certificateOidcIssuer",
+			Message: "required for keyless signature verification (or set cosignKey to verify with a public key)",
+		})
+	}
+	return errors
+}
+
+// validateNotificationHook validates a notification hook definition
+func (cv *ConfigValidator) validateNotificationHook(hook *NotificationHook, index int) ValidationErrors {
+	var errors ValidationErrors
+	prefix := fmt.Sprintf("notifications[%d]", index)
+
+	if hook.Type != "slack" && hook.Type != "webhook" {
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".type",
+			Value:   hook.Type,
+			Message: "type must be 'slack' or 'webhook'",
+		})
+	}
+
+	if !strings.HasPrefix(hook.URL, "http://") && !strings.HasPrefix(hook.URL, "https://") {
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".url",
+			Value:   hook.URL,
+			Message: "url must be an http(s) URL",
+		})
+	}
+
+	for _, event := range hook.Events {
+		known := false
+		for _, name := range NotificationEvents {
+			if event == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".events",
+				Value:   event,
+				Message: fmt.Sprintf("unknown event, expected one of: %s", strings.Join(NotificationEvents, ", ")),
+			})
+		}
+	}
+
 	return errors
 }
 
@@ -400,6 +873,17 @@ func (cv *ConfigValidator) validateResolvedService(service *ResolvedService, nam
 	var errors ValidationErrors
 	prefix := fmt.Sprintf("resolved_services[%s]", name)
 
+	// Charts with an explicit repository must resolve unambiguously
+	if service.Engine() == EngineHelm && service.Chart.Repository != "" {
+		if _, err := runtime.ResolveChart(service); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".chart.repository",
+				Value:   service.Chart.Repository,
+				Message: err.Error(),
+			})
+		}
+	}
+
 	// Validate local source consistency
 	if service.IsLocal && service.LocalSource == nil {
 		errors = append(errors, ValidationError{
@@ -451,7 +935,7 @@ func (cv *ConfigValidator) checkDependencyCycles(runtime *RuntimeConfig) *Valida
 		return false
 	}
 
-	for serviceName := range runtime.ResolvedServices {
+	for _, serviceName := range runtime.ListServices() {
 		if !visited[serviceName] {
 			if hasCycle(serviceName) {
 				return &ValidationError{
@@ -466,6 +950,31 @@ func (cv *ConfigValidator) checkDependencyCycles(runtime *RuntimeConfig) *Valida
 }
 
 // Validation helper functions
+// resolvePath resolves a config path against the config directory
+func (cv *ConfigValidator) resolvePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(cv.configDir, path)
+}
+
+// dirExists checks that a config path points at a directory
+func (cv *ConfigValidator) dirExists(path string) bool {
+	info, err := cv.fs.Stat(cv.resolvePath(path))
+	return err == nil && info.IsDir()
+}
+
+// hasKustomization checks that a directory contains a kustomization file
+func (cv *ConfigValidator) hasKustomization(path string) bool {
+	dir := cv.resolvePath(path)
+	for _, name := range []string{"kustomization.yaml", "kustomization.yml", "Kustomization"} {
+		if _, err := cv.fs.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (cv *ConfigValidator) isValidKubernetesSafeName(name string) bool {
 	if len(name) == 0 || len(name) > 63 {
 		return false
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -21,21 +24,54 @@ func NewValuesManager(configDir string) *ValuesManager {
 	}
 }
 
+// Layers of resolved values, from lowest to highest precedence
+const (
+	ValuesLayerDefaults = "chart defaults"
+	ValuesLayerService  = "service values"
+	ValuesLayerFile     = "values file"
+	ValuesLayerLocal    = "local override"
+	ValuesLayerRuntime  = "runtime override"
+)
+
+// ValuesLayer is one named set of values merged into a service's values
+type ValuesLayer struct {
+	Name   string
+	Values map[string]interface{}
+}
+
 // ResolveValues resolves final Helm values for a service
 func (vm *ValuesManager) ResolveValues(service *ResolvedService, runtime *RuntimeConfig) (map[string]interface{}, error) {
+	layers, err := vm.ValuesLayers(service, runtime)
+	if err != nil {
+		return nil, err
+	}
+
 	values := make(map[string]interface{})
+	for _, layer := range layers {
+		mergeValues(values, layer.Values)
+	}
+	return values, nil
+}
+
+// ValuesLayers returns the layers ResolveValues merges for a service, in
+// merge order. Layers that set nothing are left out.
+func (vm *ValuesManager) ValuesLayers(service *ResolvedService, runtime *RuntimeConfig) ([]ValuesLayer, error) {
+	var layers []ValuesLayer
+	add := func(name string, values map[string]interface{}) {
+		if len(values) > 0 {
+			layers = append(layers, ValuesLayer{Name: name, Values: values})
+		}
+	}
 
 	// 1. Start with MSC chart defaults
 	defaults, err := vm.getChartDefaults(service.Chart.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chart defaults: %w", err)
 	}
-	vm.mergeValues(values, defaults)
+	add(ValuesLayerDefaults, defaults)
 
 	// 2. Apply service-specific values from config
-	if service.Values != nil {
-		vm.mergeValues(values, service.Values)
-	}
+	add(ValuesLayerService, service.Values)
 
 	// 3. Load values from external file if specified
 	if service.ValuesFile != "" {
@@ -43,18 +79,126 @@ func (vm *ValuesManager) ResolveValues(service *ResolvedService, runtime *Runtim
 		if err != nil {
 			return nil, fmt.Errorf("failed to load values file %s: %w", service.ValuesFile, err)
 		}
-		vm.mergeValues(values, fileValues)
+		add(ValuesLayerFile, fileValues)
 	}
 
 	// 4. Apply local development overrides
-	localOverrides := vm.buildLocalOverrides(service, runtime)
-	vm.mergeValues(values, localOverrides)
+	add(ValuesLayerLocal, vm.buildLocalOverrides(service, runtime))
 
 	// 5. Apply runtime-specific overrides (ingress, resources, etc.)
-	runtimeOverrides := vm.buildRuntimeOverrides(service, runtime)
-	vm.mergeValues(values, runtimeOverrides)
+	add(ValuesLayerRuntime, vm.buildRuntimeOverrides(service, runtime))
 
-	return values, nil
+	return layers, nil
+}
+
+// MergeValueLayers merges layers in order and reports, for every leaf key of
+// the result, the name of the layer that set it. Keys are dotted paths such
+// as image.tag; lists are leaves.
+func MergeValueLayers(layers []ValuesLayer) (values map[string]interface{}, sources map[string]string) {
+	values = make(map[string]interface{})
+	sources = make(map[string]string)
+	for _, layer := range layers {
+		mergeValues(values, layer.Values)
+		recordSources(sources, "", layer.Values, layer.Name)
+	}
+	return values, sources
+}
+
+// ValueProvenance is a leaf key of a service's resolved values and the layer
+// that set it
+type ValueProvenance struct {
+	Key    string      `json:"key"` // Dotted path such as image.tag
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"` // Name of the layer, e.g. ValuesLayerFile
+}
+
+// FormatValue renders the value on one line: lists and maps as JSON
+func (p ValueProvenance) FormatValue() string {
+	switch p.Value.(type) {
+	case []interface{}, []map[string]interface{}, map[string]interface{}:
+		if data, err := json.Marshal(p.Value); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(p.Value)
+}
+
+// GetValueProvenance resolves a service's values like ResolveValues and
+// reports the layer every leaf key comes from, sorted by key. Extra layers,
+// such as session overrides, are merged on top.
+func (vm *ValuesManager) GetValueProvenance(service *ResolvedService, runtime *RuntimeConfig, extra ...ValuesLayer) ([]ValueProvenance, error) {
+	layers, err := vm.ValuesLayers(service, runtime)
+	if err != nil {
+		return nil, err
+	}
+	values, sources := MergeValueLayers(append(layers, extra...))
+
+	leaves := make(map[string]interface{})
+	flattenValues("", values, leaves)
+
+	provenance := make([]ValueProvenance, 0, len(leaves))
+	for key, value := range leaves {
+		provenance = append(provenance, ValueProvenance{Key: key, Value: value, Source: sources[key]})
+	}
+	sort.Slice(provenance, func(i, j int) bool {
+		return provenance[i].Key < provenance[j].Key
+	})
+	return provenance, nil
+}
+
+// flattenValues collects the leaf values of a values map by dotted path.
+// Lists and empty maps are leaves.
+func flattenValues(prefix string, values map[string]interface{}, leaves map[string]interface{}) {
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, isMap := value.(map[string]interface{}); isMap && len(nested) > 0 {
+			flattenValues(path, nested, leaves)
+			continue
+		}
+		leaves[path] = value
+	}
+}
+
+// recordSources attributes the leaf keys a layer sets to it, forgetting the
+// keys it replaces: the children of a map it overwrites with a scalar, or
+// the scalar it overwrites with a map
+func recordSources(sources map[string]string, prefix string, values map[string]interface{}, layer string) {
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, isMap := value.(map[string]interface{}); isMap {
+			delete(sources, path)
+			recordSources(sources, path, nested, layer)
+			// An empty map is a leaf unless it merged into existing keys
+			if len(nested) == 0 && !hasSourceUnder(sources, path) {
+				sources[path] = layer
+			}
+			continue
+		}
+
+		for existing := range sources {
+			if strings.HasPrefix(existing, path+".") {
+				delete(sources, existing)
+			}
+		}
+		sources[path] = layer
+	}
+}
+
+// hasSourceUnder reports whether any key below path has a source
+func hasSourceUnder(sources map[string]string, path string) bool {
+	for existing := range sources {
+		if strings.HasPrefix(existing, path+".") {
+			return true
+		}
+	}
+	return false
 }
 
 // getChartDefaults returns default values for MSC chart types
@@ -132,16 +276,20 @@ func (vm *ValuesManager) buildLocalOverrides(service *ResolvedService, runtime *
 	overrides := make(map[string]interface{})
 
 	// Only apply image overrides for microservice charts, not third-party charts
-	// Third-party charts (like postgresql, redis, etc.) have their own image configurations
-	isMicroserviceChart := service.Chart.Name == "microservice" || service.Chart.Repository == ""
+	isMicroserviceChart := service.IsMicroserviceChart()
 
 	if service.IsLocal {
 		// Override image for local builds
 		if isMicroserviceChart {
+			// Imported images can't be pulled; registry images have unique tags
+			pullPolicy := "Never"
+			if service.LocalRegistry != "" {
+				pullPolicy = "IfNotPresent"
+			}
 			overrides["image"] = map[string]interface{}{
-				"repository": service.Name,
-				"tag":        "dev",
-				"pullPolicy": "Never", // Don't pull local images
+				"repository": service.LocalImageRepository(),
+				"tag":        service.LocalImageTag(),
+				"pullPolicy": pullPolicy,
 			}
 		}
 
@@ -164,9 +312,14 @@ func (vm *ValuesManager) buildLocalOverrides(service *ResolvedService, runtime *
 		}
 	} else if isMicroserviceChart {
 		// Only use registry image for microservice charts
+		tag := service.Version
+		if service.ImageDigest != "" {
+			// Pin the resolved digest while keeping the tag readable
+			tag = fmt.Sprintf("%s@%s", service.Version, service.ImageDigest)
+		}
 		overrides["image"] = map[string]interface{}{
-			"repository": fmt.Sprintf("%s/%s", runtime.Base.Defaults.Registry, service.Name),
-			"tag":        service.Version,
+			"repository": runtime.ImageRepository(service),
+			"tag":        tag,
 			"pullPolicy": "IfNotPresent",
 		}
 	}
@@ -174,6 +327,12 @@ func (vm *ValuesManager) buildLocalOverrides(service *ResolvedService, runtime *
 	return overrides
 }
 
+// TLSSecretName returns the name of the Secret holding the certificate of a
+// service's ingress host
+func TLSSecretName(serviceName string) string {
+	return "plat-tls-" + serviceName
+}
+
 // buildRuntimeOverrides creates runtime-specific values
 func (vm *ValuesManager) buildRuntimeOverrides(service *ResolvedService, runtime *RuntimeConfig) map[string]interface{} {
 	overrides := make(map[string]interface{})
@@ -181,8 +340,9 @@ func (vm *ValuesManager) buildRuntimeOverrides(service *ResolvedService, runtime
 	// Configure ingress with platform domain
 	if runtime.Base.Defaults.Domain != "" {
 		host := fmt.Sprintf("%s.%s", service.Name, runtime.Base.Defaults.Domain)
-		overrides["ingress"] = map[string]interface{}{
-			"enabled": true,
+		ingress := map[string]interface{}{
+			"enabled":   true,
+			"className": runtime.IngressClass(),
 			"hosts": []map[string]interface{}{
 				{
 					"host": host,
@@ -195,20 +355,62 @@ func (vm *ValuesManager) buildRuntimeOverrides(service *ResolvedService, runtime
 				},
 			},
 		}
+		if runtime.TLSEnabled() {
+			ingress["tls"] = []map[string]interface{}{
+				{
+					"secretName": TLSSecretName(service.Name),
+					"hosts":      []string{host},
+				},
+			}
+			// Keep plain HTTP answering too: readiness checks, assertions
+			// and tunnels reach the ingress over HTTP on localhost. Traefik
+			// doesn't redirect unless told to.
+			if runtime.IngressClass() == IngressNginx {
+				ingress["annotations"] = map[string]interface{}{
+					"nginx.ingress.kubernetes.io/ssl-redirect": "false",
+				}
+			}
+		}
+		overrides["ingress"] = ingress
+	}
+
+	// Charts that configure logging through a value get the level there
+	if level := service.EffectiveLogLevel(); level != "" && service.LogLevelValue != "" {
+		setValuePath(overrides, service.LogLevelValue, level)
 	}
 
-	// Apply environment variables
-	if len(service.Environment) > 0 {
-		env := make([]map[string]interface{}, 0, len(service.Environment))
-		for key, value := range service.Environment {
+	// Apply environment variables, sorted so repeated resolutions match
+	environment := service.PodEnvironment()
+	if len(environment) > 0 {
+		keys := make([]string, 0, len(environment))
+		for key := range environment {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		env := make([]map[string]interface{}, 0, len(keys))
+		for _, key := range keys {
 			env = append(env, map[string]interface{}{
 				"name":  key,
-				"value": value,
+				"value": environment[key],
 			})
 		}
 		overrides["env"] = env
 	}
 
+	// Load secrets set with 'plat secrets'; optional so the service starts
+	// before any are set
+	if service.EnvFrom == EnvFromSecret {
+		overrides["envFrom"] = []map[string]interface{}{
+			{
+				"secretRef": map[string]interface{}{
+					"name":     SecretName(service.Name),
+					"optional": true,
+				},
+			},
+		}
+	}
+
 	// Configure service ports
 	if len(service.Ports) > 0 {
 		// Use first port as primary service port
@@ -234,31 +436,159 @@ func (vm *ValuesManager) buildRuntimeOverrides(service *ResolvedService, runtime
 }
 
 // mergeValues merges source values into target (deep merge)
-func (vm *ValuesManager) mergeValues(target, source map[string]interface{}) {
+func mergeValues(target, source map[string]interface{}) {
 	for key, sourceValue := range source {
 		if targetValue, exists := target[key]; exists {
 			// Both exist, try to merge if both are maps
 			if targetMap, targetIsMap := targetValue.(map[string]interface{}); targetIsMap {
 				if sourceMap, sourceIsMap := sourceValue.(map[string]interface{}); sourceIsMap {
-					vm.mergeValues(targetMap, sourceMap)
+					mergeValues(targetMap, sourceMap)
 					continue
 				}
 			}
 		}
-		// Either target doesn't exist or can't merge, so overwrite
+		// Either target doesn't exist or can't merge, so overwrite. Nested maps
+		// are copied so later merges never write into the config's values.
+		if sourceMap, isMap := sourceValue.(map[string]interface{}); isMap {
+			target[key] = copyValues(sourceMap)
+			continue
+		}
 		target[key] = sourceValue
 	}
 }
 
+// setValuePath sets a dotted path such as "logging.level" in values,
+// creating the maps along it
+func setValuePath(values map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			values[key] = next
+		}
+		values = next
+	}
+	values[keys[len(keys)-1]] = value
+}
+
+// ApplyOverrides merges an override layer on top of resolved values. A nil
+// override value is kept, which makes helm drop the key from chart defaults.
+func (vm *ValuesManager) ApplyOverrides(values, overrides map[string]interface{}) {
+	mergeValues(values, overrides)
+}
+
+// DiffValues returns the override layer that turns base into edited: keys
+// that were added or changed, and nil for keys that were removed
+func DiffValues(base, edited map[string]interface{}) map[string]interface{} {
+	diff := make(map[string]interface{})
+
+	for key, editedValue := range edited {
+		baseValue, exists := base[key]
+		if !exists {
+			diff[key] = copyValue(editedValue)
+			continue
+		}
+
+		baseMap, baseIsMap := baseValue.(map[string]interface{})
+		editedMap, editedIsMap := editedValue.(map[string]interface{})
+		if baseIsMap && editedIsMap {
+			if nested := DiffValues(baseMap, editedMap); len(nested) > 0 {
+				diff[key] = nested
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(normalizeValue(baseValue), normalizeValue(editedValue)) {
+			diff[key] = copyValue(editedValue)
+		}
+	}
+
+	for key := range base {
+		if _, exists := edited[key]; !exists {
+			diff[key] = nil
+		}
+	}
+
+	return diff
+}
+
+// ValuesEqual reports whether two values maps are equivalent, treating
+// numbers and lists decoded from YAML or JSON the same as Go literals
+func ValuesEqual(a, b map[string]interface{}) bool {
+	return reflect.DeepEqual(normalizeValue(a), normalizeValue(b))
+}
+
+// normalizeValue makes values built in Go comparable with values decoded
+// from YAML, which uses int for all whole numbers and []interface{} for lists
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		return int(v)
+	case int32:
+		return int(v)
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+		return v
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return items
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = normalizeValue(item)
+		}
+		return items
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeValue(item)
+		}
+		return normalized
+	default:
+		return v
+	}
+}
+
+// copyValues deep-copies a values map's nested maps and lists
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		copied[key] = copyValue(value)
+	}
+	return copied
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyValues(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = copyValue(item)
+		}
+		return items
+	default:
+		return v
+	}
+}
+
 // ValidateValues validates the final values for common issues
 func (vm *ValuesManager) ValidateValues(service *ResolvedService, values map[string]interface{}) error {
 	var errors []string
 
 	// Only validate image configuration for microservice charts
 	// Third-party charts have their own image defaults
-	isMicroserviceChart := service.Chart.Name == "microservice" || service.Chart.Repository == ""
-
-	if isMicroserviceChart {
+	if service.IsMicroserviceChart() {
 		// Check required image configuration
 		if image, hasImage := values["image"]; hasImage {
 			if imageMap, isMap := image.(map[string]interface{}); isMap {
@@ -295,7 +625,7 @@ func (vm *ValuesManager) ValidateValues(service *ResolvedService, values map[str
 			if enabled, hasEnabled := ingressMap["enabled"]; hasEnabled {
 				if enabledBool, isBool := enabled.(bool); isBool {
 					if !enabledBool && service.IsLocal {
-						fmt.Printf("Warning: Local service %s has ingress disabled - may not be accessible\n", service.Name)
+						logger().Warn("local service has ingress disabled and may not be accessible", "service", service.Name)
 					}
 				}
 			}
@@ -308,7 +638,7 @@ func (vm *ValuesManager) ValidateValues(service *ResolvedService, values map[str
 			if limits, hasLimits := resourcesMap["limits"]; hasLimits {
 				if limitsMap, isLimitsMap := limits.(map[string]interface{}); isLimitsMap && len(limitsMap) == 0 {
 					if !service.IsLocal {
-						fmt.Printf("Warning: Service %s has no resource limits - consider setting limits for production\n", service.Name)
+						logger().Warn("service has no resource limits, consider setting limits for production", "service", service.Name)
 					}
 				}
 			}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// ServiceValuesChange is a Helm service whose resolved values differ
+// between two loads of the config
+type ServiceValuesChange struct {
+	Service string
+	Changes []ValueChange
+}
+
+// ValuesFiles returns the files resolved values are read from: config.yml,
+// local.yml and every service's values file
+func (r *RuntimeConfig) ValuesFiles() []string {
+	configDir := r.ConfigDir()
+	files := []string{filepath.Join(configDir, "local.yml")}
+	if r.ConfigFile != "" {
+		files = append([]string{r.ConfigFile}, files...)
+	}
+
+	seen := make(map[string]bool)
+	for _, service := range r.OrderedServices() {
+		if service.ValuesFile == "" {
+			continue
+		}
+		path := service.ValuesFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(configDir, path)
+		}
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// ResolveAllValues resolves the values of every Helm service, keyed by
+// service. Keep the result to compare with after files change, since the
+// files themselves then hold the new values.
+func ResolveAllValues(runtime *RuntimeConfig) (map[string]map[string]interface{}, error) {
+	vm := NewValuesManager(runtime.ConfigDir())
+	resolved := make(map[string]map[string]interface{})
+	for _, service := range runtime.OrderedServices() {
+		if service.Engine() != EngineHelm {
+			continue
+		}
+		values, err := vm.ResolveValues(service, runtime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve values for %s: %w", service.Name, err)
+		}
+		resolved[service.Name] = values
+	}
+	return resolved, nil
+}
+
+// CompareResolvedValues compares two results of ResolveAllValues and returns
+// the services present in both whose values changed, sorted by name, with
+// the keys that changed
+func CompareResolvedValues(before, after map[string]map[string]interface{}) []ServiceValuesChange {
+	var changes []ServiceValuesChange
+	for service, current := range after {
+		old, exists := before[service]
+		if !exists {
+			continue
+		}
+
+		var valueChanges []ValueChange
+		diffValueKeys("", old, current, &valueChanges)
+		if len(valueChanges) > 0 {
+			changes = append(changes, ServiceValuesChange{Service: service, Changes: valueChanges})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Service < changes[j].Service })
+	return changes
+}
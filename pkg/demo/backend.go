@@ -0,0 +1,408 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
+)
+
+// Seed makes demo sessions repeatable, so docs and recordings can be
+// regenerated with the same statuses and logs
+const Seed = 42
+
+// Backend stands in for the orchestrator with synthetic data: services
+// move through plausible statuses and actions take a short, fixed time
+// without touching docker, k3d or helm
+type Backend struct {
+	mu        sync.Mutex
+	rand      *rand.Rand
+	clock     time.Time // Synthetic clock for log timestamps and updates
+	running   bool      // Whether the cluster is up
+	services  map[string]*serviceState
+	overrides map[string]map[string]interface{}
+	events    *orchestrator.EventBus
+}
+
+type serviceState struct {
+	status   string // Helm status
+	ready    int
+	replicas int
+	reason   string
+	updated  time.Time
+	pods     []string
+}
+
+// NewBackend creates a demo backend with every service deployed
+func NewBackend(runtime *config.RuntimeConfig) *Backend {
+	b := &Backend{
+		rand:      rand.New(rand.NewSource(Seed)),
+		clock:     time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+		running:   true,
+		services:  make(map[string]*serviceState),
+		overrides: make(map[string]map[string]interface{}),
+		events:    orchestrator.NewEventBus(),
+	}
+
+	for _, name := range runtime.ListServices() {
+		replicas := 1
+		if name == "frontend" || name == "catalog-api" {
+			replicas = 2
+		}
+		state := &serviceState{replicas: replicas}
+		for i := 0; i < replicas; i++ {
+			state.pods = append(state.pods, fmt.Sprintf("%s-%s-%s", name, b.suffix(10), b.suffix(5)))
+		}
+		b.setRunning(state)
+		b.services[name] = state
+	}
+
+	// One service starts out unhealthy so the failure states are visible
+	if state, ok := b.services["payment-api"]; ok {
+		state.status = "failed"
+		state.ready = 0
+		state.reason = "CrashLoopBackOff"
+	}
+
+	return b
+}
+
+// SetLogger is a no-op: demo actions log nothing
+func (b *Backend) SetLogger(*slog.Logger) {}
+
+// Subscribe returns a channel receiving the events of demo actions
+func (b *Backend) Subscribe() (<-chan orchestrator.Event, func()) {
+	return b.events.Subscribe()
+}
+
+// SetQuiet is a no-op: demo actions print nothing
+func (b *Backend) SetQuiet(bool) {}
+
+// Status returns the synthetic environment status. Healthy services
+// occasionally go through a restart so the display has something to show.
+func (b *Backend) Status(ctx context.Context, runtime *config.RuntimeConfig) (*orchestrator.EnvironmentStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.clock = b.clock.Add(3 * time.Second)
+
+	status := &orchestrator.EnvironmentStatus{
+		Name:         runtime.Base.Name,
+		Mode:         string(runtime.Mode),
+		Services:     make(map[string]*orchestrator.ServiceStatus),
+		ServiceOrder: runtime.ListServices(),
+		Cluster: &orchestrator.ClusterStatus{
+			Name:    orchestrator.ClusterName(runtime),
+			Status:  "running",
+			Servers: 1,
+			Agents:  2,
+		},
+	}
+	if !b.running {
+		status.Cluster.Status = "stopped"
+	}
+
+	for _, service := range runtime.OrderedServices() {
+		state := b.services[service.Name]
+		b.drift(state)
+
+		svc := &orchestrator.ServiceStatus{
+			Name:    service.Name,
+			DNSName: runtime.ServiceDNSName(service),
+			Status:  state.status,
+			Version: service.Version,
+			IsLocal: service.IsLocal,
+			Ports:   service.Ports,
+			Chart:   service.Chart.Name,
+		}
+		if service.IsLocal {
+			svc.LocalPath = service.LocalSource.GetPath()
+		}
+		if !state.updated.IsZero() {
+			svc.Updated = state.updated.Format(time.RFC3339)
+		}
+		if state.status != "not-deployed" {
+			svc.Deployment = state.deployment()
+		}
+		status.Services[service.Name] = svc
+	}
+
+	return status, nil
+}
+
+// drift advances a service's synthetic state by one refresh
+func (b *Backend) drift(state *serviceState) {
+	switch state.status {
+	case "pending-upgrade":
+		state.ready++
+		if state.ready >= state.replicas {
+			b.setRunning(state)
+		}
+	case "deployed":
+		if b.rand.Intn(40) == 0 {
+			state.status = "pending-upgrade"
+			state.ready = 0
+			state.reason = "ContainerCreating"
+			state.updated = b.clock
+		}
+	}
+}
+
+func (b *Backend) setRunning(state *serviceState) {
+	state.status = "deployed"
+	state.ready = state.replicas
+	state.reason = ""
+	state.updated = b.clock
+}
+
+func (s *serviceState) deployment() *orchestrator.DeploymentStatus {
+	dep := &orchestrator.DeploymentStatus{
+		Phase:          "Running",
+		Ready:          s.ready == s.replicas,
+		PodsReady:      fmt.Sprintf("%d/%d", s.ready, s.replicas),
+		ContainerState: "running",
+		Reason:         s.reason,
+		Replicas:       fmt.Sprintf("%d/%d", s.ready, s.replicas),
+	}
+	switch s.reason {
+	case "ContainerCreating":
+		dep.Phase = "Pending"
+		dep.ContainerState = "waiting"
+	case "CrashLoopBackOff":
+		dep.ContainerState = "waiting"
+		dep.Message = "back-off 40s restarting failed container"
+		dep.Restarts = 7
+		dep.Flapping = true
+	}
+
+	// Pods past the ready count share the service's reason
+	var states []string
+	if s.ready > 0 {
+		states = append(states, fmt.Sprintf("%d ready", s.ready))
+	}
+	if s.ready < s.replicas {
+		states = append(states, fmt.Sprintf("%d %s", s.replicas-s.ready, s.reason))
+	}
+	dep.Summary = strings.Join(states, ", ")
+	for i, name := range s.pods {
+		pod := tools.PodDetail{Name: name, Phase: "Running", Ready: true, ContainerState: "running"}
+		if i >= s.ready {
+			pod.Phase = dep.Phase
+			pod.Ready = false
+			pod.ContainerState = dep.ContainerState
+			pod.Reason = s.reason
+			pod.Message = dep.Message
+			pod.Restarts = dep.Restarts
+		}
+		// Worst state first
+		dep.Pods = append([]tools.PodDetail{pod}, dep.Pods...)
+	}
+	return dep
+}
+
+// Up brings every service to deployed
+func (b *Backend) Up(ctx context.Context, runtime *config.RuntimeConfig) error {
+	b.events.Publish(orchestrator.Event{Type: orchestrator.EventClusterCreating, Cluster: orchestrator.ClusterName(runtime)})
+	b.wait(ctx, 1500*time.Millisecond)
+	b.mu.Lock()
+	b.running = true
+	b.mu.Unlock()
+
+	for _, name := range runtime.ListServices() {
+		if err := b.deploy(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down stops every service
+func (b *Backend) Down(ctx context.Context, runtime *config.RuntimeConfig, deleteCluster bool) error {
+	for _, name := range runtime.ListServices() {
+		b.events.Publish(orchestrator.Event{Type: orchestrator.EventServiceRemoving, Service: name})
+		b.wait(ctx, 300*time.Millisecond)
+		b.mu.Lock()
+		b.services[name].status = "not-deployed"
+		b.services[name].ready = 0
+		b.mu.Unlock()
+		b.events.Publish(orchestrator.Event{Type: orchestrator.EventServiceRemoved, Service: name})
+	}
+	if deleteCluster {
+		b.step(ctx, "", "Deleting cluster", time.Second)
+		b.mu.Lock()
+		b.running = false
+		b.mu.Unlock()
+	}
+	return ctx.Err()
+}
+
+// StartService deploys one service
+func (b *Backend) StartService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
+	return b.deploy(ctx, serviceName)
+}
+
+// StopService uninstalls one service
+func (b *Backend) StopService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
+	state, err := b.service(serviceName)
+	if err != nil {
+		return err
+	}
+	b.events.Publish(orchestrator.Event{Type: orchestrator.EventServiceRemoving, Service: serviceName})
+	b.wait(ctx, 800*time.Millisecond)
+
+	b.mu.Lock()
+	state.status = "not-deployed"
+	state.ready = 0
+	state.reason = ""
+	b.mu.Unlock()
+	b.events.Publish(orchestrator.Event{Type: orchestrator.EventServiceRemoved, Service: serviceName})
+	return ctx.Err()
+}
+
+// RestartService rolls one service's pods
+func (b *Backend) RestartService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
+	state, err := b.service(serviceName)
+	if err != nil {
+		return err
+	}
+	for i := 1; i <= state.replicas; i++ {
+		b.step(ctx, serviceName, fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", serviceName, i-1, state.replicas), 700*time.Millisecond)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := range state.pods {
+		state.pods[i] = fmt.Sprintf("%s-%s-%s", serviceName, b.suffix(10), b.suffix(5))
+	}
+	b.setRunning(state)
+	return ctx.Err()
+}
+
+// Insights reports drift on the local service and an update for frontend
+func (b *Backend) Insights(ctx context.Context, runtime *config.RuntimeConfig) *orchestrator.ServiceInsights {
+	insights := &orchestrator.ServiceInsights{
+		Drift:   make(map[string]bool),
+		Updates: make(map[string]string),
+	}
+	if _, ok := runtime.ResolvedServices["user-api"]; ok {
+		insights.Drift["user-api"] = true
+	}
+	if _, ok := runtime.ResolvedServices["frontend"]; ok {
+		insights.Updates["frontend"] = "v3.5.0"
+	}
+	return insights
+}
+
+// ServiceValues returns the service's real resolved values
+func (b *Backend) ServiceValues(runtime *config.RuntimeConfig, serviceName string) (base, overrides map[string]interface{}, err error) {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return nil, nil, fmt.Errorf("service %s not found in configuration", serviceName)
+	}
+
+	base, err = config.NewValuesManager(runtime.ConfigDir()).ResolveValues(service, runtime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return base, b.overrides[serviceName], nil
+}
+
+// ApplyValueOverrides keeps the overrides and pretends to upgrade the release
+func (b *Backend) ApplyValueOverrides(ctx context.Context, runtime *config.RuntimeConfig, serviceName string, overrides map[string]interface{}) error {
+	b.mu.Lock()
+	b.overrides[serviceName] = overrides
+	b.mu.Unlock()
+	return b.deploy(ctx, serviceName)
+}
+
+// deploy moves a service through an install or upgrade
+func (b *Backend) deploy(ctx context.Context, serviceName string) error {
+	state, err := b.service(serviceName)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	state.status = "pending-upgrade"
+	state.ready = 0
+	state.reason = "ContainerCreating"
+	b.mu.Unlock()
+
+	b.events.Publish(orchestrator.Event{Type: orchestrator.EventServiceDeploying, Service: serviceName})
+	b.wait(ctx, 900*time.Millisecond)
+
+	b.mu.Lock()
+	b.setRunning(state)
+	b.mu.Unlock()
+	b.events.Publish(orchestrator.Event{Type: orchestrator.EventServiceReady, Service: serviceName})
+	b.events.Publish(orchestrator.Event{Type: orchestrator.EventPodsReady, Service: serviceName})
+	return ctx.Err()
+}
+
+func (b *Backend) service(name string) (*serviceState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.services[name]
+	if !ok {
+		return nil, fmt.Errorf("service %s not found in configuration", name)
+	}
+	return state, nil
+}
+
+// step publishes a progress event and waits, standing in for real work
+func (b *Backend) step(ctx context.Context, serviceName, message string, d time.Duration) {
+	b.events.Publish(orchestrator.Event{Type: orchestrator.EventProgress, Service: serviceName, Message: message})
+	b.wait(ctx, d)
+}
+
+// wait advances the synthetic clock and waits, standing in for real work
+func (b *Backend) wait(ctx context.Context, d time.Duration) {
+	b.mu.Lock()
+	b.clock = b.clock.Add(d)
+	b.mu.Unlock()
+
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
+
+// suffix returns a random pod-name style suffix; callers hold b.mu or are
+// still constructing b
+func (b *Backend) suffix(n int) string {
+	const letters = "bcdfghjklmnpqrstvwxz2456789"
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = letters[b.rand.Intn(len(letters))]
+	}
+	return string(out)
+}
+
+// ValueProvenance returns where each of the service's real resolved values
+// comes from, the demo's overrides included
+func (b *Backend) ValueProvenance(runtime *config.RuntimeConfig, serviceName string) ([]config.ValueProvenance, error) {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return nil, fmt.Errorf("service %s not found in configuration", serviceName)
+	}
+
+	b.mu.Lock()
+	overrides := b.overrides[serviceName]
+	b.mu.Unlock()
+
+	var extra []config.ValuesLayer
+	if overrides != nil {
+		extra = append(extra, config.ValuesLayer{Name: orchestrator.ValuesLayerEditor, Values: overrides})
+	}
+	return config.NewValuesManager(runtime.ConfigDir()).GetValueProvenance(service, runtime, extra...)
+}
@@ -0,0 +1,93 @@
+package demo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"plat/pkg/config"
+)
+
+// configYAML is the environment the demo shows
+const configYAML = `apiVersion: plat/v1
+kind: Environment
+name: acme-shop
+defaults:
+  registry: ghcr.io/acme
+  domain: shop.local
+  namespace: default
+  chart: microservice
+repositories:
+  - name: bitnami
+    url: https://charts.bitnami.com/bitnami
+services:
+  - name: frontend
+    version: v3.4.1
+    ports: [3000]
+    openOnUp: true
+    dependencies: [user-api, catalog-api]
+  - name: user-api
+    version: v1.12.0
+    ports: [8080]
+    dependencies: [postgres]
+  - name: catalog-api
+    version: v2.0.3
+    ports: [8080]
+    dependencies: [postgres, redis]
+  - name: payment-api
+    version: v2.1.0
+    ports: [8080, 9229]
+    environment:
+      NODE_ENV: development
+    dependencies: [postgres]
+  - name: postgres
+    protected: true
+    chart:
+      repository: bitnami
+      name: postgresql
+      version: 12.12.10
+  - name: redis
+    chart:
+      repository: bitnami
+      name: redis
+      version: 18.1.0
+`
+
+// Setup writes the demo environment to a temporary directory and loads it
+// in local mode, with user-api running from a (placeholder) local source.
+// The returned cleanup removes the directory.
+func Setup() (*config.RuntimeConfig, func(), error) {
+	dir, err := os.MkdirTemp("", "plat-demo-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create demo directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	source := filepath.Join(dir, "user-service")
+	localYAML := fmt.Sprintf("local_sources:\n  user-api: %q\n", source)
+
+	files := map[string]string{
+		filepath.Join(dir, ".plat", "config.yml"):    configYAML,
+		filepath.Join(dir, ".plat", "local.yml"):     localYAML,
+		filepath.Join(source, "Dockerfile"):          "FROM scratch\n",
+		filepath.Join(source, "chart", "Chart.yaml"): "apiVersion: v2\nname: user-api\nversion: 0.1.0\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+	}
+
+	runtime, err := config.NewLoader(filepath.Join(dir, ".plat", "config.yml"), config.ModeLocal).Load()
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to load demo configuration: %w", err)
+	}
+
+	return runtime, cleanup, nil
+}
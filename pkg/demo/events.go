@@ -0,0 +1,32 @@
+package demo
+
+import (
+	"sort"
+
+	"plat/pkg/tools"
+)
+
+// Events returns the events of every synthetic pod, oldest first
+func (b *Backend) Events() []tools.EventInfo {
+	b.mu.Lock()
+	reasons := make(map[string]string, len(b.services))
+	for name, state := range b.services {
+		reasons[name] = state.reason
+	}
+	b.mu.Unlock()
+
+	services := make([]string, 0, len(reasons))
+	for name := range reasons {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+
+	var events []tools.EventInfo
+	for _, service := range services {
+		for _, pod := range b.Pods(service) {
+			events = append(events, podEvents(service, pod, reasons[service])...)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].LastSeen.Before(events[j].LastSeen) })
+	return events
+}
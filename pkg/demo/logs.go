@@ -0,0 +1,130 @@
+package demo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Synthetic logs in the format of 'kubectl logs --timestamps', prefixed
+// with the pod like 'kubectl logs --prefix' when several services are shown
+
+var (
+	routes   = []string{"/api/users", "/api/users/42", "/api/products", "/api/cart", "/api/orders", "/healthz", "/api/session"}
+	methods  = []string{"GET", "GET", "GET", "POST", "PUT", "DELETE"}
+	statuses = []int{200, 200, 200, 200, 201, 204, 304, 404, 500}
+)
+
+// logGenerator produces plausible log lines for a set of services
+type logGenerator struct {
+	rand     *rand.Rand
+	clock    time.Time
+	services []string
+	pods     map[string][]string
+}
+
+func (b *Backend) newLogGenerator(services []string) *logGenerator {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(services, ",")))
+
+	g := &logGenerator{
+		rand:     rand.New(rand.NewSource(Seed + int64(h.Sum64()&0xffff))),
+		clock:    b.clock,
+		services: services,
+		pods:     make(map[string][]string),
+	}
+	for _, name := range services {
+		if state, ok := b.services[name]; ok {
+			g.pods[name] = append([]string(nil), state.pods...)
+		}
+	}
+	return g
+}
+
+// Logs returns the last tail lines of the services' logs
+func (b *Backend) Logs(services []string, tail int) []string {
+	g := b.newLogGenerator(services)
+	g.clock = g.clock.Add(-time.Duration(tail) * 700 * time.Millisecond)
+
+	lines := make([]string, 0, tail)
+	for i := 0; i < tail; i++ {
+		lines = append(lines, g.next())
+	}
+	return lines
+}
+
+// StreamLogs returns a reader producing new log lines every few hundred
+// milliseconds until it is closed
+func (b *Backend) StreamLogs(services []string) io.ReadCloser {
+	g := b.newLogGenerator(services)
+	reader, writer := io.Pipe()
+
+	go func() {
+		defer writer.Close()
+		for {
+			wait := time.Duration(200+g.rand.Intn(900)) * time.Millisecond
+			time.Sleep(wait)
+			g.clock = g.clock.Add(wait)
+			if _, err := io.WriteString(writer, g.next()+"\n"); err != nil {
+				return
+			}
+		}
+	}()
+
+	return reader
+}
+
+// next returns the next log line
+func (g *logGenerator) next() string {
+	g.clock = g.clock.Add(time.Duration(50+g.rand.Intn(1200)) * time.Millisecond)
+
+	service := g.services[g.rand.Intn(len(g.services))]
+	line := g.clock.Format("2006-01-02T15:04:05.000000000Z") + " " + g.message(service)
+
+	if len(g.services) > 1 {
+		pod := service
+		if pods := g.pods[service]; len(pods) > 0 {
+			pod = pods[g.rand.Intn(len(pods))]
+		}
+		line = fmt.Sprintf("[pod/%s/%s] %s", pod, service, line)
+	}
+	return line
+}
+
+// message returns a log message in the style of the service
+func (g *logGenerator) message(service string) string {
+	switch service {
+	case "postgres":
+		switch g.rand.Intn(4) {
+		case 0:
+			return fmt.Sprintf("LOG:  checkpoint complete: wrote %d buffers (%.1f%%); 0 WAL file(s) added", g.rand.Intn(400), g.rand.Float64()*3)
+		case 1:
+			return "LOG:  checkpoint starting: time"
+		default:
+			return fmt.Sprintf("LOG:  duration: %.3f ms  statement: SELECT * FROM users WHERE id = $1", g.rand.Float64()*20)
+		}
+	case "redis":
+		if g.rand.Intn(3) == 0 {
+			return fmt.Sprintf("1:M %s * 100 changes in 300 seconds. Saving...", g.clock.Format("02 Jan 2006 15:04:05.000"))
+		}
+		return "1:M * Background saving terminated with success"
+	case "frontend":
+		return fmt.Sprintf(`{"level":"info","msg":"rendered page","path":"%s","ms":%d}`, routes[g.rand.Intn(len(routes))], 5+g.rand.Intn(180))
+	}
+
+	status := statuses[g.rand.Intn(len(statuses))]
+	level := "INFO "
+	switch {
+	case status >= 500:
+		level = "ERROR"
+	case status >= 400:
+		level = "WARN "
+	}
+	return fmt.Sprintf("%s %s %s %d %dms", level, methods[g.rand.Intn(len(methods))], routes[g.rand.Intn(len(routes))], status, 1+g.rand.Intn(250))
+}
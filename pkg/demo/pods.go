@@ -0,0 +1,116 @@
+package demo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+// Pods returns a service's synthetic pods, oldest first
+func (b *Backend) Pods(service string) []tools.PodInfo {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.services[service]
+	if !ok || state.status == "not-deployed" {
+		return nil
+	}
+
+	pods := make([]tools.PodInfo, len(state.pods))
+	for i, name := range state.pods {
+		pods[i] = tools.PodInfo{
+			Name:       name,
+			Phase:      "Running",
+			Ready:      i < state.ready,
+			Node:       fmt.Sprintf("k3d-demo-agent-%d", i%2),
+			Containers: []string{service},
+			Created:    time.Now().Add(-b.clock.Sub(state.updated) - time.Duration(i+1)*time.Minute),
+		}
+		if !pods[i].Ready && state.reason == "CrashLoopBackOff" {
+			pods[i].Restarts = 7
+		}
+	}
+	return pods
+}
+
+// DescribePod returns a synthetic pod of a service in depth
+func (b *Backend) DescribePod(service, name string) (*tools.PodDescription, error) {
+	var pod *tools.PodInfo
+	for _, info := range b.Pods(service) {
+		if info.Name == name {
+			pod = &info
+			break
+		}
+	}
+	if pod == nil {
+		return nil, fmt.Errorf("pod %s not found", name)
+	}
+
+	b.mu.Lock()
+	reason := b.services[service].reason
+	b.mu.Unlock()
+
+	container := tools.ContainerInfo{
+		Name:     service,
+		Image:    podImage(service),
+		Ready:    pod.Ready,
+		Restarts: pod.Restarts,
+		State:    "running",
+		Since:    pod.Created.Add(20 * time.Second),
+		Env: []tools.EnvVar{
+			{Source: "configmap " + service + "-config"},
+			{Name: "PORT", Value: "8080"},
+			{Name: "LOG_LEVEL", Value: "info"},
+			{Name: "DATABASE_URL", Source: fmt.Sprintf("secret %s-db/url", service)},
+			{Name: "POD_NAME", Source: "field metadata.name"},
+		},
+	}
+	if !pod.Ready {
+		container.State = "waiting"
+		container.Reason = reason
+		container.Since = time.Time{}
+		if reason == "CrashLoopBackOff" {
+			container.Message = "back-off 40s restarting failed container=" + service
+			container.LastTermination = "Error (exit 1)"
+		}
+	}
+
+	return &tools.PodDescription{
+		Name:       name,
+		Phase:      pod.Phase,
+		Node:       pod.Node,
+		IP:         "10.42." + strings.TrimPrefix(pod.Node, "k3d-demo-agent-") + ".17",
+		Created:    pod.Created,
+		Containers: []tools.ContainerInfo{container},
+		Events:     podEvents(service, *pod, reason),
+	}, nil
+}
+
+// podEvents returns the events of a synthetic pod, oldest first: it was
+// scheduled and started, and backs off restarting when crash looping
+func podEvents(service string, pod tools.PodInfo, reason string) []tools.EventInfo {
+	object := "pod/" + pod.Name
+	image := podImage(service)
+	events := []tools.EventInfo{
+		{Type: "Normal", Object: object, Reason: "Scheduled", Message: "Successfully assigned demo/" + pod.Name + " to " + pod.Node, Count: 1, LastSeen: pod.Created},
+	}
+	if !pod.Ready && reason == "ContainerCreating" {
+		return append(events, tools.EventInfo{Type: "Normal", Object: object, Reason: "Pulling", Message: "Pulling image \"" + image + "\"", Count: 1, LastSeen: pod.Created.Add(2 * time.Second)})
+	}
+	events = append(events,
+		tools.EventInfo{Type: "Normal", Object: object, Reason: "Pulled", Message: "Container image \"" + image + "\" already present on machine", Count: 1, LastSeen: pod.Created.Add(5 * time.Second)},
+		tools.EventInfo{Type: "Normal", Object: object, Reason: "Started", Message: "Started container " + service, Count: 1, LastSeen: pod.Created.Add(20 * time.Second)},
+	)
+	if !pod.Ready && reason == "CrashLoopBackOff" {
+		events = append(events, tools.EventInfo{Type: "Warning", Object: object, Reason: "BackOff",
+			Message: "Back-off restarting failed container " + service, Count: pod.Restarts, LastSeen: time.Now().Add(-20 * time.Second)})
+	}
+	return events
+}
+
+// podImage returns the image of a service's synthetic pods
+func podImage(service string) string {
+	return fmt.Sprintf("registry.example.com/%s:1.4.2", service)
+}
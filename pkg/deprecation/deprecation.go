@@ -0,0 +1,106 @@
+// Package deprecation lists the flags and commands plat still accepts but
+// will remove, and reports their use. Deprecating
+// something means adding it here rather than marking it where it's defined.
+package deprecation
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Kind is what a deprecation applies to
+type Kind string
+
+const (
+	KindFlag    Kind = "flag"    // A command line flag
+	KindCommand Kind = "command" // A command
+)
+
+// IDs of the known deprecations
+const (
+	FlagUpServices = "flag.up.services"
+	CommandStop    = "command.stop"
+)
+
+// Deprecation describes something deprecated and what replaces it
+type Deprecation struct {
+	ID          string `json:"id"`
+	Kind        Kind   `json:"kind"`
+	Command     string `json:"command,omitempty"` // Command a flag belongs to, e.g. "up"
+	Name        string `json:"name"`              // Flag or command name
+	Replacement string `json:"replacement"`       // What to use instead
+}
+
+// known lists every deprecation, oldest first
+var known = []Deprecation{
+	{
+		ID:          CommandStop,
+		Kind:        KindCommand,
+		Name:        "stop",
+		Replacement: "'plat down'",
+	},
+	{
+		ID:          FlagUpServices,
+		Kind:        KindFlag,
+		Command:     "up",
+		Name:        "services",
+		Replacement: "service arguments, e.g. 'plat up api web'",
+	},
+}
+
+// All returns every known deprecation
+func All() []Deprecation {
+	return append([]Deprecation(nil), known...)
+}
+
+// Get returns a deprecation by ID. It panics for unknown IDs, which are
+// programming errors.
+func Get(id string) Deprecation {
+	for _, d := range known {
+		if d.ID == id {
+			return d
+		}
+	}
+	panic(fmt.Sprintf("unknown deprecation %q", id))
+}
+
+// Subject returns how the deprecated thing is written: "--flag of 'plat
+// command'" or "'plat command'"
+func (d Deprecation) Subject() string {
+	if d.Kind == KindFlag {
+		return fmt.Sprintf("--%s of 'plat %s'", d.Name, d.Command)
+	}
+	return fmt.Sprintf("'plat %s'", d.Name)
+}
+
+// String formats the deprecation, e.g. "'plat stop' is deprecated and will
+// be removed in a future release; use 'plat down'". plat has no release
+// versions yet, so no removal version is promised.
+func (d Deprecation) String() string {
+	return fmt.Sprintf("%s is deprecated and will be removed in a future release; use %s", d.Subject(), d.Replacement)
+}
+
+// Reporter writes each deprecation once
+type Reporter struct {
+	mu   sync.Mutex
+	out  io.Writer
+	seen map[string]bool
+}
+
+// NewReporter creates a reporter writing to out
+func NewReporter(out io.Writer) *Reporter {
+	return &Reporter{out: out, seen: make(map[string]bool)}
+}
+
+// Report writes a deprecation unless it was already written
+func (r *Reporter) Report(d Deprecation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.seen[d.ID] {
+		return
+	}
+	r.seen[d.ID] = true
+	fmt.Fprintf(r.out, "⚠️  Deprecated: %s\n", d)
+}
@@ -0,0 +1,278 @@
+// Package forward keeps port-forwards to service pods alive, re-establishing
+// them on a fresh pod whenever the forwarded pod goes away.
+package forward
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+// Forward states
+const (
+	StateConnecting = "connecting"
+	StateActive     = "active"
+	StateRetrying   = "retrying"
+	StateStopped    = "stopped"
+)
+
+// Retry delays after a forward drops; the delay doubles up to the maximum
+const (
+	minRetryDelay = time.Second
+	maxRetryDelay = 30 * time.Second
+)
+
+// Port maps a local port to a port on the service's pods
+type Port struct {
+	Local  int
+	Remote int
+}
+
+func (p Port) String() string {
+	return fmt.Sprintf("%d:%d", p.Local, p.Remote)
+}
+
+// ParsePort parses "8080" or "8080:80" (local:remote)
+func ParsePort(value string) (Port, error) {
+	localText, remoteText, found := strings.Cut(value, ":")
+	if !found {
+		remoteText = localText
+	}
+
+	local, err := strconv.Atoi(localText)
+	if err != nil || local < 1 || local > 65535 {
+		return Port{}, fmt.Errorf("invalid port %q, expected <port> or <local>:<remote>", value)
+	}
+	remote, err := strconv.Atoi(remoteText)
+	if err != nil || remote < 1 || remote > 65535 {
+		return Port{}, fmt.Errorf("invalid port %q, expected <port> or <local>:<remote>", value)
+	}
+	return Port{Local: local, Remote: remote}, nil
+}
+
+// Status describes one service's forward
+type Status struct {
+	Service string
+	Ports   []Port
+	Pod     string // Pod currently forwarded to
+	State   string
+	Error   string // Last error, while retrying
+	Since   time.Time
+}
+
+// Event reports a change in a forward's state
+type Event struct {
+	Service string
+	State   string
+	Pod     string
+	Err     error
+}
+
+// Manager runs port-forwards for services in one namespace
+type Manager struct {
+	kube      tools.KubernetesProvider
+	namespace string
+	onEvent   func(Event)
+
+	mu       sync.Mutex
+	forwards map[string]*forward
+	wg       sync.WaitGroup
+}
+
+type forward struct {
+	status Status
+	cancel context.CancelFunc
+}
+
+// NewManager creates a port-forward manager for a namespace
+func NewManager(namespace string) *Manager {
+	return &Manager{
+		kube:      tools.NewKubernetesProvider(),
+		namespace: namespace,
+		forwards:  make(map[string]*forward),
+	}
+}
+
+// SetEventHandler sets a function called (from the forward goroutines) on
+// every state change
+func (m *Manager) SetEventHandler(fn func(Event)) {
+	m.onEvent = fn
+}
+
+// Start forwards ports to a service's pods until Stop is called or ctx is
+// cancelled. Starting a service that is already forwarded is an error.
+func (m *Manager) Start(ctx context.Context, service string, ports []Port) error {
+	if len(ports) == 0 {
+		return fmt.Errorf("no ports to forward for %s", service)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.forwards[service]; exists {
+		return fmt.Errorf("%s is already forwarded", service)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	fwd := &forward{
+		status: Status{Service: service, Ports: ports, State: StateConnecting, Since: time.Now()},
+		cancel: cancel,
+	}
+	m.forwards[service] = fwd
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.run(ctx, fwd)
+	}()
+
+	return nil
+}
+
+// Stop ends a service's forward
+func (m *Manager) Stop(service string) error {
+	m.mu.Lock()
+	fwd, exists := m.forwards[service]
+	delete(m.forwards, service)
+	m.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("%s is not forwarded", service)
+	}
+	fwd.cancel()
+	return nil
+}
+
+// StopAll ends every forward and waits for them to close
+func (m *Manager) StopAll() {
+	m.mu.Lock()
+	for name, fwd := range m.forwards {
+		fwd.cancel()
+		delete(m.forwards, name)
+	}
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+// List returns the status of every forward, sorted by service
+func (m *Manager) List() []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]Status, 0, len(m.forwards))
+	for _, fwd := range m.forwards {
+		statuses = append(statuses, fwd.status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Service < statuses[j].Service })
+	return statuses
+}
+
+// run keeps a forward up: it picks a ready pod, forwards until the
+// connection drops (the pod was deleted, restarted or rescheduled), then
+// picks again after a backoff
+func (m *Manager) run(ctx context.Context, fwd *forward) {
+	service := fwd.status.Service
+	delay := minRetryDelay
+
+	portSpecs := make([]string, len(fwd.status.Ports))
+	for i, port := range fwd.status.Ports {
+		portSpecs[i] = port.String()
+	}
+
+	for {
+		pod, err := m.readyPod(ctx, service)
+		if err == nil {
+			var connected bool
+			connected, err = m.forwardTo(ctx, fwd, pod, portSpecs)
+			if connected {
+				delay = minRetryDelay
+			}
+		}
+
+		if ctx.Err() != nil {
+			m.update(fwd, StateStopped, "", nil)
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("connection to %s closed", pod)
+		}
+		m.update(fwd, StateRetrying, "", err)
+
+		select {
+		case <-ctx.Done():
+			m.update(fwd, StateStopped, "", nil)
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// forwardTo forwards to one pod until the connection ends, reporting
+// whether it was ever established
+func (m *Manager) forwardTo(ctx context.Context, fwd *forward, pod string, ports []string) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ready := make(chan struct{})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		select {
+		case <-ready:
+			m.update(fwd, StateActive, pod, nil)
+		case <-ctx.Done():
+		}
+	}()
+
+	err := m.kube.PortForward(ctx, m.namespace, pod, ports, ready)
+	cancel()
+	<-watched
+
+	select {
+	case <-ready:
+		return true, err
+	default:
+		return false, err
+	}
+}
+
+// readyPod returns the newest running, ready pod of a service
+func (m *Manager) readyPod(ctx context.Context, service string) (string, error) {
+	pods, err := m.kube.ListPods(ctx, m.namespace, fmt.Sprintf("app.kubernetes.io/instance=%s", service))
+	if err != nil {
+		return "", err
+	}
+
+	// ListPods is oldest first; prefer the newest so a rollout moves the forward along
+	for i := len(pods) - 1; i >= 0; i-- {
+		if pods[i].Phase == "Running" && pods[i].Ready {
+			return pods[i].Name, nil
+		}
+	}
+	return "", fmt.Errorf("no ready pods for %s", service)
+}
+
+// update records a state change and reports it
+func (m *Manager) update(fwd *forward, state, pod string, err error) {
+	m.mu.Lock()
+	fwd.status.State = state
+	fwd.status.Pod = pod
+	fwd.status.Error = ""
+	if err != nil {
+		fwd.status.Error = err.Error()
+	}
+	fwd.status.Since = time.Now()
+	m.mu.Unlock()
+
+	if m.onEvent != nil {
+		m.onEvent(Event{Service: fwd.status.Service, State: state, Pod: pod, Err: err})
+	}
+}
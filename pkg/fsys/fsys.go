@@ -0,0 +1,58 @@
+// Package fsys abstracts the file operations plat's config and state code
+// performs, so they can run against an in-memory tree.
+package fsys
+
+import (
+	"io/fs"
+	"os"
+)
+
+// FS is the subset of file system operations used by the config loader,
+// validator and state store
+type FS interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
+
+	// WriteTemp writes data to a new file with a unique name in dir, made
+	// from pattern as for os.CreateTemp, and returns its path
+	WriteTemp(dir, pattern string, data []byte, perm fs.FileMode) (string, error)
+
+	// Lock takes an exclusive lock named by a file, blocking until other
+	// holders, in this process or another, release it
+	Lock(name string) (unlock func(), err error)
+}
+
+// OS is the real file system
+type OS struct{}
+
+func (OS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+func (OS) ReadFile(name string) ([]byte, error)  { return os.ReadFile(name) }
+func (OS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+func (OS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+func (OS) Rename(oldpath, newpath string) error         { return os.Rename(oldpath, newpath) }
+func (OS) Remove(name string) error                     { return os.Remove(name) }
+
+func (OS) WriteTemp(dir, pattern string, data []byte, perm fs.FileMode) (string, error) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), perm)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,190 @@
+package fsys
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mem is an in-memory FS. Paths are cleaned but not made absolute, so
+// relative and absolute names are distinct files. It is safe for concurrent
+// use.
+type Mem struct {
+	mu    sync.Mutex
+	files map[string]*memFile
+	locks map[string]*sync.Mutex
+	temps int
+	now   func() time.Time
+}
+
+type memFile struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMem creates an empty in-memory file system. Modification times come
+// from now, so a manual clock keeps them deterministic.
+func NewMem(now func() time.Time) *Mem {
+	return &Mem{
+		files: map[string]*memFile{".": {mode: fs.ModeDir | 0755}},
+		locks: make(map[string]*sync.Mutex),
+		now:   now,
+	}
+}
+
+func (m *Mem) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	f, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return memInfo{name: filepath.Base(name), file: f}, nil
+}
+
+func (m *Mem) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	f, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if f.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+func (m *Mem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if parent, ok := m.files[filepath.Dir(name)]; !ok || !parent.mode.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	m.files[name] = &memFile{data: append([]byte(nil), data...), mode: perm, modTime: m.now()}
+	return nil
+}
+
+func (m *Mem) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if f, ok := m.files[dir]; ok {
+			if !f.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: dir, Err: fs.ErrExist}
+			}
+		} else {
+			m.files[dir] = &memFile{mode: fs.ModeDir | perm, modTime: m.now()}
+		}
+		if dir == filepath.Dir(dir) {
+			return nil
+		}
+	}
+}
+
+func (m *Mem) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldpath, newpath = filepath.Clean(oldpath), filepath.Clean(newpath)
+	f, ok := m.files[oldpath]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	if f.mode.IsDir() {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrInvalid} // Only files are renamed
+	}
+	delete(m.files, oldpath)
+	m.files[newpath] = f
+	return nil
+}
+
+func (m *Mem) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if _, ok := m.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	prefix := name + string(filepath.Separator)
+	for path := range m.files {
+		if strings.HasPrefix(path, prefix) {
+			return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+		}
+	}
+	delete(m.files, name)
+	return nil
+}
+
+func (m *Mem) WriteTemp(dir, pattern string, data []byte, perm fs.FileMode) (string, error) {
+	m.mu.Lock()
+	m.temps++
+	name := fmt.Sprint(m.temps)
+	m.mu.Unlock()
+
+	if prefix, suffix, ok := strings.Cut(pattern, "*"); ok {
+		name = prefix + name + suffix
+	} else {
+		name = pattern + name
+	}
+	path := filepath.Join(dir, name)
+	if err := m.WriteFile(path, data, perm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Lock locks name against other holders within the process; there are no
+// other processes sharing a Mem
+func (m *Mem) Lock(name string) (func(), error) {
+	m.mu.Lock()
+	name = filepath.Clean(name)
+	lock, ok := m.locks[name]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[name] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock, nil
+}
+
+// Paths lists every file and directory, sorted
+func (m *Mem) Paths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	paths := make([]string, 0, len(m.files))
+	for path := range m.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// memInfo implements fs.FileInfo for a Mem entry
+type memInfo struct {
+	name string
+	file *memFile
+}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return int64(len(i.file.data)) }
+func (i memInfo) Mode() fs.FileMode  { return i.file.mode }
+func (i memInfo) ModTime() time.Time { return i.file.modTime }
+func (i memInfo) IsDir() bool        { return i.file.mode.IsDir() }
+func (i memInfo) Sys() any           { return nil }
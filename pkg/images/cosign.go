@@ -0,0 +1,63 @@
+package images
+
+import (
+	"context"
+	"fmt"
+
+	"plat/pkg/tools"
+)
+
+// CosignVerifier verifies image signatures with the cosign CLI
+type CosignVerifier struct {
+	executor tools.ProcessExecutor
+	key      string // Public key path; keyless verification when empty
+	identity string // Certificate identity keyless verification accepts
+	issuer   string // OIDC issuer of that identity
+}
+
+// NewCosignVerifier creates a signature verification hook. Without a key,
+// signatures are verified keyless and must come from the certificate
+// identity and OIDC issuer given.
+func NewCosignVerifier(key, identity, issuer string) *CosignVerifier {
+	return &CosignVerifier{
+		executor: tools.NewProcessExecutor(),
+		key:      key,
+		identity: identity,
+		issuer:   issuer,
+	}
+}
+
+// Name returns the hook name
+func (c *CosignVerifier) Name() string {
+	return "verify-signature"
+}
+
+// Process verifies the signature of the (digest-pinned) image
+func (c *CosignVerifier) Process(ctx context.Context, image *ImageRef) error {
+	if image.Digest == "" {
+		return fmt.Errorf("signature verification requires a resolved digest")
+	}
+
+	if err := tools.ValidateCommand("cosign"); err != nil {
+		return err
+	}
+
+	args := []string{"verify"}
+	switch {
+	case c.key != "":
+		args = append(args, "--key", c.key)
+	case c.identity != "" && c.issuer != "":
+		args = append(args, "--certificate-identity", c.identity, "--certificate-oidc-issuer", c.issuer)
+	default:
+		// cosign refuses keyless verification that would accept any signer
+		return fmt.Errorf("keyless signature verification needs imagePolicy.certificateIdentity and imagePolicy.certificateOidcIssuer, or set imagePolicy.cosignKey")
+	}
+	args = append(args, image.Reference())
+
+	result, err := c.executor.Execute(ctx, tools.Command{Name: "cosign", Args: args})
+	if err != nil {
+		return fmt.Errorf("signature verification failed: %s", result.Stderr)
+	}
+
+	return nil
+}
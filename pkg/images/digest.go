@@ -0,0 +1,152 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// manifestMediaTypes are the manifest formats accepted when resolving digests
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// DigestResolver translates image tags into content digests by querying the
+// registry's v2 API. Anonymous bearer token challenges are supported.
+type DigestResolver struct {
+	client *http.Client
+}
+
+// NewDigestResolver creates a registry digest resolver
+func NewDigestResolver() *DigestResolver {
+	return &DigestResolver{
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Name returns the hook name
+func (d *DigestResolver) Name() string {
+	return "resolve-digest"
+}
+
+// Process resolves image.Tag to a digest
+func (d *DigestResolver) Process(ctx context.Context, image *ImageRef) error {
+	if image.Digest != "" {
+		return nil
+	}
+
+	registry, path := splitRepository(image.Repository)
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, path, image.Tag)
+
+	resp, err := d.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := d.fetchToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return fmt.Errorf("registry authentication failed: %w", err)
+		}
+		resp, err = d.headManifest(ctx, manifestURL, token)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry returned %s for %s:%s", resp.Status, image.Repository, image.Tag)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return fmt.Errorf("registry did not return a digest for %s:%s", image.Repository, image.Tag)
+	}
+
+	image.Digest = digest
+	return nil
+}
+
+// headManifest issues a HEAD request for a manifest
+func (d *DigestResolver) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registry: %w", err)
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+// fetchToken obtains an anonymous token from a Bearer challenge such as:
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"
+func (d *DigestResolver) fetchToken(ctx context.Context, challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", fmt.Errorf("unsupported auth challenge %q", challenge)
+	}
+
+	params := make(map[string]string)
+	for _, part := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("auth challenge has no realm")
+	}
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	if params["scope"] != "" {
+		query.Set("scope", params["scope"])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
@@ -0,0 +1,94 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"plat/pkg/config"
+)
+
+// ImageRef identifies a service image as it flows through the hook chain
+type ImageRef struct {
+	Service    string
+	Repository string // e.g. msc-registry.minitab.com/payment-api
+	Tag        string // e.g. latest, v2.1.0
+	Digest     string // sha256:... once resolved
+}
+
+// Reference returns the most specific pullable reference for the image
+func (r *ImageRef) Reference() string {
+	if r.Digest != "" {
+		return fmt.Sprintf("%s@%s", r.Repository, r.Digest)
+	}
+	return fmt.Sprintf("%s:%s", r.Repository, r.Tag)
+}
+
+// Hook pre-processes an image before its service is deployed. Hooks may
+// fill in fields (such as Digest) or return an error to block the deploy.
+type Hook interface {
+	Name() string
+	Process(ctx context.Context, image *ImageRef) error
+}
+
+// Chain runs hooks in registration order
+type Chain struct {
+	hooks []Hook
+}
+
+// NewChain creates a hook chain
+func NewChain(hooks ...Hook) *Chain {
+	return &Chain{hooks: hooks}
+}
+
+// NewChainFromPolicy builds the built-in hooks enabled by the image policy
+func NewChainFromPolicy(policy *config.ImagePolicy) *Chain {
+	chain := NewChain()
+	if policy == nil {
+		return chain
+	}
+
+	if policy.ResolveDigests || policy.VerifySignatures {
+		// Signatures are verified against a digest, so resolution always runs first
+		chain.Register(NewDigestResolver())
+	}
+	if policy.VerifySignatures {
+		chain.Register(NewCosignVerifier(policy.CosignKey, policy.CertificateIdentity, policy.CertificateOIDCIssuer))
+	}
+
+	return chain
+}
+
+// Register appends a hook to the chain
+func (c *Chain) Register(hook Hook) {
+	c.hooks = append(c.hooks, hook)
+}
+
+// Empty reports whether the chain has no hooks
+func (c *Chain) Empty() bool {
+	return len(c.hooks) == 0
+}
+
+// Process runs every hook against the image, stopping at the first error
+func (c *Chain) Process(ctx context.Context, image *ImageRef) error {
+	for _, hook := range c.hooks {
+		if err := hook.Process(ctx, image); err != nil {
+			return fmt.Errorf("image hook %s failed for %s: %w", hook.Name(), image.Reference(), err)
+		}
+	}
+	return nil
+}
+
+// splitRepository splits "registry.example.com/org/name" into registry host and path
+func splitRepository(repository string) (string, string) {
+	parts := strings.SplitN(repository, "/", 2)
+	if len(parts) == 1 || (!strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost") {
+		// Docker Hub shorthand (e.g. "nginx" or "bitnami/redis")
+		path := repository
+		if !strings.Contains(path, "/") {
+			path = "library/" + path
+		}
+		return "registry-1.docker.io", path
+	}
+	return parts[0], parts[1]
+}
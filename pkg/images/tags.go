@@ -0,0 +1,131 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ListTags returns the tags of an image repository from the registry's v2
+// API, using the same anonymous token flow as digest resolution
+func (d *DigestResolver) ListTags(ctx context.Context, repository string) ([]string, error) {
+	registry, path := splitRepository(repository)
+	tagsURL := fmt.Sprintf("https://%s/v2/%s/tags/list", registry, path)
+
+	resp, err := d.getJSON(ctx, tagsURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		token, err := d.fetchToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return nil, fmt.Errorf("registry authentication failed: %w", err)
+		}
+		resp, err = d.getJSON(ctx, tagsURL, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s listing tags of %s", resp.Status, repository)
+	}
+
+	var body struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse tag list: %w", err)
+	}
+	return body.Tags, nil
+}
+
+// getJSON issues a GET request; the caller closes the body
+func (d *DigestResolver) getJSON(ctx context.Context, url, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registry: %w", err)
+	}
+	return resp, nil
+}
+
+// NewerVersion returns the highest semantic version tag greater than
+// current. Pre-release tags and non-version tags such as "latest" are
+// ignored; ok is false if current is not a version or nothing is newer.
+func NewerVersion(current string, tags []string) (newest string, ok bool) {
+	base, valid := parseVersion(current)
+	if !valid {
+		return "", false
+	}
+
+	best := base
+	for _, tag := range tags {
+		version, valid := parseVersion(tag)
+		if valid && compareVersions(version, best) > 0 {
+			best = version
+			newest = tag
+		}
+	}
+	return newest, newest != ""
+}
+
+// CompareVersions compares two version tags, returning -1, 0 or 1. ok is
+// false if either is not a release version like "v1.2.3".
+func CompareVersions(a, b string) (result int, ok bool) {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return compareVersions(va, vb), true
+}
+
+// parseVersion parses "v1.2.3", "1.2" or "1" into major, minor and patch
+func parseVersion(tag string) ([3]int, bool) {
+	var version [3]int
+
+	tag = strings.TrimPrefix(tag, "v")
+	if tag == "" || strings.ContainsAny(tag, "-+") {
+		return version, false
+	}
+
+	parts := strings.Split(tag, ".")
+	if len(parts) > 3 {
+		return version, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version, false
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+// Package logbuf keeps the most recent lines of long-running log streams in
+// bounded memory.
+package logbuf
+
+import "strings"
+
+// Ring is a ring buffer of log lines that drops the oldest lines once full
+// and counts how many it dropped
+type Ring struct {
+	lines   []string
+	start   int // Index of the oldest line once the ring is full
+	limit   int
+	dropped int
+}
+
+// New creates a ring holding at most limit lines
+func New(limit int) *Ring {
+	if limit < 1 {
+		limit = 1
+	}
+	return &Ring{limit: limit}
+}
+
+// Add appends lines, dropping the oldest once the ring is full
+func (r *Ring) Add(lines ...string) {
+	for _, line := range lines {
+		if len(r.lines) < r.limit {
+			r.lines = append(r.lines, line)
+			continue
+		}
+		r.lines[r.start] = line
+		r.start = (r.start + 1) % r.limit
+		r.dropped++
+	}
+}
+
+// Len returns the number of lines held
+func (r *Ring) Len() int {
+	return len(r.lines)
+}
+
+// Dropped returns how many lines were dropped to make room since the last Reset
+func (r *Ring) Dropped() int {
+	return r.dropped
+}
+
+// Each calls fn for every line, oldest first
+func (r *Ring) Each(fn func(string)) {
+	for _, line := range r.lines[r.start:] {
+		fn(line)
+	}
+	for _, line := range r.lines[:r.start] {
+		fn(line)
+	}
+}
+
+// Join returns the lines, oldest first, separated by sep
+func (r *Ring) Join(sep string) string {
+	var b strings.Builder
+	first := true
+	r.Each(func(line string) {
+		if !first {
+			b.WriteString(sep)
+		}
+		b.WriteString(line)
+		first = false
+	})
+	return b.String()
+}
+
+// Reset empties the ring, keeping its storage
+func (r *Ring) Reset() {
+	r.lines = r.lines[:0]
+	r.start = 0
+	r.dropped = 0
+}
@@ -0,0 +1,205 @@
+// Package logging builds the structured logger shared by the CLI, the
+// orchestrator, tools and config, and the in-memory sink the TUI reads it
+// through
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options configure the logger built by New
+type Options struct {
+	Level  string // debug, info, warn or error
+	Format string // FormatText or FormatJSON
+	File   string // Written instead of stderr when set
+}
+
+// ParseLevel parses a level name
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q, must be 'debug', 'info', 'warn' or 'error'", name)
+	}
+}
+
+// New builds a logger from options. The returned function closes the log
+// file, if any.
+func New(opts Options) (*slog.Logger, func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out io.Writer = os.Stderr
+	closeFn := func() error { return nil }
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		out = f
+		closeFn = f.Close
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	switch opts.Format {
+	case FormatText, "":
+		// A file has no reader watching it live, so it gets timestamps
+		if opts.File != "" {
+			return slog.New(slog.NewTextHandler(out, handlerOpts)), closeFn, nil
+		}
+		return slog.New(NewConsoleHandler(out, level)), closeFn, nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(out, handlerOpts)), closeFn, nil
+	default:
+		closeFn()
+		return nil, nil, fmt.Errorf("invalid log format %q, must be '%s' or '%s'", opts.Format, FormatText, FormatJSON)
+	}
+}
+
+var (
+	defaultMu     sync.Mutex
+	defaultLogger = Discard()
+)
+
+// SetDefault sets the logger components created afterwards start with
+func SetDefault(logger *slog.Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLogger = logger
+}
+
+// Default returns the logger set with SetDefault, which discards everything
+// until one is set
+func Default() *slog.Logger {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	return defaultLogger
+}
+
+// Discard returns a logger that drops every record
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// ConsoleHandler writes records for people at a terminal: the message and
+// its attributes on one line, warnings and errors marked, no timestamps
+type ConsoleHandler struct {
+	mu    *sync.Mutex
+	out   io.Writer
+	level slog.Leveler
+	attrs []slog.Attr
+	group string
+}
+
+// NewConsoleHandler creates a console handler writing records at or above
+// level to out
+func NewConsoleHandler(out io.Writer, level slog.Leveler) *ConsoleHandler {
+	return &ConsoleHandler{mu: &sync.Mutex{}, out: out, level: level}
+}
+
+// Enabled reports whether records of the level are written
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes a record
+func (h *ConsoleHandler) Handle(_ context.Context, record slog.Record) error {
+	line := FormatRecord(record, h.attrs, h.group)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := fmt.Fprintln(h.out, line)
+	return err
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), qualify(h.group, attrs)...)
+	return &clone
+}
+
+// WithGroup returns a handler qualifying later attributes with a group
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.group = joinGroup(h.group, name)
+	return &clone
+}
+
+// FormatRecord renders a record as one console line: a level marker for
+// anything but info, the message, then key=value attributes
+func FormatRecord(record slog.Record, attrs []slog.Attr, group string) string {
+	var b strings.Builder
+
+	switch {
+	case record.Level >= slog.LevelError:
+		b.WriteString("❌ ")
+	case record.Level >= slog.LevelWarn:
+		b.WriteString("⚠️  ")
+	case record.Level < slog.LevelInfo:
+		b.WriteString("· ")
+	}
+	b.WriteString(record.Message)
+
+	write := func(attr slog.Attr) {
+		if attr.Equal(slog.Attr{}) {
+			return
+		}
+		value := attr.Value.Resolve().String()
+		if strings.ContainsAny(value, " \t\"") {
+			value = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(&b, " %s=%s", attr.Key, value)
+	}
+	for _, attr := range attrs {
+		write(attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		for _, qualified := range qualify(group, []slog.Attr{attr}) {
+			write(qualified)
+		}
+		return true
+	})
+
+	return b.String()
+}
+
+// qualify prefixes attribute keys with a group
+func qualify(group string, attrs []slog.Attr) []slog.Attr {
+	if group == "" {
+		return attrs
+	}
+	qualified := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		qualified[i] = slog.Attr{Key: group + "." + attr.Key, Value: attr.Value}
+	}
+	return qualified
+}
+
+func joinGroup(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
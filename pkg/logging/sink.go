@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Entry is a record kept by a Sink
+type Entry struct {
+	Time  time.Time
+	Level slog.Level
+	Line  string // Formatted like the console handler
+}
+
+// Sink is a handler keeping the most recent records in memory, for
+// programs that own the terminal, like the TUI
+type Sink struct {
+	state *sinkState
+	level slog.Leveler
+	attrs []slog.Attr
+	group string
+}
+
+// sinkState is shared by a sink and the handlers derived from it
+type sinkState struct {
+	mu       sync.Mutex
+	entries  []Entry
+	capacity int
+}
+
+// NewSink creates a sink keeping up to capacity records at or above level
+func NewSink(capacity int, level slog.Leveler) *Sink {
+	return &Sink{state: &sinkState{capacity: capacity}, level: level}
+}
+
+// Entries returns the kept records, oldest first
+func (s *Sink) Entries() []Entry {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	return append([]Entry(nil), s.state.entries...)
+}
+
+// Enabled reports whether records of the level are kept
+func (s *Sink) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= s.level.Level()
+}
+
+// Handle keeps a record, dropping the oldest when full
+func (s *Sink) Handle(_ context.Context, record slog.Record) error {
+	entry := Entry{
+		Time:  record.Time,
+		Level: record.Level,
+		Line:  FormatRecord(record, s.attrs, s.group),
+	}
+
+	st := s.state
+	st.mu.Lock()
+	st.entries = append(st.entries, entry)
+	if len(st.entries) > st.capacity {
+		st.entries = st.entries[len(st.entries)-st.capacity:]
+	}
+	st.mu.Unlock()
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (s *Sink) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *s
+	clone.attrs = append(append([]slog.Attr{}, s.attrs...), qualify(s.group, attrs)...)
+	return &clone
+}
+
+// WithGroup returns a handler qualifying later attributes with a group
+func (s *Sink) WithGroup(name string) slog.Handler {
+	clone := *s
+	clone.group = joinGroup(s.group, name)
+	return &clone
+}
@@ -0,0 +1,203 @@
+// Package logmux merges the log streams of several services into one,
+// interleaved by the timestamps the API server adds to each line.
+package logmux
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// readAhead is how many lines the streams may buffer before reading stops,
+// pushing back until batches are taken
+const readAhead = 4000
+
+// Line is a log line of one of the merged streams
+type Line struct {
+	Source string    // Service the line came from
+	Time   time.Time // Timestamp of the line; zero when it has none
+	Text   string    // The line as read, timestamp included
+}
+
+// NewLine splits the timestamp off a raw line of source
+func NewLine(source, text string) Line {
+	line := Line{Source: source, Text: text}
+	field, _, _ := strings.Cut(text, " ")
+	if t, err := time.Parse(time.RFC3339Nano, field); err == nil {
+		line.Time = t
+	}
+	return line
+}
+
+// Sort orders lines by time. Lines without a timestamp stay behind the line
+// before them from the same source, and lines with equal times keep their
+// order.
+func Sort(lines []Line) {
+	last := make(map[string]time.Time)
+	keys := make([]time.Time, len(lines))
+	for i, line := range lines {
+		if !line.Time.IsZero() {
+			last[line.Source] = line.Time
+		}
+		keys[i] = last[line.Source]
+	}
+
+	order := make([]int, len(lines))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]].Before(keys[order[j]])
+	})
+
+	sorted := make([]Line, len(lines))
+	for i, index := range order {
+		sorted[i] = lines[index]
+	}
+	copy(lines, sorted)
+}
+
+// Mux reads several log streams concurrently and delivers their lines in
+// batches, each ordered by time
+type Mux struct {
+	window  time.Duration
+	lines   chan Line
+	batches chan []Line
+	done    chan struct{}
+	streams map[string]io.ReadCloser
+
+	mu        sync.Mutex
+	err       error // First failure of a stream other than its end
+	closeOnce sync.Once
+}
+
+// Merge starts reading the streams, keyed by source. Lines arriving within
+// window of the first line of a batch are sorted together, so lines of
+// different sources written at the same time come out in order.
+func Merge(streams map[string]io.ReadCloser, window time.Duration) *Mux {
+	m := &Mux{
+		window:  window,
+		lines:   make(chan Line, readAhead),
+		batches: make(chan []Line),
+		done:    make(chan struct{}),
+		streams: streams,
+	}
+
+	var readers sync.WaitGroup
+	for source, stream := range streams {
+		readers.Add(1)
+		go func(source string, stream io.Reader) {
+			defer readers.Done()
+			m.read(source, stream)
+		}(source, stream)
+	}
+	go func() {
+		readers.Wait()
+		close(m.lines)
+	}()
+	go m.batch()
+
+	return m
+}
+
+// Batches returns the channel delivering sorted batches of lines. It is
+// closed once every stream ended or the mux is closed.
+func (m *Mux) Batches() <-chan []Line {
+	return m.batches
+}
+
+// Err returns why a stream failed, if one did; streams ending are not
+// failures
+func (m *Mux) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+// Close stops reading and closes every stream
+func (m *Mux) Close() error {
+	var errs []error
+	m.closeOnce.Do(func() {
+		close(m.done)
+		for _, stream := range m.streams {
+			if err := stream.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// read sends a stream's lines until it ends or the mux is closed
+func (m *Mux) read(source string, stream io.Reader) {
+	reader := bufio.NewReader(stream)
+	for {
+		text, err := reader.ReadString('\n')
+		if text != "" {
+			select {
+			case m.lines <- NewLine(source, strings.TrimRight(text, "\r\n")):
+			case <-m.done:
+				return
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				m.fail(fmt.Errorf("%s logs: %w", source, err))
+			}
+			return
+		}
+	}
+}
+
+func (m *Mux) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	select {
+	case <-m.done:
+		// Closing the streams fails their reads; that's no failure
+	default:
+		if m.err == nil {
+			m.err = err
+		}
+	}
+}
+
+// batch collects lines for window after the first of each batch and
+// delivers them sorted
+func (m *Mux) batch() {
+	defer close(m.batches)
+	for {
+		line, ok := <-m.lines
+		if !ok {
+			return
+		}
+
+		batch := []Line{line}
+		timer := time.NewTimer(m.window)
+	collect:
+		for {
+			select {
+			case line, ok := <-m.lines:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, line)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		Sort(batch)
+		select {
+		case m.batches <- batch:
+		case <-m.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package netconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// defaultHostsFile returns the system hosts file
+func defaultHostsFile() string {
+	if runtime.GOOS == "windows" {
+		return os.ExpandEnv(`${SystemRoot}\System32\drivers\etc\hosts`)
+	}
+	return "/etc/hosts"
+}
+
+// blockMarkers returns the comment lines around an environment's entries
+func blockMarkers(environment string) (begin, end string) {
+	return "# BEGIN plat " + environment, "# END plat " + environment
+}
+
+// writeHostsBlock replaces the environment's block of hosts file entries,
+// one host per line since some systems limit the names on a line
+func (m *Manager) writeHostsBlock(ctx context.Context, environment string, hosts []string) error {
+	content, err := os.ReadFile(m.hostsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", m.hostsFile, err)
+	}
+
+	begin, end := blockMarkers(environment)
+	lines := []string{begin}
+	for _, host := range hosts {
+		lines = append(lines, fmt.Sprintf("%s %s", Loopback, host))
+	}
+	lines = append(lines, end)
+
+	updated := strings.TrimRight(stripBlock(string(content), environment), "\n")
+	if updated != "" {
+		updated += "\n\n"
+	}
+	updated += strings.Join(lines, "\n") + "\n"
+
+	return m.writeFile(ctx, m.hostsFile, updated)
+}
+
+// removeHostsBlock removes the environment's block from the hosts file,
+// reporting whether there was one
+func (m *Manager) removeHostsBlock(ctx context.Context, environment string) (bool, error) {
+	content, err := os.ReadFile(m.hostsFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", m.hostsFile, err)
+	}
+
+	updated := stripBlock(string(content), environment)
+	if updated == string(content) {
+		return false, nil
+	}
+	return true, m.writeFile(ctx, m.hostsFile, strings.TrimRight(updated, "\n")+"\n")
+}
+
+// stripBlock removes the environment's block, and the blank line before it,
+// from hosts file content
+func stripBlock(content, environment string) string {
+	begin, end := blockMarkers(environment)
+
+	var kept []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		switch {
+		case strings.TrimSpace(line) == begin:
+			inBlock = true
+			if n := len(kept); n > 0 && strings.TrimSpace(kept[n-1]) == "" {
+				kept = kept[:n-1]
+			}
+		case strings.TrimSpace(line) == end && inBlock:
+			inBlock = false
+		case !inBlock:
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,188 @@
+// Package netconfig makes an environment's ingress hosts, such as
+// frontend.platform.local, resolve to the local cluster
+package netconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// Loopback is the address ingress hosts resolve to; the cluster's load
+// balancer listens on it
+const Loopback = "127.0.0.1"
+
+const (
+	// lookupTimeout bounds resolving one host name when checking resolution
+	lookupTimeout = 2 * time.Second
+
+	// sudoTimeout leaves time to type a password at sudo's prompt
+	sudoTimeout = 2 * time.Minute
+)
+
+// Method is a way of making ingress hosts resolve
+type Method string
+
+const (
+	// MethodHosts lists each service's host in the hosts file
+	MethodHosts Method = "hosts"
+	// MethodResolver answers for the whole domain with a dnsmasq stub
+	MethodResolver Method = "resolver"
+)
+
+// ParseMethod validates a DNS setup method name
+func ParseMethod(name string) (Method, error) {
+	switch Method(name) {
+	case MethodHosts, MethodResolver:
+		return Method(name), nil
+	}
+	return "", fmt.Errorf("unknown DNS method %q, must be 'hosts' or 'resolver'", name)
+}
+
+// Hosts returns the ingress host names of an environment's services. They
+// are empty when the environment has no domain, and services are reached on
+// localhost ports.
+func Hosts(runtime *config.RuntimeConfig) []string {
+	domain := runtime.Base.Defaults.Domain
+	if domain == "" {
+		return nil
+	}
+
+	var hosts []string
+	for _, service := range runtime.OrderedServices() {
+		hosts = append(hosts, service.Name+"."+domain)
+	}
+	return hosts
+}
+
+// Manager writes and removes the DNS configuration of an environment. Files
+// outside the user's reach are written with sudo, which prompts for a
+// password on the terminal.
+type Manager struct {
+	executor  tools.ProcessExecutor
+	hostsFile string
+}
+
+// NewManager creates a DNS configuration manager for this machine
+func NewManager() *Manager {
+	return &Manager{
+		executor:  tools.NewProcessExecutor(),
+		hostsFile: defaultHostsFile(),
+	}
+}
+
+// Setup makes an environment's hosts resolve to the cluster with the given
+// method, replacing an earlier setup of the same environment. It returns the
+// files it wrote.
+func (m *Manager) Setup(ctx context.Context, method Method, environment, domain string, hosts []string) ([]string, error) {
+	switch method {
+	case MethodHosts:
+		if err := m.writeHostsBlock(ctx, environment, hosts); err != nil {
+			return nil, err
+		}
+		return []string{m.hostsFile}, nil
+	case MethodResolver:
+		return m.writeResolver(ctx, domain)
+	}
+	return nil, fmt.Errorf("unknown DNS method %q", method)
+}
+
+// Teardown removes whatever Setup wrote for an environment, with either
+// method. It returns the files it changed or removed.
+func (m *Manager) Teardown(ctx context.Context, environment, domain string) ([]string, error) {
+	var changed []string
+
+	removed, err := m.removeHostsBlock(ctx, environment)
+	if err != nil {
+		return changed, err
+	}
+	if removed {
+		changed = append(changed, m.hostsFile)
+	}
+
+	files, err := m.removeResolver(ctx, domain)
+	return append(changed, files...), err
+}
+
+// Resolution is whether a host name resolves to the local cluster
+type Resolution struct {
+	Host      string
+	Addresses []string
+	Err       error
+}
+
+// OK reports whether the host resolves to a loopback address
+func (r Resolution) OK() bool {
+	for _, address := range r.Addresses {
+		if ip := net.ParseIP(address); ip != nil && ip.IsLoopback() {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckResolution resolves each host with the system resolver
+func CheckResolution(ctx context.Context, hosts []string) []Resolution {
+	results := make([]Resolution, 0, len(hosts))
+	for _, host := range hosts {
+		lookupCtx, cancel := context.WithTimeout(ctx, lookupTimeout)
+		addresses, err := net.DefaultResolver.LookupHost(lookupCtx, host)
+		cancel()
+		results = append(results, Resolution{Host: host, Addresses: addresses, Err: err})
+	}
+	return results
+}
+
+// writeFile writes a file, through sudo when the user can't
+func (m *Manager) writeFile(ctx context.Context, path, content string) error {
+	err := os.WriteFile(path, []byte(content), 0644)
+	if err == nil || !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+
+	if _, err := m.sudo(ctx, "mkdir", "-p", filepath.Dir(path)); err != nil {
+		return err
+	}
+	_, err = m.executor.Execute(ctx, tools.Command{
+		Name:    "sudo",
+		Args:    []string{"tee", path},
+		Stdin:   strings.NewReader(content),
+		Timeout: sudoTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s with sudo: %w", path, err)
+	}
+	return nil
+}
+
+// removeFile removes a file, through sudo when the user can't. A missing
+// file is not an error.
+func (m *Manager) removeFile(ctx context.Context, path string) error {
+	err := os.Remove(path)
+	if err == nil || errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+	_, err = m.sudo(ctx, "rm", "-f", path)
+	return err
+}
+
+// sudo runs a command as root; sudo asks for a password on the terminal
+func (m *Manager) sudo(ctx context.Context, args ...string) (*tools.ExecuteResult, error) {
+	result, err := m.executor.Execute(ctx, tools.Command{Name: "sudo", Args: args, Timeout: sudoTimeout})
+	if err != nil {
+		return result, fmt.Errorf("sudo %s failed: %w", strings.Join(args, " "), err)
+	}
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package netconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// dnsmasqDirs are where dnsmasq reads extra configuration, on Linux and
+// with Homebrew on Apple silicon and Intel Macs
+var dnsmasqDirs = []string{
+	"/etc/dnsmasq.d",
+	"/opt/homebrew/etc/dnsmasq.d",
+	"/usr/local/etc/dnsmasq.d",
+}
+
+// resolvedDir holds systemd-resolved drop-ins, which route a domain's
+// queries to dnsmasq on Linux
+const resolvedDir = "/etc/systemd/resolved.conf.d"
+
+// resolverFile is the configuration written under a directory for a domain
+func resolverFile(dir, domain string) string {
+	return filepath.Join(dir, "plat-"+domain+".conf")
+}
+
+// writeResolver configures dnsmasq to answer for every host of the domain
+// with the loopback address, and routes the domain's queries to it: with
+// /etc/resolver on macOS, or a systemd-resolved drop-in on Linux
+func (m *Manager) writeResolver(ctx context.Context, domain string) ([]string, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("the resolver method isn't supported on Windows; use the hosts method")
+	}
+
+	dir, ok := dnsmasqDir()
+	if !ok {
+		return nil, fmt.Errorf("no dnsmasq configuration directory found (%v); install dnsmasq or use the hosts method", dnsmasqDirs)
+	}
+
+	var written []string
+	path := resolverFile(dir, domain)
+	content := fmt.Sprintf("# Written by plat dns setup\naddress=/%s/%s\n", domain, Loopback)
+	if err := m.writeFile(ctx, path, content); err != nil {
+		return written, err
+	}
+	written = append(written, path)
+
+	switch {
+	case runtime.GOOS == "darwin":
+		path = filepath.Join("/etc/resolver", domain)
+		content = fmt.Sprintf("# Written by plat dns setup\nnameserver %s\n", Loopback)
+	case isDir("/run/systemd/resolve"):
+		path = resolverFile(resolvedDir, domain)
+		content = fmt.Sprintf("# Written by plat dns setup\n[Resolve]\nDNS=%s\nDomains=~%s\n", Loopback, domain)
+	default:
+		// dnsmasq is expected to be the system resolver already
+		return written, nil
+	}
+	if err := m.writeFile(ctx, path, content); err != nil {
+		return written, err
+	}
+	return append(written, path), nil
+}
+
+// removeResolver removes the files writeResolver writes, returning those
+// that existed
+func (m *Manager) removeResolver(ctx context.Context, domain string) ([]string, error) {
+	var paths []string
+	for _, dir := range dnsmasqDirs {
+		paths = append(paths, resolverFile(dir, domain))
+	}
+	paths = append(paths, filepath.Join("/etc/resolver", domain), resolverFile(resolvedDir, domain))
+
+	var removed []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := m.removeFile(ctx, path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// ReloadHint returns the commands that make the system pick up a resolver
+// setup, which plat leaves to the user since services are managed differently
+// on every system
+func ReloadHint() string {
+	switch {
+	case runtime.GOOS == "darwin":
+		return "sudo brew services restart dnsmasq"
+	case isDir("/run/systemd/resolve"):
+		return "sudo systemctl restart dnsmasq systemd-resolved"
+	default:
+		return "sudo systemctl restart dnsmasq"
+	}
+}
+
+// dnsmasqDir returns the first dnsmasq configuration directory present
+func dnsmasqDir() (string, bool) {
+	for _, dir := range dnsmasqDirs {
+		if isDir(dir) {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
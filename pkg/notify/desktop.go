@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+// desktopTimeout bounds how long a desktop notification may take to post
+const desktopTimeout = 5 * time.Second
+
+// Desktop shows a native OS notification. It uses osascript on macOS,
+// notify-send on Linux and a PowerShell toast on Windows.
+func Desktop(ctx context.Context, title, message string) error {
+	var cmd tools.Command
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		cmd = tools.Command{Name: "osascript", Args: []string{"-e", script}}
+	case "linux":
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return fmt.Errorf("notify-send not found; install libnotify to enable desktop notifications")
+		}
+		cmd = tools.Command{Name: "notify-send", Args: []string{"--app-name=plat", title, message}}
+	case "windows":
+		cmd = tools.Command{Name: "powershell", Args: []string{"-NoProfile", "-NonInteractive", "-Command", windowsToastScript(title, message)}}
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	cmd.Timeout = desktopTimeout
+
+	result, err := tools.NewProcessExecutor().Execute(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to show notification: %s", strings.TrimSpace(result.Stderr))
+	}
+	return nil
+}
+
+// Bell rings the terminal bell
+func Bell(w io.Writer) {
+	fmt.Fprint(w, "\a")
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// windowsToastScript builds a PowerShell script that posts a toast through
+// the WinRT notification API, which needs no extra modules
+func windowsToastScript(title, message string) string {
+	escape := func(s string) string {
+		s = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+		return strings.ReplaceAll(s, "'", "''")
+	}
+
+	return fmt.Sprintf(`[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+$xml.LoadXml('<toast><visual><binding template="ToastGeneric"><text>%s</text><text>%s</text></binding></visual></toast>')
+$toast = New-Object Windows.UI.Notifications.ToastNotification $xml
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('plat').Show($toast)`, escape(title), escape(message))
+}
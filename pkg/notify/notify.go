@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"plat/pkg/config"
+)
+
+// EventType identifies an environment event that can trigger notifications
+type EventType string
+
+const (
+	EventUpFinished     EventType = "up.finished"
+	EventServiceCrashed EventType = "service.crashed"
+)
+
+// Event describes something that happened to an environment
+type Event struct {
+	Type        EventType `json:"event"`
+	Environment string    `json:"environment"`
+	Service     string    `json:"service,omitempty"`
+	Message     string    `json:"message"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Notifier delivers events to the configured notification hooks
+type Notifier struct {
+	hooks  []config.NotificationHook
+	client *http.Client
+}
+
+// NewNotifier creates a notifier for the given hooks
+func NewNotifier(hooks []config.NotificationHook) *Notifier {
+	return &Notifier{
+		hooks:  hooks,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify sends the event to every hook subscribed to its type.
+// All hooks are attempted; the first delivery error is returned.
+func (n *Notifier) Notify(ctx context.Context, event Event) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	var firstErr error
+	for _, hook := range n.hooks {
+		if !subscribed(hook, event.Type) {
+			continue
+		}
+
+		if err := n.send(ctx, hook, event); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("notification hook %s failed: %w", hookName(hook), err)
+		}
+	}
+
+	return firstErr
+}
+
+// send posts a single event to a hook
+func (n *Notifier) send(ctx context.Context, hook config.NotificationHook, event Event) error {
+	var payload any = event
+	if hook.Type == "slack" {
+		payload = map[string]string{"text": formatSlackText(event)}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// subscribed reports whether a hook wants events of the given type
+func subscribed(hook config.NotificationHook, eventType EventType) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	for _, name := range hook.Events {
+		if name == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func hookName(hook config.NotificationHook) string {
+	if hook.Name != "" {
+		return hook.Name
+	}
+	return hook.URL
+}
+
+// formatSlackText renders an event as a single Slack message line
+func formatSlackText(event Event) string {
+	icon := "ℹ️"
+	switch event.Type {
+	case EventUpFinished:
+		icon = "✅"
+	case EventServiceCrashed:
+		icon = "❌"
+	}
+
+	if event.Service != "" {
+		return fmt.Sprintf("%s [%s/%s] %s", icon, event.Environment, event.Service, event.Message)
+	}
+	return fmt.Sprintf("%s [%s] %s", icon, event.Environment, event.Message)
+}
@@ -0,0 +1,209 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/images"
+	"plat/pkg/tools"
+)
+
+// Assertion kinds supported by Orchestrator.Assert
+const (
+	AssertRunning = "running" // "<service> running": released and all pods ready
+	AssertVersion = "version" // "<service> version >= v2": deployed version comparison
+	AssertReturns = "returns" // "<service> returns 200": ingress HTTP status
+)
+
+// versionOperators are the comparisons allowed in version assertions
+var versionOperators = []string{">=", "<=", "==", "!=", ">", "<"}
+
+// Assertion is a single environment invariant, written as a short sentence
+type Assertion struct {
+	Raw      string
+	Service  string
+	Kind     string
+	Operator string // Version comparison operator
+	Version  string // Version compared against
+	Status   int    // Expected HTTP status
+}
+
+func (a Assertion) String() string {
+	return a.Raw
+}
+
+// AssertionResult is the outcome of evaluating one assertion
+type AssertionResult struct {
+	Assertion Assertion
+	Passed    bool
+	Detail    string // What was observed, e.g. "version is v1.4.0"
+}
+
+// ParseAssertion parses one of:
+//
+//	<service> running
+//	<service> version <op> <version>   (op: >=, >, <=, <, ==, !=)
+//	<service> returns <status>
+func ParseAssertion(value string) (Assertion, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return Assertion{}, fmt.Errorf("invalid assertion %q, expected '<service> running|version <op> <version>|returns <status>'", value)
+	}
+
+	a := Assertion{Raw: strings.Join(fields, " "), Service: fields[0], Kind: fields[1]}
+	switch a.Kind {
+	case AssertRunning:
+		if len(fields) != 2 {
+			return Assertion{}, fmt.Errorf("invalid assertion %q, expected '<service> running'", value)
+		}
+
+	case AssertVersion:
+		// Accept both "version >= v2" and "version >=v2"
+		rest := strings.Join(fields[2:], "")
+		for _, op := range versionOperators {
+			if strings.HasPrefix(rest, op) {
+				a.Operator = op
+				a.Version = strings.TrimPrefix(rest, op)
+				break
+			}
+		}
+		if a.Operator == "" || a.Version == "" {
+			return Assertion{}, fmt.Errorf("invalid assertion %q, expected '<service> version <op> <version>' with op one of %s",
+				value, strings.Join(versionOperators, " "))
+		}
+		if _, ok := images.CompareVersions(a.Version, a.Version); !ok {
+			return Assertion{}, fmt.Errorf("invalid assertion %q: %q is not a version like v1.2.3", value, a.Version)
+		}
+
+	case AssertReturns:
+		if len(fields) != 3 {
+			return Assertion{}, fmt.Errorf("invalid assertion %q, expected '<service> returns <status>'", value)
+		}
+		status, err := strconv.Atoi(fields[2])
+		if err != nil || status < 100 || status > 599 {
+			return Assertion{}, fmt.Errorf("invalid assertion %q: %q is not an HTTP status", value, fields[2])
+		}
+		a.Status = status
+
+	default:
+		return Assertion{}, fmt.Errorf("unknown assertion %q, must be one of: %s, %s, %s",
+			a.Kind, AssertRunning, AssertVersion, AssertReturns)
+	}
+
+	return a, nil
+}
+
+// Assert evaluates each assertion once and returns the results in order.
+// An assertion about a service missing from the config fails rather than
+// aborting the run, so one typo doesn't hide the other results.
+func (o *Orchestrator) Assert(ctx context.Context, runtime *config.RuntimeConfig, assertions []Assertion) []AssertionResult {
+	results := make([]AssertionResult, 0, len(assertions))
+	for _, a := range assertions {
+		result := AssertionResult{Assertion: a}
+		if _, exists := runtime.ResolvedServices[a.Service]; !exists {
+			result.Detail = fmt.Sprintf("service '%s' not found in configuration", a.Service)
+		} else {
+			result.Passed, result.Detail = o.checkAssertion(ctx, runtime, a)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// checkAssertion evaluates one assertion and describes what it observed
+func (o *Orchestrator) checkAssertion(ctx context.Context, runtime *config.RuntimeConfig, a Assertion) (bool, string) {
+	namespace := runtime.Base.Defaults.Namespace
+	releaseName := o.serviceManager.getReleaseName(a.Service, runtime)
+
+	switch a.Kind {
+	case AssertRunning:
+		status, err := o.serviceManager.helm(runtime).GetReleaseStatus(ctx, releaseName, namespace)
+		if err != nil {
+			return false, "not deployed"
+		}
+		if status.Status != "deployed" {
+			return false, fmt.Sprintf("release is %s", status.Status)
+		}
+		podStatus, err := tools.GetPodStatus(ctx, releaseName, namespace)
+		if err != nil {
+			return false, err.Error()
+		}
+		if !podStatus.Ready {
+			detail := fmt.Sprintf("%s pods ready", podStatus.PodsReady)
+			if podStatus.Reason != "" {
+				detail += " (" + podStatus.Reason + ")"
+			}
+			return false, detail
+		}
+		return true, fmt.Sprintf("%s pods ready", podStatus.PodsReady)
+
+	case AssertVersion:
+		version, err := o.deployedVersion(ctx, runtime, releaseName)
+		if err != nil {
+			return false, err.Error()
+		}
+		cmp, ok := images.CompareVersions(version, a.Version)
+		if !ok {
+			return false, fmt.Sprintf("version %q is not comparable", version)
+		}
+		return versionSatisfies(cmp, a.Operator), fmt.Sprintf("version is %s", version)
+
+	case AssertReturns:
+		status, err := ingressStatus(ctx, a.Service, runtime.Base.Defaults.Domain)
+		if err != nil {
+			return false, fmt.Sprintf("request failed: %v", err)
+		}
+		return status == a.Status, fmt.Sprintf("returned %d", status)
+	}
+
+	return false, fmt.Sprintf("unknown assertion %q", a.Kind)
+}
+
+// deployedVersion returns the image tag a release runs, falling back to the
+// chart's app version for third-party charts that don't set image.tag
+func (o *Orchestrator) deployedVersion(ctx context.Context, runtime *config.RuntimeConfig, releaseName string) (string, error) {
+	namespace := runtime.Base.Defaults.Namespace
+	helm := o.serviceManager.helm(runtime)
+
+	values, err := helm.GetReleaseValues(ctx, releaseName, namespace)
+	if err != nil {
+		return "", fmt.Errorf("not deployed")
+	}
+	if image, ok := values["image"].(map[string]any); ok {
+		if tag, ok := image["tag"].(string); ok && tag != "" {
+			// Digest-pinned tags look like "v1.2.3@sha256:..."
+			tag, _, _ = strings.Cut(tag, "@")
+			return tag, nil
+		}
+	}
+
+	status, err := helm.GetReleaseStatus(ctx, releaseName, namespace)
+	if err != nil {
+		return "", fmt.Errorf("not deployed")
+	}
+	if status.AppVersion == "" {
+		return "", fmt.Errorf("release has no image tag or app version")
+	}
+	return status.AppVersion, nil
+}
+
+func versionSatisfies(cmp int, op string) bool {
+	switch op {
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// Benchmark phases, in the order they run during a cycle
+const (
+	PhaseClusterCreate    = "cluster.create"
+	PhaseServicesDeploy   = "services.deploy"
+	PhaseServicesUndeploy = "services.undeploy"
+	PhaseClusterDelete    = "cluster.delete"
+)
+
+// BenchPhases lists the phases of a benchmark cycle in order
+var BenchPhases = []string{PhaseClusterCreate, PhaseServicesDeploy, PhaseServicesUndeploy, PhaseClusterDelete}
+
+// BenchRun holds the timings of one cold up/down cycle
+type BenchRun struct {
+	Cycle    int                      `json:"cycle"`
+	Phases   map[string]time.Duration `json:"phases"`
+	Services map[string]time.Duration `json:"services"` // Deploy time per service
+	Total    time.Duration            `json:"total"`
+}
+
+// BenchReport is the result of 'plat bench', saved so later runs can be compared
+type BenchReport struct {
+	Environment string     `json:"environment"`
+	Cluster     string     `json:"cluster"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Runs        []BenchRun `json:"runs"`
+}
+
+// BenchStats summarises one measurement across all runs
+type BenchStats struct {
+	Mean time.Duration
+	Min  time.Duration
+	Max  time.Duration
+}
+
+// Bench performs cold up/down cycles of the environment on a scratch cluster
+// named after the environment with a "-bench" suffix. The scratch cluster is
+// deleted at the end of every cycle so each run starts cold. It maps no host
+// ports and keeps its credentials in a kubeconfig of its own, so it runs next
+// to the environment's cluster without touching it.
+func (o *Orchestrator) Bench(ctx context.Context, runtime *config.RuntimeConfig, cycles int) (*BenchReport, error) {
+	kubeconfigDir, err := os.MkdirTemp("", "plat-bench-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch kubeconfig: %w", err)
+	}
+	defer os.RemoveAll(kubeconfigDir)
+
+	scratch := runtime.Clone()
+	base := *runtime.Base
+	base.Name = runtime.Base.Name + "-bench"
+	scratch.Base = &base
+	scratch.NoHostPorts = true
+	scratch.Kubeconfig = filepath.Join(kubeconfigDir, config.KubeconfigFile)
+
+	if err := tools.UseKubeconfig(scratch.KubeconfigPath()); err != nil {
+		return nil, err
+	}
+	defer tools.UseKubeconfig(runtime.KubeconfigPath())
+
+	report := &BenchReport{
+		Environment: runtime.Base.Name,
+		Cluster:     o.clusterManager.getClusterName(scratch),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	for cycle := 1; cycle <= cycles; cycle++ {
+		o.log.Info("bench cycle", "cycle", cycle, "cycles", cycles)
+
+		run, err := o.benchCycle(ctx, scratch, cycle)
+		if err != nil {
+			// Leave nothing behind on failure
+			o.clusterManager.DeleteCluster(context.Background(), scratch)
+			return report, fmt.Errorf("cycle %d failed: %w", cycle, err)
+		}
+		report.Runs = append(report.Runs, *run)
+	}
+
+	return report, nil
+}
+
+// benchCycle runs and times one cold up/down cycle
+func (o *Orchestrator) benchCycle(ctx context.Context, runtime *config.RuntimeConfig, cycle int) (*BenchRun, error) {
+	run := &BenchRun{
+		Cycle:    cycle,
+		Phases:   make(map[string]time.Duration),
+		Services: make(map[string]time.Duration),
+	}
+
+	var mu sync.Mutex
+	o.serviceManager.onDeployed = func(name string, elapsed time.Duration) {
+		mu.Lock()
+		run.Services[name] = elapsed
+		mu.Unlock()
+	}
+	defer func() { o.serviceManager.onDeployed = nil }()
+
+	phases := []struct {
+		name string
+		fn   func() error
+	}{
+		{PhaseClusterCreate, func() error { return o.clusterManager.EnsureCluster(ctx, runtime) }},
+		{PhaseServicesDeploy, func() error { return o.serviceManager.DeployServices(ctx, runtime) }},
+		{PhaseServicesUndeploy, func() error { return o.serviceManager.UndeployServices(ctx, runtime) }},
+		{PhaseClusterDelete, func() error { return o.clusterManager.DeleteCluster(ctx, runtime) }},
+	}
+
+	started := time.Now()
+	for _, phase := range phases {
+		phaseStarted := time.Now()
+		if err := phase.fn(); err != nil {
+			return nil, fmt.Errorf("%s: %w", phase.name, err)
+		}
+		run.Phases[phase.name] = time.Since(phaseStarted)
+
+		o.log.Info("bench phase", "phase", phase.name, "took", run.Phases[phase.name].Round(time.Millisecond))
+	}
+	run.Total = time.Since(started)
+
+	return run, nil
+}
+
+// PhaseStats summarises each phase, plus "total", across all runs
+func (r *BenchReport) PhaseStats() map[string]BenchStats {
+	stats := make(map[string]BenchStats)
+	for _, phase := range BenchPhases {
+		stats[phase] = r.collect(func(run BenchRun) (time.Duration, bool) {
+			d, ok := run.Phases[phase]
+			return d, ok
+		})
+	}
+	stats["total"] = r.collect(func(run BenchRun) (time.Duration, bool) {
+		return run.Total, true
+	})
+	return stats
+}
+
+// ServiceStats summarises deploy time per service across all runs
+func (r *BenchReport) ServiceStats() map[string]BenchStats {
+	names := make(map[string]bool)
+	for _, run := range r.Runs {
+		for name := range run.Services {
+			names[name] = true
+		}
+	}
+
+	stats := make(map[string]BenchStats)
+	for name := range names {
+		stats[name] = r.collect(func(run BenchRun) (time.Duration, bool) {
+			d, ok := run.Services[name]
+			return d, ok
+		})
+	}
+	return stats
+}
+
+// SlowestServices returns service names ordered by mean deploy time, slowest first
+func (r *BenchReport) SlowestServices() []string {
+	stats := r.ServiceStats()
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return stats[names[i]].Mean > stats[names[j]].Mean
+	})
+	return names
+}
+
+func (r *BenchReport) collect(value func(BenchRun) (time.Duration, bool)) BenchStats {
+	var stats BenchStats
+	var sum time.Duration
+	count := 0
+
+	for _, run := range r.Runs {
+		d, ok := value(run)
+		if !ok {
+			continue
+		}
+		if count == 0 || d < stats.Min {
+			stats.Min = d
+		}
+		if d > stats.Max {
+			stats.Max = d
+		}
+		sum += d
+		count++
+	}
+
+	if count > 0 {
+		stats.Mean = sum / time.Duration(count)
+	}
+	return stats
+}
+
+// WriteFile saves the report as JSON
+func (r *BenchReport) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadBenchReport loads a report saved with WriteFile
+func ReadBenchReport(path string) (*BenchReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var report BenchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse bench report: %w", err)
+	}
+	return &report, nil
+}
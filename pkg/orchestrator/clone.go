@@ -0,0 +1,81 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// dumpPostgresScript dumps every database using the credentials the
+// postgres container was started with
+const dumpPostgresScript = `PGPASSWORD="${POSTGRES_POSTGRES_PASSWORD:-$POSTGRES_PASSWORD}" pg_dumpall --clean --if-exists -U postgres`
+
+// restorePostgresScript replays a dump produced by dumpPostgresScript
+const restorePostgresScript = `PGPASSWORD="${POSTGRES_POSTGRES_PASSWORD:-$POSTGRES_PASSWORD}" psql -q -U postgres -d postgres`
+
+// CopyData copies database contents from the source environment into a clone.
+// Both environments must be running. Only PostgreSQL services are supported;
+// the names of services whose data could not be copied are returned.
+func (o *Orchestrator) CopyData(ctx context.Context, source, target *config.RuntimeConfig) ([]string, error) {
+	sourceKube := tools.NewKubernetesProviderFor(source.KubeconfigPath(), o.clusterManager.kubeContext(source))
+	targetKube := tools.NewKubernetesProviderFor(target.KubeconfigPath(), o.clusterManager.kubeContext(target))
+
+	var skipped []string
+	for _, name := range source.ListServices() {
+		service := source.ResolvedServices[name]
+		if _, ok := target.ResolvedServices[name]; !ok {
+			continue
+		}
+		if !strings.Contains(service.Chart.Name, "postgres") {
+			if !service.IsMicroserviceChart() {
+				skipped = append(skipped, name)
+			}
+			continue
+		}
+
+		o.log.Info("copying service data", "service", name)
+
+		dump, err := podShell(ctx, sourceKube, source.Base.Defaults.Namespace, name, dumpPostgresScript, "")
+		if err != nil {
+			return skipped, fmt.Errorf("failed to dump %s: %w", name, err)
+		}
+
+		if _, err := podShell(ctx, targetKube, target.Base.Defaults.Namespace, name, restorePostgresScript, dump); err != nil {
+			return skipped, fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+
+		o.log.Info("service data copied", "service", name)
+	}
+
+	return skipped, nil
+}
+
+// podShell runs a shell script in the oldest pod of a release, feeding it
+// stdin when non-empty, and returns its stdout
+func podShell(ctx context.Context, kube tools.KubernetesProvider, namespace, release, script, stdin string) (string, error) {
+	pods, err := kube.ListPods(ctx, namespace, fmt.Sprintf("app.kubernetes.io/instance=%s", release))
+	if err != nil {
+		return "", fmt.Errorf("failed to find pod: %w", err)
+	}
+	if len(pods) == 0 {
+		return "", fmt.Errorf("no pods found for %s", release)
+	}
+
+	var stdout, stderr bytes.Buffer
+	opts := tools.ExecOptions{Stdout: &stdout, Stderr: &stderr}
+	if stdin != "" {
+		opts.Stdin = strings.NewReader(stdin)
+	}
+	if err := kube.Exec(ctx, namespace, pods[0].Name, []string{"sh", "-c", script}, opts); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%w: %s", err, message)
+		}
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
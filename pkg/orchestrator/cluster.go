@@ -3,67 +3,102 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"os"
 	"strings"
 	"time"
 
 	"plat/pkg/config"
+	"plat/pkg/logging"
+	"plat/pkg/registry"
 	"plat/pkg/tools"
 )
 
-// ClusterManager orchestrates k3d cluster lifecycle for plat environments
+// ClusterManager orchestrates the cluster lifecycle for plat environments,
+// with the provider each environment selects in defaults.clusterProvider
 type ClusterManager struct {
-	provider tools.ClusterProvider
-	verbose  bool
+	verbose bool
+	log     *slog.Logger
+	events  *EventBus
 }
 
 // NewClusterManager creates a new cluster manager
 func NewClusterManager(verbose bool) *ClusterManager {
 	return &ClusterManager{
-		provider: tools.NewK3dProvider(),
-		verbose:  verbose,
+		verbose: verbose,
+		log:     logging.Default(),
+		events:  NewEventBus(),
 	}
 }
 
+// provider returns the cluster provider an environment is configured for
+func (cm *ClusterManager) provider(runtime *config.RuntimeConfig) tools.ClusterProvider {
+	return tools.NewClusterProvider(runtime.Base.Defaults.ClusterProvider, ContainerRuntime(runtime))
+}
+
+// ContainerRuntime returns the container runtime an environment builds
+// images and runs its cluster nodes with
+func ContainerRuntime(runtime *config.RuntimeConfig) tools.ContainerRuntime {
+	return tools.NewContainerRuntime(runtime.Base.Defaults.ContainerRuntime, runtime.Base.Defaults.ContainerSocket)
+}
+
+// usesK3d reports whether an environment runs on k3d, the only provider the
+// local registry and the k3d-specific cluster options apply to
+func usesK3d(runtime *config.RuntimeConfig) bool {
+	provider := runtime.Base.Defaults.ClusterProvider
+	return provider == "" || provider == tools.ClusterProviderK3d
+}
+
 // EnsureCluster ensures the cluster exists and is running for the environment
 func (cm *ClusterManager) EnsureCluster(ctx context.Context, runtime *config.RuntimeConfig) error {
 	clusterName := cm.getClusterName(runtime)
+	provider := cm.provider(runtime)
+
+	cm.log.Info("checking cluster", "cluster", clusterName)
 
-	if cm.verbose {
-		fmt.Printf("🔍 Checking cluster: %s\n", clusterName)
+	// Local builds are pushed to the shared registry, which must be up
+	// before a cluster using it is created
+	if usesLocalRegistry(runtime) {
+		if err := registry.New(ContainerRuntime(runtime), cm.verbose).Ensure(ctx); err != nil {
+			return err
+		}
 	}
 
 	// Check if cluster already exists
-	status, err := cm.provider.GetClusterStatus(ctx, clusterName)
+	status, err := provider.GetClusterStatus(ctx, clusterName)
 	if err == nil && status.Status == "running" {
-		if cm.verbose {
-			fmt.Printf("✅ Cluster %s is already running (%d servers, %d agents)\n",
-				clusterName, status.Servers, status.Agents)
+		cm.log.Info("cluster is already running", "cluster", clusterName, "servers", status.Servers, "agents", status.Agents)
+
+		// Clusters created before plat kept its own kubeconfig only have
+		// credentials in ~/.kube/config
+		if tools.CurrentKubeContext(ctx) != provider.KubeContext(clusterName) {
+			if err := provider.WriteKubeconfig(ctx, clusterName); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
 
 	// Create cluster if it doesn't exist or isn't running
-	if cm.verbose {
-		fmt.Printf("🚀 Creating k3d cluster: %s\n", clusterName)
-	}
+	cm.log.Info("creating cluster", "cluster", clusterName, "provider", runtime.Base.Defaults.ClusterProvider)
+	cm.events.Publish(Event{Type: EventClusterCreating, Cluster: clusterName})
 
 	clusterConfig := cm.buildClusterConfig(runtime)
-	if err := cm.provider.CreateCluster(ctx, clusterConfig); err != nil {
+	if err := provider.CreateCluster(ctx, clusterConfig); err != nil {
 		// Check if this is a port conflict error
 		if strings.Contains(err.Error(), "port is already allocated") {
-			return fmt.Errorf("failed to create cluster: %w\n\nHint: Another k3d cluster may be using the same ports. Try:\n  • plat down --cluster  (to stop current environment)\n  • k3d cluster delete <name>  (to delete conflicting cluster)\n  • k3d cluster list  (to see all clusters)", err)
+			return fmt.Errorf("failed to create cluster: %w\n\nHint: Another cluster may be using the same ports. Try:\n  • plat down --cluster  (to stop current environment)\n  • k3d cluster list, kind get clusters or minikube profile list  (to see all clusters)", err)
 		}
 		return fmt.Errorf("failed to create cluster: %w", err)
 	}
 
 	// Wait for cluster to be ready
-	if err := cm.waitForClusterReady(ctx, clusterName); err != nil {
+	if err := cm.waitForClusterReady(ctx, provider, clusterName); err != nil {
 		return fmt.Errorf("cluster failed to become ready: %w", err)
 	}
 
-	if cm.verbose {
-		fmt.Printf("✅ Cluster %s is ready\n", clusterName)
-	}
+	cm.log.Info("cluster is ready", "cluster", clusterName)
+	cm.events.Publish(Event{Type: EventClusterReady, Cluster: clusterName})
 
 	return nil
 }
@@ -72,30 +107,31 @@ func (cm *ClusterManager) EnsureCluster(ctx context.Context, runtime *config.Run
 func (cm *ClusterManager) DeleteCluster(ctx context.Context, runtime *config.RuntimeConfig) error {
 	clusterName := cm.getClusterName(runtime)
 
-	if cm.verbose {
-		fmt.Printf("🗑️  Deleting cluster: %s\n", clusterName)
-	}
+	cm.log.Info("deleting cluster", "cluster", clusterName)
 
-	if err := cm.provider.DeleteCluster(ctx, clusterName); err != nil {
+	if err := cm.provider(runtime).DeleteCluster(ctx, clusterName); err != nil {
 		return fmt.Errorf("failed to delete cluster: %w", err)
 	}
 
-	if cm.verbose {
-		fmt.Printf("✅ Cluster %s deleted\n", clusterName)
+	// The environment's kubeconfig only ever held this cluster
+	if err := os.Remove(runtime.KubeconfigPath()); err != nil && !os.IsNotExist(err) {
+		cm.log.Info("failed to remove kubeconfig", "path", runtime.KubeconfigPath(), "error", err)
 	}
 
+	cm.log.Info("cluster deleted", "cluster", clusterName)
+
 	return nil
 }
 
 // GetClusterStatus returns the current cluster status
 func (cm *ClusterManager) GetClusterStatus(ctx context.Context, runtime *config.RuntimeConfig) (*tools.ClusterStatus, error) {
 	clusterName := cm.getClusterName(runtime)
-	return cm.provider.GetClusterStatus(ctx, clusterName)
+	return cm.provider(runtime).GetClusterStatus(ctx, clusterName)
 }
 
-// ListClusters returns all plat-managed clusters
-func (cm *ClusterManager) ListClusters(ctx context.Context) ([]tools.ClusterInfo, error) {
-	allClusters, err := cm.provider.ListClusters(ctx)
+// ListClusters returns all plat-managed clusters of the environment's provider
+func (cm *ClusterManager) ListClusters(ctx context.Context, runtime *config.RuntimeConfig) ([]tools.ClusterInfo, error) {
+	allClusters, err := cm.provider(runtime).ListClusters(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -113,16 +149,26 @@ func (cm *ClusterManager) ListClusters(ctx context.Context) ([]tools.ClusterInfo
 
 // getClusterName generates a consistent cluster name from environment config
 func (cm *ClusterManager) getClusterName(runtime *config.RuntimeConfig) string {
+	return ClusterName(runtime)
+}
+
+// ClusterName returns the cluster name of an environment
+func ClusterName(runtime *config.RuntimeConfig) string {
 	// Use environment name with plat prefix for consistency
 	return fmt.Sprintf("plat-%s", runtime.Base.Name)
 }
 
+// kubeContext returns the kubeconfig context of the environment's cluster
+func (cm *ClusterManager) kubeContext(runtime *config.RuntimeConfig) string {
+	return cm.provider(runtime).KubeContext(cm.getClusterName(runtime))
+}
+
 // isPlatCluster checks if a cluster name indicates it's managed by plat
 func (cm *ClusterManager) isPlatCluster(name string) bool {
 	return len(name) > 5 && name[:5] == "plat-"
 }
 
-// buildClusterConfig creates k3d cluster configuration from environment config
+// buildClusterConfig creates the cluster configuration from environment config
 func (cm *ClusterManager) buildClusterConfig(runtime *config.RuntimeConfig) tools.ClusterConfig {
 	clusterName := cm.getClusterName(runtime)
 
@@ -130,15 +176,6 @@ func (cm *ClusterManager) buildClusterConfig(runtime *config.RuntimeConfig) tool
 		Name:    clusterName,
 		Servers: 1, // Single server for local development
 		Agents:  0, // No agents needed for local dev
-		Ports: []string{
-			// Standard web traffic
-			"80:80@loadbalancer",
-			"443:443@loadbalancer",
-		},
-		Options: []string{
-			// Disable default traefik since we'll use nginx ingress
-			"--k3s-arg=--disable=traefik@server:0",
-		},
 		Labels: map[string]string{
 			"plat.env":       runtime.Base.Name,
 			"plat.domain":    runtime.Base.Defaults.Domain,
@@ -146,6 +183,24 @@ func (cm *ClusterManager) buildClusterConfig(runtime *config.RuntimeConfig) tool
 		},
 	}
 
+	if usesK3d(runtime) {
+		// Disable the bundled Traefik; Bootstrap installs the configured
+		// ingress controller
+		config.Options = []string{"--k3s-arg=--disable=traefik@server:0"}
+	}
+
+	if usesLocalRegistry(runtime) {
+		config.Registries = []string{registry.ClusterAddress()}
+	}
+
+	// The environment's own cluster already holds the host ports
+	if runtime.NoHostPorts {
+		return config
+	}
+
+	// Standard web traffic
+	config.Ports = []string{"80:80@loadbalancer", "443:443@loadbalancer"}
+
 	// Add additional port mappings for services that need them
 	servicePorts := cm.collectServicePorts(runtime)
 	for _, port := range servicePorts {
@@ -177,8 +232,23 @@ func (cm *ClusterManager) collectServicePorts(runtime *config.RuntimeConfig) []i
 	return ports
 }
 
+// usesLocalRegistry reports whether any service is built from a local
+// source, so the environment needs the local registry. Clusters of other
+// providers than k3d get local images imported instead.
+func usesLocalRegistry(runtime *config.RuntimeConfig) bool {
+	if !usesK3d(runtime) {
+		return false
+	}
+	for _, service := range runtime.ResolvedServices {
+		if service.IsLocal && service.LocalSource != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // waitForClusterReady waits for the cluster to be fully operational
-func (cm *ClusterManager) waitForClusterReady(ctx context.Context, clusterName string) error {
+func (cm *ClusterManager) waitForClusterReady(ctx context.Context, provider tools.ClusterProvider, clusterName string) error {
 	timeout := 60 * time.Second
 	interval := 2 * time.Second
 
@@ -193,11 +263,9 @@ func (cm *ClusterManager) waitForClusterReady(ctx context.Context, clusterName s
 		case <-ctx.Done():
 			return fmt.Errorf("timeout waiting for cluster %s to be ready", clusterName)
 		case <-ticker.C:
-			status, err := cm.provider.GetClusterStatus(ctx, clusterName)
+			status, err := provider.GetClusterStatus(ctx, clusterName)
 			if err != nil {
-				if cm.verbose {
-					fmt.Printf("⏳ Waiting for cluster (error: %v)\n", err)
-				}
+				cm.log.Debug("waiting for cluster", "cluster", clusterName, "error", err)
 				continue
 			}
 
@@ -205,17 +273,20 @@ func (cm *ClusterManager) waitForClusterReady(ctx context.Context, clusterName s
 				return nil
 			}
 
-			if cm.verbose {
-				fmt.Printf("⏳ Cluster status: %s\n", status.Status)
-			}
+			cm.log.Debug("waiting for cluster", "cluster", clusterName, "status", status.Status)
 		}
 	}
 }
 
-// ValidatePrerequisites checks that k3d is available
-func (cm *ClusterManager) ValidatePrerequisites(ctx context.Context) error {
-	if err := tools.ValidateK3d(ctx); err != nil {
-		return fmt.Errorf("k3d validation failed: %w", err)
+// ValidatePrerequisites checks that the environment's cluster provider is
+// available
+func (cm *ClusterManager) ValidatePrerequisites(ctx context.Context, runtime *config.RuntimeConfig) error {
+	engine := ContainerRuntime(runtime)
+	if _, err := engine.Validate(ctx); err != nil {
+		return fmt.Errorf("%s validation failed: %w", engine.Name(), err)
+	}
+	if err := cm.provider(runtime).Validate(ctx); err != nil {
+		return fmt.Errorf("%s validation failed: %w", runtime.Base.Defaults.ClusterProvider, err)
 	}
 	return nil
 }
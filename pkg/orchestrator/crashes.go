@@ -0,0 +1,35 @@
+package orchestrator
+
+import "sync"
+
+// CrashLedger records which services were reported as crash-looping
+type CrashLedger interface {
+	// RecordCrashes records whether each checked service is crash-looping
+	// and returns those that weren't already recorded as such
+	RecordCrashes(crashing map[string]bool) ([]string, error)
+}
+
+// memoryCrashLedger is the ledger of an orchestrator without a persistent one
+type memoryCrashLedger struct {
+	mu      sync.Mutex
+	crashed map[string]bool
+}
+
+func newMemoryCrashLedger() *memoryCrashLedger {
+	return &memoryCrashLedger{crashed: make(map[string]bool)}
+}
+
+// RecordCrashes implements CrashLedger
+func (l *memoryCrashLedger) RecordCrashes(crashing map[string]bool) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var newly []string
+	for name, isCrashing := range crashing {
+		if isCrashing && !l.crashed[name] {
+			newly = append(newly, name)
+		}
+		l.crashed[name] = isCrashing
+	}
+	return newly, nil
+}
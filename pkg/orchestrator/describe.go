@@ -0,0 +1,212 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// maxDescribedRevisions and maxDescribedEvents bound the release history and
+// events of a service description, most recent kept
+const (
+	maxDescribedRevisions = 10
+	maxDescribedEvents    = 20
+)
+
+// ServiceDescription is everything known about one service, for 'plat
+// describe'
+type ServiceDescription struct {
+	Service   string                  `json:"service"`
+	Config    DescribedConfig         `json:"config"`
+	Release   *tools.ReleaseStatus    `json:"release"`
+	History   []tools.ReleaseRevision `json:"history,omitempty"` // Oldest first; only for Helm releases
+	Pods      []tools.PodDescription  `json:"pods"`
+	Endpoints DescribedEndpoints      `json:"endpoints"`
+	Mounts    []string                `json:"mounts,omitempty"` // Secrets and config maps the pods read, e.g. "secret api-db"
+	Events    []tools.EventInfo       `json:"events,omitempty"` // Of the pods, oldest first
+	Errors    []string                `json:"errors,omitempty"` // Parts that couldn't be read
+}
+
+// DescribedConfig is a service's resolved configuration
+type DescribedConfig struct {
+	DisplayName  string                 `json:"display_name,omitempty"`
+	Source       string                 `json:"source"` // e.g. "chart bitnami/postgresql 12.1.0" or "local ./api"
+	Version      string                 `json:"version,omitempty"`
+	Engine       string                 `json:"engine"`
+	Namespace    string                 `json:"namespace"`
+	Dependencies []string               `json:"dependencies,omitempty"`
+	Ports        []int                  `json:"ports,omitempty"`
+	Environment  map[string]string      `json:"environment,omitempty"` // As set in the pods
+	EnvFrom      string                 `json:"env_from,omitempty"`
+	ValuesFile   string                 `json:"values_file,omitempty"`
+	Values       map[string]interface{} `json:"values,omitempty"` // Resolved chart values
+}
+
+// DescribedEndpoints are the addresses a service is reachable at
+type DescribedEndpoints struct {
+	DNSName   string   `json:"dns_name"`            // Inside the cluster
+	URL       string   `json:"url,omitempty"`       // From the host
+	Ingress   string   `json:"ingress,omitempty"`   // Ingress host
+	Addresses []string `json:"addresses,omitempty"` // Ready pods, ip:port
+}
+
+// Describe collects a service's resolved config, release status and
+// history, pods, endpoints, mounted secrets and config maps, and recent
+// events. Parts that can't be read from the cluster are recorded in Errors
+// rather than failing the description.
+func (o *Orchestrator) Describe(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) (*ServiceDescription, error) {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return nil, &config.UnknownServiceError{Name: serviceName}
+	}
+	so := o.serviceManager
+	namespace := runtime.Base.Defaults.Namespace
+
+	description := &ServiceDescription{
+		Service: serviceName,
+		Config:  describeConfig(service, runtime),
+		Endpoints: DescribedEndpoints{
+			DNSName: runtime.ServiceDNSName(service),
+		},
+	}
+	if url, ok := runtime.ServiceURL(service); ok {
+		description.Endpoints.URL = url
+	}
+	if domain := runtime.Base.Defaults.Domain; domain != "" {
+		description.Endpoints.Ingress = fmt.Sprintf("%s.%s", serviceName, domain)
+	}
+	if service.Engine() == config.EngineHelm {
+		values, err := so.valuesManager.ResolveValues(service, runtime)
+		if err != nil {
+			description.Errors = append(description.Errors, fmt.Sprintf("values: %v", err))
+		}
+		description.Config.Values = values
+	}
+
+	// Release status and history
+	releaseName := so.getReleaseName(serviceName, runtime)
+	if service.AppliesManifests() {
+		description.Release = so.manifestStatus(ctx, serviceName, namespace)
+	} else {
+		status, err := so.helm(runtime).GetReleaseStatus(ctx, releaseName, namespace)
+		if err != nil {
+			status = &tools.ReleaseStatus{Name: releaseName, Namespace: namespace, Status: "not-deployed"}
+		}
+		description.Release = status
+		if status.Status != "not-deployed" {
+			history, err := so.helm(runtime).GetReleaseHistory(ctx, releaseName, namespace)
+			if err != nil {
+				description.Errors = append(description.Errors, fmt.Sprintf("history: %v", err))
+			}
+			if len(history) > maxDescribedRevisions {
+				history = history[len(history)-maxDescribedRevisions:]
+			}
+			description.History = history
+		}
+	}
+
+	// Pods, with the endpoints, mounts and events derived from them
+	pods, err := tools.ListPods(ctx, namespace, fmt.Sprintf("app.kubernetes.io/instance=%s", releaseName))
+	if err != nil {
+		description.Errors = append(description.Errors, fmt.Sprintf("pods: %v", err))
+	}
+	mounts := make(map[string]bool)
+	for _, info := range pods {
+		pod, err := tools.DescribePod(ctx, namespace, info.Name)
+		if err != nil {
+			description.Errors = append(description.Errors, fmt.Sprintf("pod %s: %v", info.Name, err))
+			continue
+		}
+		description.Pods = append(description.Pods, *pod)
+		description.Events = append(description.Events, pod.Events...)
+
+		ready := len(pod.Containers) > 0
+		for _, container := range pod.Containers {
+			if !container.Init && !container.Ready {
+				ready = false
+			}
+			for _, env := range container.Env {
+				if source := mountedSource(env.Source); source != "" {
+					mounts[source] = true
+				}
+			}
+			for _, mount := range container.Mounts {
+				if source := mountedSource(mount.Source); source != "" {
+					mounts[source] = true
+				}
+			}
+		}
+		if ready && pod.IP != "" {
+			for _, port := range service.Ports {
+				description.Endpoints.Addresses = append(description.Endpoints.Addresses, fmt.Sprintf("%s:%d", pod.IP, port))
+			}
+			if len(service.Ports) == 0 {
+				description.Endpoints.Addresses = append(description.Endpoints.Addresses, pod.IP)
+			}
+		}
+	}
+
+	for source := range mounts {
+		description.Mounts = append(description.Mounts, source)
+	}
+	sort.Strings(description.Mounts)
+
+	sort.SliceStable(description.Events, func(i, j int) bool {
+		return description.Events[i].LastSeen.Before(description.Events[j].LastSeen)
+	})
+	if len(description.Events) > maxDescribedEvents {
+		description.Events = description.Events[len(description.Events)-maxDescribedEvents:]
+	}
+
+	return description, nil
+}
+
+// describeConfig summarizes a service's resolved configuration
+func describeConfig(service *config.ResolvedService, runtime *config.RuntimeConfig) DescribedConfig {
+	described := DescribedConfig{
+		DisplayName:  service.DisplayName,
+		Version:      service.Version,
+		Engine:       service.Engine(),
+		Namespace:    runtime.Base.Defaults.Namespace,
+		Dependencies: service.Dependencies,
+		Ports:        service.Ports,
+		Environment:  service.PodEnvironment(),
+		EnvFrom:      service.EnvFrom,
+		ValuesFile:   service.ValuesFile,
+	}
+
+	switch {
+	case service.Kustomize != "":
+		described.Source = "kustomize " + service.Kustomize
+	case service.Manifests != "":
+		described.Source = "manifests " + service.Manifests
+	case service.Chart.Name != "":
+		described.Source = "chart " + strings.TrimPrefix(service.Chart.Repository+"/"+service.Chart.Name, "/")
+		if service.Chart.Version != "" {
+			described.Source += " " + service.Chart.Version
+		}
+	}
+	if service.IsLocal && service.LocalSource != nil {
+		local := "local " + service.LocalSource.GetPath()
+		if described.Source != "" {
+			local += ", " + described.Source
+		}
+		described.Source = local
+	}
+	return described
+}
+
+// mountedSource returns the secret or config map an env var or volume
+// source names, e.g. "secret api-db" for "secret api-db/password"; other
+// sources yield ""
+func mountedSource(source string) string {
+	if !strings.HasPrefix(source, "secret ") && !strings.HasPrefix(source, "configmap ") {
+		return ""
+	}
+	name, _, _ := strings.Cut(source, "/")
+	return name
+}
@@ -0,0 +1,99 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/textdiff"
+	"plat/pkg/tools"
+)
+
+// Change kinds of a service diff
+const (
+	DiffUnchanged = "unchanged"
+	DiffChanged   = "changed"
+	DiffInstall   = "install" // Not deployed yet; up would install it
+)
+
+// ServiceDiff is what 'plat up' would change for one service
+type ServiceDiff struct {
+	Service string `json:"service"`
+	Change  string `json:"change"`
+	Diff    string `json:"diff,omitempty"` // Unified diff from the live to the rendered manifests
+}
+
+// Diff renders the manifests 'plat up' would deploy for each named service
+// and compares them with what is live in the cluster. Helm services are
+// compared with their release's manifests, kubectl-applied services with
+// kubectl diff. Local services are rendered without building them, so their
+// image tags are the last built ones.
+func (o *Orchestrator) Diff(ctx context.Context, runtime *config.RuntimeConfig, serviceNames []string) ([]ServiceDiff, error) {
+	diffs := make([]ServiceDiff, 0, len(serviceNames))
+	for _, name := range serviceNames {
+		service, exists := runtime.ResolvedServices[name]
+		if !exists {
+			return nil, &config.UnknownServiceError{Name: name}
+		}
+
+		diff, err := o.serviceManager.diffService(ctx, service, runtime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff service %s: %w", name, err)
+		}
+		diffs = append(diffs, *diff)
+	}
+	return diffs, nil
+}
+
+// diffService compares a service's rendered manifests with the live ones
+func (so *ServiceOrchestrator) diffService(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) (*ServiceDiff, error) {
+	namespace := runtime.Base.Defaults.Namespace
+	diff := &ServiceDiff{Service: service.Name, Change: DiffUnchanged}
+
+	if service.AppliesManifests() {
+		manifests, err := so.renderManifests(ctx, service, runtime)
+		if err != nil {
+			return nil, err
+		}
+		if so.manifestStatus(ctx, service.Name, namespace).Status != "deployed" {
+			diff.Change = DiffInstall
+			diff.Diff = textdiff.Unified("/dev/null", service.Name, "", manifests)
+			return diff, nil
+		}
+		out, err := tools.ManifestsDiff(ctx, manifests, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if out != "" {
+			diff.Change = DiffChanged
+			diff.Diff = out
+		}
+		return diff, nil
+	}
+
+	release, err := so.serviceRelease(service, runtime)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := so.helm(runtime).Template(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := so.helm(runtime).GetReleaseManifest(ctx, release.Name, namespace)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return nil, err
+		}
+		diff.Change = DiffInstall
+		diff.Diff = textdiff.Unified("/dev/null", service.Name, "", rendered)
+		return diff, nil
+	}
+
+	if out := textdiff.Unified(service.Name+" (live)", service.Name, live, rendered); out != "" {
+		diff.Change = DiffChanged
+		diff.Diff = out
+	}
+	return diff, nil
+}
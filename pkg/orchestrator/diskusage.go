@@ -0,0 +1,159 @@
+package orchestrator
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/registry"
+	"plat/pkg/tools"
+)
+
+// DiskUsageEntry is the disk used by one kind of plat-managed resource
+type DiskUsageEntry struct {
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	Reclaimable int64  `json:"reclaimable"`    // Freed by following Hint
+	Hint        string `json:"hint,omitempty"` // How to reclaim the space
+}
+
+// DiskUsage is the disk used by an environment and the resources it shares
+// with other environments
+type DiskUsage struct {
+	Entries []DiskUsageEntry `json:"entries"`
+}
+
+// Total returns the size and reclaimable size of all entries
+func (du *DiskUsage) Total() (size, reclaimable int64) {
+	for _, entry := range du.Entries {
+		size += entry.Size
+		reclaimable += entry.Reclaimable
+	}
+	return size, reclaimable
+}
+
+// DiskUsage measures the disk used by the environment's cluster, the images
+// built for its local services, the local registry and its .plat directory
+func (o *Orchestrator) DiskUsage(ctx context.Context, runtime *config.RuntimeConfig) (*DiskUsage, error) {
+	docker, err := tools.GetDockerDiskUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := ClusterName(runtime)
+	usage := &DiskUsage{}
+	usage.Entries = append(usage.Entries,
+		clusterDiskUsage(docker, runtime.Base.Defaults.ClusterProvider, cluster),
+		localImageDiskUsage(docker, runtime),
+		registryDiskUsage(docker),
+		configDiskUsage(runtime),
+	)
+	return usage, nil
+}
+
+// clusterDiskUsage sums the cluster's node containers, which hold its
+// images and pod data, and its volumes. Each provider names them its own
+// way; minikube clusters not running in docker use none.
+func clusterDiskUsage(docker *tools.DockerDiskUsage, provider, cluster string) DiskUsageEntry {
+	owned := func(name string) bool {
+		switch provider {
+		case tools.ClusterProviderKind:
+			return strings.HasPrefix(name, cluster+"-")
+		case tools.ClusterProviderMinikube:
+			return name == cluster || strings.HasPrefix(name, cluster+"-m")
+		default:
+			return strings.HasPrefix(name, "k3d-"+cluster+"-")
+		}
+	}
+
+	entry := DiskUsageEntry{Name: "Cluster " + cluster}
+	for _, container := range docker.Containers {
+		if owned(container.Name) {
+			entry.Size += container.Size
+		}
+	}
+	for _, volume := range docker.Volumes {
+		if owned(volume.Name) {
+			entry.Size += volume.Size
+		}
+	}
+
+	if entry.Size > 0 {
+		entry.Reclaimable = entry.Size
+		entry.Hint = "plat down --cluster"
+	}
+	return entry
+}
+
+// localImageDiskUsage sums the images built for local services, whether
+// tagged for import or for the registry. Builds other than the current
+// "dev" one are reclaimable.
+func localImageDiskUsage(docker *tools.DockerDiskUsage, runtime *config.RuntimeConfig) DiskUsageEntry {
+	repositories := make(map[string]bool)
+	for name := range runtime.ResolvedServices {
+		repositories[name] = true
+		repositories[registry.HostAddress()+"/"+name] = true
+	}
+
+	current := make(map[string]bool)
+	for _, image := range docker.Images {
+		if repositories[image.Repository] && image.Tag == config.DefaultLocalTag {
+			current[image.ID] = true
+		}
+	}
+
+	// An image with several tags is listed once per tag
+	entry := DiskUsageEntry{Name: "Local service images"}
+	seen := make(map[string]bool)
+	for _, image := range docker.Images {
+		if !repositories[image.Repository] || seen[image.ID] {
+			continue
+		}
+		seen[image.ID] = true
+		entry.Size += image.UniqueSize
+		if !current[image.ID] {
+			entry.Reclaimable += image.UniqueSize
+		}
+	}
+
+	if entry.Reclaimable > 0 {
+		entry.Hint = "docker image rm <service>:dev-<id> for old builds"
+	}
+	return entry
+}
+
+// registryDiskUsage measures the local registry, which is shared by every
+// environment. What prune frees depends on what the pods run, so none of it
+// is counted as reclaimable.
+func registryDiskUsage(docker *tools.DockerDiskUsage) DiskUsageEntry {
+	entry := DiskUsageEntry{Name: "Local registry (shared)"}
+	for _, container := range docker.Containers {
+		if container.Name == "k3d-"+registry.Name {
+			entry.Size += container.Size
+		}
+	}
+
+	if entry.Size > 0 {
+		entry.Hint = "plat registry prune"
+	}
+	return entry
+}
+
+// configDiskUsage sums the files in the environment's .plat directory: its
+// configuration, state, lock file and snapshots
+func configDiskUsage(runtime *config.RuntimeConfig) DiskUsageEntry {
+	dir := runtime.ConfigDir()
+	entry := DiskUsageEntry{Name: dir + " directory"}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			entry.Size += info.Size()
+		}
+		return nil
+	})
+	return entry
+}
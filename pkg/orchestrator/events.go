@@ -0,0 +1,123 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EventType identifies what an orchestration event reports
+type EventType string
+
+// Orchestration events
+const (
+	EventClusterCreating   EventType = "cluster-creating"
+	EventClusterReady      EventType = "cluster-ready"
+	EventIngressInstalling EventType = "ingress-installing"
+	EventIngressReady      EventType = "ingress-ready"
+	EventServiceDeploying  EventType = "service-deploying"
+	EventServiceReady      EventType = "service-ready" // Installed; pods may still be starting
+	EventPodsReady         EventType = "pods-ready"
+	EventServiceRemoving   EventType = "service-removing"
+	EventServiceRemoved    EventType = "service-removed"
+	EventRolledBack        EventType = "rolled-back"  // Release rolled back after failing readiness
+	EventInstallSlow       EventType = "install-slow" // Chart install still running; repeated until it ends
+	EventProgress          EventType = "progress"
+	EventError             EventType = "error"
+)
+
+// eventSubscriberBacklog is how many events a subscriber may fall behind
+const eventSubscriberBacklog = 64
+
+// Event reports a step of a long-running operation
+type Event struct {
+	Type    EventType `json:"type"`
+	Time    time.Time `json:"time"`
+	Service string    `json:"service,omitempty"`
+	Cluster string    `json:"cluster,omitempty"`
+	Level   int       `json:"level"`             // Dependency level of a deploying service
+	Pods    string    `json:"pods,omitempty"`    // Ready containers while waiting, e.g. "1/3"
+	Message string    `json:"message,omitempty"` // Progress text, e.g. kubectl's rollout status
+	Err     error     `json:"-"`
+}
+
+// String describes the event in one line
+func (e Event) String() string {
+	switch e.Type {
+	case EventClusterCreating:
+		return fmt.Sprintf("Creating cluster %s", e.Cluster)
+	case EventClusterReady:
+		return fmt.Sprintf("Cluster %s is ready", e.Cluster)
+	case EventServiceDeploying:
+		return fmt.Sprintf("Deploying %s", e.Service)
+	case EventServiceReady:
+		return fmt.Sprintf("%s deployed", e.Service)
+	case EventPodsReady:
+		return fmt.Sprintf("%s ready", e.Service)
+	case EventServiceRemoving:
+		return fmt.Sprintf("Removing %s", e.Service)
+	case EventServiceRemoved:
+		return fmt.Sprintf("%s removed", e.Service)
+	case EventRolledBack:
+		return e.Message
+	case EventError:
+		if e.Service != "" {
+			return fmt.Sprintf("%s failed: %v", e.Service, e.Err)
+		}
+		return fmt.Sprintf("Failed: %v", e.Err)
+	default:
+		return e.Message
+	}
+}
+
+// EventBus fans events out to subscribers. Publishing never blocks: a
+// subscriber that falls behind by more than its backlog misses events
+// rather than stalling the operation.
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers map[int]chan Event
+	next        int
+}
+
+// NewEventBus creates an event bus without subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[int]chan Event)}
+}
+
+// Subscribe returns a channel receiving events published from now on, and
+// a function that ends the subscription and closes the channel
+func (b *EventBus) Subscribe() (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+	ch := make(chan Event, eventSubscriberBacklog)
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, id)
+			close(ch)
+		})
+	}
+}
+
+// Publish sends an event to every subscriber
+func (b *EventBus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
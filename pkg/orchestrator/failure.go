@@ -0,0 +1,57 @@
+package orchestrator
+
+import "errors"
+
+// FailureKind classifies what part of an environment operation failed, so
+// the CLI can report it as a distinct exit code
+type FailureKind int
+
+const (
+	// FailurePrerequisite means a required tool is missing or unusable
+	FailurePrerequisite FailureKind = iota + 1
+
+	// FailureCluster means the cluster could not be created, started or
+	// reached
+	FailureCluster
+
+	// FailureDeploy means no service of the operation was deployed
+	FailureDeploy
+
+	// FailurePartial means some services were deployed before others failed.
+	// Deploys are fail-fast per dependency level: the services of the failing
+	// level all finish, later levels are never started.
+	FailurePartial
+)
+
+// Failure is an error tagged with its kind. Its message is the wrapped
+// error's, so tagging doesn't change what users see.
+type Failure struct {
+	Kind FailureKind
+	Err  error
+}
+
+func (f *Failure) Error() string {
+	return f.Err.Error()
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// fail tags an error with a failure kind; nil stays nil
+func fail(kind FailureKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Failure{Kind: kind, Err: err}
+}
+
+// FailureKindOf returns the kind of the outermost Failure in an error's
+// chain, or 0 when the error is untagged
+func FailureKindOf(err error) FailureKind {
+	var failure *Failure
+	if errors.As(err, &failure) {
+		return failure.Kind
+	}
+	return 0
+}
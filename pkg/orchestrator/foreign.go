@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"context"
+	"sort"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// ForeignResources are what the environment's namespace holds that plat
+// didn't deploy for this environment
+type ForeignResources struct {
+	Namespace string
+	Releases  []string // Helm releases of no configured service
+	Workloads []string // Workloads outside any Helm release or plat service
+}
+
+// Empty reports whether the namespace holds nothing foreign
+func (f *ForeignResources) Empty() bool {
+	return len(f.Releases) == 0 && len(f.Workloads) == 0
+}
+
+// FindForeignResources lists the Helm releases and workloads in the
+// environment's namespace that don't belong to any of its services, so Up
+// doesn't silently mix plat-managed and user resources. A cluster that isn't
+// running holds nothing.
+func (o *Orchestrator) FindForeignResources(ctx context.Context, runtime *config.RuntimeConfig) (*ForeignResources, error) {
+	namespace := runtime.Base.Defaults.Namespace
+	foreign := &ForeignResources{Namespace: namespace}
+
+	if status, err := o.clusterManager.GetClusterStatus(ctx, runtime); err != nil || status.Status != "running" {
+		return foreign, nil
+	}
+
+	owned := make(map[string]bool)
+	for serviceName := range runtime.ResolvedServices {
+		owned[serviceName] = true
+		owned[o.serviceManager.getReleaseName(serviceName, runtime)] = true
+	}
+
+	releases, err := o.serviceManager.helm(runtime).ListReleases(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	released := make(map[string]bool, len(releases))
+	for _, release := range releases {
+		released[release.Name] = true
+		if !owned[release.Name] {
+			foreign.Releases = append(foreign.Releases, release.Name)
+		}
+	}
+
+	workloads, err := tools.ListNamespaceWorkloads(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, workload := range workloads {
+		// Workloads of foreign releases are already reported as their release
+		if workload.Instance != "" && (owned[workload.Instance] || released[workload.Instance]) {
+			continue
+		}
+		foreign.Workloads = append(foreign.Workloads, workload.Name)
+	}
+
+	sort.Strings(foreign.Releases)
+	sort.Strings(foreign.Workloads)
+	return foreign, nil
+}
@@ -0,0 +1,73 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// ForeignClusterError is returned before a mutating operation when the
+// active kube context isn't the environment's local cluster, so installs
+// can't land on a shared or staging cluster KUBECONFIG happens to point at
+type ForeignClusterError struct {
+	Context  string // Active context
+	Server   string // API server of the active context
+	Expected string // Context of the environment's cluster
+}
+
+func (e *ForeignClusterError) Error() string {
+	target := fmt.Sprintf("kube context %s (%s) is not the environment's cluster", e.Context, e.Server)
+	if e.Context == e.Expected {
+		target = fmt.Sprintf("kube context %s points at %s, which is not a local cluster", e.Context, e.Server)
+	}
+	return fmt.Sprintf("refusing to modify the cluster: %s; expected %s (use --allow-any-cluster to override)", target, e.Expected)
+}
+
+// CheckClusterTarget verifies that the active kube context is the
+// environment's own cluster and that its API server runs on this machine or
+// a local VM. Operations that change the cluster call it first; it passes
+// when runtime.AllowAnyCluster is set, and without an active context, which
+// leaves nothing to modify.
+func CheckClusterTarget(ctx context.Context, runtime *config.RuntimeConfig) error {
+	if runtime.AllowAnyCluster {
+		return nil
+	}
+
+	expected := tools.NewClusterProvider(runtime.Base.Defaults.ClusterProvider, ContainerRuntime(runtime)).KubeContext(ClusterName(runtime))
+	target, err := tools.CurrentKubeTarget(ctx)
+	if err != nil {
+		return fail(FailureCluster, err)
+	}
+	if target.Context == "" {
+		return nil
+	}
+	if target.Context != expected || !isLocalServer(target.Server) {
+		return fail(FailureCluster, &ForeignClusterError{
+			Context:  target.Context,
+			Server:   target.Server,
+			Expected: expected,
+		})
+	}
+	return nil
+}
+
+// isLocalServer reports whether an API server address is on this machine,
+// a container network or a local VM, as k3d, kind and minikube clusters are
+func isLocalServer(server string) bool {
+	u, err := url.Parse(server)
+	if err != nil {
+		return false
+	}
+
+	host := u.Hostname()
+	switch host {
+	case "localhost", "host.docker.internal", "host.containers.internal":
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified())
+}
@@ -0,0 +1,165 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// ingressReadyTimeout is how long Bootstrap waits for the ingress controller
+// to roll out; the first install pulls its image
+const ingressReadyTimeout = 3 * time.Minute
+
+// ingressController is an ingress controller plat installs with Helm
+type ingressController struct {
+	name       string // defaults.ingressController value
+	release    string // Helm release, also its namespace
+	chart      string
+	repository string
+	repoName   string
+	workload   string // Workload Bootstrap waits for
+}
+
+// ingressControllers are the ingress controllers plat can install
+var ingressControllers = map[string]ingressController{
+	config.IngressNginx: {
+		name:       config.IngressNginx,
+		release:    "ingress-nginx",
+		chart:      "ingress-nginx",
+		repository: "https://kubernetes.github.io/ingress-nginx",
+		repoName:   "ingress-nginx",
+		workload:   "deployment.apps/ingress-nginx-controller",
+	},
+	config.IngressTraefik: {
+		name:       config.IngressTraefik,
+		release:    "traefik",
+		chart:      "traefik",
+		repository: "https://traefik.github.io/charts",
+		repoName:   "traefik",
+		workload:   "deployment.apps/traefik",
+	},
+}
+
+// ingressNamespaces returns the namespaces the ingress controller of an
+// environment runs in: the one plat installs, or those of the controllers
+// users usually bring (k3s' Traefik, ingress-nginx) when it installs none
+func ingressNamespaces(runtime *config.RuntimeConfig) []string {
+	if controller, ok := ingressControllers[runtime.Base.Defaults.IngressController]; ok {
+		return []string{controller.release}
+	}
+	return []string{"kube-system", "ingress-nginx"}
+}
+
+// values returns the chart values exposing the controller on ports 80 and
+// 443 of the host: through k3d's load balancer, which forwards to a
+// LoadBalancer Service, or on the node's host ports, which kind and minikube
+// map to the host
+func (c ingressController) values(runtime *config.RuntimeConfig) map[string]any {
+	serviceType := "LoadBalancer"
+	if !usesK3d(runtime) {
+		serviceType = "NodePort"
+	}
+
+	switch c.name {
+	case config.IngressTraefik:
+		values := map[string]any{
+			"ingressClass": map[string]any{"enabled": true, "isDefaultClass": true},
+			"service":      map[string]any{"type": serviceType},
+		}
+		if !usesK3d(runtime) {
+			values["ports"] = map[string]any{
+				"web":       map[string]any{"hostPort": 80},
+				"websecure": map[string]any{"hostPort": 443},
+			}
+		}
+		return values
+	default:
+		return map[string]any{
+			"controller": map[string]any{
+				"ingressClassResource": map[string]any{"default": true},
+				"service":              map[string]any{"type": serviceType},
+				"hostPort":             map[string]any{"enabled": !usesK3d(runtime)},
+			},
+		}
+	}
+}
+
+// Bootstrap installs the cluster infrastructure services rely on: the
+// ingress controller chosen with defaults.ingressController, waiting until
+// it serves. A controller already deployed is kept, and the other one plat
+// knows is removed when the setting changes. With "none" the cluster is
+// left alone. Under 'plat up --frozen' the controller is installed at the
+// version in the lock file, and a lock without one is refused.
+func (cm *ClusterManager) Bootstrap(ctx context.Context, runtime *config.RuntimeConfig, helm tools.HelmProvider) error {
+	controller, ok := ingressControllers[runtime.Base.Defaults.IngressController]
+	if !ok {
+		return nil
+	}
+
+	version := ""
+	if runtime.LockedAddons != nil {
+		if version, ok = runtime.LockedAddons[controller.release]; !ok {
+			return fmt.Errorf("the %s ingress controller is not present in %s; run 'plat up' without --frozen to record it", controller.name, config.LockFileName)
+		}
+	}
+
+	for _, other := range ingressControllers {
+		if other.name == controller.name {
+			continue
+		}
+		if _, err := helm.GetReleaseStatus(ctx, other.release, other.release); err != nil {
+			continue
+		}
+		cm.log.Info("removing ingress controller", "controller", other.name)
+		if err := helm.UninstallChart(ctx, other.release, other.release); err != nil {
+			return fmt.Errorf("failed to remove the %s ingress controller: %w", other.name, err)
+		}
+	}
+
+	if status, err := helm.GetReleaseStatus(ctx, controller.release, controller.release); err == nil && status.Status == "deployed" && (version == "" || status.Version == version) {
+		cm.log.Info("ingress controller is installed", "controller", controller.name, "version", status.Version)
+		return nil
+	}
+
+	cm.log.Info("installing ingress controller", "controller", controller.name, "version", version)
+	cm.events.Publish(Event{Type: EventIngressInstalling, Message: fmt.Sprintf("Installing the %s ingress controller", controller.name)})
+
+	if err := helm.InstallChart(ctx, tools.HelmRelease{
+		Name:       controller.release,
+		Chart:      controller.chart,
+		Version:    version,
+		Repository: controller.repository,
+		RepoName:   controller.repoName,
+		Namespace:  controller.release,
+		Values:     controller.values(runtime),
+		NoWait:     true,
+	}); err != nil {
+		return fmt.Errorf("failed to install the %s ingress controller: %w", controller.name, err)
+	}
+
+	if err := tools.RolloutStatus(ctx, controller.workload, controller.release, ingressReadyTimeout, nil); err != nil {
+		return fmt.Errorf("the %s ingress controller failed to become ready: %w", controller.name, err)
+	}
+
+	cm.log.Info("ingress controller is ready", "controller", controller.name)
+	cm.events.Publish(Event{Type: EventIngressReady, Message: fmt.Sprintf("The %s ingress controller is ready", controller.name)})
+
+	return nil
+}
+
+// addonVersions returns the chart version of each cluster addon plat
+// installed, by release, for the lock file
+func (cm *ClusterManager) addonVersions(ctx context.Context, runtime *config.RuntimeConfig, helm tools.HelmProvider) map[string]string {
+	versions := make(map[string]string)
+	controller, ok := ingressControllers[runtime.Base.Defaults.IngressController]
+	if !ok {
+		return versions
+	}
+	if status, err := helm.GetReleaseStatus(ctx, controller.release, controller.release); err == nil && status.Status == "deployed" {
+		versions[controller.release] = status.Version
+	}
+	return versions
+}
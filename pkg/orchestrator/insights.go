@@ -0,0 +1,119 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/images"
+)
+
+// ServiceInsights are facts about services that are too slow to gather on
+// every status refresh: configuration drift and available image updates
+type ServiceInsights struct {
+	Drift   map[string]bool   // Deployed values differ from the configured values
+	Updates map[string]string // Newer image version available in the registry
+}
+
+// Insights checks deployed services for values drift and newer image
+// versions. Services that can't be checked are left out.
+func (o *Orchestrator) Insights(ctx context.Context, runtime *config.RuntimeConfig) *ServiceInsights {
+	insights := &ServiceInsights{
+		Drift:   make(map[string]bool),
+		Updates: make(map[string]string),
+	}
+
+	for _, service := range runtime.OrderedServices() {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if drifted, err := o.serviceManager.valuesDrifted(ctx, service, runtime); err == nil && drifted {
+			insights.Drift[service.Name] = true
+		}
+
+		if newer, ok := o.serviceManager.imageUpdate(ctx, service, runtime); ok {
+			insights.Updates[service.Name] = newer
+		}
+	}
+
+	return insights
+}
+
+// valuesDrifted reports whether the values a helm release was installed
+// with differ from what deploying the service now would use
+func (so *ServiceOrchestrator) valuesDrifted(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) (bool, error) {
+	if service.Engine() != config.EngineHelm {
+		return false, nil
+	}
+
+	namespace := runtime.Base.Defaults.Namespace
+	deployed, err := so.helm(runtime).GetReleaseValues(ctx, so.getReleaseName(service.Name, runtime), namespace)
+	if err != nil {
+		return false, err
+	}
+
+	// Digests pinned by image hooks in an earlier session aren't in config;
+	// reuse the deployed one so pinning alone doesn't count as drift
+	if service.ImageDigest == "" {
+		if digest := deployedDigest(deployed, service.Version); digest != "" {
+			service = service.Clone()
+			service.ImageDigest = digest
+		}
+	}
+
+	// Likewise the tag of a locally built image changes with every build
+	if service.IsLocal && service.LocalTag == "" {
+		if tag := deployedLocalTag(deployed); tag != "" {
+			service = service.Clone()
+			service.LocalTag = tag
+		}
+	}
+
+	release, err := so.serviceRelease(service, runtime)
+	if err != nil {
+		return false, err
+	}
+
+	return !config.ValuesEqual(deployed, release.Values), nil
+}
+
+// deployedDigest extracts the digest from a deployed "<version>@<digest>" image tag
+func deployedDigest(values map[string]interface{}, version string) string {
+	image, ok := values["image"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	tag, _ := image["tag"].(string)
+	if digest, found := strings.CutPrefix(tag, version+"@"); found {
+		return digest
+	}
+	return ""
+}
+
+// deployedLocalTag extracts a "dev-<id>" tag given to a locally built image
+func deployedLocalTag(values map[string]interface{}) string {
+	image, ok := values["image"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	tag, _ := image["tag"].(string)
+	if strings.HasPrefix(tag, config.DefaultLocalTag+"-") {
+		return tag
+	}
+	return ""
+}
+
+// imageUpdate returns a newer version tag of the service's image, for
+// registry images of the microservice chart with a version pinned
+func (so *ServiceOrchestrator) imageUpdate(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) (string, bool) {
+	if service.IsLocal || !service.IsMicroserviceChart() {
+		return "", false
+	}
+
+	tags, err := images.NewDigestResolver().ListTags(ctx, runtime.ImageRepository(service))
+	if err != nil {
+		return "", false
+	}
+	return images.NewerVersion(service.Version, tags)
+}
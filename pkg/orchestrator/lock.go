@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// GenerateLock captures the chart versions and image digests currently
+// deployed for the environment's services, and the versions of the cluster
+// addons plat installed
+func (o *Orchestrator) GenerateLock(ctx context.Context, runtime *config.RuntimeConfig) (*config.Lock, error) {
+	lock := config.NewLock()
+	namespace := runtime.Base.Defaults.Namespace
+
+	statuses, err := o.serviceManager.GetServiceStatuses(ctx, runtime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service statuses: %w", err)
+	}
+
+	for serviceName, service := range runtime.ResolvedServices {
+		releaseStatus := statuses[serviceName]
+		if releaseStatus == nil || releaseStatus.Status != "deployed" {
+			continue
+		}
+
+		entry := config.LockedService{
+			Name:         serviceName,
+			Version:      service.Version,
+			Chart:        service.Chart.Name,
+			Repository:   service.Chart.Repository,
+			ChartVersion: releaseStatus.Version,
+			Digest:       o.serviceManager.ImageDigest(service),
+		}
+
+		images, err := tools.GetPodImages(ctx, serviceName, namespace)
+		if err != nil {
+			o.log.Info("could not resolve image digests", "service", serviceName, "error", err)
+		} else {
+			entry.Images = images
+		}
+
+		// Without image hooks the digest comes from what the pods run, so
+		// --frozen can pin it
+		if entry.Digest == "" && !service.IsLocal && service.IsMicroserviceChart() {
+			entry.Digest = imageDigest(entry.Images, runtime.ImageRepository(service))
+		}
+
+		lock.SetService(entry)
+	}
+
+	for release, version := range o.clusterManager.addonVersions(ctx, runtime, o.serviceManager.helm(runtime)) {
+		lock.Addons[release] = version
+	}
+
+	return lock, nil
+}
+
+// imageDigest returns the digest of the repository's image among pod image
+// references (repo@sha256:...), or "" when none runs it
+func imageDigest(images []string, repository string) string {
+	for _, ref := range images {
+		repo, digest, ok := strings.Cut(ref, "@")
+		if !ok {
+			continue
+		}
+		if repo == repository || strings.HasSuffix(repo, "/"+repository) {
+			return digest
+		}
+	}
+	return ""
+}
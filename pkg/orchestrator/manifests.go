@@ -0,0 +1,242 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// Labels plat adds to every resource it applies with kubectl, so services
+// deployed without Helm can be found for status, pruning and teardown
+const (
+	instanceLabel  = "app.kubernetes.io/instance"
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByPlat  = "plat"
+)
+
+// ownershipSelector selects the kubectl-applied resources of a service
+func ownershipSelector(serviceName string) string {
+	return fmt.Sprintf("%s=%s,%s=%s", instanceLabel, serviceName, managedByLabel, managedByPlat)
+}
+
+// renderManifests produces the final manifests for a service deployed with
+// kubectl: the engine's output (a rendered chart or a manifests directory),
+// then patched and labelled
+func (so *ServiceOrchestrator) renderManifests(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) (string, error) {
+	var base string
+
+	switch service.Engine() {
+	case config.EngineKustomize:
+		built, err := tools.KustomizeBuild(ctx, runtime.ResolvePath(service.Kustomize))
+		if err != nil {
+			return "", err
+		}
+		base = built
+	case config.EngineManifests:
+		manifests, err := readManifestsDir(runtime.ResolvePath(service.Manifests))
+		if err != nil {
+			return "", err
+		}
+		base = manifests
+	default:
+		release, err := so.serviceRelease(service, runtime)
+		if err != nil {
+			return "", err
+		}
+		base, err = so.helm(runtime).Template(ctx, release)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return postProcessManifests(ctx, base, service)
+}
+
+// readManifestsDir concatenates every YAML and JSON file below dir, in
+// lexical path order
+func readManifestsDir(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			// kustomization files describe overlays, not resources
+			if !strings.HasPrefix(entry.Name(), "kustomization.") {
+				files = append(files, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifests directory: %w", err)
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no manifests found in %s", dir)
+	}
+
+	sort.Strings(files)
+
+	var manifests strings.Builder
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read manifest %s: %w", file, err)
+		}
+		manifests.WriteString("---\n")
+		manifests.Write(data)
+		manifests.WriteString("\n")
+	}
+
+	return manifests.String(), nil
+}
+
+// postProcessManifests runs the manifests through a generated kustomization
+// that applies the service's patches and adds plat's ownership labels
+func postProcessManifests(ctx context.Context, manifests string, service *config.ResolvedService) (string, error) {
+	dir, err := os.MkdirTemp("", "plat-kustomize-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create kustomization directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "resources.yaml"), []byte(manifests), 0644); err != nil {
+		return "", fmt.Errorf("failed to write manifests: %w", err)
+	}
+
+	type kustomizePatch struct {
+		Patch  string              `yaml:"patch"`
+		Target *config.PatchTarget `yaml:"target,omitempty"`
+	}
+
+	kustomization := map[string]any{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  []string{"resources.yaml"},
+		"labels": []map[string]any{{
+			"pairs": map[string]string{
+				instanceLabel:  service.Name,
+				managedByLabel: managedByPlat,
+			},
+			"includeTemplates": true,
+		}},
+	}
+
+	if len(service.Patches) > 0 {
+		patches := make([]kustomizePatch, 0, len(service.Patches))
+		for _, patch := range service.Patches {
+			patches = append(patches, kustomizePatch{Patch: patch.Patch, Target: patch.Target})
+		}
+		kustomization["patches"] = patches
+	}
+
+	data, err := yaml.Marshal(kustomization)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode kustomization: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "kustomization.yaml"), data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write kustomization: %w", err)
+	}
+
+	rendered, err := tools.KustomizeBuild(ctx, dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply patches for %s: %w", service.Name, err)
+	}
+
+	return rendered, nil
+}
+
+// applyManifests renders and applies a service with kubectl, pruning
+// resources that are no longer rendered
+func (so *ServiceOrchestrator) applyManifests(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) error {
+	namespace := runtime.Base.Defaults.Namespace
+
+	manifests, err := so.renderManifests(ctx, service, runtime)
+	if err != nil {
+		return err
+	}
+
+	if err := tools.EnsureNamespace(ctx, namespace); err != nil {
+		return err
+	}
+
+	// Skip the apply when the cluster already matches; kubectl apply would be
+	// a no-op anyway but this keeps re-running 'plat up' quiet and fast.
+	// Resources dropped from the manifests are pruned on the next apply.
+	drifted, err := tools.DiffManifests(ctx, manifests, namespace)
+	if err != nil {
+		return err
+	}
+	if !drifted {
+		so.log.Info("manifests are up to date", "service", service.Name)
+		return nil
+	}
+
+	if err := tools.ApplyManifests(ctx, manifests, namespace, ownershipSelector(service.Name)); err != nil {
+		return err
+	}
+
+	if !so.noWait {
+		if err := so.waitForWorkloads(ctx, service.Name, namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteManifests removes a kubectl-applied service by re-rendering its
+// manifests and deleting what they describe
+func (so *ServiceOrchestrator) deleteManifests(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) error {
+	manifests, err := so.renderManifests(ctx, service, runtime)
+	if err != nil {
+		return err
+	}
+
+	return tools.DeleteManifests(ctx, manifests, runtime.Base.Defaults.Namespace)
+}
+
+// manifestStatus reports whether a kubectl-applied service has resources in the cluster
+func (so *ServiceOrchestrator) manifestStatus(ctx context.Context, serviceName, namespace string) *tools.ReleaseStatus {
+	status := &tools.ReleaseStatus{
+		Name:      serviceName,
+		Namespace: namespace,
+		Status:    "not-deployed",
+	}
+
+	if resources, err := tools.ListLabeledResources(ctx, namespace, ownershipSelector(serviceName)); err == nil && len(resources) > 0 {
+		status.Status = "deployed"
+	}
+
+	return status
+}
+
+// waitForWorkloads waits for the rollout of every workload of a service,
+// mirroring helm --wait for kubectl-applied services
+func (so *ServiceOrchestrator) waitForWorkloads(ctx context.Context, serviceName, namespace string) error {
+	workloads, err := tools.ListWorkloads(ctx, serviceName, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, workload := range workloads {
+		if err := tools.RolloutStatus(ctx, workload, namespace, DefaultRolloutTimeout, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+const (
+	// networkPolicyLabel marks the NetworkPolicies plat generates
+	networkPolicyLabel = "plat.dev/network-policy"
+
+	// defaultDenyPolicy is the name of the policy denying all ingress traffic
+	// to pods of the namespace
+	defaultDenyPolicy = "plat-default-deny"
+)
+
+// networkPolicySelector selects the NetworkPolicies plat generated
+func networkPolicySelector() string {
+	return fmt.Sprintf("%s=%s,%s=true", managedByLabel, managedByPlat, networkPolicyLabel)
+}
+
+// NetworkRule is who may connect to a service's pods under the generated
+// NetworkPolicies
+type NetworkRule struct {
+	Service string   `json:"service"`
+	From    []string `json:"from,omitempty"` // Services depending on it
+	Ingress bool     `json:"ingress"`        // Reachable through the ingress controller
+}
+
+// NetworkRules derives allow rules from the dependency graph: a service
+// accepts connections from its own pods, from the services depending on it
+// (declared or inferred) and, when services get ingress hosts, from the
+// ingress controller. The rules cover the whole environment even when
+// runtime is filtered, since services left out still connect to the ones
+// deployed.
+func NetworkRules(runtime *config.RuntimeConfig) []NetworkRule {
+	runtime = runtime.Unfiltered()
+	dependents := make(map[string][]string)
+	for _, service := range runtime.OrderedServices() {
+		for _, dependency := range service.Dependencies {
+			dependents[dependency] = append(dependents[dependency], service.Name)
+		}
+	}
+
+	var rules []NetworkRule
+	for _, service := range runtime.OrderedServices() {
+		from := dependents[service.Name]
+		sort.Strings(from)
+		rules = append(rules, NetworkRule{
+			Service: service.Name,
+			From:    from,
+			Ingress: runtime.Base.Defaults.Domain != "",
+		})
+	}
+	return rules
+}
+
+// networkPolicyManifests renders the default-deny policy and each service's
+// allow policy
+func (so *ServiceOrchestrator) networkPolicyManifests(runtime *config.RuntimeConfig) (string, error) {
+	documents := []map[string]any{
+		networkPolicy(defaultDenyPolicy, map[string]any{
+			"podSelector": map[string]any{},
+			"policyTypes": []string{"Ingress"},
+		}),
+	}
+
+	for _, rule := range NetworkRules(runtime) {
+		// A release's own pods may talk to each other (replicas, clusters)
+		instances := []string{so.getReleaseName(rule.Service, runtime)}
+		for _, name := range rule.From {
+			instances = append(instances, so.getReleaseName(name, runtime))
+		}
+
+		peers := []map[string]any{
+			{"podSelector": instanceSelector(instances)},
+		}
+		if rule.Ingress {
+			peers = append(peers, map[string]any{
+				"namespaceSelector": map[string]any{
+					"matchExpressions": []map[string]any{
+						{"key": "kubernetes.io/metadata.name", "operator": "In", "values": ingressNamespaces(runtime)},
+					},
+				},
+			})
+		}
+
+		documents = append(documents, networkPolicy("plat-allow-"+rule.Service, map[string]any{
+			"podSelector": instanceSelector(instances[:1]),
+			"policyTypes": []string{"Ingress"},
+			"ingress":     []map[string]any{{"from": peers}},
+		}))
+	}
+
+	var manifests []string
+	for _, document := range documents {
+		data, err := yaml.Marshal(document)
+		if err != nil {
+			return "", fmt.Errorf("failed to render network policies: %w", err)
+		}
+		manifests = append(manifests, string(data))
+	}
+	return strings.Join(manifests, "---\n"), nil
+}
+
+// networkPolicy builds a NetworkPolicy labelled as generated by plat
+func networkPolicy(name string, spec map[string]any) map[string]any {
+	return map[string]any{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "NetworkPolicy",
+		"metadata": map[string]any{
+			"name": name,
+			"labels": map[string]any{
+				managedByLabel:     managedByPlat,
+				networkPolicyLabel: "true",
+			},
+		},
+		"spec": spec,
+	}
+}
+
+// instanceSelector selects the pods of the given releases
+func instanceSelector(instances []string) map[string]any {
+	return map[string]any{
+		"matchExpressions": []map[string]any{
+			{"key": instanceLabel, "operator": "In", "values": instances},
+		},
+	}
+}
+
+// syncNetworkPolicies applies the generated NetworkPolicies when they are
+// enabled, and removes any an earlier run applied otherwise
+func (so *ServiceOrchestrator) syncNetworkPolicies(ctx context.Context, runtime *config.RuntimeConfig) error {
+	if runtime.NetworkPoliciesEnabled() {
+		return so.applyNetworkPolicies(ctx, runtime)
+	}
+	if err := so.removeNetworkPolicies(ctx, runtime); err != nil {
+		so.log.Warn("could not remove network policies", "error", err)
+	}
+	return nil
+}
+
+// applyNetworkPolicies applies the default-deny policy and the allow
+// policies of every service of the environment
+func (so *ServiceOrchestrator) applyNetworkPolicies(ctx context.Context, runtime *config.RuntimeConfig) error {
+	namespace := runtime.Base.Defaults.Namespace
+	manifests, err := so.networkPolicyManifests(runtime)
+	if err != nil {
+		return err
+	}
+
+	if err := tools.EnsureNamespace(ctx, namespace); err != nil {
+		return err
+	}
+
+	so.log.Info("applying network policies", "namespace", namespace, "services", len(runtime.Unfiltered().ResolvedServices))
+	if err := tools.ApplyManifests(ctx, manifests, namespace, networkPolicySelector()); err != nil {
+		return fmt.Errorf("failed to apply network policies: %w", err)
+	}
+	return nil
+}
+
+// removeNetworkPolicies deletes every NetworkPolicy plat generated in the
+// environment's namespace, if any
+func (so *ServiceOrchestrator) removeNetworkPolicies(ctx context.Context, runtime *config.RuntimeConfig) error {
+	namespace := runtime.Base.Defaults.Namespace
+	deleted, err := tools.DeleteNetworkPolicies(ctx, namespace, networkPolicySelector())
+	if err != nil {
+		return fmt.Errorf("failed to remove network policies: %w", err)
+	}
+	if len(deleted) > 0 {
+		so.log.Info("removed network policies", "namespace", namespace, "policies", len(deleted))
+	}
+	return nil
+}
+
+// NetworkPolicyManifests renders the NetworkPolicies 'plat up' applies when
+// defaults.networkPolicies is on
+func (o *Orchestrator) NetworkPolicyManifests(runtime *config.RuntimeConfig) (string, error) {
+	return o.serviceManager.networkPolicyManifests(runtime)
+}
+
+// ApplyNetworkPolicies applies the generated NetworkPolicies now, without
+// redeploying services
+func (o *Orchestrator) ApplyNetworkPolicies(ctx context.Context, runtime *config.RuntimeConfig) error {
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
+	}
+	return o.serviceManager.applyNetworkPolicies(ctx, runtime)
+}
+
+// RemoveNetworkPolicies deletes the generated NetworkPolicies, opening
+// traffic between all pods of the namespace again
+func (o *Orchestrator) RemoveNetworkPolicies(ctx context.Context, runtime *config.RuntimeConfig) error {
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
+	}
+	return o.serviceManager.removeNetworkPolicies(ctx, runtime)
+}
@@ -3,8 +3,17 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"plat/pkg/config"
+	"plat/pkg/images"
+	"plat/pkg/logging"
+	"plat/pkg/notify"
 	"plat/pkg/tools"
 )
 
@@ -13,104 +22,266 @@ type Orchestrator struct {
 	clusterManager *ClusterManager
 	serviceManager *ServiceOrchestrator
 	verbose        bool
+	log            *slog.Logger
+	events         *EventBus     // Shared with the cluster and service managers
+	quiet          bool          // Leave stdout to the caller rendering events
+	rolloutTimeout time.Duration // How long restarts wait for the rollout
+
+	// Services already reported as crashed, so repeated status
+	// refreshes don't re-send the same notification
+	crashes      CrashLedger
+	crashNotices bool           // Send notifications for newly crashed services
+	notifying    sync.WaitGroup // Notifications being delivered
+
+	// Restart counts of the previous status, to detect flapping services
+	restartsMu  sync.Mutex
+	restarts    map[string]int
+	restartedAt map[string]time.Time // When each service's restarts last grew
+
+	// Releases that fail the readiness check are rolled back when enabled
+	autoRollback  bool
+	rollbacksMu   sync.Mutex
+	rollbacks     []Rollback          // Made by this orchestrator
+	lastRollbacks map[string]Rollback // Reported by Status, by service
+
+	// Services an aborted run left mid-deploy, cleared by the next Up
+	interrupted []string
 }
 
 // NewOrchestrator creates a new orchestrator
 func NewOrchestrator(verbose bool) *Orchestrator {
+	events := NewEventBus()
+	clusterManager := NewClusterManager(verbose)
+	clusterManager.events = events
+	serviceManager := NewServiceOrchestrator(verbose)
+	serviceManager.events = events
+
 	return &Orchestrator{
-		clusterManager: NewClusterManager(verbose),
-		serviceManager: NewServiceOrchestrator(verbose),
+		clusterManager: clusterManager,
+		serviceManager: serviceManager,
 		verbose:        verbose,
+		log:            logging.Default(),
+		events:         events,
+		rolloutTimeout: DefaultRolloutTimeout,
+		crashes:        newMemoryCrashLedger(),
+		crashNotices:   true,
+		restarts:       make(map[string]int),
+		restartedAt:    make(map[string]time.Time),
 	}
 }
 
+// RegisterImageHook adds a hook run against service images before deploy
+func (o *Orchestrator) RegisterImageHook(hook images.Hook) {
+	o.serviceManager.imageHooks = append(o.serviceManager.imageHooks, hook)
+}
+
+// SetNoWait controls whether service installs wait for resources to become ready
+func (o *Orchestrator) SetNoWait(noWait bool) {
+	o.serviceManager.noWait = noWait
+}
+
+// SetIncludeProtected controls whether Down removes services marked protected.
+// Protected services are kept by default.
+func (o *Orchestrator) SetIncludeProtected(include bool) {
+	o.serviceManager.keepProtected = !include
+}
+
+// SetCrashNotifications controls whether Status notifies hooks about
+// crash-looping services. Background pollers disable it so every poll
+// doesn't re-send the same notification.
+func (o *Orchestrator) SetCrashNotifications(enabled bool) {
+	o.crashNotices = enabled
+}
+
+// SetCrashLedger sets where crash notifications are recorded, instead of
+// this orchestrator's memory, so every plat process of an environment
+// reports a crash once
+func (o *Orchestrator) SetCrashLedger(ledger CrashLedger) {
+	o.crashes = ledger
+}
+
+// WaitNotifications waits until notifications being delivered in the
+// background are sent. Commands call it before exiting.
+func (o *Orchestrator) WaitNotifications() {
+	o.notifying.Wait()
+}
+
+// SetLogger sets the logger the orchestrator reports to, instead of the
+// default one
+func (o *Orchestrator) SetLogger(logger *slog.Logger) {
+	o.log = logger
+	o.clusterManager.log = logger
+	o.serviceManager.log = logger
+}
+
+// Subscribe returns a channel receiving the events of operations started
+// from now on (cluster creation, service deploys, rollout progress, errors)
+// and a function ending the subscription
+func (o *Orchestrator) Subscribe() (<-chan Event, func()) {
+	return o.events.Subscribe()
+}
+
+// SetQuiet stops the orchestrator printing progress and access information
+// to stdout, for callers that own the terminal and render events themselves
+func (o *Orchestrator) SetQuiet(quiet bool) {
+	o.quiet = quiet
+}
+
+// SetRolloutTimeout bounds how long RestartService waits for the restarted
+// pods to roll out
+func (o *Orchestrator) SetRolloutTimeout(timeout time.Duration) {
+	o.rolloutTimeout = timeout
+}
+
+// SetPurgeData makes Down delete every service's persistent volume claims,
+// regardless of its dataRetention policy
+func (o *Orchestrator) SetPurgeData(purge bool) {
+	o.serviceManager.purgeData = purge
+}
+
 // Up brings up the entire environment (cluster + services)
 func (o *Orchestrator) Up(ctx context.Context, runtime *config.RuntimeConfig) error {
-	if o.verbose {
-		fmt.Printf("🚀 Starting environment: %s\n", runtime.Base.Name)
-	}
+	o.log.Info("starting environment", "environment", runtime.Base.Name)
 
 	// 1. Ensure cluster is running
 	if err := o.clusterManager.EnsureCluster(ctx, runtime); err != nil {
-		return fmt.Errorf("cluster setup failed: %w", err)
+		return fail(FailureCluster, fmt.Errorf("cluster setup failed: %w", err))
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
+	}
+
+	// 2. Install the ingress controller serving service hosts
+	if err := o.clusterManager.Bootstrap(ctx, runtime, o.serviceManager.helm(runtime)); err != nil {
+		return fail(FailureCluster, fmt.Errorf("cluster bootstrap failed: %w", err))
+	}
+
+	// 3. Clear Helm operations an aborted run left pending; they would
+	// block upgrading the releases
+	if len(o.interrupted) > 0 {
+		o.recoverReleases(ctx, runtime)
+	}
+
+	// 4. Isolate services like production, before their pods start
+	if err := o.serviceManager.syncNetworkPolicies(ctx, runtime); err != nil {
+		return fail(FailureDeploy, err)
 	}
 
-	// 2. Deploy services
+	// 5. Issue certificates for the ingress hosts the charts reference
+	if runtime.TLSEnabled() {
+		ca, err := o.serviceManager.applyTLSSecrets(ctx, runtime)
+		if err != nil {
+			return fail(FailureDeploy, err)
+		}
+		if !ca.Trusted() {
+			message := fmt.Sprintf("The local CA isn't trusted yet; run 'plat tls trust' so browsers accept https://*.%s", runtime.Base.Defaults.Domain)
+			o.report(Event{Type: EventProgress, Message: message}, "⚠️  "+message)
+		}
+	}
+
+	// 6. Deploy services; the error is tagged as a deploy or partial failure
 	if err := o.serviceManager.DeployServices(ctx, runtime); err != nil {
 		return fmt.Errorf("service deployment failed: %w", err)
 	}
 
-	// 3. Print access information
-	o.printEnvironmentInfo(runtime)
+	// 7. Wait for pods to become ready; installs finish while pods may still crash-loop
+	if !o.serviceManager.noWait {
+		if err := o.WaitForReadiness(ctx, runtime); err != nil {
+			return fmt.Errorf("readiness check failed: %w", err)
+		}
+	}
 
-	if o.verbose {
-		fmt.Printf("✅ Environment %s is ready!\n", runtime.Base.Name)
+	// 8. Print access information, unless the caller (the TUI) owns the
+	// terminal
+	if !o.quiet {
+		o.printEnvironmentInfo(runtime)
 	}
 
+	o.log.Info("environment is ready", "environment", runtime.Base.Name)
+
+	o.notify(runtime, notify.Event{
+		Type:    notify.EventUpFinished,
+		Message: fmt.Sprintf("Environment is ready with %d service(s)", len(runtime.ResolvedServices)),
+	})
+
 	return nil
 }
 
 // Down brings down the entire environment
 func (o *Orchestrator) Down(ctx context.Context, runtime *config.RuntimeConfig, deleteCluster bool) error {
-	if o.verbose {
-		fmt.Printf("🛑 Stopping environment: %s\n", runtime.Base.Name)
+	o.log.Info("stopping environment", "environment", runtime.Base.Name)
+
+	if err := o.checkClusterDeletion(runtime, deleteCluster); err != nil {
+		return err
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
 	}
 
 	// 1. Undeploy services first
 	if err := o.serviceManager.UndeployServices(ctx, runtime); err != nil {
-		fmt.Printf("⚠️  Service undeployment warnings: %v\n", err)
+		o.log.Warn("service undeployment had errors", "error", err)
 		// Continue to cluster deletion even if some services failed
 	}
 
 	// 2. Delete cluster if requested
 	if deleteCluster {
 		if err := o.clusterManager.DeleteCluster(ctx, runtime); err != nil {
-			return fmt.Errorf("cluster deletion failed: %w", err)
+			return fail(FailureCluster, fmt.Errorf("cluster deletion failed: %w", err))
 		}
-	} else if o.verbose {
-		fmt.Printf("🔄 Cluster kept running (use --cluster to delete)\n")
+	} else {
+		o.log.Info("cluster kept running, use --cluster to delete it")
 	}
 
-	if o.verbose {
-		fmt.Printf("✅ Environment %s stopped\n", runtime.Base.Name)
-	}
+	o.log.Info("environment stopped", "environment", runtime.Base.Name)
 
 	return nil
 }
 
+// checkClusterDeletion refuses to delete the cluster while it holds
+// protected services, since their data would go with it
+func (o *Orchestrator) checkClusterDeletion(runtime *config.RuntimeConfig, deleteCluster bool) error {
+	if deleteCluster && o.serviceManager.keepProtected {
+		if protected := runtime.ProtectedServices(); len(protected) > 0 {
+			return fmt.Errorf("deleting the cluster would remove protected services %s (use --include-protected to confirm)", strings.Join(protected, ", "))
+		}
+	}
+	return nil
+}
+
 // StartService starts a single service
 func (o *Orchestrator) StartService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
-	if o.verbose {
-		fmt.Printf("🚀 Starting service: %s\n", serviceName)
-	}
+	o.log.Info("starting service", "service", serviceName)
 
 	// Verify service exists
 	service, exists := runtime.ResolvedServices[serviceName]
 	if !exists {
-		return fmt.Errorf("service %s not found in configuration", serviceName)
+		return &config.UnknownServiceError{Name: serviceName}
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
 	}
 
 	// Deploy the service
 	if err := o.serviceManager.DeployService(ctx, service, runtime); err != nil {
-		return fmt.Errorf("failed to start service %s: %w", serviceName, err)
+		return fail(FailureDeploy, fmt.Errorf("failed to start service %s: %w", serviceName, err))
 	}
 
-	if o.verbose {
-		fmt.Printf("✅ Service %s started successfully\n", serviceName)
-	}
+	o.log.Info("service started", "service", serviceName)
 
 	return nil
 }
 
 // StopService stops a single service
 func (o *Orchestrator) StopService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
-	if o.verbose {
-		fmt.Printf("🛑 Stopping service: %s\n", serviceName)
-	}
+	o.log.Info("stopping service", "service", serviceName)
 
 	// Verify service exists
 	if _, exists := runtime.ResolvedServices[serviceName]; !exists {
-		return fmt.Errorf("service %s not found in configuration", serviceName)
+		return &config.UnknownServiceError{Name: serviceName}
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
 	}
 
 	// Undeploy the service
@@ -118,41 +289,7 @@ func (o *Orchestrator) StopService(ctx context.Context, runtime *config.RuntimeC
 		return fmt.Errorf("failed to stop service %s: %w", serviceName, err)
 	}
 
-	if o.verbose {
-		fmt.Printf("✅ Service %s stopped successfully\n", serviceName)
-	}
-
-	return nil
-}
-
-// RestartService restarts a single service
-func (o *Orchestrator) RestartService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
-	if o.verbose {
-		fmt.Printf("🔄 Restarting service: %s\n", serviceName)
-	}
-
-	// Verify service exists
-	service, exists := runtime.ResolvedServices[serviceName]
-	if !exists {
-		return fmt.Errorf("service %s not found in configuration", serviceName)
-	}
-
-	// Stop then start the service
-	if err := o.StopService(ctx, runtime, serviceName); err != nil {
-		// Continue with start even if stop failed (service might not be running)
-		if o.verbose {
-			fmt.Printf("⚠️  Stop failed (service may not be running): %v\n", err)
-		}
-	}
-
-	// Deploy the service
-	if err := o.serviceManager.DeployService(ctx, service, runtime); err != nil {
-		return fmt.Errorf("failed to restart service %s: %w", serviceName, err)
-	}
-
-	if o.verbose {
-		fmt.Printf("✅ Service %s restarted successfully\n", serviceName)
-	}
+	o.log.Info("service stopped", "service", serviceName)
 
 	return nil
 }
@@ -160,9 +297,10 @@ func (o *Orchestrator) RestartService(ctx context.Context, runtime *config.Runti
 // Status returns the current status of the environment
 func (o *Orchestrator) Status(ctx context.Context, runtime *config.RuntimeConfig) (*EnvironmentStatus, error) {
 	status := &EnvironmentStatus{
-		Name:     runtime.Base.Name,
-		Mode:     string(runtime.Mode),
-		Services: make(map[string]*ServiceStatus),
+		Name:         runtime.Base.Name,
+		Mode:         string(runtime.Mode),
+		Services:     make(map[string]*ServiceStatus),
+		ServiceOrder: runtime.ListServices(),
 	}
 
 	// Get cluster status
@@ -191,12 +329,16 @@ func (o *Orchestrator) Status(ctx context.Context, runtime *config.RuntimeConfig
 		helmStatus := serviceStatuses[serviceName]
 
 		serviceStatus := &ServiceStatus{
-			Name:    serviceName,
-			Status:  helmStatus.Status,
-			Version: service.Version,
-			IsLocal: service.IsLocal,
-			Chart:   service.Chart.FullName(),
-			Updated: helmStatus.Updated,
+			Name:        serviceName,
+			DisplayName: service.DisplayName,
+			DNSName:     runtime.ServiceDNSName(service),
+			Status:      helmStatus.Status,
+			Version:     service.Version,
+			IsLocal:     service.IsLocal,
+			Chart:       service.Chart.FullName(),
+			Updated:     helmStatus.Updated,
+			Revision:    helmStatus.Revision,
+			Rollback:    o.lastRollback(serviceName, helmStatus.Revision),
 		}
 
 		if service.IsLocal && service.LocalSource != nil {
@@ -218,24 +360,135 @@ func (o *Orchestrator) Status(ctx context.Context, runtime *config.RuntimeConfig
 					ContainerState: podStatus.ContainerState,
 					Reason:         podStatus.Reason,
 					Message:        podStatus.Message,
+					Replicas:       fmt.Sprintf("%d/%d", podStatus.ReadyPods, podStatus.Pods),
+					Restarts:       podStatus.Restarts,
+					Summary:        podStatus.Summary,
+					Pods:           podStatus.Details,
 				}
+				serviceStatus.Ready = podStatus.Ready
 			}
 		}
 
 		status.Services[serviceName] = serviceStatus
 	}
 
+	o.detectFlapping(status)
+
+	if o.crashNotices {
+		o.detectCrashes(runtime, status)
+	}
+
 	return status, nil
 }
 
-// ValidatePrerequisites checks that all required tools are available
-func (o *Orchestrator) ValidatePrerequisites(ctx context.Context) error {
-	if err := o.clusterManager.ValidatePrerequisites(ctx); err != nil {
-		return err
+// flappingWindow is how long a service stays flagged as flapping after its
+// restart count last grew, so frequent refreshes don't blink the flag
+const flappingWindow = 2 * time.Minute
+
+// detectFlapping flags services whose restart count grew since the previous
+// status, or within the flapping window, and remembers the counts for the
+// next one
+func (o *Orchestrator) detectFlapping(status *EnvironmentStatus) {
+	o.restartsMu.Lock()
+	defer o.restartsMu.Unlock()
+
+	now := time.Now()
+	for name, service := range status.Services {
+		if service.Deployment == nil {
+			delete(o.restarts, name)
+			delete(o.restartedAt, name)
+			continue
+		}
+		if previous, seen := o.restarts[name]; seen && service.Deployment.Restarts > previous {
+			o.restartedAt[name] = now
+		}
+		if restarted, ok := o.restartedAt[name]; ok && now.Sub(restarted) < flappingWindow {
+			service.Deployment.Flapping = true
+		}
+		o.restarts[name] = service.Deployment.Restarts
+	}
+}
+
+// RestartCounts returns the restart count of every deployed service as of
+// the latest status
+func (o *Orchestrator) RestartCounts() map[string]int {
+	o.restartsMu.Lock()
+	defer o.restartsMu.Unlock()
+
+	counts := make(map[string]int, len(o.restarts))
+	for name, restarts := range o.restarts {
+		counts[name] = restarts
+	}
+	return counts
+}
+
+// SetRestartCounts sets the restart counts the next status is compared with,
+// so short-lived processes can detect flapping across runs
+func (o *Orchestrator) SetRestartCounts(counts map[string]int) {
+	o.restartsMu.Lock()
+	defer o.restartsMu.Unlock()
+
+	o.restarts = make(map[string]int, len(counts))
+	for name, restarts := range counts {
+		o.restarts[name] = restarts
+	}
+}
+
+// detectCrashes fires a notification the first time a service is seen crash-looping
+func (o *Orchestrator) detectCrashes(runtime *config.RuntimeConfig, status *EnvironmentStatus) {
+	crashing := make(map[string]bool, len(status.Services))
+	for name, service := range status.Services {
+		crashing[name] = service.Deployment != nil && service.Deployment.Reason == "CrashLoopBackOff"
+	}
+
+	newlyCrashed, err := o.crashes.RecordCrashes(crashing)
+	if err != nil {
+		o.log.Info("failed to record crashed services", "error", err)
+		return
+	}
+
+	for _, name := range runtime.ListServices() {
+		if !slices.Contains(newlyCrashed, name) {
+			continue
+		}
+		service := status.Services[name]
+		o.notify(runtime, notify.Event{
+			Type:    notify.EventServiceCrashed,
+			Service: service.Name,
+			Message: fmt.Sprintf("Service is crash-looping (%s)", service.Deployment.PodsReady),
+		})
+	}
+}
+
+// notify delivers an event to the environment's notification hooks in the
+// background (best effort), so a slow hook never holds up the caller
+func (o *Orchestrator) notify(runtime *config.RuntimeConfig, event notify.Event) {
+	if len(runtime.Base.Notifications) == 0 {
+		return
+	}
+
+	event.Environment = runtime.Base.Name
+	notifier := notify.NewNotifier(runtime.Base.Notifications)
+
+	o.notifying.Add(1)
+	go func() {
+		defer o.notifying.Done()
+		// Not the caller's context, which ends when it returns
+		if err := notifier.Notify(context.Background(), event); err != nil {
+			o.log.Info("notification failed", "error", err)
+		}
+	}()
+}
+
+// ValidatePrerequisites checks that all tools the environment needs are
+// available
+func (o *Orchestrator) ValidatePrerequisites(ctx context.Context, runtime *config.RuntimeConfig) error {
+	if err := o.clusterManager.ValidatePrerequisites(ctx, runtime); err != nil {
+		return fail(FailurePrerequisite, err)
 	}
 
 	if err := o.serviceManager.ValidatePrerequisites(ctx); err != nil {
-		return err
+		return fail(FailurePrerequisite, err)
 	}
 
 	return nil
@@ -246,22 +499,14 @@ func (o *Orchestrator) printEnvironmentInfo(runtime *config.RuntimeConfig) {
 	fmt.Printf("\n🌐 Environment Access Information\n")
 	fmt.Printf("=================================\n")
 
-	domain := runtime.Base.Defaults.Domain
-
 	fmt.Printf("\nServices available at:\n")
-	for serviceName, service := range runtime.ResolvedServices {
-		if len(service.Ports) > 0 {
-			// Show primary port
-			port := service.Ports[0]
-			if domain != "" {
-				fmt.Printf("  • %s: http://%s.%s", serviceName, serviceName, domain)
-				if port != 80 {
-					fmt.Printf(":%d", port)
-				}
-				fmt.Printf("\n")
-			} else {
-				fmt.Printf("  • %s: http://localhost:%d\n", serviceName, port)
-			}
+	for _, service := range runtime.OrderedServices() {
+		if len(service.Ports) == 0 {
+			continue
+		}
+		// Show primary port
+		if url, ok := runtime.ServiceURL(service); ok {
+			fmt.Printf("  • %s: %s\n", service.Name, url)
 		}
 	}
 
@@ -269,15 +514,16 @@ func (o *Orchestrator) printEnvironmentInfo(runtime *config.RuntimeConfig) {
 	fmt.Printf("  • plat status     - Check environment health\n")
 	fmt.Printf("  • plat down       - Stop services\n")
 	fmt.Printf("  • plat logs <svc> - View service logs\n")
+	fmt.Printf("\nUse kubectl with: export KUBECONFIG=%s\n", runtime.KubeconfigPath())
 
 	if runtime.Mode == config.ModeLocal {
 		fmt.Printf("\n📝 Local Development:\n")
-		for serviceName, service := range runtime.ResolvedServices {
+		for _, service := range runtime.OrderedServices() {
 			if service.IsLocal && service.LocalSource != nil {
-				fmt.Printf("  • %s: %s\n", serviceName, service.LocalSource.GetPath())
+				fmt.Printf("  • %s: %s\n", service.Name, service.LocalSource.GetPath())
 			}
 		}
-		fmt.Printf("  Changes will be hot-reloaded automatically\n")
+		fmt.Printf("  Run 'plat dev' to rebuild and redeploy them as you save\n")
 	}
 
 	fmt.Println()
@@ -286,39 +532,78 @@ func (o *Orchestrator) printEnvironmentInfo(runtime *config.RuntimeConfig) {
 // Status types
 
 type EnvironmentStatus struct {
-	Name     string                    `json:"name"`
-	Mode     string                    `json:"mode"`
-	Cluster  *ClusterStatus            `json:"cluster"`
-	Services map[string]*ServiceStatus `json:"services"`
+	Name     string                    `json:"name" yaml:"name"`
+	Mode     string                    `json:"mode" yaml:"mode"`
+	Cluster  *ClusterStatus            `json:"cluster" yaml:"cluster"`
+	Services map[string]*ServiceStatus `json:"services" yaml:"services"`
+
+	// ServiceOrder lists service names in config order for display
+	ServiceOrder []string `json:"-" yaml:"-"`
+}
+
+// ServiceNames returns the service names in config order. Services missing
+// from ServiceOrder follow alphabetically.
+func (s *EnvironmentStatus) ServiceNames() []string {
+	names := make([]string, 0, len(s.Services))
+	seen := make(map[string]bool, len(s.Services))
+	for _, name := range s.ServiceOrder {
+		if _, exists := s.Services[name]; exists && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range s.Services {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
 }
 
 type ClusterStatus struct {
-	Name    string `json:"name,omitempty"`
-	Status  string `json:"status"`
-	Servers int    `json:"servers,omitempty"`
-	Agents  int    `json:"agents,omitempty"`
-	Error   string `json:"error,omitempty"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Status  string `json:"status" yaml:"status"`
+	Servers int    `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Agents  int    `json:"agents,omitempty" yaml:"agents,omitempty"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 type ServiceStatus struct {
-	Name      string `json:"name"`
-	Status    string `json:"status"` // Helm status: deployed, pending-install, pending-upgrade, failed
-	Version   string `json:"version"`
-	IsLocal   bool   `json:"is_local"`
-	LocalPath string `json:"local_path,omitempty"`
-	Chart     string `json:"chart,omitempty"`
-	Ports     []int  `json:"ports,omitempty"`
-	Updated   string `json:"updated,omitempty"`
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"display_name,omitempty" yaml:"display_name,omitempty"`
+	DNSName     string `json:"dns_name" yaml:"dns_name"` // In-cluster DNS name
+	Status      string `json:"status" yaml:"status"`     // Helm status: deployed, pending-install, pending-upgrade, failed
+	Version     string `json:"version" yaml:"version"`
+	Ready       bool   `json:"ready" yaml:"ready"` // Deployed with all pods ready
+	IsLocal     bool   `json:"is_local" yaml:"is_local"`
+	LocalPath   string `json:"local_path,omitempty" yaml:"local_path,omitempty"`
+	Chart       string `json:"chart,omitempty" yaml:"chart,omitempty"`
+	Ports       []int  `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Updated     string `json:"updated,omitempty" yaml:"updated,omitempty"`
+	Revision    int    `json:"revision,omitempty" yaml:"revision,omitempty"` // Helm release revision
+
+	// Rollback that produced the current revision, if any
+	Rollback *Rollback `json:"rollback,omitempty" yaml:"rollback,omitempty"`
 
 	// Deployment details from Kubernetes
-	Deployment *DeploymentStatus `json:"deployment,omitempty"`
+	Deployment *DeploymentStatus `json:"deployment,omitempty" yaml:"deployment,omitempty"`
 }
 
 type DeploymentStatus struct {
-	Phase          string `json:"phase"`            // Pod phase: Pending, Running, Succeeded, Failed, Unknown
-	Ready          bool   `json:"ready"`            // All containers ready
-	PodsReady      string `json:"pods_ready"`       // e.g., "1/1", "0/1"
-	ContainerState string `json:"container_state"`  // running, waiting, terminated
-	Reason         string `json:"reason,omitempty"` // Reason for current state (e.g., ContainerCreating, CrashLoopBackOff)
-	Message        string `json:"message,omitempty"`
+	Phase          string `json:"phase" yaml:"phase"`                       // Pod phase: Pending, Running, Succeeded, Failed, Unknown
+	Ready          bool   `json:"ready" yaml:"ready"`                       // All containers ready
+	PodsReady      string `json:"pods_ready" yaml:"pods_ready"`             // e.g., "1/1", "0/1"
+	ContainerState string `json:"container_state" yaml:"container_state"`   // running, waiting, terminated
+	Reason         string `json:"reason,omitempty" yaml:"reason,omitempty"` // Reason for current state (e.g., ContainerCreating, CrashLoopBackOff)
+	Message        string `json:"message,omitempty" yaml:"message,omitempty"`
+	Replicas       string `json:"replicas" yaml:"replicas"` // Ready pods of all pods, e.g. "2/3"
+	Restarts       int    `json:"restarts" yaml:"restarts"` // Container restarts across all pods
+	Flapping       bool   `json:"flapping" yaml:"flapping"` // Restarts increased since the previous status
+
+	// Phase, state and reason above are of the pod in the worst state
+	Summary string            `json:"summary,omitempty" yaml:"summary,omitempty"` // Pods by state, e.g. "2 ready, 1 CrashLoopBackOff"
+	Pods    []tools.PodDetail `json:"pods,omitempty" yaml:"pods,omitempty"`       // Worst state first
 }
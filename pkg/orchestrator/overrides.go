@@ -0,0 +1,93 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"plat/pkg/config"
+)
+
+// ValuesLayerEditor names the override layer set from the values editor,
+// merged on top of the configured layers
+const ValuesLayerEditor = "values editor"
+
+// ServiceValues returns the values a service is deployed with, without and
+// with the session's override layer
+func (o *Orchestrator) ServiceValues(runtime *config.RuntimeConfig, serviceName string) (base, overrides map[string]interface{}, err error) {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return nil, nil, &config.UnknownServiceError{Name: serviceName}
+	}
+	if service.Engine() != config.EngineHelm {
+		return nil, nil, fmt.Errorf("service %s is deployed from %s and has no chart values", serviceName, service.Engine())
+	}
+
+	base, err = o.serviceManager.valuesManager.ResolveValues(service, runtime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve values: %w", err)
+	}
+
+	return base, o.serviceManager.valueOverrides(serviceName), nil
+}
+
+// ApplyValueOverrides sets the override layer merged on top of a service's
+// resolved values and upgrades its release. Overrides last for the lifetime
+// of the orchestrator; they are not written to config. On failure the
+// previous overrides are restored.
+func (o *Orchestrator) ApplyValueOverrides(ctx context.Context, runtime *config.RuntimeConfig, serviceName string, overrides map[string]interface{}) error {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return &config.UnknownServiceError{Name: serviceName}
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
+	}
+
+	previous := o.serviceManager.valueOverrides(serviceName)
+	o.serviceManager.setValueOverrides(serviceName, overrides)
+
+	if err := o.serviceManager.DeployService(ctx, service, runtime); err != nil {
+		o.serviceManager.setValueOverrides(serviceName, previous)
+		return fmt.Errorf("failed to upgrade %s: %w", serviceName, err)
+	}
+
+	return nil
+}
+
+// valueOverrides returns the override layer of a service, or nil
+func (so *ServiceOrchestrator) valueOverrides(serviceName string) map[string]interface{} {
+	so.overridesMu.Lock()
+	defer so.overridesMu.Unlock()
+	return so.overrides[serviceName]
+}
+
+// setValueOverrides replaces the override layer of a service; an empty
+// layer removes it
+func (so *ServiceOrchestrator) setValueOverrides(serviceName string, overrides map[string]interface{}) {
+	so.overridesMu.Lock()
+	defer so.overridesMu.Unlock()
+
+	if len(overrides) == 0 {
+		delete(so.overrides, serviceName)
+		return
+	}
+	so.overrides[serviceName] = overrides
+}
+
+// ValueProvenance returns a service's resolved values with the layer each
+// key comes from, the session's override layer included
+func (o *Orchestrator) ValueProvenance(runtime *config.RuntimeConfig, serviceName string) ([]config.ValueProvenance, error) {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return nil, &config.UnknownServiceError{Name: serviceName}
+	}
+	if service.Engine() != config.EngineHelm {
+		return nil, fmt.Errorf("service %s is deployed from %s and has no chart values", serviceName, service.Engine())
+	}
+
+	var extra []config.ValuesLayer
+	if overrides := o.serviceManager.valueOverrides(serviceName); overrides != nil {
+		extra = append(extra, config.ValuesLayer{Name: ValuesLayerEditor, Values: overrides})
+	}
+	return o.serviceManager.valuesManager.GetValueProvenance(service, runtime, extra...)
+}
@@ -0,0 +1,328 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"plat/pkg/config"
+	"plat/pkg/registry"
+	"plat/pkg/tools"
+)
+
+// Plan is the ordered list of actions an operation would take. Stages run
+// one after another; the steps of a stage run concurrently.
+type Plan struct {
+	Stages []PlanStage `json:"stages"`
+}
+
+// PlanStage is a group of steps that run together
+type PlanStage struct {
+	Name  string     `json:"name"`
+	Steps []PlanStep `json:"steps"`
+}
+
+// PlanStep is one action of a plan
+type PlanStep struct {
+	Action string `json:"action"` // e.g. create-cluster, install, uninstall, delete-volumes
+	Target string `json:"target"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Actions of plan steps
+const (
+	ActionEnsureRegistry = "ensure-registry"
+	ActionCreateCluster  = "create-cluster"
+	ActionBuild          = "build"
+	ActionInstall        = "install"
+	ActionUpgrade        = "upgrade"
+	ActionApply          = "apply"
+	ActionWait           = "wait"
+	ActionUninstall      = "uninstall"
+	ActionDeleteVolumes  = "delete-volumes"
+	ActionKeep           = "keep"
+	ActionDeleteCluster  = "delete-cluster"
+	ActionKeepCluster    = "keep-cluster"
+	ActionDeleteManifest = "delete-manifests"
+)
+
+// Empty reports whether the plan changes nothing
+func (p *Plan) Empty() bool {
+	for _, stage := range p.Stages {
+		for _, step := range stage.Steps {
+			if step.Action != ActionKeep && step.Action != ActionKeepCluster {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// PlanUp returns what Up would do, in order, without changing anything: the
+// cluster it creates with its port mappings, then the services of each
+// dependency level. It needs no cluster, so configs can be reviewed anywhere;
+// when the cluster is running, deployed services are planned as upgrades.
+func (o *Orchestrator) PlanUp(ctx context.Context, runtime *config.RuntimeConfig) (*Plan, error) {
+	plan := &Plan{}
+	cm := o.clusterManager
+	clusterName := cm.getClusterName(runtime)
+
+	cluster := PlanStage{Name: "Cluster"}
+	if usesLocalRegistry(runtime) {
+		cluster.Steps = append(cluster.Steps, PlanStep{
+			Action: ActionEnsureRegistry,
+			Target: registry.ClusterAddress(),
+			Detail: "local builds are pushed to it",
+		})
+	}
+
+	running := false
+	if status, err := cm.GetClusterStatus(ctx, runtime); err == nil && status.Status == "running" {
+		running = true
+		cluster.Steps = append(cluster.Steps, PlanStep{
+			Action: ActionKeepCluster,
+			Target: clusterName,
+			Detail: "already running",
+		})
+	} else {
+		clusterConfig := cm.buildClusterConfig(runtime)
+		ports := make([]string, 0, len(clusterConfig.Ports))
+		for _, port := range clusterConfig.Ports {
+			ports = append(ports, strings.TrimSuffix(port, "@loadbalancer"))
+		}
+		provider := runtime.Base.Defaults.ClusterProvider
+		if provider == "" {
+			provider = tools.ClusterProviderK3d
+		}
+		cluster.Steps = append(cluster.Steps, PlanStep{
+			Action: ActionCreateCluster,
+			Target: clusterName,
+			Detail: fmt.Sprintf("%s, ports %s", provider, strings.Join(ports, ", ")),
+		})
+	}
+	if controller, ok := ingressControllers[runtime.Base.Defaults.IngressController]; ok {
+		step := PlanStep{
+			Action: ActionInstall,
+			Target: controller.release,
+			Detail: fmt.Sprintf("%s ingress controller", controller.name),
+		}
+		if running {
+			if status, err := o.serviceManager.helm(runtime).GetReleaseStatus(ctx, controller.release, controller.release); err == nil && status.Status == "deployed" {
+				step.Action = ActionKeep
+				step.Detail = fmt.Sprintf("%s ingress controller, %s", controller.name, status.Version)
+			}
+		}
+		cluster.Steps = append(cluster.Steps, step)
+	}
+	plan.Stages = append(plan.Stages, cluster)
+
+	if runtime.NetworkPoliciesEnabled() {
+		allowed := 0
+		for _, rule := range NetworkRules(runtime) {
+			allowed += len(rule.From)
+		}
+		plan.Stages = append(plan.Stages, PlanStage{Name: "Network policies", Steps: []PlanStep{{
+			Action: ActionApply,
+			Target: "network policies",
+			Detail: fmt.Sprintf("default deny, %d allowed dependency connection(s)", allowed),
+		}}})
+	}
+
+	if runtime.TLSEnabled() {
+		plan.Stages = append(plan.Stages, PlanStage{Name: "TLS certificates", Steps: []PlanStep{{
+			Action: ActionApply,
+			Target: "tls secrets",
+			Detail: fmt.Sprintf("certificates for %d ingress host(s) from the local CA", len(runtime.Unfiltered().ResolvedServices)),
+		}}})
+	}
+
+	stages, err := o.serviceManager.planDeploy(ctx, runtime, running)
+	if err != nil {
+		return nil, err
+	}
+	plan.Stages = append(plan.Stages, stages...)
+
+	return plan, nil
+}
+
+// planDeploy mirrors DeployServices: one stage per dependency level. Helm
+// releases are looked up only when the cluster is running.
+func (so *ServiceOrchestrator) planDeploy(ctx context.Context, runtime *config.RuntimeConfig, clusterRunning bool) ([]PlanStage, error) {
+	serviceLevels, err := so.groupServicesByDependencyLevel(runtime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve service dependencies: %w", err)
+	}
+
+	deployed := make(map[string]bool)
+	if clusterRunning {
+		if statuses, err := so.GetServiceStatuses(ctx, runtime); err == nil {
+			for name, status := range statuses {
+				deployed[name] = status.Status != "not-deployed"
+			}
+		}
+	}
+
+	stages := make([]PlanStage, 0, len(serviceLevels))
+	for i, level := range serviceLevels {
+		stage := PlanStage{Name: fmt.Sprintf("Deploy level %d", i)}
+
+		for _, serviceName := range level {
+			service := runtime.ResolvedServices[serviceName]
+
+			if service.IsLocal && service.LocalSource != nil {
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionBuild,
+					Target: serviceName,
+					Detail: fmt.Sprintf("from %s", service.LocalSource.GetPath()),
+				})
+			}
+
+			switch {
+			case service.AppliesManifests():
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionApply,
+					Target: serviceName,
+					Detail: fmt.Sprintf("%s with kubectl", service.Engine()),
+				})
+			case deployed[serviceName]:
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionUpgrade,
+					Target: serviceName,
+					Detail: fmt.Sprintf("helm chart %s", service.Chart.FullName()),
+				})
+			default:
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionInstall,
+					Target: serviceName,
+					Detail: fmt.Sprintf("helm chart %s", service.Chart.FullName()),
+				})
+			}
+		}
+
+		if !so.noWait {
+			stage.Steps = append(stage.Steps, PlanStep{
+				Action: ActionWait,
+				Target: strings.Join(level, ", "),
+				Detail: "until ready",
+			})
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
+}
+
+// PlanDown returns what Down would remove, in order, without changing
+// anything: the services of each dependency level from the last to the
+// first, the volumes deleted with them, and the cluster
+func (o *Orchestrator) PlanDown(ctx context.Context, runtime *config.RuntimeConfig, deleteCluster bool) (*Plan, error) {
+	if err := o.checkClusterDeletion(runtime, deleteCluster); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	clusterName := o.clusterManager.getClusterName(runtime)
+
+	// Without a cluster there is nothing to remove
+	if _, err := o.clusterManager.GetClusterStatus(ctx, runtime); err != nil {
+		return plan, nil
+	}
+
+	stages, err := o.serviceManager.planUndeploy(ctx, runtime)
+	if err != nil {
+		return nil, err
+	}
+	plan.Stages = append(plan.Stages, stages...)
+
+	cluster := PlanStage{Name: "Cluster"}
+	if deleteCluster {
+		cluster.Steps = append(cluster.Steps, PlanStep{
+			Action: ActionDeleteCluster,
+			Target: clusterName,
+			Detail: fmt.Sprintf("with namespace %s and everything left in it", runtime.Base.Defaults.Namespace),
+		})
+	} else {
+		cluster.Steps = append(cluster.Steps, PlanStep{
+			Action: ActionKeepCluster,
+			Target: clusterName,
+			Detail: "use --cluster to delete it",
+		})
+	}
+	plan.Stages = append(plan.Stages, cluster)
+
+	return plan, nil
+}
+
+// planUndeploy mirrors UndeployServices: one stage per dependency level, in
+// reverse order
+func (so *ServiceOrchestrator) planUndeploy(ctx context.Context, runtime *config.RuntimeConfig) ([]PlanStage, error) {
+	namespace := runtime.Base.Defaults.Namespace
+
+	releases, err := so.helm(runtime).ListReleases(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list helm releases: %w", err)
+	}
+	platReleases := so.filterPlatReleases(releases, runtime)
+
+	serviceLevels, err := so.groupServicesByDependencyLevel(runtime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve service dependencies: %w", err)
+	}
+
+	var stages []PlanStage
+	for i := len(serviceLevels) - 1; i >= 0; i-- {
+		stage := PlanStage{Name: fmt.Sprintf("Undeploy level %d", i)}
+
+		for _, serviceName := range serviceLevels[i] {
+			service := runtime.ResolvedServices[serviceName]
+
+			switch so.undeployAction(serviceName, platReleases, runtime) {
+			case undeploySkip:
+				continue
+			case undeployKeep:
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionKeep,
+					Target: serviceName,
+					Detail: "protected, use --include-protected to remove it",
+				})
+				continue
+			}
+
+			if service != nil && service.AppliesManifests() {
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionDeleteManifest,
+					Target: serviceName,
+					Detail: "resources applied with kubectl",
+				})
+			} else {
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionUninstall,
+					Target: serviceName,
+					Detail: fmt.Sprintf("helm release %s", so.getReleaseName(serviceName, runtime)),
+				})
+			}
+
+			if service == nil || (!so.purgeData && !service.DeletesData()) {
+				continue
+			}
+			claims, err := tools.ListPersistentVolumeClaims(ctx, so.getReleaseName(serviceName, runtime), namespace)
+			if err != nil {
+				return nil, err
+			}
+			if len(claims) > 0 {
+				stage.Steps = append(stage.Steps, PlanStep{
+					Action: ActionDeleteVolumes,
+					Target: serviceName,
+					Detail: strings.Join(claims, ", "),
+				})
+			}
+		}
+
+		if len(stage.Steps) > 0 {
+			stages = append(stages, stage)
+		}
+	}
+
+	return stages, nil
+}
@@ -0,0 +1,212 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+const (
+	// probeInterval is how often a failing readiness probe is retried
+	probeInterval = 2 * time.Second
+
+	// probeTimeout bounds a single probe attempt
+	probeTimeout = 10 * time.Second
+
+	// tcpGreetingWait is how long a TCP probe waits for the forwarded
+	// connection to be refused before treating it as accepted
+	tcpGreetingWait = time.Second
+)
+
+// waitForProbes blocks until the readiness probes of the given services pass.
+// Services without a probe are skipped.
+func (so *ServiceOrchestrator) waitForProbes(ctx context.Context, serviceNames []string, runtime *config.RuntimeConfig) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(serviceNames))
+	probed := 0
+
+	for i, name := range serviceNames {
+		service := runtime.ResolvedServices[name]
+		if service.Readiness == nil {
+			continue
+		}
+
+		probed++
+		wg.Add(1)
+		go func(i int, service *config.ResolvedService) {
+			defer wg.Done()
+			errs[i] = so.waitForProbe(ctx, service, runtime)
+		}(i, service)
+	}
+	wg.Wait()
+
+	failures := &ServiceErrors{Operation: "become ready", Total: probed}
+	for i, err := range errs {
+		if err != nil {
+			failures.Add(serviceNames[i], err)
+		}
+	}
+	return failures.Err()
+}
+
+// waitForProbe retries a service's readiness probe until it passes or the
+// service's ready timeout runs out
+func (so *ServiceOrchestrator) waitForProbe(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) error {
+	so.log.Info("waiting for readiness", "service", service.Name, "probe", service.Readiness)
+
+	ctx, cancel := context.WithTimeout(ctx, service.ReadyTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+
+	for {
+		err := so.runProbe(ctx, service, runtime)
+		if err == nil {
+			so.log.Info("service is ready", "service", service.Name)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return categorize(CategoryReadiness, fmt.Errorf("readiness probe (%s) did not pass within %s: %w", service.Readiness, service.ReadyTimeout, err))
+		case <-ticker.C:
+		}
+	}
+}
+
+// runProbe runs one attempt of a service's readiness probe against its
+// newest running pod
+func (so *ServiceOrchestrator) runProbe(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	namespace := runtime.Base.Defaults.Namespace
+	pod, err := newestRunningPod(ctx, namespace, so.getReleaseName(service.Name, runtime))
+	if err != nil {
+		return err
+	}
+
+	probe := service.Readiness
+	if len(probe.Exec) > 0 {
+		var output bytes.Buffer
+		err := tools.Exec(ctx, namespace, pod, probe.Exec, tools.ExecOptions{Stdout: &output, Stderr: &output})
+		if err != nil && output.Len() > 0 {
+			return fmt.Errorf("%w: %s", err, lastLine(output.String()))
+		}
+		return err
+	}
+
+	remotePort := probe.TCP
+	if probe.HTTP != "" {
+		remotePort = probe.Port
+	}
+	localPort, err := freeLocalPort()
+	if err != nil {
+		return err
+	}
+
+	// Probe through a port-forward so probes work without an Ingress
+	forwardErr := make(chan error, 1)
+	ready := make(chan struct{})
+	go func() {
+		forwardErr <- tools.PortForward(ctx, namespace, pod, []string{fmt.Sprintf("%d:%d", localPort, remotePort)}, ready)
+	}()
+	select {
+	case <-ready:
+	case err := <-forwardErr:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	address := fmt.Sprintf("127.0.0.1:%d", localPort)
+	if probe.HTTP != "" {
+		return probeHTTP(ctx, "http://"+address+probe.HTTP)
+	}
+	return probeTCP(address)
+}
+
+// probeHTTP checks that a URL answers with a status below 400
+func probeHTTP(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// probeTCP checks that a forwarded port accepts connections. The local end
+// of a port-forward always accepts; when nothing listens in the pod, the
+// forward closes the connection right away. A connection that stays open
+// (servers waiting for the client to speak first) or that sends a greeting
+// is accepted.
+func probeTCP(address string) error {
+	conn, err := net.DialTimeout("tcp", address, probeTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(tcpGreetingWait))
+	_, err = conn.Read(make([]byte, 1))
+	var netErr net.Error
+	if err == nil || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return nil
+	}
+	if err == io.EOF {
+		return fmt.Errorf("connection refused in pod")
+	}
+	return err
+}
+
+// newestRunningPod returns the newest running pod of a release
+func newestRunningPod(ctx context.Context, namespace, releaseName string) (string, error) {
+	pods, err := tools.ListPods(ctx, namespace, fmt.Sprintf("app.kubernetes.io/instance=%s", releaseName))
+	if err != nil {
+		return "", err
+	}
+
+	// ListPods is oldest first
+	for i := len(pods) - 1; i >= 0; i-- {
+		if pods[i].Phase == "Running" {
+			return pods[i].Name, nil
+		}
+	}
+	return "", fmt.Errorf("no running pods")
+}
+
+// freeLocalPort finds a local port that is not in use
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// lastLine returns the last non-empty line of command output
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return lines[len(lines)-1]
+}
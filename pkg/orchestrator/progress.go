@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"plat/pkg/config"
+)
+
+// DeployPhase is a step a service goes through while Up deploys it
+type DeployPhase string
+
+// Deploy phases
+const (
+	PhaseDeploying DeployPhase = "deploying" // Helm install or upgrade, or kubectl apply, running
+	PhaseDeployed  DeployPhase = "deployed"
+	PhaseFailed    DeployPhase = "failed"
+)
+
+// SetPhaseRecorder sets a function called as each service enters a deploy
+// phase. It is called synchronously, concurrently for services of the same
+// level, before the phase's work starts, so what it records survives the
+// process dying in the middle of it.
+func (o *Orchestrator) SetPhaseRecorder(record func(serviceName string, phase DeployPhase)) {
+	o.serviceManager.onPhase = record
+}
+
+// SetInterruptedServices names services an aborted run left mid-deploy. Up
+// clears the Helm operations they left pending before deploying again.
+func (o *Orchestrator) SetInterruptedServices(names []string) {
+	o.interrupted = names
+}
+
+// recordPhase passes a phase transition to the phase recorder, if any
+func (so *ServiceOrchestrator) recordPhase(serviceName string, phase DeployPhase) {
+	if so.onPhase != nil {
+		so.onPhase(serviceName, phase)
+	}
+}
+
+// recoverReleases clears the pending Helm operations of the interrupted
+// services: a pending install is uninstalled so the service installs
+// afresh, and a pending upgrade or rollback is rolled back to the revision
+// before it. Releases in any other state are left alone.
+func (o *Orchestrator) recoverReleases(ctx context.Context, runtime *config.RuntimeConfig) {
+	helm := o.serviceManager.helm(runtime)
+	namespace := runtime.Base.Defaults.Namespace
+
+	for _, name := range o.interrupted {
+		service, exists := runtime.Unfiltered().ResolvedServices[name]
+		if !exists || service.AppliesManifests() {
+			continue
+		}
+		releaseName := o.serviceManager.getReleaseName(name, runtime)
+		status, err := helm.GetReleaseStatus(ctx, releaseName, namespace)
+		if err != nil {
+			continue // Never installed, or the cluster can't tell
+		}
+
+		var action string
+		switch status.Status {
+		case "pending-install":
+			action = "uninstalled the release"
+			err = helm.UninstallChart(ctx, releaseName, namespace)
+		case "pending-upgrade", "pending-rollback":
+			action = "rolled back to the previous revision"
+			err = helm.RollbackRelease(ctx, releaseName, namespace, 0)
+		default:
+			continue
+		}
+
+		if err != nil {
+			o.log.Warn("failed to clear stuck release", "service", name, "status", status.Status, "error", err)
+			o.report(Event{Type: EventProgress, Service: name, Message: fmt.Sprintf("Couldn't clear %s's %s: %v", name, status.Status, err)},
+				fmt.Sprintf("⚠️  Couldn't clear %s's %s left by the aborted run: %v", name, status.Status, err))
+			continue
+		}
+		o.report(Event{Type: EventProgress, Service: name, Message: fmt.Sprintf("Cleared %s's %s", name, status.Status)},
+			fmt.Sprintf("🧹 Cleared %s's %s left by the aborted run (%s)", name, status.Status, action))
+	}
+	o.interrupted = nil
+}
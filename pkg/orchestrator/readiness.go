@@ -0,0 +1,217 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+const (
+	// readinessPollInterval is how often the readiness phase checks pods
+	readinessPollInterval = 2 * time.Second
+
+	// noPodsGrace is how long a service may have no pods before the
+	// readiness phase assumes it runs none (e.g. a chart of only config)
+	noPodsGrace = 30 * time.Second
+
+	// readinessEventLimit is how many recent warning events are reported
+	// for a service that failed to become ready
+	readinessEventLimit = 5
+)
+
+// failingReasons are container states that waiting won't fix, so the
+// readiness phase fails on them instead of running out the timeout
+var failingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// ReadinessResult is the outcome of waiting for one service's pods
+type ReadinessResult struct {
+	Service string
+	Ready   bool
+	Elapsed time.Duration
+	Reason  string            // Why the service is not ready
+	Events  []tools.EventInfo // Recent warning events of its pods, when not ready
+}
+
+// WaitForReadiness polls the pods of every service until they are all ready,
+// each within its readyTimeout. Services whose pods crash-loop or can't pull
+// their image fail immediately. The error lists each failed service with its
+// recent pod events, and is a partial failure when some services did become
+// ready. With auto rollback on, failed services are rolled back to
+// their last successful revision before it returns.
+func (o *Orchestrator) WaitForReadiness(ctx context.Context, runtime *config.RuntimeConfig) error {
+	services := runtime.OrderedServices()
+	results := make([]ReadinessResult, len(services))
+
+	var wg sync.WaitGroup
+	for i, service := range services {
+		wg.Add(1)
+		go func(i int, service *config.ResolvedService) {
+			defer wg.Done()
+			results[i] = o.waitForService(ctx, runtime, service)
+
+			result := results[i]
+			if result.Ready {
+				o.report(Event{Type: EventPodsReady, Service: result.Service},
+					fmt.Sprintf("✅ %s ready (%s)", result.Service, result.Elapsed.Round(time.Second)))
+			} else {
+				o.report(Event{Type: EventError, Service: result.Service, Err: errors.New(result.Reason)},
+					fmt.Sprintf("❌ %s not ready: %s", result.Service, result.Reason))
+			}
+		}(i, service)
+	}
+	wg.Wait()
+
+	var rollbacks map[string]string
+	if o.autoRollback {
+		rollbacks = o.rollbackUnready(ctx, runtime, results)
+	}
+
+	var failures []string
+	for _, result := range results {
+		if result.Ready {
+			continue
+		}
+		failure := fmt.Sprintf("%s: %s", result.Service, result.Reason)
+		if outcome, ok := rollbacks[result.Service]; ok {
+			failure += fmt.Sprintf(" (%s)", outcome)
+		}
+		for _, event := range result.Events {
+			failure += fmt.Sprintf("\n    %s %s %s: %s", event.LastSeen.Format("15:04:05"), event.Reason, event.Object, event.Message)
+		}
+		failures = append(failures, failure)
+	}
+	if len(failures) > 0 {
+		kind := FailureDeploy
+		if len(failures) < len(results) {
+			kind = FailurePartial
+		}
+		return fail(kind, fmt.Errorf("%d service(s) did not become ready:\n  %s", len(failures), strings.Join(failures, "\n  ")))
+	}
+	return nil
+}
+
+// waitForService polls one service's pods until they are ready, fail, or the
+// service's ready timeout passes
+func (o *Orchestrator) waitForService(ctx context.Context, runtime *config.RuntimeConfig, service *config.ResolvedService) ReadinessResult {
+	namespace := runtime.Base.Defaults.Namespace
+	releaseName := o.serviceManager.getReleaseName(service.Name, runtime)
+	result := ReadinessResult{Service: service.Name}
+
+	ctx, cancel := context.WithTimeout(ctx, service.ReadyTimeout)
+	defer cancel()
+
+	started := time.Now()
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+
+	var podsReady, lastReason string
+	for {
+		status, err := tools.GetPodStatus(ctx, releaseName, namespace)
+		result.Elapsed = time.Since(started)
+		if err == nil {
+			podsReady = status.PodsReady
+		}
+
+		switch {
+		case err != nil:
+			result.Reason = err.Error()
+		case status.Ready:
+			result.Ready = true
+			return result
+		case status.Reason == "NoPods" && result.Elapsed >= noPodsGrace:
+			// Nothing to wait for; charts of only config or jobs have no pods
+			result.Ready = true
+			return result
+		case failingReasons[status.Reason] || status.Phase == "Failed":
+			result.Reason = describePodStatus(status)
+			result.Events = o.podWarnings(releaseName, namespace)
+			return result
+		default:
+			result.Reason = describePodStatus(status)
+		}
+
+		// Publish what the pods wait on whenever it changes
+		if result.Reason != lastReason {
+			lastReason = result.Reason
+			o.events.Publish(Event{Type: EventProgress, Service: service.Name, Pods: podsReady, Message: result.Reason})
+		}
+
+		select {
+		case <-ctx.Done():
+			result.Reason = fmt.Sprintf("timed out after %s (%s)", service.ReadyTimeout, result.Reason)
+			result.Events = o.podWarnings(releaseName, namespace)
+			return result
+		case <-ticker.C:
+		}
+	}
+}
+
+// podWarnings returns the most recent warning events of a release's pods
+func (o *Orchestrator) podWarnings(releaseName, namespace string) []tools.EventInfo {
+	// The wait context may already be done; events are worth a short call of their own
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pods, err := tools.ListPods(ctx, namespace, fmt.Sprintf("app.kubernetes.io/instance=%s", releaseName))
+	if err != nil {
+		return nil
+	}
+	objects := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		objects["pod/"+pod.Name] = true
+	}
+
+	events, err := tools.ListEvents(ctx, namespace)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []tools.EventInfo
+	for _, event := range events {
+		if event.Type == "Warning" && objects[event.Object] {
+			warnings = append(warnings, event)
+		}
+	}
+	if len(warnings) > readinessEventLimit {
+		warnings = warnings[len(warnings)-readinessEventLimit:]
+	}
+	return warnings
+}
+
+// describePodStatus summarises why pods are not ready
+func describePodStatus(status *tools.PodStatus) string {
+	description := fmt.Sprintf("%s pods ready", status.PodsReady)
+	if status.Pods > 1 {
+		description += fmt.Sprintf(" (%s)", status.Summary)
+	}
+	if status.Reason != "" {
+		description += ", " + status.Reason
+	}
+	if status.Message != "" {
+		description += ": " + status.Message
+	}
+	return description
+}
+
+// report publishes a phase result, and prints it unless the orchestrator is
+// quiet
+func (o *Orchestrator) report(event Event, message string) {
+	o.events.Publish(event)
+	if !o.quiet {
+		fmt.Println(message)
+	}
+}
@@ -0,0 +1,45 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"plat/pkg/config"
+)
+
+// ReloadService rebuilds a local service's image, imports it into the
+// cluster and rolls the service's deployment onto it. Services on the
+// microservice chart roll by upgrading the release to the new image tag;
+// others are given a rollout restart to pick up the re-imported "dev" tag.
+// It reports false when the rebuilt image is identical to the last one, in
+// which case nothing is rolled.
+func (o *Orchestrator) ReloadService(ctx context.Context, runtime *config.RuntimeConfig, service *config.ResolvedService) (bool, error) {
+	if !service.IsLocal || service.LocalSource == nil {
+		return false, fmt.Errorf("service %s has no local source", service.Name)
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return false, err
+	}
+
+	previous := o.serviceManager.localTag(service.Name)
+
+	if service.IsMicroserviceChart() {
+		if err := o.serviceManager.DeployService(ctx, service, runtime); err != nil {
+			return false, fmt.Errorf("failed to reload %s: %w", service.Name, err)
+		}
+		return o.serviceManager.localTag(service.Name) != previous, nil
+	}
+
+	built, err := o.serviceManager.buildLocalImage(ctx, service, runtime)
+	if err != nil {
+		return false, fmt.Errorf("failed to reload %s: %w", service.Name, err)
+	}
+	if built.LocalTag == previous {
+		return false, nil
+	}
+
+	if err := o.RestartService(ctx, runtime, service.Name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
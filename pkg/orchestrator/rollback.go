@@ -0,0 +1,160 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"plat/pkg/config"
+)
+
+// Rollback records a service's Helm release rolled back to an earlier
+// revision
+type Rollback struct {
+	Service   string    `json:"service" yaml:"service"`
+	From      int       `json:"from" yaml:"from"`         // Revision rolled back
+	To        int       `json:"to" yaml:"to"`             // Revision restored
+	Revision  int       `json:"revision" yaml:"revision"` // Revision the rollback created
+	Reason    string    `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Automatic bool      `json:"automatic" yaml:"automatic"` // Rolled back after failing the readiness check
+	Time      time.Time `json:"time" yaml:"time"`
+}
+
+// SetAutoRollback makes Up roll back the releases of services that fail the
+// readiness check to their last successful revision
+func (o *Orchestrator) SetAutoRollback(enabled bool) {
+	o.autoRollback = enabled
+}
+
+// Rollbacks returns the rollbacks made by this orchestrator
+func (o *Orchestrator) Rollbacks() []Rollback {
+	o.rollbacksMu.Lock()
+	defer o.rollbacksMu.Unlock()
+	return append([]Rollback(nil), o.rollbacks...)
+}
+
+// SetRollbacks sets the rollbacks Status reports, by service. A rollback is
+// reported while the release is still at the revision it created.
+func (o *Orchestrator) SetRollbacks(rollbacks map[string]Rollback) {
+	o.rollbacksMu.Lock()
+	defer o.rollbacksMu.Unlock()
+
+	o.lastRollbacks = make(map[string]Rollback, len(rollbacks))
+	for name, rollback := range rollbacks {
+		o.lastRollbacks[name] = rollback
+	}
+}
+
+// lastRollback returns the rollback that created a service's current
+// revision, if any
+func (o *Orchestrator) lastRollback(serviceName string, revision int) *Rollback {
+	o.rollbacksMu.Lock()
+	defer o.rollbacksMu.Unlock()
+
+	rollback, ok := o.lastRollbacks[serviceName]
+	if !ok || revision == 0 || rollback.Revision != revision {
+		return nil
+	}
+	return &rollback
+}
+
+// RollbackService rolls a service's Helm release back to revision, or to the
+// last revision that deployed successfully before the current one when
+// revision is 0. The reason is reported by Status.
+func (o *Orchestrator) RollbackService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string, revision int, reason string) (*Rollback, error) {
+	return o.rollbackService(ctx, runtime, serviceName, revision, reason, false)
+}
+
+// rollbackService rolls a service back and records the rollback
+func (o *Orchestrator) rollbackService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string, revision int, reason string, automatic bool) (*Rollback, error) {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return nil, &config.UnknownServiceError{Name: serviceName}
+	}
+	if service.AppliesManifests() {
+		return nil, fmt.Errorf("%s is deployed with kubectl; only Helm releases can be rolled back", serviceName)
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return nil, err
+	}
+
+	helm := o.serviceManager.helm(runtime)
+	releaseName := o.serviceManager.getReleaseName(serviceName, runtime)
+	namespace := runtime.Base.Defaults.Namespace
+
+	history, err := helm.GetReleaseHistory(ctx, releaseName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("release %s has no revisions", releaseName)
+	}
+	current := history[len(history)-1].Revision
+
+	target := 0
+	for _, rev := range history[:len(history)-1] {
+		if revision > 0 && rev.Revision == revision {
+			target = revision
+			break
+		}
+		// Superseded revisions deployed successfully before being replaced
+		if revision == 0 && (rev.Status == "superseded" || rev.Status == "deployed") {
+			target = rev.Revision
+		}
+	}
+	switch {
+	case revision > 0 && revision == current:
+		return nil, fmt.Errorf("%s is already at revision %d", serviceName, revision)
+	case revision > 0 && target == 0:
+		return nil, fmt.Errorf("%s has no revision %d; see 'plat describe %s' for its history", serviceName, revision, serviceName)
+	case target == 0:
+		return nil, fmt.Errorf("%s has no earlier successful revision to roll back to", serviceName)
+	}
+
+	o.log.Info("rolling back service", "service", serviceName, "from", current, "to", target, "reason", reason)
+	if err := helm.RollbackRelease(ctx, releaseName, namespace, target); err != nil {
+		return nil, categorize(CategoryHelm, err)
+	}
+
+	rollback := &Rollback{
+		Service:   serviceName,
+		From:      current,
+		To:        target,
+		Reason:    reason,
+		Automatic: automatic,
+		Time:      time.Now(),
+	}
+	// Helm records the rollback as a new revision
+	if status, err := helm.GetReleaseStatus(ctx, releaseName, namespace); err == nil {
+		rollback.Revision = status.Revision
+	}
+
+	o.rollbacksMu.Lock()
+	o.rollbacks = append(o.rollbacks, *rollback)
+	o.rollbacksMu.Unlock()
+
+	return rollback, nil
+}
+
+// rollbackUnready rolls back each service that failed the readiness check,
+// returning what happened to each for the failure report
+func (o *Orchestrator) rollbackUnready(ctx context.Context, runtime *config.RuntimeConfig, results []ReadinessResult) map[string]string {
+	outcomes := make(map[string]string)
+	for _, result := range results {
+		if result.Ready || runtime.ResolvedServices[result.Service].AppliesManifests() {
+			continue
+		}
+
+		rollback, err := o.rollbackService(ctx, runtime, result.Service, 0, result.Reason, true)
+		if err != nil {
+			o.log.Warn("rollback failed", "service", result.Service, "error", err)
+			outcomes[result.Service] = fmt.Sprintf("not rolled back: %v", err)
+			continue
+		}
+
+		o.report(Event{Type: EventRolledBack, Service: result.Service, Message: fmt.Sprintf("%s rolled back to revision %d", result.Service, rollback.To)},
+			fmt.Sprintf("⏪ %s rolled back from revision %d to %d", result.Service, rollback.From, rollback.To))
+		outcomes[result.Service] = fmt.Sprintf("rolled back to revision %d", rollback.To)
+	}
+	return outcomes
+}
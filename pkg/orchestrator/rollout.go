@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// DefaultRolloutTimeout bounds how long a restart waits for pods to roll over
+const DefaultRolloutTimeout = 5 * time.Minute
+
+// RestartService performs a rolling restart of a single service, keeping the
+// old pods serving until their replacements are ready. A service that is not
+// deployed yet is installed instead.
+func (o *Orchestrator) RestartService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
+	o.log.Info("restarting service", "service", serviceName)
+
+	// Verify service exists
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return &config.UnknownServiceError{Name: serviceName}
+	}
+	if err := CheckClusterTarget(ctx, runtime); err != nil {
+		return err
+	}
+
+	namespace := runtime.Base.Defaults.Namespace
+
+	// Release name is simply the service name
+	workloads, err := tools.ListWorkloads(ctx, serviceName, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to restart service %s: %w", serviceName, err)
+	}
+
+	if len(workloads) == 0 {
+		o.log.Info("service has no running workloads, deploying it", "service", serviceName)
+		if err := o.serviceManager.DeployService(ctx, service, runtime); err != nil {
+			return fail(FailureDeploy, fmt.Errorf("failed to restart service %s: %w", serviceName, err))
+		}
+		return nil
+	}
+
+	for _, workload := range workloads {
+		if err := tools.RolloutRestart(ctx, workload, namespace); err != nil {
+			return fmt.Errorf("failed to restart service %s: %w", serviceName, err)
+		}
+	}
+
+	onProgress := func(message string) {
+		o.publishProgress(serviceName, message)
+	}
+	if err := o.RolloutStatus(ctx, runtime, serviceName, o.rolloutTimeout, onProgress); err != nil {
+		return fmt.Errorf("failed to restart service %s: %w", serviceName, err)
+	}
+
+	o.log.Info("service restarted", "service", serviceName)
+
+	return nil
+}
+
+// RolloutStatus waits until every workload of a service has finished rolling
+// out, reporting kubectl's progress messages to onProgress
+func (o *Orchestrator) RolloutStatus(ctx context.Context, runtime *config.RuntimeConfig, serviceName string, timeout time.Duration, onProgress func(string)) error {
+	if _, exists := runtime.ResolvedServices[serviceName]; !exists {
+		return &config.UnknownServiceError{Name: serviceName}
+	}
+
+	namespace := runtime.Base.Defaults.Namespace
+
+	workloads, err := tools.ListWorkloads(ctx, serviceName, namespace)
+	if err != nil {
+		return err
+	}
+	if len(workloads) == 0 {
+		return fmt.Errorf("service %s has no deployed workloads", serviceName)
+	}
+
+	for _, workload := range workloads {
+		if err := tools.RolloutStatus(ctx, workload, namespace, timeout, onProgress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishProgress publishes rollout progress as an event and logs it
+func (o *Orchestrator) publishProgress(serviceName, message string) {
+	o.events.Publish(Event{Type: EventProgress, Service: serviceName, Message: message})
+	o.log.Info(message, "service", serviceName)
+}
@@ -0,0 +1,171 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrorCategory says which step of a service operation failed, so failures
+// of many services can be grouped by cause
+type ErrorCategory string
+
+const (
+	CategoryBuild     ErrorCategory = "build"     // Building the local image
+	CategoryImage     ErrorCategory = "image"     // Image policy, import or pull
+	CategoryValues    ErrorCategory = "values"    // Resolving values or the chart
+	CategoryHelm      ErrorCategory = "helm"      // Installing or removing the release
+	CategoryManifests ErrorCategory = "manifests" // Applying or deleting raw manifests
+	CategoryReadiness ErrorCategory = "readiness" // The readiness probe never passed
+	CategoryTimeout   ErrorCategory = "timeout"   // A deadline ran out
+	CategoryCanceled  ErrorCategory = "canceled"  // The operation was interrupted
+	CategoryOther     ErrorCategory = "other"
+)
+
+// maxNamedServices bounds how many failed services a ServiceErrors message
+// names; the rest are counted
+const maxNamedServices = 5
+
+// categoryError tags an error with its category. Its message is the wrapped
+// error's, like Failure's.
+type categoryError struct {
+	category ErrorCategory
+	err      error
+}
+
+func (e *categoryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categoryError) Unwrap() error {
+	return e.err
+}
+
+// categorize tags an error with a category; nil stays nil
+func categorize(category ErrorCategory, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &categoryError{category: category, err: err}
+}
+
+// ErrorCategoryOf returns the category of an error. An interrupted
+// operation is canceled whatever step it was in; a deadline is a timeout
+// unless the step that ran out of time tagged its error.
+func ErrorCategoryOf(err error) ErrorCategory {
+	if errors.Is(err, context.Canceled) {
+		return CategoryCanceled
+	}
+
+	var categorized *categoryError
+	switch {
+	case errors.As(err, &categorized):
+		return categorized.category
+	case errors.Is(err, context.DeadlineExceeded):
+		return CategoryTimeout
+	}
+	return CategoryOther
+}
+
+// ServiceError is the failure of one service in an operation on several
+type ServiceError struct {
+	Service  string
+	Category ErrorCategory
+	Err      error
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Service, e.Err)
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+// ServiceErrors collects the failures of an operation on several services.
+// Its message is a single line naming the failed services, so it stays short
+// however many fail; the CLI and TUI render the individual errors from
+// Groups. errors.Is and errors.As see every service's error.
+type ServiceErrors struct {
+	Operation string // What the services failed to do, e.g. "deploy"
+	Total     int    // Services the operation covered
+	Errors    []*ServiceError
+}
+
+// Add records a service's failure, categorized by ErrorCategoryOf
+func (e *ServiceErrors) Add(service string, err error) {
+	e.Errors = append(e.Errors, &ServiceError{Service: service, Category: ErrorCategoryOf(err), Err: err})
+}
+
+// Err returns e, or nil when no service failed
+func (e *ServiceErrors) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ServiceErrors) Error() string {
+	names := e.Services()
+	if len(names) > maxNamedServices {
+		names = append(names[:maxNamedServices:maxNamedServices], fmt.Sprintf("and %d more", len(e.Errors)-maxNamedServices))
+	}
+
+	failed := fmt.Sprintf("%d services", len(e.Errors))
+	if e.Total > 0 {
+		failed = fmt.Sprintf("%d of %d services", len(e.Errors), e.Total)
+	}
+	return fmt.Sprintf("%s failed to %s: %s", failed, e.Operation, strings.Join(names, ", "))
+}
+
+func (e *ServiceErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Services returns the names of the failed services, sorted
+func (e *ServiceErrors) Services() []string {
+	names := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		names[i] = err.Service
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ErrorGroup is the failures of one category
+type ErrorGroup struct {
+	Category ErrorCategory
+	Errors   []*ServiceError // Sorted by service
+}
+
+// Groups returns the failures by category, the largest group first
+func (e *ServiceErrors) Groups() []ErrorGroup {
+	byCategory := make(map[ErrorCategory][]*ServiceError)
+	var categories []ErrorCategory
+	for _, err := range e.Errors {
+		if _, ok := byCategory[err.Category]; !ok {
+			categories = append(categories, err.Category)
+		}
+		byCategory[err.Category] = append(byCategory[err.Category], err)
+	}
+
+	groups := make([]ErrorGroup, 0, len(categories))
+	for _, category := range categories {
+		errs := byCategory[category]
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Service < errs[j].Service })
+		groups = append(groups, ErrorGroup{Category: category, Errors: errs})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].Errors) != len(groups[j].Errors) {
+			return len(groups[i].Errors) > len(groups[j].Errors)
+		}
+		return groups[i].Category < groups[j].Category
+	})
+	return groups
+}
@@ -2,70 +2,126 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"plat/pkg/build"
 	"plat/pkg/config"
+	"plat/pkg/images"
+	"plat/pkg/logging"
 	"plat/pkg/tools"
 )
 
 // ServiceOrchestrator manages service deployment and lifecycle
 type ServiceOrchestrator struct {
 	helmProvider  tools.HelmProvider
+	helmSDK       tools.HelmProvider // Used when defaults.helmDriver is "sdk"
 	valuesManager *config.ValuesManager
 	verbose       bool
+	log           *slog.Logger
+	events        *EventBus
+	noWait        bool // Skip helm --wait so installs return immediately
+	keepProtected bool // Leave protected services deployed on UndeployServices
+	purgeData     bool // Delete every service's PVCs on UndeployServices
+
+	// onDeployed is called (concurrently) with each service's deploy duration
+	onDeployed func(serviceName string, elapsed time.Duration)
+
+	// onPhase is called (concurrently) as each service enters a deploy phase
+	onPhase func(serviceName string, phase DeployPhase)
+
+	// Additional image hooks registered by integrations, run after the
+	// built-in hooks enabled by the config's imagePolicy
+	imageHooks []images.Hook
+
+	// Digests resolved by image hooks, keyed by service. Kept here rather
+	// than on the shared runtime config, which is read concurrently.
+	digestsMu sync.Mutex
+	digests   map[string]string
+	localTags map[string]string // Tags of the last local builds
+
+	// Values override layers set from the TUI values editor, keyed by service
+	overridesMu sync.Mutex
+	overrides   map[string]map[string]interface{}
 }
 
 // NewServiceOrchestrator creates a new service orchestrator
 func NewServiceOrchestrator(verbose bool) *ServiceOrchestrator {
 	return &ServiceOrchestrator{
 		helmProvider:  tools.NewHelmProvider(),
+		helmSDK:       tools.NewHelmSDKProvider(),
 		valuesManager: config.NewValuesManager(".plat"),
 		verbose:       verbose,
+		log:           logging.Default(),
+		events:        NewEventBus(),
+		keepProtected: true,
+		digests:       make(map[string]string),
+		localTags:     make(map[string]string),
+		overrides:     make(map[string]map[string]interface{}),
 	}
 }
 
-// DeployServices deploys all services in the environment with dependency ordering
+// DeployServices deploys all services in the environment with dependency
+// ordering. It fails fast per level: every service of a level is attempted,
+// but a level with a failure stops the deploy before the next one starts.
+// Errors are tagged FailurePartial once any service was deployed, and
+// FailureDeploy otherwise.
 func (so *ServiceOrchestrator) DeployServices(ctx context.Context, runtime *config.RuntimeConfig) error {
 	// Group services by dependency level for concurrent deployment
 	serviceLevels, err := so.groupServicesByDependencyLevel(runtime)
 	if err != nil {
-		return fmt.Errorf("failed to resolve service dependencies: %w", err)
+		return fail(FailureDeploy, fmt.Errorf("failed to resolve service dependencies: %w", err))
 	}
 
-	if so.verbose {
-		fmt.Printf("🚀 Deploying %d services across %d level(s)\n", len(runtime.ResolvedServices), len(serviceLevels))
-		for levelIdx, level := range serviceLevels {
-			if len(level) == 1 {
-				fmt.Printf("  Level %d: %s\n", levelIdx, level[0])
-			} else {
-				fmt.Printf("  Level %d: %s (concurrent)\n", levelIdx, strings.Join(level, ", "))
-			}
-		}
+	so.log.Info("deploying services", "services", len(runtime.ResolvedServices), "levels", len(serviceLevels))
+	for levelIdx, level := range serviceLevels {
+		so.log.Debug("deploy level", "level", levelIdx, "services", strings.Join(level, ", "))
 	}
 
 	// Deploy each level, services within a level deploy concurrently
+	deployed := 0
 	for levelIdx, level := range serviceLevels {
-		if so.verbose && len(level) > 1 {
-			fmt.Printf("📦 Deploying level %d (%d services concurrently)...\n", levelIdx, len(level))
+		if len(level) > 1 {
+			so.log.Info("deploying level concurrently", "level", levelIdx, "services", len(level))
 		}
 
-		if err := so.deployServicesInLevel(ctx, level, runtime); err != nil {
-			return fmt.Errorf("failed to deploy level %d: %w", levelIdx, err)
+		levelDeployed, err := so.deployServicesInLevel(ctx, levelIdx, level, runtime)
+		deployed += levelDeployed
+		if err != nil {
+			return fail(deployFailureKind(deployed), fmt.Errorf("failed to deploy level %d: %w", levelIdx, err))
 		}
 
-		if so.verbose {
-			fmt.Printf("✅ Level %d deployed successfully\n", levelIdx)
+		// The next level starts only once this one actually serves. Services
+		// that installed but never became ready don't count as deployed.
+		if !so.noWait {
+			if err := so.waitForProbes(ctx, level, runtime); err != nil {
+				return fail(deployFailureKind(deployed-levelDeployed), fmt.Errorf("level %d not ready: %w", levelIdx, err))
+			}
 		}
+
+		so.log.Info("level deployed", "level", levelIdx)
 	}
 
 	return nil
 }
 
-// deployServicesInLevel deploys multiple services concurrently
-func (so *ServiceOrchestrator) deployServicesInLevel(ctx context.Context, serviceNames []string, runtime *config.RuntimeConfig) error {
+// deployFailureKind classifies a failed deploy by how many services were
+// deployed before it failed
+func deployFailureKind(deployed int) FailureKind {
+	if deployed > 0 {
+		return FailurePartial
+	}
+	return FailureDeploy
+}
+
+// deployServicesInLevel deploys multiple services concurrently, returning how
+// many of them were deployed
+func (so *ServiceOrchestrator) deployServicesInLevel(ctx context.Context, levelIdx int, serviceNames []string, runtime *config.RuntimeConfig) (int, error) {
 	// Use error group for concurrent deployment with error aggregation
 	type deployResult struct {
 		serviceName string
@@ -83,18 +139,24 @@ func (so *ServiceOrchestrator) deployServicesInLevel(ctx context.Context, servic
 
 			service := runtime.ResolvedServices[name]
 
-			if so.verbose {
-				fmt.Printf("📦 Deploying %s...\n", name)
-			}
+			so.log.Info("deploying service", "service", name)
+			so.events.Publish(Event{Type: EventServiceDeploying, Service: name, Level: levelIdx})
+			so.recordPhase(name, PhaseDeploying)
 
+			started := time.Now()
 			err := so.deployService(ctx, service, runtime)
+			if err == nil && so.onDeployed != nil {
+				so.onDeployed(name, time.Since(started))
+			}
 
 			if err != nil {
+				so.recordPhase(name, PhaseFailed)
+				so.events.Publish(Event{Type: EventError, Service: name, Level: levelIdx, Err: err})
 				resultChan <- deployResult{serviceName: name, err: err}
 			} else {
-				if so.verbose {
-					fmt.Printf("✅ %s deployed successfully\n", name)
-				}
+				so.recordPhase(name, PhaseDeployed)
+				so.log.Info("service deployed", "service", name)
+				so.events.Publish(Event{Type: EventServiceReady, Service: name, Level: levelIdx})
 				resultChan <- deployResult{serviceName: name, err: nil}
 			}
 		}(serviceName)
@@ -107,36 +169,24 @@ func (so *ServiceOrchestrator) deployServicesInLevel(ctx context.Context, servic
 	}()
 
 	// Collect results and aggregate errors
-	var errors []error
+	failures := &ServiceErrors{Operation: "deploy", Total: len(serviceNames)}
 	for result := range resultChan {
 		if result.err != nil {
-			errors = append(errors, fmt.Errorf("%s: %w", result.serviceName, result.err))
-		}
-	}
-
-	// If any deployments failed, return combined error
-	if len(errors) > 0 {
-		var errMsg strings.Builder
-		errMsg.WriteString("service deployment failures:\n")
-		for _, err := range errors {
-			errMsg.WriteString(fmt.Sprintf("  - %v\n", err))
+			failures.Add(result.serviceName, result.err)
 		}
-		return fmt.Errorf(errMsg.String())
 	}
 
-	return nil
+	return len(serviceNames) - len(failures.Errors), failures.Err()
 }
 
 // UndeployServices removes all services from the environment
 func (so *ServiceOrchestrator) UndeployServices(ctx context.Context, runtime *config.RuntimeConfig) error {
 	namespace := runtime.Base.Defaults.Namespace
 
-	if so.verbose {
-		fmt.Printf("🗑️  Undeploying services from namespace: %s\n", namespace)
-	}
+	so.log.Info("undeploying services", "namespace", namespace)
 
 	// Get all releases in the namespace
-	releases, err := so.helmProvider.ListReleases(ctx, namespace)
+	releases, err := so.helm(runtime).ListReleases(ctx, namespace)
 	if err != nil {
 		return fmt.Errorf("failed to list helm releases: %w", err)
 	}
@@ -154,13 +204,13 @@ func (so *ServiceOrchestrator) UndeployServices(ctx context.Context, runtime *co
 	for i := len(serviceLevels) - 1; i >= 0; i-- {
 		level := serviceLevels[i]
 
-		if so.verbose && len(level) > 1 {
-			fmt.Printf("🗑️  Undeploying level %d (%d services concurrently)...\n", i, len(level))
+		if len(level) > 1 {
+			so.log.Info("undeploying level concurrently", "level", i, "services", len(level))
 		}
 
 		if err := so.undeployServicesInLevel(ctx, level, platReleases, runtime, namespace); err != nil {
 			// Continue with other levels even if this one has errors
-			fmt.Printf("⚠️  Level %d undeployment had errors: %v\n", i, err)
+			so.log.Warn("level undeployment had errors", "level", i, "error", err)
 		}
 	}
 
@@ -170,20 +220,21 @@ func (so *ServiceOrchestrator) UndeployServices(ctx context.Context, runtime *co
 // undeployServicesInLevel undeploys multiple services concurrently
 func (so *ServiceOrchestrator) undeployServicesInLevel(ctx context.Context, serviceNames []string, platReleases []tools.ReleaseInfo, runtime *config.RuntimeConfig, namespace string) error {
 	var wg sync.WaitGroup
-	errorsChan := make(chan error, len(serviceNames))
+	var mu sync.Mutex
+	failures := &ServiceErrors{Operation: "undeploy", Total: len(serviceNames)}
+	failed := func(name string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures.Add(name, err)
+	}
 
 	// Undeploy all services in this level concurrently
 	for _, serviceName := range serviceNames {
-		// Check if this service has a release
-		var releaseExists bool
-		for _, release := range platReleases {
-			if release.Name == serviceName || release.Name == so.getReleaseName(serviceName, runtime) {
-				releaseExists = true
-				break
-			}
-		}
-
-		if !releaseExists {
+		switch so.undeployAction(serviceName, platReleases, runtime) {
+		case undeploySkip:
+			continue
+		case undeployKeep:
+			so.log.Warn("keeping protected service, use --include-protected to remove it", "service", serviceName)
 			continue
 		}
 
@@ -191,34 +242,78 @@ func (so *ServiceOrchestrator) undeployServicesInLevel(ctx context.Context, serv
 		go func(name string) {
 			defer wg.Done()
 
-			if so.verbose {
-				fmt.Printf("🗑️  Undeploying %s...\n", name)
-			}
+			so.log.Info("undeploying service", "service", name)
+			so.events.Publish(Event{Type: EventServiceRemoving, Service: name})
 
 			releaseName := so.getReleaseName(name, runtime)
-			if err := so.helmProvider.UninstallChart(ctx, releaseName, namespace); err != nil {
-				errorsChan <- fmt.Errorf("%s: %w", name, err)
-				fmt.Printf("⚠️  Failed to undeploy %s: %v\n", name, err)
-			} else if so.verbose {
-				fmt.Printf("✅ %s undeployed\n", name)
+			if err := so.removeService(ctx, runtime, name); err != nil {
+				failed(name, err)
+				so.log.Warn("failed to undeploy service", "service", name, "error", err)
+				so.events.Publish(Event{Type: EventError, Service: name, Err: err})
+				return
+			}
+			so.log.Info("service undeployed", "service", name)
+			so.events.Publish(Event{Type: EventServiceRemoved, Service: name})
+
+			if err := so.removeServiceData(ctx, runtime, name, releaseName, namespace); err != nil {
+				failed(name, err)
+				so.log.Warn("failed to delete service data", "service", name, "error", err)
 			}
 		}(serviceName)
 	}
 
-	// Wait for all undeployments
-	go func() {
-		wg.Wait()
-		close(errorsChan)
-	}()
+	// Wait for all undeployments; failures don't stop the others
+	wg.Wait()
+
+	return failures.Err()
+}
 
-	// Collect errors (but don't fail - best effort undeployment)
-	var errors []error
-	for err := range errorsChan {
-		errors = append(errors, err)
+// What UndeployServices does with a service
+const (
+	undeployRemove = iota
+	undeploySkip   // Not deployed
+	undeployKeep   // Protected
+)
+
+// undeployAction decides whether UndeployServices removes a service
+func (so *ServiceOrchestrator) undeployAction(serviceName string, platReleases []tools.ReleaseInfo, runtime *config.RuntimeConfig) int {
+	// Check if this service has a release; kubectl-applied services
+	// have none and are always removed
+	service, known := runtime.ResolvedServices[serviceName]
+	releaseExists := known && service.AppliesManifests()
+	for _, release := range platReleases {
+		if release.Name == serviceName || release.Name == so.getReleaseName(serviceName, runtime) {
+			releaseExists = true
+			break
+		}
+	}
+
+	switch {
+	case !releaseExists:
+		return undeploySkip
+	case so.keepProtected && known && service.Protected:
+		return undeployKeep
+	default:
+		return undeployRemove
+	}
+}
+
+// removeServiceData deletes a service's persistent volume claims when its
+// retention policy or --purge-data asks for it. Helm leaves PVCs behind on
+// uninstall, so data is kept otherwise.
+func (so *ServiceOrchestrator) removeServiceData(ctx context.Context, runtime *config.RuntimeConfig, serviceName, releaseName, namespace string) error {
+	service, ok := runtime.ResolvedServices[serviceName]
+	if !ok || (!so.purgeData && !service.DeletesData()) {
+		return nil
 	}
 
-	if len(errors) > 0 {
-		return fmt.Errorf("some services failed to undeploy: %d errors", len(errors))
+	deleted, err := tools.DeletePersistentVolumeClaims(ctx, releaseName, namespace)
+	if err != nil {
+		return err
+	}
+
+	if len(deleted) > 0 {
+		so.log.Warn("deleted data volumes", "service", serviceName, "claims", strings.Join(deleted, ", "))
 	}
 
 	return nil
@@ -232,7 +327,15 @@ func (so *ServiceOrchestrator) GetServiceStatuses(ctx context.Context, runtime *
 	for serviceName := range runtime.ResolvedServices {
 		releaseName := so.getReleaseName(serviceName, runtime)
 
-		status, err := so.helmProvider.GetReleaseStatus(ctx, releaseName, namespace)
+		if runtime.ResolvedServices[serviceName].AppliesManifests() {
+			statuses[serviceName] = so.manifestStatus(ctx, serviceName, namespace)
+			continue
+		}
+
+		status, err := so.helm(runtime).GetReleaseStatus(ctx, releaseName, namespace)
+		if errors.Is(err, tools.ErrUnexpectedHelmOutput) {
+			return nil, fmt.Errorf("failed to read status of %s: %w", serviceName, err)
+		}
 		if err != nil {
 			// Service not deployed - create a placeholder status
 			status = &tools.ReleaseStatus{
@@ -250,64 +353,148 @@ func (so *ServiceOrchestrator) GetServiceStatuses(ctx context.Context, runtime *
 
 // DeployService deploys a single service (public method)
 func (so *ServiceOrchestrator) DeployService(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) error {
-	if so.verbose {
-		fmt.Printf("📦 Deploying %s...\n", service.Name)
-	}
+	so.log.Info("deploying service", "service", service.Name)
+	so.events.Publish(Event{Type: EventServiceDeploying, Service: service.Name})
 
 	if err := so.deployService(ctx, service, runtime); err != nil {
+		so.events.Publish(Event{Type: EventError, Service: service.Name, Err: err})
 		return err
 	}
 
-	if so.verbose {
-		fmt.Printf("✅ %s deployed successfully\n", service.Name)
-	}
+	so.log.Info("service deployed", "service", service.Name)
+	so.events.Publish(Event{Type: EventServiceReady, Service: service.Name})
 
 	return nil
 }
 
 // UndeployService removes a single service from the environment
 func (so *ServiceOrchestrator) UndeployService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
-	namespace := runtime.Base.Defaults.Namespace
-	releaseName := so.getReleaseName(serviceName, runtime)
-
-	if so.verbose {
-		fmt.Printf("🗑️  Undeploying %s...\n", serviceName)
-	}
+	so.log.Info("undeploying service", "service", serviceName)
+	so.events.Publish(Event{Type: EventServiceRemoving, Service: serviceName})
 
-	if err := so.helmProvider.UninstallChart(ctx, releaseName, namespace); err != nil {
+	if err := so.removeService(ctx, runtime, serviceName); err != nil {
+		so.events.Publish(Event{Type: EventError, Service: serviceName, Err: err})
 		return fmt.Errorf("failed to undeploy: %w", err)
 	}
 
-	if so.verbose {
-		fmt.Printf("✅ %s undeployed\n", serviceName)
-	}
+	so.log.Info("service undeployed", "service", serviceName)
+	so.events.Publish(Event{Type: EventServiceRemoved, Service: serviceName})
 
 	return nil
 }
 
 // deployService deploys a single service
 func (so *ServiceOrchestrator) deployService(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) error {
+	// Build local sources and import the image into the cluster
+	service, err := so.buildLocalImage(ctx, service, runtime)
+	if err != nil {
+		return categorize(CategoryBuild, err)
+	}
+
+	// Run image hooks (digest resolution, signature checks) for registry images
+	service, err = so.processImage(ctx, service, runtime)
+	if err != nil {
+		return categorize(CategoryImage, err)
+	}
+
+	if service.AppliesManifests() {
+		if err := so.applyManifests(ctx, service, runtime); err != nil {
+			return categorize(CategoryManifests, fmt.Errorf("manifest deployment failed: %w", err))
+		}
+		return nil
+	}
+
+	release, err := so.serviceRelease(service, runtime)
+	if err != nil {
+		return categorize(CategoryValues, err)
+	}
+
+	// Install/upgrade the chart
+	stopReporting := so.reportSlowInstall(service.Name)
+	err = so.helm(runtime).InstallChart(ctx, release)
+	stopReporting()
+	if err != nil {
+		return categorize(CategoryHelm, fmt.Errorf("helm deployment failed: %w", err))
+	}
+
+	return nil
+}
+
+// A chart install running longer than slowInstallAfter is reported every
+// slowInstallEvery, so a long helm --wait doesn't look hung
+const (
+	slowInstallAfter = time.Minute
+	slowInstallEvery = 30 * time.Second
+)
+
+// reportSlowInstall publishes EventInstallSlow while a service's chart
+// install runs long, until the returned function is called
+func (so *ServiceOrchestrator) reportSlowInstall(serviceName string) func() {
+	started := time.Now()
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		timer := time.NewTimer(slowInstallAfter)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-timer.C:
+			}
+
+			message := fmt.Sprintf("Still installing %s after %s (gives up after %s)",
+				serviceName, time.Since(started).Round(time.Second), tools.CurrentTimeouts().Install)
+			so.events.Publish(Event{Type: EventInstallSlow, Service: serviceName, Message: message})
+			so.log.Info("chart install still running", "service", serviceName, "elapsed", time.Since(started).Round(time.Second))
+			timer.Reset(slowInstallEvery)
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// serviceRelease builds the Helm release for a service with its resolved values
+func (so *ServiceOrchestrator) serviceRelease(service *config.ResolvedService, runtime *config.RuntimeConfig) (tools.HelmRelease, error) {
 	// Resolve Helm values for the service
 	values, err := so.valuesManager.ResolveValues(service, runtime)
 	if err != nil {
-		return fmt.Errorf("failed to resolve values: %w", err)
+		return tools.HelmRelease{}, fmt.Errorf("failed to resolve values: %w", err)
+	}
+
+	// Overrides from the values editor take precedence over config
+	if overrides := so.valueOverrides(service.Name); overrides != nil {
+		so.valuesManager.ApplyOverrides(values, overrides)
 	}
 
 	// Validate values
 	if err := so.valuesManager.ValidateValues(service, values); err != nil {
-		if so.verbose {
-			fmt.Printf("⚠️  Values validation warning for %s: %v\n", service.Name, err)
-		}
+		so.log.Info("values validation warning", "service", service.Name, "error", err)
+	}
+
+	// Fully qualify the chart so same-named charts from different
+	// repositories never resolve to each other
+	chart, err := runtime.ResolveChart(service)
+	if err != nil {
+		return tools.HelmRelease{}, err
 	}
 
 	// Create Helm release configuration
 	release := tools.HelmRelease{
 		Name:       so.getReleaseName(service.Name, runtime),
-		Chart:      service.Chart.Name,
+		Chart:      chart.Chart,
 		Version:    service.Chart.Version,
-		Repository: service.Chart.Repository,
+		Repository: chart.RepoURL,
+		RepoName:   chart.RepoName,
 		Namespace:  runtime.Base.Defaults.Namespace,
 		Values:     values,
+		NoWait:     so.noWait,
 	}
 
 	// Add values file if specified
@@ -315,12 +502,101 @@ func (so *ServiceOrchestrator) deployService(ctx context.Context, service *confi
 		release.ValuesFiles = []string{service.ValuesFile}
 	}
 
-	// Install/upgrade the chart
-	if err := so.helmProvider.InstallChart(ctx, release); err != nil {
-		return fmt.Errorf("helm deployment failed: %w", err)
+	return release, nil
+}
+
+// removeService uninstalls a service with the engine that deployed it
+func (so *ServiceOrchestrator) removeService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error {
+	service, ok := runtime.ResolvedServices[serviceName]
+	if ok && service.AppliesManifests() {
+		return categorize(CategoryManifests, so.deleteManifests(ctx, service, runtime))
 	}
 
-	return nil
+	return categorize(CategoryHelm, so.helm(runtime).UninstallChart(ctx, so.getReleaseName(serviceName, runtime), runtime.Base.Defaults.Namespace))
+}
+
+// processImage runs the image hook chain for services whose image plat
+// manages. It returns the service to deploy: a copy pinned to the resolved
+// digest, since the runtime config is shared and must not be modified.
+func (so *ServiceOrchestrator) processImage(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) (*config.ResolvedService, error) {
+	if service.IsLocal || !service.IsMicroserviceChart() {
+		return service, nil
+	}
+
+	chain := images.NewChainFromPolicy(runtime.Base.ImagePolicy)
+	for _, hook := range so.imageHooks {
+		chain.Register(hook)
+	}
+	if chain.Empty() {
+		return service, nil
+	}
+
+	image := &images.ImageRef{
+		Service:    service.Name,
+		Repository: runtime.ImageRepository(service),
+		Tag:        service.Version,
+		Digest:     service.ImageDigest,
+	}
+
+	if err := chain.Process(ctx, image); err != nil {
+		return nil, err
+	}
+
+	if image.Digest != service.ImageDigest {
+		so.log.Info("image resolved", "service", service.Name, "image", image.Reference())
+	}
+
+	so.digestsMu.Lock()
+	so.digests[service.Name] = image.Digest
+	so.digestsMu.Unlock()
+
+	pinned := service.Clone()
+	pinned.ImageDigest = image.Digest
+	return pinned, nil
+}
+
+// buildLocalImage builds the image of a service running from a local source.
+// Like processImage, it returns a copy carrying the built tag rather than
+// modifying the shared runtime config.
+func (so *ServiceOrchestrator) buildLocalImage(ctx context.Context, service *config.ResolvedService, runtime *config.RuntimeConfig) (*config.ResolvedService, error) {
+	if !service.IsLocal || service.LocalSource == nil {
+		return service, nil
+	}
+
+	engine := ContainerRuntime(runtime)
+	clusters := tools.NewClusterProvider(runtime.Base.Defaults.ClusterProvider, engine)
+	result, err := build.NewBuilder(engine, so.verbose).BuildService(ctx, service, clusters, ClusterName(runtime))
+	if err != nil {
+		return nil, fmt.Errorf("local build failed: %w", err)
+	}
+
+	so.digestsMu.Lock()
+	so.localTags[service.Name] = result.Tag
+	so.digestsMu.Unlock()
+
+	built := service.Clone()
+	built.LocalTag = result.Tag
+	built.LocalRegistry = result.Registry
+	return built, nil
+}
+
+// localTag returns the tag of the service's last local build
+func (so *ServiceOrchestrator) localTag(serviceName string) string {
+	so.digestsMu.Lock()
+	defer so.digestsMu.Unlock()
+	return so.localTags[serviceName]
+}
+
+// ImageDigest returns the digest image hooks pinned the service to during
+// the last deploy, falling back to the digest in its configuration
+func (so *ServiceOrchestrator) ImageDigest(service *config.ResolvedService) string {
+	so.digestsMu.Lock()
+	defer so.digestsMu.Unlock()
+
+	if digest, ok := so.digests[service.Name]; ok {
+		return digest
+	}
+	return service.ImageDigest
 }
 
 // orderServicesByDependencies returns services ordered by their dependencies
@@ -447,6 +723,14 @@ func (so *ServiceOrchestrator) groupServicesByDependencyLevel(runtime *config.Ru
 	return levels, nil
 }
 
+// helm returns the Helm provider selected by the runtime's helm driver
+func (so *ServiceOrchestrator) helm(runtime *config.RuntimeConfig) tools.HelmProvider {
+	if runtime.Base.Defaults.HelmDriver == tools.HelmDriverSDK {
+		return so.helmSDK
+	}
+	return so.helmProvider
+}
+
 // getReleaseName generates a consistent Helm release name for a service
 func (so *ServiceOrchestrator) getReleaseName(serviceName string, _ *config.RuntimeConfig) string {
 	// Use simple service name for release - Helm will handle namespace isolation
@@ -0,0 +1,172 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/fsys"
+	"plat/pkg/images"
+	"plat/pkg/tools"
+)
+
+const testConfig = `apiVersion: plat/v1
+kind: Environment
+name: race
+defaults:
+  chart: microservice
+  registry: registry.example.com
+repositories:
+  - name: example
+    url: https://charts.example.com
+services:
+  - name: api
+    chart: {name: microservice, repository: example}
+    values:
+      env:
+        LOG_FORMAT: json
+  - name: worker
+    chart: {name: microservice, repository: example}
+    dependencies: [api]
+  - name: web
+    chart: {name: microservice, repository: example}
+    dependencies: [api]
+  - name: postgres
+    chart:
+      name: postgresql
+      repository: example
+      version: 13.2.0
+`
+
+// fakeHelm records installed releases in memory. Methods the tests don't use
+// panic through the nil embedded provider.
+type fakeHelm struct {
+	tools.HelmProvider
+
+	mu       sync.Mutex
+	releases map[string]tools.HelmRelease
+}
+
+func newFakeHelm() *fakeHelm {
+	return &fakeHelm{releases: make(map[string]tools.HelmRelease)}
+}
+
+func (h *fakeHelm) InstallChart(ctx context.Context, release tools.HelmRelease) error {
+	time.Sleep(time.Millisecond) // Let status refreshes interleave
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.releases[release.Name] = release
+	return nil
+}
+
+func (h *fakeHelm) GetReleaseStatus(ctx context.Context, releaseName, namespace string) (*tools.ReleaseStatus, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	release, ok := h.releases[releaseName]
+	if !ok {
+		return nil, fmt.Errorf("release %s not found", releaseName)
+	}
+	return &tools.ReleaseStatus{Name: release.Name, Namespace: namespace, Status: "deployed", Version: release.Version}, nil
+}
+
+// digestHook pins every image to a fixed digest, like the digest resolver
+type digestHook struct{}
+
+func (digestHook) Name() string { return "digest" }
+
+func (digestHook) Process(ctx context.Context, image *images.ImageRef) error {
+	image.Digest = "sha256:" + image.Service
+	return nil
+}
+
+// loadTestConfig loads testConfig from an in-memory file system
+func loadTestConfig(t *testing.T) *config.RuntimeConfig {
+	t.Helper()
+
+	mem := fsys.NewMem(func() time.Time { return time.Unix(0, 0) })
+	if err := mem.MkdirAll(".plat", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.WriteFile(".plat/config.yml", []byte(testConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := config.NewLoader(".plat/config.yml", config.ModeArtifact)
+	loader.SetFS(mem)
+	runtime, err := loader.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	return runtime
+}
+
+// TestStatusRefreshDuringDeploy refreshes statuses and reads resolved digests
+// while services deploy in parallel, as the TUI does. Run with -race.
+func TestStatusRefreshDuringDeploy(t *testing.T) {
+	runtime := loadTestConfig(t)
+	helm := newFakeHelm()
+
+	so := NewServiceOrchestrator(false)
+	so.helmProvider = helm
+	so.noWait = true
+	so.imageHooks = []images.Hook{digestHook{}}
+
+	ctx := context.Background()
+	deployed := make(chan error, 1)
+	go func() {
+		deployed <- so.DeployServices(ctx, runtime)
+	}()
+
+	stop := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if _, err := so.GetServiceStatuses(ctx, runtime); err != nil {
+					t.Error(err)
+					return
+				}
+				for _, service := range runtime.OrderedServices() {
+					so.ImageDigest(service)
+					_ = service.ImageDigest
+				}
+			}
+		}()
+	}
+
+	err := <-deployed
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("deploy failed: %v", err)
+	}
+
+	statuses, err := so.GetServiceStatuses(ctx, runtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range runtime.ListServices() {
+		if status := statuses[name]; status == nil || status.Status != "deployed" {
+			t.Errorf("%s is %v, want deployed", name, status)
+		}
+	}
+
+	api := runtime.ResolvedServices["api"]
+	if got := so.ImageDigest(api); got != "sha256:api" {
+		t.Errorf("api digest is %q, want sha256:api", got)
+	}
+	if api.ImageDigest != "" {
+		t.Errorf("deploy pinned the shared config to %s", api.ImageDigest)
+	}
+}
@@ -0,0 +1,66 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"plat/pkg/config"
+)
+
+// ServiceTemplate is what 'plat up' would deploy for a service
+type ServiceTemplate struct {
+	Service   string                 `json:"service"`
+	Values    map[string]interface{} `json:"values,omitempty"`  // Merged Helm values; nil for services without a chart
+	Sources   map[string]string      `json:"sources,omitempty"` // Layer that set each leaf key, by dotted path
+	Manifests string                 `json:"manifests,omitempty"`
+}
+
+// Template resolves a service's values the way Up does, recording which
+// layer set each key, and renders its manifests unless valuesOnly is set.
+// Local services are rendered without building them, so their image tags
+// are the last built ones.
+func (o *Orchestrator) Template(ctx context.Context, runtime *config.RuntimeConfig, serviceName string, valuesOnly bool) (*ServiceTemplate, error) {
+	service, exists := runtime.ResolvedServices[serviceName]
+	if !exists {
+		return nil, &config.UnknownServiceError{Name: serviceName}
+	}
+	so := o.serviceManager
+	template := &ServiceTemplate{Service: serviceName}
+
+	if service.Engine() == config.EngineHelm {
+		layers, err := so.valuesManager.ValuesLayers(service, runtime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve values: %w", err)
+		}
+		if overrides := so.valueOverrides(serviceName); overrides != nil {
+			layers = append(layers, config.ValuesLayer{Name: ValuesLayerEditor, Values: overrides})
+		}
+		template.Values, template.Sources = config.MergeValueLayers(layers)
+	} else if valuesOnly {
+		return nil, fmt.Errorf("service %s is deployed from %s and has no chart values", serviceName, service.Engine())
+	}
+
+	if valuesOnly {
+		return template, nil
+	}
+
+	if service.AppliesManifests() {
+		manifests, err := so.renderManifests(ctx, service, runtime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", serviceName, err)
+		}
+		template.Manifests = manifests
+		return template, nil
+	}
+
+	release, err := so.serviceRelease(service, runtime)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := so.helm(runtime).Template(ctx, release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", serviceName, err)
+	}
+	template.Manifests = manifests
+	return template, nil
+}
@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"plat/pkg/certs"
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// tlsSecretLabel marks the TLS Secrets plat creates for ingress hosts
+const tlsSecretLabel = "plat.dev/tls"
+
+// tlsSecretSelector selects the TLS Secrets plat created
+func tlsSecretSelector() string {
+	return fmt.Sprintf("%s=%s,%s=true", managedByLabel, managedByPlat, tlsSecretLabel)
+}
+
+// applyTLSSecrets creates a Secret holding a certificate for the ingress host
+// of every service of the environment, issued by the local CA. Certificates
+// are cached under .plat/certs and reused until they near expiry. The CA is
+// created on first use and returned, to check that it is trusted.
+func (so *ServiceOrchestrator) applyTLSSecrets(ctx context.Context, runtime *config.RuntimeConfig) (*certs.CA, error) {
+	dir, err := certs.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	ca, _, err := certs.LoadOrCreateCA(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the local CA: %w", err)
+	}
+
+	certDir := filepath.Join(runtime.ConfigDir(), "certs")
+	domain := runtime.Base.Defaults.Domain
+	var secrets []tools.SecretSpec
+	for _, service := range runtime.Unfiltered().OrderedServices() {
+		host := service.Name + "." + domain
+		certPEM, keyPEM, err := ca.HostCert(certDir, host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue a certificate for %s: %w", host, err)
+		}
+
+		secrets = append(secrets, tools.SecretSpec{
+			Name: config.TLSSecretName(service.Name),
+			Type: "kubernetes.io/tls",
+			Labels: map[string]string{
+				managedByLabel: managedByPlat,
+				tlsSecretLabel: "true",
+			},
+			Data: map[string][]byte{
+				"tls.crt": certPEM,
+				"tls.key": keyPEM,
+			},
+		})
+	}
+
+	namespace := runtime.Base.Defaults.Namespace
+	if err := tools.EnsureNamespace(ctx, namespace); err != nil {
+		return nil, err
+	}
+
+	so.log.Info("applying TLS secrets", "namespace", namespace, "ca", ca.Dir)
+	if err := tools.SyncSecrets(ctx, namespace, tlsSecretSelector(), secrets); err != nil {
+		return nil, fmt.Errorf("failed to apply TLS secrets: %w", err)
+	}
+	return ca, nil
+}
@@ -0,0 +1,179 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"plat/pkg/config"
+	"plat/pkg/tools"
+)
+
+// Wait conditions supported by Orchestrator.Wait
+const (
+	ConditionReady     = "ready"     // All pods of the service are ready
+	ConditionReachable = "reachable" // The service ingress answers HTTP requests
+	ConditionComplete  = "complete"  // The job with this name completed successfully
+)
+
+// WaitCondition is a single "<target>=<condition>" requirement
+type WaitCondition struct {
+	Target    string
+	Condition string
+}
+
+func (c WaitCondition) String() string {
+	return c.Target + "=" + c.Condition
+}
+
+// ParseWaitCondition parses "<target>=<condition>". The target "all" expands
+// to every configured service.
+func ParseWaitCondition(value string) (WaitCondition, error) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return WaitCondition{}, fmt.Errorf("invalid condition %q, expected <target>=<condition>", value)
+	}
+
+	cond := WaitCondition{Target: parts[0], Condition: parts[1]}
+	switch cond.Condition {
+	case ConditionReady, ConditionReachable:
+		return cond, nil
+	case ConditionComplete:
+		// Jobs aren't declared in config, so there is no "all" to expand
+		if cond.Target == "all" {
+			return WaitCondition{}, fmt.Errorf("invalid condition %q: name the job to wait for, e.g. db-migrate=%s", value, ConditionComplete)
+		}
+		return cond, nil
+	default:
+		return WaitCondition{}, fmt.Errorf("unknown condition %q, must be one of: %s, %s, %s",
+			cond.Condition, ConditionReady, ConditionReachable, ConditionComplete)
+	}
+}
+
+// Wait polls until every condition is satisfied or the context expires
+func (o *Orchestrator) Wait(ctx context.Context, runtime *config.RuntimeConfig, conditions []WaitCondition, interval time.Duration) error {
+	pending, err := expandWaitConditions(runtime, conditions)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var remaining []WaitCondition
+		for _, cond := range pending {
+			satisfied, err := o.checkCondition(ctx, runtime, cond)
+			if err != nil {
+				return fmt.Errorf("%s: %w", cond, err)
+			}
+			if satisfied {
+				fmt.Printf("✅ %s\n", cond)
+			} else {
+				remaining = append(remaining, cond)
+			}
+		}
+
+		pending = remaining
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			names := make([]string, len(pending))
+			for i, cond := range pending {
+				names[i] = cond.String()
+			}
+			return fmt.Errorf("timed out waiting for: %s", strings.Join(names, ", "))
+		case <-ticker.C:
+		}
+	}
+}
+
+// expandWaitConditions validates targets and expands the "all" target
+func expandWaitConditions(runtime *config.RuntimeConfig, conditions []WaitCondition) ([]WaitCondition, error) {
+	var expanded []WaitCondition
+	for _, cond := range conditions {
+		if cond.Target == "all" {
+			for _, name := range runtime.ListServices() {
+				expanded = append(expanded, WaitCondition{Target: name, Condition: cond.Condition})
+			}
+			continue
+		}
+
+		// Jobs are not services, so only service conditions are checked against config
+		if cond.Condition != ConditionComplete {
+			if _, exists := runtime.ResolvedServices[cond.Target]; !exists {
+				return nil, &config.UnknownServiceError{Name: cond.Target}
+			}
+		}
+		expanded = append(expanded, cond)
+	}
+	return expanded, nil
+}
+
+// checkCondition evaluates a condition once
+func (o *Orchestrator) checkCondition(ctx context.Context, runtime *config.RuntimeConfig, cond WaitCondition) (bool, error) {
+	namespace := runtime.Base.Defaults.Namespace
+
+	switch cond.Condition {
+	case ConditionReady:
+		podStatus, err := tools.GetPodStatus(ctx, cond.Target, namespace)
+		if err != nil {
+			return false, nil // Not deployed yet
+		}
+		return podStatus.Ready, nil
+
+	case ConditionReachable:
+		return checkIngressReachable(ctx, cond.Target, runtime.Base.Defaults.Domain), nil
+
+	case ConditionComplete:
+		jobStatus, err := tools.GetJobStatus(ctx, cond.Target, namespace)
+		if err != nil {
+			return false, nil // Job not created yet
+		}
+		// Failed pods alone don't fail the job while it retries them
+		if jobStatus.GaveUp {
+			return false, fmt.Errorf("job failed: %s (%d failed pod(s))", jobStatus.Reason, jobStatus.Failed)
+		}
+		return jobStatus.Complete, nil
+	}
+
+	return false, fmt.Errorf("unknown condition %q", cond.Condition)
+}
+
+// checkIngressReachable reports whether the service's ingress has a ready backend
+func checkIngressReachable(ctx context.Context, serviceName, domain string) bool {
+	status, err := ingressStatus(ctx, serviceName, domain)
+	if err != nil {
+		return false
+	}
+
+	// The ingress controller answers 404/503 when no backend is ready
+	return status != http.StatusNotFound && status < 500
+}
+
+// ingressStatus sends a request through the k3d load balancer using the
+// service's ingress host, so it works without local DNS for the domain, and
+// returns the HTTP status code
+func ingressStatus(ctx context.Context, serviceName, domain string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1/", nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Host = fmt.Sprintf("%s.%s", serviceName, domain)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,237 @@
+// Package registry manages the image registry plat runs next to its k3d
+// clusters. Local builds are pushed to it and pods pull from it, instead of
+// importing every image into every cluster node.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+const (
+	// Name is the k3d name of the registry; its container is "k3d-" + Name
+	Name = "plat-registry"
+
+	// Port is the registry's port, on the host and inside the cluster network
+	Port = 5111
+
+	containerName = "k3d-" + Name
+
+	// Timeouts for registry operations
+	createTimeout = 2 * time.Minute
+	queryTimeout  = 30 * time.Second
+	gcTimeout     = 5 * time.Minute
+)
+
+// manifestMediaTypes are the manifest formats accepted when looking up digests
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// HostAddress is where images are pushed from the host
+func HostAddress() string {
+	return fmt.Sprintf("localhost:%d", Port)
+}
+
+// ClusterAddress is where pods pull images from. k3d configures clusters
+// created with --registry-use to reach the registry container by this name.
+func ClusterAddress() string {
+	return fmt.Sprintf("%s:%d", containerName, Port)
+}
+
+// Status describes the registry container
+type Status struct {
+	Exists   bool
+	Running  bool
+	Networks []string // Docker networks the registry is attached to
+}
+
+// AttachedTo reports whether a k3d cluster can pull from the registry
+func (s *Status) AttachedTo(cluster string) bool {
+	for _, network := range s.Networks {
+		if network == "k3d-"+cluster {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry manages the registry container and talks to its v2 API
+type Registry struct {
+	executor tools.ProcessExecutor
+	runtime  tools.ContainerRuntime // Engine the registry container runs in
+	client   *http.Client
+	verbose  bool
+}
+
+// New creates a registry manager
+func New(runtime tools.ContainerRuntime, verbose bool) *Registry {
+	return &Registry{
+		executor: tools.NewProcessExecutor(),
+		runtime:  runtime,
+		client:   &http.Client{Timeout: 15 * time.Second},
+		verbose:  verbose,
+	}
+}
+
+// Status inspects the registry container
+func (r *Registry) Status(ctx context.Context) (*Status, error) {
+	cmd := tools.Command{
+		Name:    r.runtime.Name(),
+		Args:    []string{"inspect", "--format", "{{.State.Running}}{{range $name, $_ := .NetworkSettings.Networks}} {{$name}}{{end}}", containerName},
+		Env:     r.runtime.Env(),
+		Timeout: queryTimeout,
+	}
+
+	result, err := r.executor.Execute(ctx, cmd)
+	if err != nil {
+		if result != nil && strings.Contains(strings.ToLower(result.Stderr), "no such") {
+			return &Status{}, nil
+		}
+		return nil, fmt.Errorf("failed to inspect registry: %w", err)
+	}
+
+	fields := strings.Fields(result.Stdout)
+	status := &Status{Exists: true}
+	if len(fields) > 0 {
+		status.Running = fields[0] == "true"
+		status.Networks = fields[1:]
+	}
+	return status, nil
+}
+
+// Ensure creates the registry if it doesn't exist and starts it if stopped
+func (r *Registry) Ensure(ctx context.Context) error {
+	status, err := r.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var cmd tools.Command
+	switch {
+	case status.Running:
+		return nil
+	case status.Exists:
+		cmd = tools.Command{Name: r.runtime.Name(), Args: []string{"start", containerName}, Env: r.runtime.Env(), Timeout: createTimeout}
+	default:
+		if r.verbose {
+			fmt.Printf("📦 Creating local registry %s\n", HostAddress())
+		}
+		// Deletes are enabled so 'plat registry prune' can free space
+		cmd = tools.Command{
+			Name:    "k3d",
+			Args:    []string{"registry", "create", Name, "--port", fmt.Sprintf("%d", Port), "--delete-enabled"},
+			Env:     r.runtime.Env(),
+			Timeout: createTimeout,
+		}
+	}
+
+	if _, err := r.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to start local registry: %w", err)
+	}
+	return nil
+}
+
+// Repositories lists the repositories in the registry
+func (r *Registry) Repositories(ctx context.Context) ([]string, error) {
+	var catalog struct {
+		Repositories []string `json:"repositories"`
+	}
+	if err := r.getJSON(ctx, "/v2/_catalog", &catalog); err != nil {
+		return nil, fmt.Errorf("failed to list registry repositories: %w", err)
+	}
+	return catalog.Repositories, nil
+}
+
+// Tags lists the tags of a repository
+func (r *Registry) Tags(ctx context.Context, repository string) ([]string, error) {
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := r.getJSON(ctx, fmt.Sprintf("/v2/%s/tags/list", repository), &list); err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s: %w", repository, err)
+	}
+	return list.Tags, nil
+}
+
+// Digest returns the manifest digest a tag points to
+func (r *Registry) Digest(ctx context.Context, repository, tag string) (string, error) {
+	req, err := r.request(ctx, http.MethodHead, fmt.Sprintf("/v2/%s/manifests/%s", repository, tag))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s:%s: %w", repository, tag, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to look up %s:%s: registry returned %s", repository, tag, resp.Status)
+	}
+	return resp.Header.Get("Docker-Content-Digest"), nil
+}
+
+// DeleteManifest removes a manifest and every tag pointing to it. The
+// layers are freed by GarbageCollect.
+func (r *Registry) DeleteManifest(ctx context.Context, repository, digest string) error {
+	req, err := r.request(ctx, http.MethodDelete, fmt.Sprintf("/v2/%s/manifests/%s", repository, digest))
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s@%s: %w", repository, digest, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete %s@%s: registry returned %s", repository, digest, resp.Status)
+	}
+	return nil
+}
+
+// GarbageCollect frees the layers no manifest references anymore
+func (r *Registry) GarbageCollect(ctx context.Context) error {
+	cmd := tools.Command{
+		Name:    r.runtime.Name(),
+		Args:    []string{"exec", containerName, "registry", "garbage-collect", "--delete-untagged", "/etc/docker/registry/config.yml"},
+		Env:     r.runtime.Env(),
+		Timeout: gcTimeout,
+	}
+	if _, err := r.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to garbage-collect registry: %w", err)
+	}
+	return nil
+}
+
+func (r *Registry) request(ctx context.Context, method, path string) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, method, "http://"+HostAddress()+path, nil)
+}
+
+func (r *Registry) getJSON(ctx context.Context, path string, v interface{}) error {
+	req, err := r.request(ctx, http.MethodGet, path)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
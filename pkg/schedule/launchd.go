@@ -0,0 +1,163 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"plat/pkg/tools"
+)
+
+// launchdScheduler installs LaunchAgents on macOS
+type launchdScheduler struct {
+	dir      string
+	executor tools.ProcessExecutor
+}
+
+func newLaunchdScheduler() (*launchdScheduler, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &launchdScheduler{
+		dir:      filepath.Join(home, "Library", "LaunchAgents"),
+		executor: tools.NewProcessExecutor(),
+	}, nil
+}
+
+func (s *launchdScheduler) Name() string {
+	return "launchd"
+}
+
+func (s *launchdScheduler) label(environment string) string {
+	return "com.plat." + jobName(environment)
+}
+
+func (s *launchdScheduler) path(environment string) string {
+	return filepath.Join(s.dir, s.label(environment)+".plist")
+}
+
+// Install writes the LaunchAgent plist and loads it
+func (s *launchdScheduler) Install(ctx context.Context, entry Entry) error {
+	hour, minute, err := ParseTime(entry.At)
+	if err != nil {
+		return err
+	}
+
+	var args strings.Builder
+	for _, arg := range append([]string{entry.Executable}, upArgs(entry)...) {
+		fmt.Fprintf(&args, "\t\t<string>%s</string>\n", html.EscapeString(arg))
+	}
+
+	var env strings.Builder
+	for _, variable := range jobEnvironment(entry) {
+		name, value, _ := strings.Cut(variable, "=")
+		fmt.Fprintf(&env, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", html.EscapeString(name), html.EscapeString(value))
+	}
+
+	// launchd has no weekday ranges; one calendar entry per day
+	var calendar strings.Builder
+	days := []int{-1}
+	if entry.Weekdays {
+		days = []int{1, 2, 3, 4, 5}
+	}
+	for _, day := range days {
+		calendar.WriteString("\t\t<dict>\n")
+		if day >= 0 {
+			fmt.Fprintf(&calendar, "\t\t\t<key>Weekday</key><integer>%d</integer>\n", day)
+		}
+		fmt.Fprintf(&calendar, "\t\t\t<key>Hour</key><integer>%d</integer>\n", hour)
+		fmt.Fprintf(&calendar, "\t\t\t<key>Minute</key><integer>%d</integer>\n", minute)
+		calendar.WriteString("\t\t</dict>\n")
+	}
+
+	var plist bytes.Buffer
+	fmt.Fprintf(&plist, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<!-- %s -->
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+	<key>EnvironmentVariables</key>
+	<dict>
+%s	</dict>
+	<key>WorkingDirectory</key>
+	<string>%s</string>
+	<key>StartCalendarInterval</key>
+	<array>
+%s	</array>
+	<key>StandardOutPath</key>
+	<string>%s</string>
+	<key>StandardErrorPath</key>
+	<string>%s</string>
+</dict>
+</plist>
+`, marker(entry), s.label(entry.Environment), args.String(), env.String(),
+		html.EscapeString(filepath.Dir(filepath.Dir(entry.ConfigFile))), calendar.String(),
+		html.EscapeString(entry.LogFile), html.EscapeString(entry.LogFile))
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+
+	path := s.path(entry.Environment)
+
+	// Unload any previous version so the new schedule takes effect
+	s.executor.Execute(ctx, tools.Command{Name: "launchctl", Args: []string{"unload", path}})
+
+	if err := os.WriteFile(path, plist.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	result, err := s.executor.Execute(ctx, tools.Command{Name: "launchctl", Args: []string{"load", "-w", path}})
+	if err != nil {
+		return fmt.Errorf("failed to load launch agent: %s", result.Stderr)
+	}
+
+	return nil
+}
+
+// Remove unloads and deletes the LaunchAgent
+func (s *launchdScheduler) Remove(ctx context.Context, environment string) error {
+	path := s.path(environment)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("no schedule installed for %s", environment)
+	}
+
+	s.executor.Execute(ctx, tools.Command{Name: "launchctl", Args: []string{"unload", "-w", path}})
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// List returns the LaunchAgents installed by plat
+func (s *launchdScheduler) List() ([]Entry, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, "com.plat.plat-up-*.plist"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if entry, ok := parseMarker(data); ok {
+			entry.Path = path
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,111 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Entry is a scheduled 'plat up' for one environment
+type Entry struct {
+	Environment string // Environment name, used to name the job
+	At          string // Local time of day, HH:MM
+	Weekdays    bool   // Only run Monday to Friday
+	Executable  string // Absolute path of the plat binary
+	ConfigFile  string // Absolute path of the environment config
+	LogFile     string // Where the job's output is written
+	SearchPath  string // PATH of the installing shell, so the job finds k3d, helm and kubectl
+	Path        string // Job definition file; set by List
+}
+
+// Scheduler registers jobs with the operating system's user scheduler
+type Scheduler interface {
+	// Name returns the scheduler backing this platform (launchd, systemd)
+	Name() string
+
+	// Install registers or replaces the job for an entry
+	Install(ctx context.Context, entry Entry) error
+
+	// Remove unregisters the job for an environment
+	Remove(ctx context.Context, environment string) error
+
+	// List returns all jobs installed by plat
+	List() ([]Entry, error)
+}
+
+// New returns the scheduler for the current platform
+func New() (Scheduler, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return newLaunchdScheduler()
+	case "linux":
+		return newSystemdScheduler()
+	default:
+		return nil, fmt.Errorf("scheduling is not supported on %s (requires launchd or systemd)", runtime.GOOS)
+	}
+}
+
+// ParseTime validates a HH:MM time of day and returns its parts
+func ParseTime(value string) (hour, minute int, err error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+
+	hour, err = strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("invalid hour in %q", value)
+	}
+	minute, err = strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q", value)
+	}
+
+	return hour, minute, nil
+}
+
+// jobName is the scheduler job identifier for an environment
+func jobName(environment string) string {
+	return "plat-up-" + environment
+}
+
+// markerPattern matches the metadata line written into every job definition
+var markerPattern = regexp.MustCompile(`(?m)plat-schedule env=(\S+) at=(\S+) weekdays=(\S+) config=(.+?)(?: -->)?$`)
+
+// marker renders the metadata line List reads back
+func marker(entry Entry) string {
+	return fmt.Sprintf("plat-schedule env=%s at=%s weekdays=%t config=%s", entry.Environment, entry.At, entry.Weekdays, entry.ConfigFile)
+}
+
+// parseMarker extracts an entry from a job definition written by plat
+func parseMarker(data []byte) (Entry, bool) {
+	match := markerPattern.FindSubmatch(data)
+	if match == nil {
+		return Entry{}, false
+	}
+	return Entry{
+		Environment: string(match[1]),
+		At:          string(match[2]),
+		Weekdays:    string(match[3]) == "true",
+		ConfigFile:  string(match[4]),
+	}, true
+}
+
+// upArgs returns the plat arguments a scheduled job runs
+func upArgs(entry Entry) []string {
+	return []string{"--config", entry.ConfigFile, "up", "--quiet"}
+}
+
+// jobEnvironment returns the environment variables a scheduled job runs
+// with, as name=value. launchd and systemd start jobs with a minimal PATH
+// that misses Homebrew and ~/.local/bin installs of the tools.
+func jobEnvironment(entry Entry) []string {
+	env := []string{"PLAT_BANNER=false"}
+	if entry.SearchPath != "" {
+		env = append([]string{"PATH=" + entry.SearchPath}, env...)
+	}
+	return env
+}
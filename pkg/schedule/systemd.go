@@ -0,0 +1,161 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"plat/pkg/tools"
+)
+
+// systemdScheduler installs user service and timer units on Linux
+type systemdScheduler struct {
+	dir      string
+	executor tools.ProcessExecutor
+}
+
+func newSystemdScheduler() (*systemdScheduler, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &systemdScheduler{
+		dir:      filepath.Join(configDir, "systemd", "user"),
+		executor: tools.NewProcessExecutor(),
+	}, nil
+}
+
+func (s *systemdScheduler) Name() string {
+	return "systemd"
+}
+
+func (s *systemdScheduler) unitPath(environment, suffix string) string {
+	return filepath.Join(s.dir, jobName(environment)+suffix)
+}
+
+// Install writes the service and timer units and enables the timer
+func (s *systemdScheduler) Install(ctx context.Context, entry Entry) error {
+	hour, minute, err := ParseTime(entry.At)
+	if err != nil {
+		return err
+	}
+
+	days := "*-*-*"
+	if entry.Weekdays {
+		days = "Mon..Fri *-*-*"
+	}
+
+	args := append([]string{entry.Executable}, upArgs(entry)...)
+	for i, arg := range args {
+		args[i] = quoteSystemdArg(arg)
+	}
+
+	env := jobEnvironment(entry)
+	for i, variable := range env {
+		env[i] = quoteSystemdArg(strings.ReplaceAll(variable, "%", "%%"))
+	}
+
+	service := fmt.Sprintf(`# %s
+[Unit]
+Description=Start plat environment %s
+
+[Service]
+Type=oneshot
+Environment=%s
+WorkingDirectory=%s
+ExecStart=%s
+StandardOutput=append:%s
+StandardError=append:%s
+`, marker(entry), entry.Environment, strings.Join(env, " "), filepath.Dir(filepath.Dir(entry.ConfigFile)),
+		strings.Join(args, " "), entry.LogFile, entry.LogFile)
+
+	timer := fmt.Sprintf(`# %s
+[Unit]
+Description=Warm up plat environment %s
+
+[Timer]
+OnCalendar=%s %02d:%02d:00
+Persistent=false
+
+[Install]
+WantedBy=timers.target
+`, marker(entry), entry.Environment, days, hour, minute)
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+	if err := os.WriteFile(s.unitPath(entry.Environment, ".service"), []byte(service), 0644); err != nil {
+		return fmt.Errorf("failed to write service unit: %w", err)
+	}
+	if err := os.WriteFile(s.unitPath(entry.Environment, ".timer"), []byte(timer), 0644); err != nil {
+		return fmt.Errorf("failed to write timer unit: %w", err)
+	}
+
+	if err := s.systemctl(ctx, "daemon-reload"); err != nil {
+		return err
+	}
+	return s.systemctl(ctx, "enable", "--now", jobName(entry.Environment)+".timer")
+}
+
+// Remove disables the timer and deletes both units
+func (s *systemdScheduler) Remove(ctx context.Context, environment string) error {
+	timerPath := s.unitPath(environment, ".timer")
+	if _, err := os.Stat(timerPath); os.IsNotExist(err) {
+		return fmt.Errorf("no schedule installed for %s", environment)
+	}
+
+	if err := s.systemctl(ctx, "disable", "--now", jobName(environment)+".timer"); err != nil {
+		return err
+	}
+
+	for _, path := range []string{timerPath, s.unitPath(environment, ".service")} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+
+	return s.systemctl(ctx, "daemon-reload")
+}
+
+// List returns the timers installed by plat
+func (s *systemdScheduler) List() ([]Entry, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, "plat-up-*.timer"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if entry, ok := parseMarker(data); ok {
+			entry.Path = path
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *systemdScheduler) systemctl(ctx context.Context, args ...string) error {
+	result, err := s.executor.Execute(ctx, tools.Command{
+		Name: "systemctl",
+		Args: append([]string{"--user"}, args...),
+	})
+	if err != nil {
+		return fmt.Errorf("systemctl %s failed: %s", strings.Join(args, " "), result.Stderr)
+	}
+	return nil
+}
+
+// quoteSystemdArg quotes an ExecStart argument containing spaces
+func quoteSystemdArg(arg string) string {
+	if !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
@@ -0,0 +1,248 @@
+// Package settings stores personal plat preferences that apply to every
+// project, in ~/.config/plat/settings.yml
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the settings file inside the plat user config directory
+const FileName = "settings.yml"
+
+// Setting keys
+const (
+	KeyMode      = "mode"
+	KeyDomain    = "domain"
+	KeyStrict    = "strict"
+	KeyTemplate  = "template"
+	KeyTelemetry = "telemetry"
+	KeyBanner    = "banner"
+	KeyTunnel    = "tunnel"
+)
+
+// Sources a setting's effective value can come from
+const (
+	SourceEnv      = "env"
+	SourceSettings = "settings"
+)
+
+// Key describes a setting: what it means, the environment variable that
+// overrides it and the values it accepts
+type Key struct {
+	Name        string
+	Description string
+	Env         string
+
+	// normalize validates a value and returns its canonical form
+	normalize func(string) (string, error)
+}
+
+// Keys lists every setting, in display order
+var Keys = []Key{
+	{Name: KeyMode, Description: "Default execution mode (local|artifact)", Env: "PLAT_MODE", normalize: oneOf("local", "artifact")},
+	{Name: KeyDomain, Description: "Ingress domain, overriding defaults.domain", Env: "PLAT_DOMAIN", normalize: nonEmpty},
+	{Name: KeyStrict, Description: "Strict validation, failing on warnings (true|false)", Env: "PLAT_STRICT", normalize: boolean},
+	{Name: KeyTemplate, Description: "Default 'plat init' template (microservices|fullstack|backend-only)", Env: "PLAT_TEMPLATE", normalize: oneOf("microservices", "fullstack", "backend-only")},
+	{Name: KeyTelemetry, Description: "Usage telemetry; false opts out (true|false)", Env: "PLAT_TELEMETRY", normalize: boolean},
+	{Name: KeyBanner, Description: "Print a one-line environment summary before commands (true|false)", Env: "PLAT_BANNER", normalize: boolean},
+	{Name: KeyTunnel, Description: "Default 'plat tunnel' provider (cloudflared|ngrok|tailscale)", Env: "PLAT_TUNNEL", normalize: oneOf("cloudflared", "ngrok", "tailscale")},
+}
+
+// Lookup returns the setting with the given name
+func Lookup(name string) (Key, bool) {
+	for _, key := range Keys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// UnknownKeyError reports a setting name that doesn't exist
+type UnknownKeyError struct {
+	Name string
+}
+
+func (e *UnknownKeyError) Error() string {
+	names := make([]string, 0, len(Keys))
+	for _, key := range Keys {
+		names = append(names, key.Name)
+	}
+	return fmt.Sprintf("unknown setting %q, must be one of: %s", e.Name, strings.Join(names, ", "))
+}
+
+// Settings is the settings file's content. The zero value holds no settings
+// and can be resolved but not saved.
+type Settings struct {
+	path   string
+	values map[string]string
+}
+
+// DefaultPath returns the settings file path: settings.yml in the plat
+// directory of the user config directory (~/.config/plat on Linux)
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user config directory: %w", err)
+	}
+	return filepath.Join(dir, "plat", FileName), nil
+}
+
+// Load reads the default settings file. A missing file holds no settings.
+func Load() (*Settings, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads a settings file. A missing file holds no settings.
+func LoadFile(path string) (*Settings, error) {
+	s := &Settings{path: path, values: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &s.values); err != nil {
+		return nil, fmt.Errorf("failed to parse settings %s: %w", path, err)
+	}
+	if s.values == nil {
+		s.values = make(map[string]string)
+	}
+	return s, nil
+}
+
+// Path returns the settings file path
+func (s *Settings) Path() string {
+	return s.path
+}
+
+// Get returns the stored value of a setting
+func (s *Settings) Get(name string) (string, bool) {
+	value, ok := s.values[name]
+	return value, ok
+}
+
+// Set validates and stores a setting; Save writes it
+func (s *Settings) Set(name, value string) error {
+	key, ok := Lookup(name)
+	if !ok {
+		return &UnknownKeyError{Name: name}
+	}
+	normalized, err := key.normalize(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", name, err)
+	}
+	s.values[name] = normalized
+	return nil
+}
+
+// Unset removes a stored setting, reporting whether it was set
+func (s *Settings) Unset(name string) (bool, error) {
+	if _, ok := Lookup(name); !ok {
+		return false, &UnknownKeyError{Name: name}
+	}
+	_, ok := s.values[name]
+	delete(s.values, name)
+	return ok, nil
+}
+
+// Save writes the settings file
+func (s *Settings) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create settings directory: %w", err)
+	}
+
+	// Sorted keys keep the file stable across writes
+	names := make([]string, 0, len(s.values))
+	for name := range s.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var node yaml.Node
+	node.Kind = yaml.MappingNode
+	for _, name := range names {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: name},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: s.values[name]})
+	}
+
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
+
+// Resolve returns a setting's effective value and where it came from: its
+// environment variable takes precedence over the settings file. Invalid
+// environment values are ignored.
+func (s *Settings) Resolve(name string) (value, source string, ok bool) {
+	key, known := Lookup(name)
+	if !known {
+		return "", "", false
+	}
+
+	if env := os.Getenv(key.Env); env != "" {
+		if normalized, err := key.normalize(env); err == nil {
+			return normalized, SourceEnv, true
+		}
+	}
+
+	if value, ok := s.values[name]; ok {
+		return value, SourceSettings, true
+	}
+	return "", "", false
+}
+
+// Bool resolves a boolean setting; unset settings are false
+func (s *Settings) Bool(name string) (value, ok bool) {
+	raw, _, ok := s.Resolve(name)
+	if !ok {
+		return false, false
+	}
+	value, _ = strconv.ParseBool(raw)
+	return value, true
+}
+
+func oneOf(allowed ...string) func(string) (string, error) {
+	return func(value string) (string, error) {
+		for _, a := range allowed {
+			if value == a {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("%q must be one of: %s", value, strings.Join(allowed, ", "))
+	}
+}
+
+func nonEmpty(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("value must not be empty")
+	}
+	return strings.TrimSpace(value), nil
+}
+
+func boolean(value string) (string, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Errorf("%q must be true or false", value)
+	}
+	return strconv.FormatBool(b), nil
+}
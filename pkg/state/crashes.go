@@ -0,0 +1,31 @@
+package state
+
+import "slices"
+
+// RecordCrashes records whether each checked service is crash-looping and
+// returns those that weren't already recorded as such (implements
+// orchestrator.CrashLedger)
+func (s *Store) RecordCrashes(crashing map[string]bool) ([]string, error) {
+	var newly []string
+	err := s.Update(func(st *State) error {
+		var crashed []string
+		for _, name := range st.Crashed {
+			if isCrashing, checked := crashing[name]; !checked || isCrashing {
+				crashed = append(crashed, name)
+			}
+		}
+		for name, isCrashing := range crashing {
+			if isCrashing && !slices.Contains(st.Crashed, name) {
+				newly = append(newly, name)
+				crashed = append(crashed, name)
+			}
+		}
+		slices.Sort(crashed)
+		st.Crashed = crashed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newly, nil
+}
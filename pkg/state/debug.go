@@ -0,0 +1,27 @@
+package state
+
+import (
+	"slices"
+	"sort"
+)
+
+// DebugServices returns the services switched to debug logging
+func (s *Store) DebugServices() ([]string, error) {
+	st, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return st.Debug, nil
+}
+
+// SetDebug switches debug logging of a service on or off
+func (s *Store) SetDebug(service string, on bool) error {
+	return s.Update(func(st *State) error {
+		st.Debug = slices.DeleteFunc(st.Debug, func(name string) bool { return name == service })
+		if on {
+			st.Debug = append(st.Debug, service)
+			sort.Strings(st.Debug)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,110 @@
+package state
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+// DeployRecord is a 'plat up' in progress. It is written before each phase
+// of each service starts and removed when the run ends, so a record whose
+// process is gone belongs to a run that aborted (killed, out of memory, the
+// laptop put to sleep).
+type DeployRecord struct {
+	PID       int                    `json:"pid"`
+	StartedAt time.Time              `json:"started_at"`
+	Services  map[string]PhaseRecord `json:"services"`        // Phase of each service the run deploys
+	Order     []string               `json:"order,omitempty"` // Services in the order the run was given them
+}
+
+// PhaseRecord is the deploy phase a service was last in
+type PhaseRecord struct {
+	Phase string    `json:"phase"` // pending, deploying, deployed or failed
+	Since time.Time `json:"since"`
+}
+
+// PhasePending is the phase of services a run hasn't started deploying
+const PhasePending = "pending"
+
+// InPhase returns the names of services in a phase, in the order the run
+// was given them. Services missing from the order, such as every service
+// of a record written before it was kept, follow sorted.
+func (r *DeployRecord) InPhase(phase string) []string {
+	ordered := make(map[string]bool, len(r.Order))
+	var names, unordered []string
+	for _, name := range r.Order {
+		ordered[name] = true
+		if record, ok := r.Services[name]; ok && record.Phase == phase {
+			names = append(names, name)
+		}
+	}
+	for name, record := range r.Services {
+		if !ordered[name] && record.Phase == phase {
+			unordered = append(unordered, name)
+		}
+	}
+	sort.Strings(unordered)
+	return append(names, unordered...)
+}
+
+// BeginDeploy records a run of this process about to deploy the services,
+// replacing the record of an earlier run
+func (s *Store) BeginDeploy(services []string) error {
+	now := s.clock.Now()
+	record := &DeployRecord{
+		PID:       os.Getpid(),
+		StartedAt: now,
+		Services:  make(map[string]PhaseRecord, len(services)),
+		Order:     append([]string(nil), services...),
+	}
+	for _, name := range services {
+		record.Services[name] = PhaseRecord{Phase: PhasePending, Since: now}
+	}
+	return s.Update(func(st *State) error {
+		st.Deploy = record
+		return nil
+	})
+}
+
+// RecordPhase records a service of the running deploy entering a phase. The
+// state file is written before it returns.
+func (s *Store) RecordPhase(service, phase string) error {
+	return s.Update(func(st *State) error {
+		if st.Deploy == nil || st.Deploy.PID != os.Getpid() {
+			return nil
+		}
+		st.Deploy.Services[service] = PhaseRecord{Phase: phase, Since: s.clock.Now()}
+		return nil
+	})
+}
+
+// EndDeploy removes the record of this process's run, which ended on its own
+// whether it succeeded or not
+func (s *Store) EndDeploy() error {
+	return s.Update(func(st *State) error {
+		if st.Deploy != nil && st.Deploy.PID == os.Getpid() {
+			st.Deploy = nil
+		}
+		return nil
+	})
+}
+
+// AbortedDeploy returns the record of a run whose process is gone without
+// having ended it, or nil
+func (s *Store) AbortedDeploy() (*DeployRecord, error) {
+	st, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	record := st.Deploy
+	if record == nil || record.PID == os.Getpid() || isPlatProcess(record.PID) {
+		return nil, nil
+	}
+	return record, nil
+}
+
+// isPlatProcess reports whether the PID is alive and runs plat, rather than
+// another process that reused the PID after a reboot
+func isPlatProcess(pid int) bool {
+	return isRecordedProcess(ProcessRecord{PID: pid, Command: os.Args[0]})
+}
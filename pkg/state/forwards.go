@@ -0,0 +1,87 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// ForwardRecord is a port-forward kept alive by a 'plat forward' process
+type ForwardRecord struct {
+	Service   string    `json:"service"`
+	Ports     []string  `json:"ports"` // local:remote
+	PID       int       `json:"pid"`   // PID of the plat process running the forward
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Uptime returns how long the forward has been running
+func (f ForwardRecord) Uptime() time.Duration {
+	return time.Since(f.StartedAt)
+}
+
+// ForwardStarted records a forward run by the current process. It fails if
+// another live process already forwards the service.
+func (s *Store) ForwardStarted(service string, ports []string) error {
+	return s.Update(func(st *State) error {
+		st.Forwards = liveForwards(st.Forwards)
+		for _, f := range st.Forwards {
+			if f.Service == service {
+				return fmt.Errorf("%s is already forwarded by PID %d, stop it with 'plat forward stop %s'", service, f.PID, service)
+			}
+		}
+		st.Forwards = append(st.Forwards, ForwardRecord{
+			Service:   service,
+			Ports:     ports,
+			PID:       os.Getpid(),
+			StartedAt: s.clock.Now(),
+		})
+		return nil
+	})
+}
+
+// ForwardsStopped removes the forwards run by the current process
+func (s *Store) ForwardsStopped() {
+	pid := os.Getpid()
+	_ = s.Update(func(st *State) error {
+		kept := st.Forwards[:0]
+		for _, f := range st.Forwards {
+			if f.PID != pid {
+				kept = append(kept, f)
+			}
+		}
+		st.Forwards = kept
+		return nil
+	})
+}
+
+// Forwards returns the running forwards. Records of processes that already
+// exited are pruned from the state file.
+func (s *Store) Forwards() ([]ForwardRecord, error) {
+	var forwards []ForwardRecord
+	err := s.Update(func(st *State) error {
+		st.Forwards = liveForwards(st.Forwards)
+		forwards = append(forwards, st.Forwards...)
+		return nil
+	})
+	return forwards, err
+}
+
+// StopForward terminates the process running a forward. The process removes
+// its own records as it exits; every service it forwards stops with it.
+func (s *Store) StopForward(f ForwardRecord) error {
+	if err := killProcess(f.PID); err != nil {
+		return fmt.Errorf("failed to stop forward for %s (PID %d): %w", f.Service, f.PID, err)
+	}
+	return nil
+}
+
+// liveForwards drops records whose process is gone
+func liveForwards(forwards []ForwardRecord) []ForwardRecord {
+	kept := forwards[:0]
+	for _, f := range forwards {
+		if processAlive(f.PID) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,19 @@
+package state
+
+// NetworkPoliciesOff reports whether network policies were switched off
+// with 'plat netpol disable'
+func (s *Store) NetworkPoliciesOff() (bool, error) {
+	st, err := s.Load()
+	if err != nil {
+		return false, err
+	}
+	return st.NetworkPoliciesOff, nil
+}
+
+// SetNetworkPoliciesOff switches network policies off, or back on
+func (s *Store) SetNetworkPoliciesOff(off bool) error {
+	return s.Update(func(st *State) error {
+		st.NetworkPoliciesOff = off
+		return nil
+	})
+}
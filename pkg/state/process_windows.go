@@ -0,0 +1,28 @@
+//go:build windows
+
+package state
+
+import "os"
+
+// processAlive reports whether a process with the PID exists
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	_, err := os.FindProcess(pid)
+	return err == nil
+}
+
+// processName is not available without extra dependencies on Windows
+func processName(pid int) (string, bool) {
+	return "", false
+}
+
+// killProcess terminates a process
+func killProcess(pid int) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Kill()
+}
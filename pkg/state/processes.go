@@ -0,0 +1,95 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+// ProcessRecord is an external process spawned by a plat invocation
+type ProcessRecord struct {
+	PID       int       `json:"pid"`
+	Command   string    `json:"command"`
+	Args      []string  `json:"args,omitempty"`
+	OwnerPID  int       `json:"owner_pid"` // PID of the plat process that spawned it
+	StartedAt time.Time `json:"started_at"`
+}
+
+// ProcessStarted records a spawned process (implements tools.ProcessTracker)
+func (s *Store) ProcessStarted(pid int, cmd tools.Command) {
+	_ = s.Update(func(st *State) error {
+		st.Processes = append(st.Processes, ProcessRecord{
+			PID:       pid,
+			Command:   cmd.Name,
+			Args:      cmd.Args,
+			OwnerPID:  os.Getpid(),
+			StartedAt: s.clock.Now(),
+		})
+		return nil
+	})
+}
+
+// ProcessExited removes a reaped process (implements tools.ProcessTracker)
+func (s *Store) ProcessExited(pid int) {
+	_ = s.Update(func(st *State) error {
+		kept := st.Processes[:0]
+		for _, p := range st.Processes {
+			if p.PID != pid {
+				kept = append(kept, p)
+			}
+		}
+		st.Processes = kept
+		return nil
+	})
+}
+
+// FindOrphans returns recorded processes that are still running although the
+// plat process that spawned them is gone. Records of processes that already
+// exited are pruned from the state file.
+func (s *Store) FindOrphans() ([]ProcessRecord, error) {
+	var orphans []ProcessRecord
+
+	err := s.Update(func(st *State) error {
+		kept := st.Processes[:0]
+		for _, p := range st.Processes {
+			if !isRecordedProcess(p) {
+				continue // Exited (or PID reused by something else)
+			}
+			kept = append(kept, p)
+			if !processAlive(p.OwnerPID) {
+				orphans = append(orphans, p)
+			}
+		}
+		st.Processes = kept
+		return nil
+	})
+
+	return orphans, err
+}
+
+// KillOrphans terminates the given orphaned processes and forgets them
+func (s *Store) KillOrphans(orphans []ProcessRecord) []error {
+	var errs []error
+	for _, p := range orphans {
+		if err := killProcess(p.PID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.ProcessExited(p.PID)
+	}
+	return errs
+}
+
+// isRecordedProcess checks the PID is alive and still runs the recorded command
+func isRecordedProcess(p ProcessRecord) bool {
+	if !processAlive(p.PID) {
+		return false
+	}
+	name, ok := processName(p.PID)
+	if !ok {
+		return true // Can't verify on this platform; trust the PID
+	}
+	return filepath.Base(name) == filepath.Base(p.Command)
+}
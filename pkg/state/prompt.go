@@ -0,0 +1,82 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PromptFileName is the prompt status cache written next to config.yml
+const PromptFileName = "prompt.json"
+
+// PromptStatus is the cached environment summary shown by 'plat prompt'
+type PromptStatus struct {
+	Environment string    `json:"environment"`
+	ClusterUp   bool      `json:"clusterUp"`
+	Healthy     int       `json:"healthy"`
+	Total       int       `json:"total"`
+	CheckedAt   time.Time `json:"checkedAt"`
+}
+
+// Age returns how long ago the status was checked
+func (p *PromptStatus) Age() time.Duration {
+	return time.Since(p.CheckedAt)
+}
+
+// ReadPromptStatus reads the cached prompt status from the config directory
+func ReadPromptStatus(configDir string) (*PromptStatus, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, PromptFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var status PromptStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt cache: %w", err)
+	}
+	return &status, nil
+}
+
+// WritePromptStatus caches the prompt status in the config directory
+func WritePromptStatus(configDir string, status *PromptStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to encode prompt cache: %w", err)
+	}
+
+	// Write atomically; prompts read the file while refreshes write it
+	path := filepath.Join(configDir, PromptFileName)
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// promptRefreshTimeout is how long a refresh claim is honored before another
+// prompt may start a new refresh (e.g. after a refresh process was killed)
+const promptRefreshTimeout = time.Minute
+
+// ClaimPromptRefresh marks a background prompt refresh as running. It
+// returns false if another refresh already holds the claim, so shells
+// rendering many prompts at once start only one refresh.
+func ClaimPromptRefresh(configDir string) bool {
+	path := filepath.Join(configDir, PromptFileName+".lock")
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > promptRefreshTimeout {
+		os.Remove(path)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// ReleasePromptRefresh removes the refresh claim
+func ReleasePromptRefresh(configDir string) {
+	os.Remove(filepath.Join(configDir, PromptFileName+".lock"))
+}
@@ -0,0 +1,18 @@
+package state
+
+// Restarts returns the service restart counts recorded by the last status
+func (s *Store) Restarts() (map[string]int, error) {
+	st, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return st.Restarts, nil
+}
+
+// RecordRestarts replaces the recorded service restart counts
+func (s *Store) RecordRestarts(counts map[string]int) error {
+	return s.Update(func(st *State) error {
+		st.Restarts = counts
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package state
+
+import "time"
+
+// RollbackRecord is the last rollback of a service's Helm release
+type RollbackRecord struct {
+	Service   string    `json:"service"`
+	From      int       `json:"from"`     // Revision rolled back
+	To        int       `json:"to"`       // Revision restored
+	Revision  int       `json:"revision"` // Revision the rollback created
+	Reason    string    `json:"reason,omitempty"`
+	Automatic bool      `json:"automatic,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Rollbacks returns the last rollback of each service, by service
+func (s *Store) Rollbacks() (map[string]RollbackRecord, error) {
+	st, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	rollbacks := make(map[string]RollbackRecord, len(st.Rollbacks))
+	for _, record := range st.Rollbacks {
+		rollbacks[record.Service] = record
+	}
+	return rollbacks, nil
+}
+
+// RecordRollbacks records rollbacks, replacing earlier ones of the same services
+func (s *Store) RecordRollbacks(records []RollbackRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return s.Update(func(st *State) error {
+		for _, record := range records {
+			replaced := false
+			for i := range st.Rollbacks {
+				if st.Rollbacks[i].Service == record.Service {
+					st.Rollbacks[i] = record
+					replaced = true
+				}
+			}
+			if !replaced {
+				st.Rollbacks = append(st.Rollbacks, record)
+			}
+		}
+		return nil
+	})
+}
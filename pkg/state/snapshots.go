@@ -0,0 +1,172 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SnapshotsFileName is the status history written next to config.yml
+const SnapshotsFileName = "snapshots.json"
+
+const (
+	// snapshotInterval is the least time between two recorded snapshots, so
+	// frequent status checks don't crowd out the history
+	snapshotInterval = time.Minute
+
+	// snapshotRetention is how long snapshots are kept
+	snapshotRetention = 24 * time.Hour
+)
+
+// Snapshot is the environment status at one point in time
+type Snapshot struct {
+	Time     time.Time                  `json:"time"`
+	Cluster  string                     `json:"cluster"` // Cluster status: running, stopped, not-found
+	Services map[string]ServiceSnapshot `json:"services"`
+}
+
+// ServiceSnapshot is one service's status in a snapshot
+type ServiceSnapshot struct {
+	Status   string `json:"status"` // Helm status, or not-deployed
+	Version  string `json:"version,omitempty"`
+	Ready    bool   `json:"ready"`
+	Revision int    `json:"revision,omitempty"`
+	Restarts int    `json:"restarts,omitempty"`
+}
+
+// ReadSnapshots returns the recorded snapshots, oldest first. A missing file
+// holds none.
+func ReadSnapshots(configDir string) ([]Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, SnapshotsFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse status history: %w", err)
+	}
+	return snapshots, nil
+}
+
+// RecordSnapshot adds a snapshot to the history unless one was recorded less
+// than a minute before it, and drops snapshots older than a day
+func RecordSnapshot(configDir string, snapshot Snapshot) error {
+	snapshots, err := ReadSnapshots(configDir)
+	if err != nil {
+		// Start over rather than fail every status on a damaged file
+		snapshots = nil
+	}
+	if n := len(snapshots); n > 0 && snapshot.Time.Sub(snapshots[n-1].Time) < snapshotInterval {
+		return nil
+	}
+
+	cutoff := snapshot.Time.Add(-snapshotRetention)
+	kept := snapshots[:0]
+	for _, s := range snapshots {
+		if s.Time.After(cutoff) {
+			kept = append(kept, s)
+		}
+	}
+	kept = append(kept, snapshot)
+
+	data, err := json.Marshal(kept)
+	if err != nil {
+		return fmt.Errorf("failed to encode status history: %w", err)
+	}
+
+	// Write atomically; prompt refreshes record snapshots in the background
+	path := filepath.Join(configDir, SnapshotsFileName)
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// SnapshotAt returns the latest snapshot taken at or before t, or the oldest
+// one when all are newer. It returns false when there are none.
+func SnapshotAt(snapshots []Snapshot, t time.Time) (Snapshot, bool) {
+	if len(snapshots) == 0 {
+		return Snapshot{}, false
+	}
+	found := snapshots[0]
+	for _, s := range snapshots {
+		if s.Time.After(t) {
+			break
+		}
+		found = s
+	}
+	return found, true
+}
+
+// SnapshotChange is one difference between two snapshots
+type SnapshotChange struct {
+	Service string `json:"service,omitempty"` // Empty for the cluster
+	Field   string `json:"field"`             // added, removed, cluster, status, version, ready, revision or restarts
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+}
+
+// DiffSnapshots lists what changed from one snapshot to a later one: the
+// cluster status, services appearing and disappearing, and each service's
+// status, version, readiness, revision and restarts
+func DiffSnapshots(from, to Snapshot) []SnapshotChange {
+	var changes []SnapshotChange
+	if from.Cluster != to.Cluster {
+		changes = append(changes, SnapshotChange{Field: "cluster", From: from.Cluster, To: to.Cluster})
+	}
+
+	names := make(map[string]bool)
+	for name := range from.Services {
+		names[name] = true
+	}
+	for name := range to.Services {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		before, existed := from.Services[name]
+		after, exists := to.Services[name]
+		switch {
+		case !existed:
+			changes = append(changes, SnapshotChange{Service: name, Field: "added", To: after.Status})
+			continue
+		case !exists:
+			changes = append(changes, SnapshotChange{Service: name, Field: "removed", From: before.Status})
+			continue
+		}
+
+		if before.Status != after.Status {
+			changes = append(changes, SnapshotChange{Service: name, Field: "status", From: before.Status, To: after.Status})
+		}
+		if before.Version != after.Version {
+			changes = append(changes, SnapshotChange{Service: name, Field: "version", From: before.Version, To: after.Version})
+		}
+		if before.Ready != after.Ready {
+			changes = append(changes, SnapshotChange{Service: name, Field: "ready", From: strconv.FormatBool(before.Ready), To: strconv.FormatBool(after.Ready)})
+		}
+		if before.Revision != after.Revision && before.Revision > 0 && after.Revision > 0 {
+			changes = append(changes, SnapshotChange{Service: name, Field: "revision", From: strconv.Itoa(before.Revision), To: strconv.Itoa(after.Revision)})
+		}
+		// Counts reset when pods are replaced, so only increases are restarts
+		if after.Restarts > before.Restarts {
+			changes = append(changes, SnapshotChange{Service: name, Field: "restarts", From: strconv.Itoa(before.Restarts), To: strconv.Itoa(after.Restarts)})
+		}
+	}
+	return changes
+}
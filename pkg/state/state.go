@@ -0,0 +1,149 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"plat/pkg/clock"
+	"plat/pkg/fsys"
+)
+
+// FileName is the state file written next to config.yml
+const FileName = "state.json"
+
+// State is plat's persisted runtime bookkeeping for an environment
+type State struct {
+	Processes          []ProcessRecord  `json:"processes,omitempty"`
+	Forwards           []ForwardRecord  `json:"forwards,omitempty"`
+	Tunnels            []TunnelRecord   `json:"tunnels,omitempty"`
+	Restarts           map[string]int   `json:"restarts,omitempty"`           // Restart counts of the last 'plat status', by service
+	Debug              []string         `json:"debug,omitempty"`              // Services switched to debug logging with 'plat debug enable'
+	Rollbacks          []RollbackRecord `json:"rollbacks,omitempty"`          // Last rollback of each service
+	NetworkPoliciesOff bool             `json:"networkPoliciesOff,omitempty"` // Switched off with 'plat netpol disable'
+	Deploy             *DeployRecord    `json:"deploy,omitempty"`             // 'plat up' in progress, left behind by one that aborted
+	Crashed            []string         `json:"crashed,omitempty"`            // Services notified as crash-looping, while they still are
+}
+
+// Store reads and writes the environment state file. Updates hold a lock
+// file next to it, so plat processes sharing an environment (the TUI and a
+// CLI command, a prompt refresh) don't overwrite each other's records.
+type Store struct {
+	path  string
+	mu    sync.Mutex
+	fs    fsys.FS
+	clock clock.Clock
+}
+
+var (
+	storesMu sync.Mutex
+	stores   = make(map[string]*Store)
+)
+
+// NewStore returns the state store inside the given config directory. A
+// process has one store per state file, so commands and the process tracker
+// share its lock.
+func NewStore(configDir string) *Store {
+	path := filepath.Join(configDir, FileName)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	storesMu.Lock()
+	defer storesMu.Unlock()
+	if store, ok := stores[path]; ok {
+		return store
+	}
+	store := &Store{
+		path:  path,
+		fs:    fsys.OS{},
+		clock: clock.Real{},
+	}
+	stores[path] = store
+	return store
+}
+
+// SetFS sets the file system the state file is kept on
+func (s *Store) SetFS(fs fsys.FS) {
+	s.fs = fs
+}
+
+// SetClock sets the clock that stamps state records
+func (s *Store) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// Path returns the location of the state file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the current state. A missing file yields an empty state.
+func (s *Store) Load() (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Update applies fn to the current state and persists the result, holding
+// the lock file throughout so no other process updates the state in between
+func (s *Store) Update(fn func(*State) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.fs.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	unlock, err := s.fs.Lock(s.path + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock state file: %w", err)
+	}
+	defer unlock()
+
+	st, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(st); err != nil {
+		return err
+	}
+
+	return s.save(st)
+}
+
+func (s *Store) load() (*State, error) {
+	data, err := s.fs.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", s.path, err)
+	}
+	return &st, nil
+}
+
+func (s *Store) save(st *State) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	// Write atomically so a crash never leaves a truncated state file
+	tmp, err := s.fs.WriteTemp(filepath.Dir(s.path), FileName+".*.tmp", data, 0644)
+	if err != nil {
+		return err
+	}
+	if err := s.fs.Rename(tmp, s.path); err != nil {
+		s.fs.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// TunnelRecord is a public tunnel kept open by a 'plat tunnel' process
+type TunnelRecord struct {
+	Service   string    `json:"service"`
+	Provider  string    `json:"provider"`
+	URL       string    `json:"url"`
+	PID       int       `json:"pid"` // PID of the plat process running the tunnel
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"` // Zero when the tunnel stays open until stopped
+}
+
+// TunnelStarted records a tunnel run by the current process. It fails if
+// another live process already tunnels the service.
+func (s *Store) TunnelStarted(service, provider, url string, expiresAt time.Time) error {
+	return s.Update(func(st *State) error {
+		st.Tunnels = liveTunnels(st.Tunnels)
+		for _, t := range st.Tunnels {
+			if t.Service == service {
+				return fmt.Errorf("%s is already exposed at %s by PID %d", service, t.URL, t.PID)
+			}
+		}
+		st.Tunnels = append(st.Tunnels, TunnelRecord{
+			Service:   service,
+			Provider:  provider,
+			URL:       url,
+			PID:       os.Getpid(),
+			StartedAt: s.clock.Now(),
+			ExpiresAt: expiresAt,
+		})
+		return nil
+	})
+}
+
+// TunnelsStopped removes the tunnels run by the current process
+func (s *Store) TunnelsStopped() {
+	pid := os.Getpid()
+	_ = s.Update(func(st *State) error {
+		kept := st.Tunnels[:0]
+		for _, t := range st.Tunnels {
+			if t.PID != pid {
+				kept = append(kept, t)
+			}
+		}
+		st.Tunnels = kept
+		return nil
+	})
+}
+
+// Tunnels returns the open tunnels. Records of processes that already
+// exited are pruned from the state file.
+func (s *Store) Tunnels() ([]TunnelRecord, error) {
+	var tunnels []TunnelRecord
+	err := s.Update(func(st *State) error {
+		st.Tunnels = liveTunnels(st.Tunnels)
+		tunnels = append(tunnels, st.Tunnels...)
+		return nil
+	})
+	return tunnels, err
+}
+
+// liveTunnels drops records whose process is gone
+func liveTunnels(tunnels []TunnelRecord) []TunnelRecord {
+	kept := tunnels[:0]
+	for _, t := range tunnels {
+		if processAlive(t.PID) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
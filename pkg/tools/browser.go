@@ -0,0 +1,28 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// OpenURL opens url in the default browser
+func OpenURL(ctx context.Context, url string) error {
+	var cmd Command
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = Command{Name: "open", Args: []string{url}}
+	case "windows":
+		cmd = Command{Name: "rundll32", Args: []string{"url.dll,FileProtocolHandler", url}}
+	default:
+		cmd = Command{Name: "xdg-open", Args: []string{url}}
+	}
+	cmd.Timeout = queryTimeout()
+
+	executor := NewProcessExecutor()
+	result, err := executor.Execute(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %s", url, result.Stderr)
+	}
+	return nil
+}
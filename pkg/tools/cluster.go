@@ -0,0 +1,34 @@
+package tools
+
+import "strings"
+
+// Cluster providers selectable with defaults.clusterProvider
+const (
+	ClusterProviderK3d      = "k3d"      // k3s in docker (default)
+	ClusterProviderKind     = "kind"     // Kubernetes in docker
+	ClusterProviderMinikube = "minikube" // minikube with its default driver
+)
+
+// NewClusterProvider returns the provider for a defaults.clusterProvider
+// value, with its nodes in a container runtime. An empty name selects k3d.
+func NewClusterProvider(name string, runtime ContainerRuntime) ClusterProvider {
+	switch name {
+	case ClusterProviderKind:
+		return NewKindProvider(runtime)
+	case ClusterProviderMinikube:
+		return NewMinikubeProvider(runtime)
+	default:
+		return NewK3dProvider(runtime)
+	}
+}
+
+// portMapping splits a k3d-style port mapping ("8080:80@loadbalancer") into
+// its host and container ports
+func portMapping(mapping string) (host, container string) {
+	mapping, _, _ = strings.Cut(mapping, "@")
+	host, container, found := strings.Cut(mapping, ":")
+	if !found {
+		return mapping, mapping
+	}
+	return host, container
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DockerDiskUsage is docker's accounting of the disk it uses, from
+// 'docker system df -v'
+type DockerDiskUsage struct {
+	Images     []DockerImageUsage
+	Containers []DockerContainerUsage
+	Volumes    []DockerVolumeUsage
+}
+
+// DockerImageUsage is the disk used by an image
+type DockerImageUsage struct {
+	ID         string
+	Repository string
+	Tag        string
+	Size       int64 // Including layers shared with other images
+	UniqueSize int64 // Freed by removing the image
+}
+
+// DockerContainerUsage is the disk used by a container's writable layer
+type DockerContainerUsage struct {
+	Name string
+	Size int64
+}
+
+// DockerVolumeUsage is the disk used by a volume
+type DockerVolumeUsage struct {
+	Name string
+	Size int64
+}
+
+// GetDockerDiskUsage returns the disk used by docker images, containers and
+// volumes
+func GetDockerDiskUsage(ctx context.Context) (*DockerDiskUsage, error) {
+	cmd := Command{
+		Name:    "docker",
+		Args:    []string{"system", "df", "-v", "--format", "{{json .}}"},
+		Timeout: queryTimeout(),
+	}
+
+	result, err := NewProcessExecutor().Execute(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get docker disk usage: %w", err)
+	}
+
+	// Sizes are human readable strings such as "1.2GB"
+	var raw struct {
+		Images []struct {
+			ID         string
+			Repository string
+			Tag        string
+			Size       string
+			UniqueSize string
+		}
+		Containers []struct {
+			Names string
+			Size  string
+		}
+		Volumes []struct {
+			Name string
+			Size string
+		}
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse docker disk usage: %w", err)
+	}
+
+	usage := &DockerDiskUsage{}
+	for _, image := range raw.Images {
+		usage.Images = append(usage.Images, DockerImageUsage{
+			ID:         image.ID,
+			Repository: image.Repository,
+			Tag:        image.Tag,
+			Size:       parseDockerSize(image.Size),
+			UniqueSize: parseDockerSize(image.UniqueSize),
+		})
+	}
+	for _, container := range raw.Containers {
+		usage.Containers = append(usage.Containers, DockerContainerUsage{
+			Name: container.Names,
+			Size: parseDockerSize(container.Size),
+		})
+	}
+	for _, volume := range raw.Volumes {
+		usage.Volumes = append(usage.Volumes, DockerVolumeUsage{
+			Name: volume.Name,
+			Size: parseDockerSize(volume.Size),
+		})
+	}
+	return usage, nil
+}
+
+// dockerSizeUnits are the decimal units docker prints sizes in
+var dockerSizeUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	// Longest suffixes first, so "kB" isn't read as "B"
+	{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12}, {"B", 1},
+}
+
+// parseDockerSize converts a size printed by docker ("1.2GB", "0B", or
+// "12kB (virtual 90MB)") to bytes; unparseable sizes are 0
+func parseDockerSize(size string) int64 {
+	size, _, _ = strings.Cut(strings.TrimSpace(size), " ")
+	for _, unit := range dockerSizeUnits {
+		if number, ok := strings.CutSuffix(size, unit.suffix); ok {
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0
+			}
+			return int64(value * unit.multiplier)
+		}
+	}
+	return 0
+}
@@ -3,13 +3,78 @@ package tools
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
 )
 
+// DefaultKillGrace is how long a process gets to exit after SIGTERM before it is killed
+const DefaultKillGrace = 5 * time.Second
+
+// Timeouts limits how long plat waits on the external tools it drives.
+// Zero fields keep the defaults.
+type Timeouts struct {
+	Query     time.Duration // Read-only lookups (status, list, get)
+	Mutate    time.Duration // Uninstalls, repository updates, cluster deletion
+	Install   time.Duration // helm --wait plus margin, cluster creation
+	KillGrace time.Duration // Time between SIGTERM and SIGKILL for commands without their own
+}
+
+// DefaultTimeouts are the timeouts used unless configured otherwise
+var DefaultTimeouts = Timeouts{
+	Query:     30 * time.Second,
+	Mutate:    2 * time.Minute,
+	Install:   6 * time.Minute,
+	KillGrace: DefaultKillGrace,
+}
+
+var (
+	timeoutsMu sync.RWMutex
+	timeouts   = DefaultTimeouts
+)
+
+// SetTimeouts sets the timeouts applied to external tools, e.g. from
+// defaults.timeouts in the config
+func SetTimeouts(t Timeouts) {
+	if t.Query <= 0 {
+		t.Query = DefaultTimeouts.Query
+	}
+	if t.Mutate <= 0 {
+		t.Mutate = DefaultTimeouts.Mutate
+	}
+	if t.Install <= 0 {
+		t.Install = DefaultTimeouts.Install
+	}
+	if t.KillGrace <= 0 {
+		t.KillGrace = DefaultTimeouts.KillGrace
+	}
+
+	timeoutsMu.Lock()
+	defer timeoutsMu.Unlock()
+	timeouts = t
+}
+
+// CurrentTimeouts returns the timeouts applied to external tools
+func CurrentTimeouts() Timeouts {
+	timeoutsMu.RLock()
+	defer timeoutsMu.RUnlock()
+	return timeouts
+}
+
+func queryTimeout() time.Duration   { return CurrentTimeouts().Query }
+func mutateTimeout() time.Duration  { return CurrentTimeouts().Mutate }
+func installTimeout() time.Duration { return CurrentTimeouts().Install }
+
+// ErrCommandTimeout is returned when a command exceeds its Timeout
+var ErrCommandTimeout = errors.New("command timed out")
+
 // DefaultProcessExecutor implements ProcessExecutor using Go's os/exec
 type DefaultProcessExecutor struct{}
 
@@ -20,26 +85,18 @@ func NewProcessExecutor() ProcessExecutor {
 
 // Execute runs a command and captures all output
 func (e *DefaultProcessExecutor) Execute(ctx context.Context, cmd Command) (*ExecuteResult, error) {
-	execCmd := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
-
-	// Set working directory if specified
-	if cmd.Dir != "" {
-		execCmd.Dir = cmd.Dir
-	}
-
-	// Set environment variables
-	if len(cmd.Env) > 0 {
-		execCmd.Env = os.Environ()
-		for key, value := range cmd.Env {
-			execCmd.Env = append(execCmd.Env, fmt.Sprintf("%s=%s", key, value))
-		}
-	}
+	execCmd, runCtx, cancel := prepareCommand(ctx, cmd)
+	defer cancel()
 
 	var stdout, stderr bytes.Buffer
+	execCmd.Stdin = cmd.Stdin
 	execCmd.Stdout = &stdout
 	execCmd.Stderr = &stderr
 
-	err := execCmd.Run()
+	err := runTracked(execCmd, cmd)
+	if timeoutErr := checkTimeout(ctx, runCtx, cmd); timeoutErr != nil {
+		err = timeoutErr
+	}
 
 	result := &ExecuteResult{
 		ExitCode: 0,
@@ -53,6 +110,9 @@ func (e *DefaultProcessExecutor) Execute(ctx context.Context, cmd Command) (*Exe
 		} else {
 			result.ExitCode = 1
 		}
+		if errors.Is(err, ErrCommandTimeout) {
+			return result, err
+		}
 		// Include stderr in error message for better debugging
 		if result.Stderr != "" {
 			return result, fmt.Errorf("command failed: %w\nStderr: %s", err, result.Stderr)
@@ -65,7 +125,137 @@ func (e *DefaultProcessExecutor) Execute(ctx context.Context, cmd Command) (*Exe
 
 // Stream runs a command with real-time output streaming
 func (e *DefaultProcessExecutor) Stream(ctx context.Context, cmd Command, output io.Writer) error {
-	execCmd := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
+	return e.StreamOutput(ctx, cmd, StreamOptions{Stdout: output, Stderr: output})
+}
+
+// StreamOutput runs a command streaming stdout and stderr separately
+func (e *DefaultProcessExecutor) StreamOutput(ctx context.Context, cmd Command, opts StreamOptions) error {
+	execCmd, runCtx, cancel := prepareCommand(ctx, cmd)
+	defer cancel()
+
+	stdout := newLineWriter(opts.Stdout, StreamStdout, opts.OnLine)
+	stderr := newLineWriter(opts.Stderr, StreamStderr, opts.OnLine)
+	execCmd.Stdout = stdout
+	execCmd.Stderr = stderr
+
+	err := runTracked(execCmd, cmd)
+
+	// Deliver any trailing partial lines
+	stdout.Flush()
+	stderr.Flush()
+
+	if timeoutErr := checkTimeout(ctx, runCtx, cmd); timeoutErr != nil {
+		return timeoutErr
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("streaming command cancelled: %w", ctx.Err())
+	}
+	if err != nil {
+		return fmt.Errorf("streaming command failed: %w", err)
+	}
+
+	return nil
+}
+
+// ExecuteInteractive runs a command with the user's terminal attached. Output
+// is not captured; the result only carries the exit code.
+func (e *DefaultProcessExecutor) ExecuteInteractive(ctx context.Context, cmd Command) (*ExecuteResult, error) {
+	stdin := cmd.Stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+
+	if cmd.TTY {
+		if f, ok := stdin.(*os.File); !ok || !isatty.IsTerminal(f.Fd()) {
+			return &ExecuteResult{ExitCode: 1}, fmt.Errorf("%s requires an interactive terminal", cmd.Name)
+		}
+	}
+
+	execCmd, runCtx, cancel := prepareCommand(ctx, cmd)
+	defer cancel()
+
+	execCmd.Stdin = stdin
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+
+	result := &ExecuteResult{}
+	if err := runTracked(execCmd, cmd); err != nil {
+		if timeoutErr := checkTimeout(ctx, runCtx, cmd); timeoutErr != nil {
+			result.ExitCode = 1
+			return result, timeoutErr
+		}
+		if exitError, ok := err.(*exec.ExitError); ok {
+			result.ExitCode = exitError.ExitCode()
+		} else {
+			result.ExitCode = 1
+		}
+		return result, fmt.Errorf("interactive command failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// lineWriter forwards output to a writer and splits it into lines for a callback
+type lineWriter struct {
+	out     io.Writer
+	stream  StreamName
+	onLine  func(StreamName, string)
+	mu      sync.Mutex
+	pending []byte
+}
+
+func newLineWriter(out io.Writer, stream StreamName, onLine func(StreamName, string)) *lineWriter {
+	return &lineWriter{out: out, stream: stream, onLine: onLine}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.out != nil {
+		if _, err := w.out.Write(p); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.onLine != nil {
+		w.pending = append(w.pending, p...)
+		for {
+			idx := bytes.IndexByte(w.pending, '\n')
+			if idx == -1 {
+				break
+			}
+			w.onLine(w.stream, strings.TrimRight(string(w.pending[:idx]), "\r"))
+			w.pending = w.pending[idx+1:]
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush delivers a final line that was not newline-terminated
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.onLine != nil && len(w.pending) > 0 {
+		w.onLine(w.stream, strings.TrimRight(string(w.pending), "\r"))
+		w.pending = nil
+	}
+}
+
+// prepareCommand builds the process for cmd, applying its working directory,
+// environment, stdin and timeout. When the context ends the process receives
+// SIGTERM and is killed if it is still running after the kill grace period.
+// The returned context carries the command timeout; cancel must be called
+// once the process has exited.
+func prepareCommand(ctx context.Context, cmd Command) (*exec.Cmd, context.Context, context.CancelFunc) {
+	runCtx, cancel := ctx, context.CancelFunc(func() {})
+	if cmd.Timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+	}
+
+	execCmd := exec.CommandContext(runCtx, cmd.Name, cmd.Args...)
 
 	// Set working directory if specified
 	if cmd.Dir != "" {
@@ -80,18 +270,44 @@ func (e *DefaultProcessExecutor) Stream(ctx context.Context, cmd Command, output
 		}
 	}
 
-	// Stream output to provided writer
-	execCmd.Stdout = output
-	execCmd.Stderr = output
+	if cmd.Stdin != nil {
+		execCmd.Stdin = cmd.Stdin
+	}
 
-	err := execCmd.Run()
-	if err != nil {
-		return fmt.Errorf("streaming command failed: %w", err)
+	grace := cmd.KillGrace
+	if grace <= 0 {
+		grace = CurrentTimeouts().KillGrace
 	}
+	execCmd.Cancel = func() error {
+		return terminateProcess(execCmd.Process)
+	}
+	execCmd.WaitDelay = grace
+
+	return execCmd, runCtx, cancel
+}
 
+// checkTimeout reports whether the command hit its own timeout rather than
+// the caller's context ending
+func checkTimeout(ctx, runCtx context.Context, cmd Command) error {
+	if cmd.Timeout > 0 && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: '%s %s' did not finish within %s", ErrCommandTimeout, cmd.Name, strings.Join(cmd.Args, " "), cmd.Timeout)
+	}
 	return nil
 }
 
+// runTracked runs the command, reporting its PID to the process tracker
+func runTracked(execCmd *exec.Cmd, cmd Command) error {
+	if err := execCmd.Start(); err != nil {
+		return err
+	}
+
+	pid := execCmd.Process.Pid
+	TrackProcess(pid, cmd)
+	defer UntrackProcess(pid)
+
+	return execCmd.Wait()
+}
+
 // ValidateCommand checks if a command is available in PATH
 func ValidateCommand(name string) error {
 	_, err := exec.LookPath(name)
@@ -2,9 +2,10 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -26,15 +27,83 @@ func NewHelmProvider() HelmProvider {
 func (h *HelmClient) InstallChart(ctx context.Context, release HelmRelease) error {
 	args := []string{"upgrade", "--install", release.Name}
 
+	chartArgs, cleanup, err := h.chartArgs(ctx, release)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	args = append(args, chartArgs...)
+	args = append(args, "--create-namespace")
+
+	// Add common options for better UX
+	if !release.NoWait {
+		args = append(args, "--wait", "--timeout", helmWaitTimeout().String())
+	}
+
+	cmd := Command{
+		Name:    "helm",
+		Args:    args,
+		Timeout: installTimeout(),
+	}
+
+	result, err := h.executor.Execute(ctx, cmd)
+	if errors.Is(err, ErrCommandTimeout) {
+		return fmt.Errorf("helm install failed: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("helm install failed (exit code %d): %s", result.ExitCode, result.Stderr)
+	}
+
+	return nil
+}
+
+// Template renders a chart locally and returns the manifests
+func (h *HelmClient) Template(ctx context.Context, release HelmRelease) (string, error) {
+	args := []string{"template", release.Name}
+
+	chartArgs, cleanup, err := h.chartArgs(ctx, release)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	args = append(args, chartArgs...)
+	args = append(args, "--include-crds", "--skip-tests")
+
+	cmd := Command{
+		Name:    "helm",
+		Args:    args,
+		Timeout: mutateTimeout(),
+	}
+
+	result, err := h.executor.Execute(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("helm template failed: %s", result.Stderr)
+	}
+
+	return result.Stdout, nil
+}
+
+// chartArgs resolves the chart reference and returns the chart, version,
+// namespace and values arguments shared by install and template. The cleanup
+// func removes temporary values files.
+func (h *HelmClient) chartArgs(ctx context.Context, release HelmRelease) ([]string, func(), error) {
+	cleanup := func() {}
 	chartRef := release.Chart
 
 	// Add repository if specified
 	if release.Repository != "" {
-		// Add repository first if it's a URL
-		if strings.HasPrefix(release.Repository, "http") {
-			repoName := fmt.Sprintf("plat-%s", release.Name)
+		switch {
+		case strings.HasPrefix(release.Repository, "oci://"):
+			chartRef = fmt.Sprintf("%s/%s", strings.TrimSuffix(release.Repository, "/"), release.Chart)
+		case strings.HasPrefix(release.Repository, "http"):
+			repoName := release.RepoName
+			if repoName == "" {
+				repoName = fmt.Sprintf("plat-%s", release.Name)
+			}
 			if err := h.addRepository(ctx, repoName, release.Repository); err != nil {
-				return fmt.Errorf("failed to add helm repository: %w", err)
+				return nil, cleanup, fmt.Errorf("failed to add helm repository: %w", err)
 			}
 			// Update chart reference to use repository
 			chartRef = fmt.Sprintf("%s/%s", repoName, release.Chart)
@@ -43,12 +112,12 @@ func (h *HelmClient) InstallChart(ctx context.Context, release HelmRelease) erro
 		// No repository specified - chart must be a local path or from a configured repo
 		// Check if it's a valid chart reference
 		if !strings.Contains(release.Chart, "/") && !strings.HasPrefix(release.Chart, ".") {
-			return fmt.Errorf("chart '%s' needs a repository. Either:\n  • Add a 'repository' field to the service config\n  • Use 'repo/chart' format (e.g., 'stable/nginx')\n  • Provide a local chart path", release.Chart)
+			return nil, cleanup, fmt.Errorf("chart '%s' needs a repository. Either:\n  • Add a 'repository' field to the service config\n  • Use 'repo/chart' format (e.g., 'stable/nginx')\n  • Provide a local chart path", release.Chart)
 		}
 	}
 
 	// Add chart reference
-	args = append(args, chartRef)
+	args := []string{chartRef}
 
 	// Add version if specified
 	if release.Version != "" {
@@ -57,7 +126,6 @@ func (h *HelmClient) InstallChart(ctx context.Context, release HelmRelease) erro
 
 	// Add namespace
 	args = append(args, "--namespace", release.Namespace)
-	args = append(args, "--create-namespace")
 
 	// Add values files
 	for _, valuesFile := range release.ValuesFiles {
@@ -68,27 +136,14 @@ func (h *HelmClient) InstallChart(ctx context.Context, release HelmRelease) erro
 	if len(release.Values) > 0 {
 		valuesFile, err := h.createTempValuesFile(release.Values)
 		if err != nil {
-			return fmt.Errorf("failed to create temporary values file: %w", err)
+			return nil, cleanup, fmt.Errorf("failed to create temporary values file: %w", err)
 		}
-		defer os.Remove(valuesFile)
+		cleanup = func() { os.Remove(valuesFile) }
 
 		args = append(args, "--values", valuesFile)
 	}
 
-	// Add common options for better UX
-	args = append(args, "--wait", "--timeout", "300s")
-
-	cmd := Command{
-		Name: "helm",
-		Args: args,
-	}
-
-	result, err := h.executor.Execute(ctx, cmd)
-	if err != nil {
-		return fmt.Errorf("helm install failed (exit code %d): %s", result.ExitCode, result.Stderr)
-	}
-
-	return nil
+	return args, cleanup, nil
 }
 
 // UninstallChart removes a Helm release
@@ -100,8 +155,9 @@ func (h *HelmClient) UninstallChart(ctx context.Context, releaseName, namespace
 	}
 
 	cmd := Command{
-		Name: "helm",
-		Args: args,
+		Name:    "helm",
+		Args:    args,
+		Timeout: mutateTimeout(),
 	}
 
 	result, err := h.executor.Execute(ctx, cmd)
@@ -125,8 +181,9 @@ func (h *HelmClient) GetReleaseStatus(ctx context.Context, releaseName, namespac
 	}
 
 	cmd := Command{
-		Name: "helm",
-		Args: args,
+		Name:    "helm",
+		Args:    args,
+		Timeout: queryTimeout(),
 	}
 
 	result, err := h.executor.Execute(ctx, cmd)
@@ -137,41 +194,12 @@ func (h *HelmClient) GetReleaseStatus(ctx context.Context, releaseName, namespac
 		return nil, fmt.Errorf("failed to get helm status: %s", result.Stderr)
 	}
 
-	var helmStatus map[string]any
-	if err := json.Unmarshal([]byte(result.Stdout), &helmStatus); err != nil {
-		return nil, fmt.Errorf("failed to parse helm status output: %w", err)
-	}
-
-	status := &ReleaseStatus{
-		Name:      releaseName,
-		Namespace: namespace,
-		Status:    "unknown",
-	}
-
-	// Extract status information
-	if info, ok := helmStatus["info"].(map[string]any); ok {
-		if statusInfo, ok := info["status"].(string); ok {
-			status.Status = strings.ToLower(statusInfo)
-		}
-		if lastDeployed, ok := info["last_deployed"].(string); ok {
-			status.Updated = lastDeployed
-		}
+	status, err := parseHelmStatus([]byte(result.Stdout))
+	if err != nil {
+		return nil, err
 	}
-
-	// Extract chart information
-	if chart, ok := helmStatus["chart"].(map[string]any); ok {
-		if metadata, ok := chart["metadata"].(map[string]any); ok {
-			if name, ok := metadata["name"].(string); ok {
-				if version, ok := metadata["version"].(string); ok {
-					status.Chart = fmt.Sprintf("%s-%s", name, version)
-				} else {
-					status.Chart = name
-				}
-			}
-			if version, ok := metadata["version"].(string); ok {
-				status.Version = version
-			}
-		}
+	if status.Namespace == "" {
+		status.Namespace = namespace
 	}
 
 	return status, nil
@@ -188,8 +216,9 @@ func (h *HelmClient) ListReleases(ctx context.Context, namespace string) ([]Rele
 	}
 
 	cmd := Command{
-		Name: "helm",
-		Args: args,
+		Name:    "helm",
+		Args:    args,
+		Timeout: queryTimeout(),
 	}
 
 	result, err := h.executor.Execute(ctx, cmd)
@@ -197,49 +226,136 @@ func (h *HelmClient) ListReleases(ctx context.Context, namespace string) ([]Rele
 		return nil, fmt.Errorf("failed to list helm releases: %s", result.Stderr)
 	}
 
-	var helmReleases []map[string]any
-	if err := json.Unmarshal([]byte(result.Stdout), &helmReleases); err != nil {
-		return nil, fmt.Errorf("failed to parse helm list output: %w", err)
+	releases, err := parseHelmList([]byte(result.Stdout))
+	if err != nil {
+		return nil, err
 	}
 
-	releases := make([]ReleaseInfo, 0, len(helmReleases))
+	return releases, nil
+}
 
-	for _, release := range helmReleases {
-		info := ReleaseInfo{
-			Status: "unknown",
-		}
+// GetReleaseHistory returns the revisions of a Helm release, oldest first
+func (h *HelmClient) GetReleaseHistory(ctx context.Context, releaseName, namespace string) ([]ReleaseRevision, error) {
+	args := []string{"history", releaseName, "--output", "json"}
 
-		if name, ok := release["name"].(string); ok {
-			info.Name = name
-		}
-		if ns, ok := release["namespace"].(string); ok {
-			info.Namespace = ns
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+
+	cmd := Command{
+		Name:    "helm",
+		Args:    args,
+		Timeout: queryTimeout(),
+	}
+
+	result, err := h.executor.Execute(ctx, cmd)
+	if err != nil {
+		if strings.Contains(result.Stderr, "not found") {
+			return nil, fmt.Errorf("release %s not found", releaseName)
 		}
-		if status, ok := release["status"].(string); ok {
-			info.Status = strings.ToLower(status)
+		return nil, fmt.Errorf("failed to get helm history: %s", result.Stderr)
+	}
+
+	return parseHelmHistory([]byte(result.Stdout))
+}
+
+// GetReleaseValues returns the user-supplied values of a Helm release
+func (h *HelmClient) GetReleaseValues(ctx context.Context, releaseName, namespace string) (map[string]any, error) {
+	args := []string{"get", "values", releaseName, "--output", "json"}
+
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+
+	cmd := Command{
+		Name:    "helm",
+		Args:    args,
+		Timeout: queryTimeout(),
+	}
+
+	result, err := h.executor.Execute(ctx, cmd)
+	if err != nil {
+		if strings.Contains(result.Stderr, "not found") {
+			return nil, fmt.Errorf("release %s not found", releaseName)
 		}
-		if chart, ok := release["chart"].(string); ok {
-			info.Chart = chart
+		return nil, fmt.Errorf("failed to get helm values: %s", result.Stderr)
+	}
+
+	return parseHelmValues([]byte(result.Stdout))
+}
+
+// GetReleaseManifest returns the manifests of a Helm release's current revision
+func (h *HelmClient) GetReleaseManifest(ctx context.Context, releaseName, namespace string) (string, error) {
+	args := []string{"get", "manifest", releaseName}
+
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+
+	cmd := Command{
+		Name:    "helm",
+		Args:    args,
+		Timeout: queryTimeout(),
+	}
+
+	result, err := h.executor.Execute(ctx, cmd)
+	if err != nil {
+		if strings.Contains(result.Stderr, "not found") {
+			return "", fmt.Errorf("release %s not found", releaseName)
 		}
+		return "", fmt.Errorf("failed to get helm manifest: %s", result.Stderr)
+	}
+
+	return result.Stdout, nil
+}
 
-		releases = append(releases, info)
+// RollbackRelease rolls a Helm release back to an earlier revision, or to
+// the previous one when revision is 0
+func (h *HelmClient) RollbackRelease(ctx context.Context, releaseName, namespace string, revision int) error {
+	args := []string{"rollback", releaseName}
+	if revision > 0 {
+		args = append(args, strconv.Itoa(revision))
 	}
 
-	return releases, nil
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+
+	cmd := Command{
+		Name:    "helm",
+		Args:    args,
+		Timeout: mutateTimeout(),
+	}
+
+	result, err := h.executor.Execute(ctx, cmd)
+	if err != nil {
+		if strings.Contains(result.Stderr, "not found") {
+			return fmt.Errorf("release %s not found", releaseName)
+		}
+		return fmt.Errorf("helm rollback failed: %s", result.Stderr)
+	}
+
+	return nil
 }
 
 // addRepository adds a Helm repository
 func (h *HelmClient) addRepository(ctx context.Context, name, url string) error {
 	// Check if repository already exists
-	if exists, err := h.repositoryExists(ctx, name); err != nil {
+	existing, err := h.repositoryURL(ctx, name)
+	if err != nil {
 		return err
-	} else if exists {
+	}
+	if existing != "" {
+		if strings.TrimSuffix(existing, "/") != strings.TrimSuffix(url, "/") {
+			return fmt.Errorf("helm repository '%s' already points to %s, not %s. Either:\n  • Declare a different alias for %s under 'repositories' in config.yml\n  • Remove the existing repository with 'helm repo remove %s'", name, existing, url, url, name)
+		}
 		return nil // Repository already exists
 	}
 
 	cmd := Command{
-		Name: "helm",
-		Args: []string{"repo", "add", name, url},
+		Name:    "helm",
+		Args:    []string{"repo", "add", name, url},
+		Timeout: mutateTimeout(),
 	}
 
 	result, err := h.executor.Execute(ctx, cmd)
@@ -249,47 +365,50 @@ func (h *HelmClient) addRepository(ctx context.Context, name, url string) error
 
 	// Update repository index
 	updateCmd := Command{
-		Name: "helm",
-		Args: []string{"repo", "update"},
+		Name:    "helm",
+		Args:    []string{"repo", "update"},
+		Timeout: mutateTimeout(),
 	}
 
 	_, err = h.executor.Execute(ctx, updateCmd)
 	if err != nil {
 		// Non-fatal error - continue
-		fmt.Printf("Warning: failed to update helm repositories: %v\n", err)
+		logger().Warn("failed to update helm repositories", "error", err)
 	}
 
 	return nil
 }
 
-// repositoryExists checks if a Helm repository is already configured
-func (h *HelmClient) repositoryExists(ctx context.Context, name string) (bool, error) {
+// repositoryURL returns the URL of a configured Helm repository, or "" if
+// no repository of that name exists
+func (h *HelmClient) repositoryURL(ctx context.Context, name string) (string, error) {
 	cmd := Command{
-		Name: "helm",
-		Args: []string{"repo", "list", "--output", "json"},
+		Name:    "helm",
+		Args:    []string{"repo", "list", "--output", "json"},
+		Timeout: queryTimeout(),
 	}
 
 	result, err := h.executor.Execute(ctx, cmd)
 	if err != nil {
 		// If no repositories exist, helm returns exit code 1
 		if strings.Contains(result.Stderr, "no repositories") {
-			return false, nil
+			return "", nil
 		}
-		return false, fmt.Errorf("failed to list repositories: %s", result.Stderr)
+		return "", fmt.Errorf("failed to list repositories: %s", result.Stderr)
 	}
 
-	var repos []map[string]any
-	if err := json.Unmarshal([]byte(result.Stdout), &repos); err != nil {
-		return false, fmt.Errorf("failed to parse repository list: %w", err)
+	repos, err := parseHelmRepoList([]byte(result.Stdout))
+	if err != nil {
+		return "", err
 	}
 
 	for _, repo := range repos {
-		if repoName, ok := repo["name"].(string); ok && repoName == name {
-			return true, nil
+		if repo.Name == name {
+			return repo.URL, nil
 		}
 	}
 
-	return false, nil
+	return "", nil
 }
 
 // createTempValuesFile creates a temporary YAML file with the given values
@@ -325,6 +444,6 @@ func ValidateHelm(ctx context.Context) error {
 		return fmt.Errorf("failed to get helm version: %w", err)
 	}
 
-	fmt.Printf("Found helm: %s\n", version)
+	logger().Debug("found helm", "version", version)
 	return nil
 }
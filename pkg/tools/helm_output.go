@@ -0,0 +1,205 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnexpectedHelmOutput is returned when helm's JSON output does not match
+// the schema plat understands
+var ErrUnexpectedHelmOutput = errors.New("unexpected helm output")
+
+// helmStatusOutput is the subset of `helm status -o json` that plat reads
+type helmStatusOutput struct {
+	Name      string         `json:"name"`
+	Namespace string         `json:"namespace"`
+	Version   flexInt        `json:"version"` // Release revision
+	Info      *helmInfo      `json:"info"`
+	Chart     *helmChartSpec `json:"chart"`
+}
+
+type helmInfo struct {
+	Status       string `json:"status"`
+	LastDeployed string `json:"last_deployed"`
+	Description  string `json:"description"`
+}
+
+type helmChartSpec struct {
+	Metadata *helmChartMetadata `json:"metadata"`
+}
+
+type helmChartMetadata struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	AppVersion string `json:"appVersion"`
+}
+
+// helmListEntry is one element of `helm list -o json`
+type helmListEntry struct {
+	Name       string  `json:"name"`
+	Namespace  string  `json:"namespace"`
+	Revision   flexInt `json:"revision"`
+	Updated    string  `json:"updated"`
+	Status     string  `json:"status"`
+	Chart      string  `json:"chart"`
+	AppVersion string  `json:"app_version"`
+}
+
+// helmHistoryEntry is one element of `helm history -o json`
+type helmHistoryEntry struct {
+	Revision    flexInt `json:"revision"`
+	Updated     string  `json:"updated"`
+	Status      string  `json:"status"`
+	Chart       string  `json:"chart"`
+	AppVersion  string  `json:"app_version"`
+	Description string  `json:"description"`
+}
+
+// helmRepoEntry is one element of `helm repo list -o json`
+type helmRepoEntry struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// flexInt accepts both JSON numbers and numeric strings; helm has emitted
+// revisions as either depending on the command and version
+type flexInt int
+
+func (f *flexInt) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "" || text == "null" {
+		*f = 0
+		return nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return fmt.Errorf("invalid revision %s", data)
+	}
+	*f = flexInt(value)
+	return nil
+}
+
+// parseHelmStatus converts `helm status -o json` output into a ReleaseStatus
+func parseHelmStatus(data []byte) (*ReleaseStatus, error) {
+	var out helmStatusOutput
+	if err := decodeHelmJSON(data, &out); err != nil {
+		return nil, fmt.Errorf("%w from helm status: %v", ErrUnexpectedHelmOutput, err)
+	}
+
+	if out.Name == "" {
+		return nil, fmt.Errorf("%w from helm status: missing name", ErrUnexpectedHelmOutput)
+	}
+	if out.Info == nil || out.Info.Status == "" {
+		return nil, fmt.Errorf("%w from helm status: missing info.status", ErrUnexpectedHelmOutput)
+	}
+
+	status := &ReleaseStatus{
+		Name:      out.Name,
+		Namespace: out.Namespace,
+		Status:    strings.ToLower(out.Info.Status),
+		Updated:   out.Info.LastDeployed,
+		Revision:  int(out.Version),
+	}
+
+	// Chart metadata is absent for some failed releases; that's not a schema error
+	if out.Chart != nil && out.Chart.Metadata != nil {
+		metadata := out.Chart.Metadata
+		status.Chart = metadata.Name
+		if metadata.Version != "" {
+			status.Chart = fmt.Sprintf("%s-%s", metadata.Name, metadata.Version)
+		}
+		status.Version = metadata.Version
+		status.AppVersion = metadata.AppVersion
+	}
+
+	return status, nil
+}
+
+// parseHelmList converts `helm list -o json` output into release infos
+func parseHelmList(data []byte) ([]ReleaseInfo, error) {
+	var entries []helmListEntry
+	if err := decodeHelmJSON(data, &entries); err != nil {
+		return nil, fmt.Errorf("%w from helm list: %v", ErrUnexpectedHelmOutput, err)
+	}
+
+	releases := make([]ReleaseInfo, 0, len(entries))
+	for i, entry := range entries {
+		if entry.Name == "" || entry.Status == "" {
+			return nil, fmt.Errorf("%w from helm list: entry %d is missing name or status", ErrUnexpectedHelmOutput, i)
+		}
+
+		releases = append(releases, ReleaseInfo{
+			Name:       entry.Name,
+			Namespace:  entry.Namespace,
+			Status:     strings.ToLower(entry.Status),
+			Chart:      entry.Chart,
+			Revision:   int(entry.Revision),
+			AppVersion: entry.AppVersion,
+			Updated:    entry.Updated,
+		})
+	}
+
+	return releases, nil
+}
+
+// parseHelmHistory converts `helm history -o json` output into revisions
+func parseHelmHistory(data []byte) ([]ReleaseRevision, error) {
+	var entries []helmHistoryEntry
+	if err := decodeHelmJSON(data, &entries); err != nil {
+		return nil, fmt.Errorf("%w from helm history: %v", ErrUnexpectedHelmOutput, err)
+	}
+
+	revisions := make([]ReleaseRevision, 0, len(entries))
+	for i, entry := range entries {
+		if entry.Revision == 0 || entry.Status == "" {
+			return nil, fmt.Errorf("%w from helm history: entry %d is missing revision or status", ErrUnexpectedHelmOutput, i)
+		}
+
+		revisions = append(revisions, ReleaseRevision{
+			Revision:    int(entry.Revision),
+			Updated:     entry.Updated,
+			Status:      strings.ToLower(entry.Status),
+			Chart:       entry.Chart,
+			AppVersion:  entry.AppVersion,
+			Description: entry.Description,
+		})
+	}
+
+	return revisions, nil
+}
+
+// parseHelmRepoList returns the configured repository names
+func parseHelmRepoList(data []byte) ([]helmRepoEntry, error) {
+	var entries []helmRepoEntry
+	if err := decodeHelmJSON(data, &entries); err != nil {
+		return nil, fmt.Errorf("%w from helm repo list: %v", ErrUnexpectedHelmOutput, err)
+	}
+	return entries, nil
+}
+
+// parseHelmValues converts `helm get values -o json` output into a values
+// map. Releases installed without values print null.
+func parseHelmValues(data []byte) (map[string]any, error) {
+	var values map[string]any
+	if err := decodeHelmJSON(data, &values); err != nil {
+		return nil, fmt.Errorf("%w from helm get values: %v", ErrUnexpectedHelmOutput, err)
+	}
+	if values == nil {
+		values = map[string]any{}
+	}
+	return values, nil
+}
+
+// decodeHelmJSON unmarshals helm output. Unknown fields are ignored so newer
+// helm versions keep working; empty output is reported explicitly.
+func decodeHelmJSON(data []byte, v any) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return errors.New("empty output")
+	}
+	return json.Unmarshal(data, v)
+}
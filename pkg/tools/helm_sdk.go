@@ -0,0 +1,437 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// Helm drivers selectable with defaults.helmDriver or --helm-driver
+const (
+	HelmDriverCLI = "cli" // Shell out to the helm binary
+	HelmDriverSDK = "sdk" // Use the Helm Go SDK in-process
+)
+
+// helmWaitTimeout is how long helm waits for a release's resources, a
+// minute short of the install timeout so helm reports what it waited on
+// before plat gives up. Both drivers use it.
+func helmWaitTimeout() time.Duration {
+	install := installTimeout()
+	if install <= 2*time.Minute {
+		return install / 2
+	}
+	return install - time.Minute
+}
+
+// HelmSDK implements HelmProvider with the Helm Go SDK. It needs no helm
+// binary, writes no temporary values files and returns Helm's own errors.
+type HelmSDK struct{}
+
+// NewHelmSDKProvider creates a Helm provider backed by the Helm Go SDK
+func NewHelmSDKProvider() HelmProvider {
+	return &HelmSDK{}
+}
+
+// configuration returns Helm settings and an action configuration for a namespace
+func (h *HelmSDK) configuration(namespace string) (*cli.EnvSettings, *action.Configuration, error) {
+	settings := cli.New()
+	if namespace != "" {
+		settings.SetNamespace(namespace)
+	}
+
+	cfg := new(action.Configuration)
+	if err := cfg.Init(settings.RESTClientGetter(), settings.Namespace(), os.Getenv("HELM_DRIVER"), func(string, ...interface{}) {}); err != nil {
+		return nil, nil, fmt.Errorf("failed to initialise helm: %w", err)
+	}
+
+	client, err := registry.NewClient(
+		registry.ClientOptCredentialsFile(settings.RegistryConfig),
+		registry.ClientOptEnableCache(true),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create registry client: %w", err)
+	}
+	cfg.RegistryClient = client
+
+	return settings, cfg, nil
+}
+
+// InstallChart installs or upgrades a Helm chart
+func (h *HelmSDK) InstallChart(ctx context.Context, release HelmRelease) error {
+	settings, cfg, err := h.configuration(release.Namespace)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, installTimeout())
+	defer cancel()
+
+	// Upgrade when the release has any history, like 'helm upgrade --install'
+	_, err = action.NewHistory(cfg).Run(release.Name)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return fmt.Errorf("helm install failed: %w", err)
+	}
+
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		install := action.NewInstall(cfg)
+		install.ReleaseName = release.Name
+		install.Namespace = release.Namespace
+		install.CreateNamespace = true
+		install.Wait = !release.NoWait
+		install.Timeout = helmWaitTimeout()
+		install.SetRegistryClient(cfg.RegistryClient)
+
+		chrt, values, err := h.loadChart(&install.ChartPathOptions, settings, release)
+		if err != nil {
+			return err
+		}
+		if _, err := install.RunWithContext(ctx, chrt, values); err != nil {
+			return fmt.Errorf("helm install failed: %w", err)
+		}
+		return nil
+	}
+
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Install = true
+	upgrade.Namespace = release.Namespace
+	upgrade.Wait = !release.NoWait
+	upgrade.Timeout = helmWaitTimeout()
+	upgrade.SetRegistryClient(cfg.RegistryClient)
+
+	chrt, values, err := h.loadChart(&upgrade.ChartPathOptions, settings, release)
+	if err != nil {
+		return err
+	}
+	if _, err := upgrade.RunWithContext(ctx, release.Name, chrt, values); err != nil {
+		return fmt.Errorf("helm install failed: %w", err)
+	}
+	return nil
+}
+
+// Template renders a chart locally and returns the manifests, including
+// CRDs and hooks but not test hooks, like 'helm template'
+func (h *HelmSDK) Template(ctx context.Context, release HelmRelease) (string, error) {
+	settings, cfg, err := h.configuration(release.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mutateTimeout())
+	defer cancel()
+
+	install := action.NewInstall(cfg)
+	install.ReleaseName = release.Name
+	install.Namespace = release.Namespace
+	install.DryRun = true
+	install.ClientOnly = true
+	install.Replace = true
+	install.IncludeCRDs = true
+	install.SetRegistryClient(cfg.RegistryClient)
+
+	chrt, values, err := h.loadChart(&install.ChartPathOptions, settings, release)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := install.RunWithContext(ctx, chrt, values)
+	if err != nil {
+		return "", fmt.Errorf("helm template failed: %w", err)
+	}
+
+	var manifests strings.Builder
+	manifests.WriteString(rel.Manifest)
+	for _, hook := range rel.Hooks {
+		if isTestHook(hook) {
+			continue
+		}
+		fmt.Fprintf(&manifests, "---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+	}
+
+	return manifests.String(), nil
+}
+
+func isTestHook(hook *release.Hook) bool {
+	for _, event := range hook.Events {
+		if event == release.HookTest {
+			return true
+		}
+	}
+	return false
+}
+
+// loadChart locates and loads the release's chart and merges its values
+// files and inline values, later sources taking precedence
+func (h *HelmSDK) loadChart(opts *action.ChartPathOptions, settings *cli.EnvSettings, release HelmRelease) (*chart.Chart, map[string]interface{}, error) {
+	chartRef := release.Chart
+	opts.Version = release.Version
+
+	switch {
+	case strings.HasPrefix(release.Repository, "oci://"):
+		chartRef = fmt.Sprintf("%s/%s", strings.TrimSuffix(release.Repository, "/"), release.Chart)
+	case strings.HasPrefix(release.Repository, "http"):
+		// Charts are fetched straight from the repository URL, so no
+		// repository needs to be added to the user's helm config
+		opts.RepoURL = release.Repository
+	case release.Repository == "":
+		if !strings.Contains(release.Chart, "/") && !strings.HasPrefix(release.Chart, ".") {
+			return nil, nil, fmt.Errorf("chart '%s' needs a repository. Either:\n  • Add a 'repository' field to the service config\n  • Use 'repo/chart' format (e.g., 'stable/nginx')\n  • Provide a local chart path", release.Chart)
+		}
+	}
+
+	path, err := opts.LocateChart(chartRef, settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to locate chart %s: %w", chartRef, err)
+	}
+
+	chrt, err := loader.Load(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load chart %s: %w", chartRef, err)
+	}
+	if deps := chrt.Metadata.Dependencies; deps != nil {
+		if err := action.CheckDependencies(chrt, deps); err != nil {
+			return nil, nil, fmt.Errorf("chart %s has missing dependencies (run 'helm dependency build'): %w", chartRef, err)
+		}
+	}
+
+	values := map[string]interface{}{}
+	for _, file := range release.ValuesFiles {
+		fileValues, err := chartutil.ReadValuesFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read values file %s: %w", file, err)
+		}
+		values = mergeValues(values, fileValues)
+	}
+	values = mergeValues(values, release.Values)
+
+	return chrt, values, nil
+}
+
+// mergeValues deep-merges src into dst the way helm merges --values files
+func mergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			if dstMap, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergeValues(dstMap, srcMap)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// UninstallChart removes a Helm release; a missing release is not an error
+func (h *HelmSDK) UninstallChart(ctx context.Context, releaseName, namespace string) error {
+	_, cfg, err := h.configuration(namespace)
+	if err != nil {
+		return err
+	}
+
+	uninstall := action.NewUninstall(cfg)
+	uninstall.Timeout = mutateTimeout()
+	if _, err := uninstall.Run(releaseName); err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil
+		}
+		return fmt.Errorf("helm uninstall failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetReleaseStatus returns status of a Helm release
+func (h *HelmSDK) GetReleaseStatus(ctx context.Context, releaseName, namespace string) (*ReleaseStatus, error) {
+	_, cfg, err := h.configuration(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := action.NewStatus(cfg).Run(releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, fmt.Errorf("release %s not found", releaseName)
+		}
+		return nil, fmt.Errorf("failed to get helm status: %w", err)
+	}
+
+	status := &ReleaseStatus{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+	}
+	if rel.Info != nil {
+		status.Status = rel.Info.Status.String()
+		status.Updated = rel.Info.LastDeployed.Format(time.RFC3339)
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		metadata := rel.Chart.Metadata
+		status.Chart = fmt.Sprintf("%s-%s", metadata.Name, metadata.Version)
+		status.Version = metadata.Version
+		status.AppVersion = metadata.AppVersion
+	}
+	if status.Namespace == "" {
+		status.Namespace = namespace
+	}
+
+	return status, nil
+}
+
+// ListReleases returns the deployed and failed releases in a namespace, or in
+// all namespaces when namespace is empty
+func (h *HelmSDK) ListReleases(ctx context.Context, namespace string) ([]ReleaseInfo, error) {
+	_, cfg, err := h.configuration(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	list := action.NewList(cfg)
+	list.AllNamespaces = namespace == ""
+	list.SetStateMask()
+
+	releases, err := list.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list helm releases: %w", err)
+	}
+
+	infos := make([]ReleaseInfo, 0, len(releases))
+	for _, rel := range releases {
+		info := ReleaseInfo{
+			Name:      rel.Name,
+			Namespace: rel.Namespace,
+			Revision:  rel.Version,
+			Chart:     chartName(rel),
+		}
+		if rel.Info != nil {
+			info.Status = rel.Info.Status.String()
+			info.Updated = rel.Info.LastDeployed.String()
+		}
+		if rel.Chart != nil && rel.Chart.Metadata != nil {
+			info.AppVersion = rel.Chart.Metadata.AppVersion
+		}
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// GetReleaseHistory returns the revisions of a Helm release, oldest first
+func (h *HelmSDK) GetReleaseHistory(ctx context.Context, releaseName, namespace string) ([]ReleaseRevision, error) {
+	_, cfg, err := h.configuration(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := action.NewHistory(cfg).Run(releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, fmt.Errorf("release %s not found", releaseName)
+		}
+		return nil, fmt.Errorf("failed to get helm history: %w", err)
+	}
+
+	sort.Slice(releases, func(i, j int) bool { return releases[i].Version < releases[j].Version })
+
+	revisions := make([]ReleaseRevision, 0, len(releases))
+	for _, rel := range releases {
+		revision := ReleaseRevision{
+			Revision: rel.Version,
+			Chart:    chartName(rel),
+		}
+		if rel.Info != nil {
+			revision.Status = rel.Info.Status.String()
+			revision.Updated = rel.Info.LastDeployed.Format(time.RFC3339)
+			revision.Description = rel.Info.Description
+		}
+		if rel.Chart != nil && rel.Chart.Metadata != nil {
+			revision.AppVersion = rel.Chart.Metadata.AppVersion
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// GetReleaseValues returns the user-supplied values of a Helm release
+func (h *HelmSDK) GetReleaseValues(ctx context.Context, releaseName, namespace string) (map[string]any, error) {
+	_, cfg, err := h.configuration(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := action.NewGetValues(cfg).Run(releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, fmt.Errorf("release %s not found", releaseName)
+		}
+		return nil, fmt.Errorf("failed to get helm values: %w", err)
+	}
+	if values == nil {
+		values = map[string]any{}
+	}
+
+	return values, nil
+}
+
+// GetReleaseManifest returns the manifests of a Helm release's current revision
+func (h *HelmSDK) GetReleaseManifest(ctx context.Context, releaseName, namespace string) (string, error) {
+	_, cfg, err := h.configuration(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := action.NewGet(cfg).Run(releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return "", fmt.Errorf("release %s not found", releaseName)
+		}
+		return "", fmt.Errorf("failed to get helm manifest: %w", err)
+	}
+
+	return rel.Manifest, nil
+}
+
+// chartName formats a release's chart as "name-version", like helm list
+func chartName(rel *release.Release) string {
+	if rel.Chart == nil || rel.Chart.Metadata == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s", rel.Chart.Metadata.Name, rel.Chart.Metadata.Version)
+}
+
+// RollbackRelease rolls a Helm release back to an earlier revision, or to
+// the previous one when revision is 0
+func (h *HelmSDK) RollbackRelease(ctx context.Context, releaseName, namespace string, revision int) error {
+	_, cfg, err := h.configuration(namespace)
+	if err != nil {
+		return err
+	}
+
+	rollback := action.NewRollback(cfg)
+	rollback.Version = revision
+	rollback.Timeout = mutateTimeout()
+	if err := rollback.Run(releaseName); err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return fmt.Errorf("release %s not found", releaseName)
+		}
+		return fmt.Errorf("helm rollback failed: %w", err)
+	}
+
+	return nil
+}
@@ -3,14 +3,15 @@ package tools
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // ClusterProvider manages Kubernetes cluster lifecycle
 type ClusterProvider interface {
-	// CreateCluster creates a new k3d cluster
+	// CreateCluster creates a new cluster and makes it the current kubeconfig context
 	CreateCluster(ctx context.Context, config ClusterConfig) error
 
-	// DeleteCluster removes a k3d cluster
+	// DeleteCluster removes a cluster
 	DeleteCluster(ctx context.Context, name string) error
 
 	// GetClusterStatus returns current cluster information
@@ -18,6 +19,19 @@ type ClusterProvider interface {
 
 	// ListClusters returns all managed clusters
 	ListClusters(ctx context.Context) ([]ClusterInfo, error)
+
+	// ImportImages copies images from the local docker daemon into a cluster
+	ImportImages(ctx context.Context, name string, images []string) error
+
+	// KubeContext returns the kubeconfig context of a cluster
+	KubeContext(name string) string
+
+	// WriteKubeconfig writes a cluster's credentials to the active kubeconfig
+	// and makes it the current context
+	WriteKubeconfig(ctx context.Context, name string) error
+
+	// Validate checks that the provider's CLI is available
+	Validate(ctx context.Context) error
 }
 
 // HelmProvider manages Helm chart deployments
@@ -33,6 +47,90 @@ type HelmProvider interface {
 
 	// ListReleases returns all releases in namespace
 	ListReleases(ctx context.Context, namespace string) ([]ReleaseInfo, error)
+
+	// GetReleaseHistory returns the revisions of a Helm release
+	GetReleaseHistory(ctx context.Context, releaseName, namespace string) ([]ReleaseRevision, error)
+
+	// GetReleaseValues returns the user-supplied values of a Helm release
+	GetReleaseValues(ctx context.Context, releaseName, namespace string) (map[string]any, error)
+
+	// GetReleaseManifest returns the manifests of a Helm release's current revision
+	GetReleaseManifest(ctx context.Context, releaseName, namespace string) (string, error)
+
+	// RollbackRelease rolls a Helm release back to an earlier revision, or to
+	// the previous one when revision is 0
+	RollbackRelease(ctx context.Context, releaseName, namespace string, revision int) error
+
+	// Template renders a chart locally without installing it
+	Template(ctx context.Context, release HelmRelease) (string, error)
+}
+
+// KubernetesProvider queries and manages cluster resources through the
+// Kubernetes API, without shelling out to kubectl
+type KubernetesProvider interface {
+	// ListPods returns the pods matching a label selector
+	ListPods(ctx context.Context, namespace, selector string) ([]PodInfo, error)
+
+	// GetPodStatus summarises the pods of a Helm release
+	GetPodStatus(ctx context.Context, releaseName, namespace string) (*PodStatus, error)
+
+	// GetPodImages returns the resolved images running for a Helm release
+	GetPodImages(ctx context.Context, releaseName, namespace string) ([]string, error)
+
+	// StreamLogs returns the combined logs of the pods matching a selector
+	StreamLogs(ctx context.Context, namespace, selector string, opts LogOptions) (io.ReadCloser, error)
+
+	// ListEvents returns the events of a namespace
+	ListEvents(ctx context.Context, namespace string) ([]EventInfo, error)
+
+	// DescribePod returns a pod's containers, environment and events
+	DescribePod(ctx context.Context, namespace, name string) (*PodDescription, error)
+
+	// PortForward forwards local ports to a pod until ctx is cancelled
+	PortForward(ctx context.Context, namespace, pod string, ports []string, ready chan struct{}) error
+
+	// Exec runs a command in a pod's container with the given streams attached
+	Exec(ctx context.Context, namespace, pod string, command []string, opts ExecOptions) error
+
+	// ListWorkloads returns the workloads of a Helm release
+	ListWorkloads(ctx context.Context, releaseName, namespace string) ([]string, error)
+
+	// ListNamespaceWorkloads returns every workload of a namespace
+	ListNamespaceWorkloads(ctx context.Context, namespace string) ([]WorkloadInfo, error)
+
+	// RolloutRestart triggers a rolling restart of a workload
+	RolloutRestart(ctx context.Context, workload, namespace string) error
+
+	// RolloutStatus waits for a workload's rollout to finish
+	RolloutStatus(ctx context.Context, workload, namespace string, timeout time.Duration, onProgress func(string)) error
+
+	// ListPersistentVolumeClaims returns the names of a Helm release's PVCs
+	ListPersistentVolumeClaims(ctx context.Context, releaseName, namespace string) ([]string, error)
+
+	// DeletePersistentVolumeClaims removes the PVCs of a Helm release
+	DeletePersistentVolumeClaims(ctx context.Context, releaseName, namespace string) ([]string, error)
+
+	// GetJobStatus returns the completion state of a job
+	GetJobStatus(ctx context.Context, jobName, namespace string) (*JobStatus, error)
+
+	// GetSecret returns the data of a secret
+	GetSecret(ctx context.Context, name, namespace string) (map[string][]byte, error)
+
+	// UpdateSecret replaces the data of a secret, creating it if needed
+	UpdateSecret(ctx context.Context, name, namespace string, data map[string][]byte) error
+
+	// DeleteSecret removes a secret
+	DeleteSecret(ctx context.Context, name, namespace string) error
+
+	// DeleteNetworkPolicies removes the NetworkPolicies matching a label selector
+	DeleteNetworkPolicies(ctx context.Context, namespace, selector string) ([]string, error)
+
+	// EnsureNamespace creates a namespace if it does not exist yet
+	EnsureNamespace(ctx context.Context, namespace string) error
+
+	// SyncSecrets creates or replaces secrets, and deletes the secrets
+	// matching selector that are not among them
+	SyncSecrets(ctx context.Context, namespace, selector string, secrets []SecretSpec) error
 }
 
 // TerraformProvider removed - using k3d + Helm only for simplicity
@@ -44,6 +142,13 @@ type ProcessExecutor interface {
 
 	// Stream runs a command with streaming output
 	Stream(ctx context.Context, cmd Command, output io.Writer) error
+
+	// StreamOutput runs a command with separate stdout/stderr destinations
+	// and optional per-line callbacks
+	StreamOutput(ctx context.Context, cmd Command, opts StreamOptions) error
+
+	// ExecuteInteractive runs a command attached to the user's terminal
+	ExecuteInteractive(ctx context.Context, cmd Command) (*ExecuteResult, error)
 }
 
 // Configuration types
@@ -55,8 +160,10 @@ type ClusterConfig struct {
 	Agents  int               `yaml:"agents"`
 	Ports   []string          `yaml:"ports,omitempty"`
 	Volumes []string          `yaml:"volumes,omitempty"`
-	Options []string          `yaml:"options,omitempty"`
+	Options []string          `yaml:"options,omitempty"` // Extra arguments for the provider's create command
 	Labels  map[string]string `yaml:"labels,omitempty"`
+
+	Registries []string `yaml:"registries,omitempty"` // k3d registries the cluster pulls from (name:port)
 }
 
 type ClusterStatus struct {
@@ -78,26 +185,143 @@ type HelmRelease struct {
 	Name        string         `yaml:"name"`
 	Chart       string         `yaml:"chart"`
 	Version     string         `yaml:"version,omitempty"`
-	Repository  string         `yaml:"repository,omitempty"`
+	Repository  string         `yaml:"repository,omitempty"` // Repository URL
+	RepoName    string         `yaml:"repo_name,omitempty"`  // Helm repo name the URL is added under
 	Namespace   string         `yaml:"namespace"`
 	Values      map[string]any `yaml:"values,omitempty"`
 	ValuesFiles []string       `yaml:"values_files,omitempty"`
+	NoWait      bool           `yaml:"no_wait,omitempty"` // Return without waiting for resources to be ready
 }
 
 type ReleaseStatus struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Status    string `json:"status"`
-	Chart     string `json:"chart"`
-	Version   string `json:"version"`
-	Updated   string `json:"updated"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Status     string `json:"status"`
+	Chart      string `json:"chart"`
+	Version    string `json:"version"`
+	AppVersion string `json:"app_version,omitempty"`
+	Revision   int    `json:"revision,omitempty"`
+	Updated    string `json:"updated"`
 }
 
 type ReleaseInfo struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Status    string `json:"status"`
-	Chart     string `json:"chart"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Status     string `json:"status"`
+	Chart      string `json:"chart"`
+	AppVersion string `json:"app_version,omitempty"`
+	Revision   int    `json:"revision,omitempty"`
+	Updated    string `json:"updated,omitempty"`
+}
+
+type ReleaseRevision struct {
+	Revision    int    `json:"revision"`
+	Updated     string `json:"updated"`
+	Status      string `json:"status"`
+	Chart       string `json:"chart"`
+	AppVersion  string `json:"app_version,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type PodInfo struct {
+	Name       string    `json:"name"`
+	Phase      string    `json:"phase"`
+	Ready      bool      `json:"ready"`
+	Restarts   int       `json:"restarts"`
+	Node       string    `json:"node,omitempty"`
+	Containers []string  `json:"containers,omitempty"`
+	Created    time.Time `json:"created"`
+}
+
+type WorkloadInfo struct {
+	Name     string `json:"name"`               // kubectl resource name, e.g. "deployment.apps/api"
+	Instance string `json:"instance,omitempty"` // app.kubernetes.io/instance label
+}
+
+type EventInfo struct {
+	Type     string    `json:"type"`   // Normal or Warning
+	Object   string    `json:"object"` // e.g. "pod/api-7d9f8-x2k4q"
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int       `json:"count"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// PodDescription is one pod in depth, for inspecting it
+type PodDescription struct {
+	Name       string          `json:"name"`
+	Phase      string          `json:"phase"`
+	Node       string          `json:"node,omitempty"`
+	IP         string          `json:"ip,omitempty"`
+	Created    time.Time       `json:"created"`
+	Containers []ContainerInfo `json:"containers"` // Init containers first
+	Events     []EventInfo     `json:"events"`     // Of the pod, oldest first
+}
+
+// ContainerInfo is the state and environment of one container of a pod
+type ContainerInfo struct {
+	Name            string    `json:"name"`
+	Image           string    `json:"image"`
+	Init            bool      `json:"init,omitempty"`
+	Ready           bool      `json:"ready"`
+	Restarts        int       `json:"restarts"`
+	State           string    `json:"state"` // running, waiting or terminated
+	Reason          string    `json:"reason,omitempty"`
+	Message         string    `json:"message,omitempty"`
+	Since           time.Time `json:"since,omitempty"`            // When the container entered its state
+	LastTermination string    `json:"last_termination,omitempty"` // Why the previous instance ended, e.g. "OOMKilled (exit 137)"
+	Env             []EnvVar  `json:"env,omitempty"`
+	Mounts          []Mount   `json:"mounts,omitempty"`
+}
+
+// Mount is a volume mounted into a container
+type Mount struct {
+	Path     string `json:"path"`
+	Source   string `json:"source"` // e.g. "secret api-tls", "configmap api-config", "pvc data"
+	ReadOnly bool   `json:"read_only,omitempty"`
+}
+
+// EnvVar is an environment variable of a container. Values from secrets and
+// config maps are not read; Source says where they come from.
+type EnvVar struct {
+	Name   string `json:"name,omitempty"` // Empty for every key of Source
+	Value  string `json:"value,omitempty"`
+	Source string `json:"source,omitempty"` // e.g. "secret api/password"
+}
+
+// LogOptions selects which log lines StreamLogs returns
+type LogOptions struct {
+	Follow     bool
+	TailLines  int           // Lines to show from the end of each log; negative shows all
+	Since      time.Duration // Only lines newer than this; zero shows all
+	Previous   bool          // Logs of the previous container instance
+	Container  string        // Defaults to the pod's default container
+	Timestamps bool
+	Prefix     bool // Prefix lines with "[pod/<name>/<container>]"
+}
+
+// ExecOptions configures a command run with Exec
+type ExecOptions struct {
+	Container string    // Defaults to the pod's default container
+	Stdin     io.Reader // nil for no input
+	Stdout    io.Writer
+	Stderr    io.Writer // Unused with TTY, which merges it into Stdout
+	TTY       bool
+	Resize    <-chan TerminalSize // Terminal size changes, with TTY
+}
+
+// SecretSpec is a secret SyncSecrets creates
+type SecretSpec struct {
+	Name   string
+	Type   string // e.g. kubernetes.io/tls; empty means Opaque
+	Labels map[string]string
+	Data   map[string][]byte
+}
+
+// TerminalSize is the size of a terminal in characters
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
 }
 
 // Terraform types removed - using k3d + Helm only
@@ -109,6 +333,32 @@ type Command struct {
 	Args []string          `json:"args"`
 	Dir  string            `json:"dir,omitempty"`
 	Env  map[string]string `json:"env,omitempty"`
+
+	Stdin io.Reader `json:"-"`             // Optional input; the terminal for interactive commands
+	TTY   bool      `json:"tty,omitempty"` // Command needs an interactive terminal
+
+	Timeout   time.Duration `json:"timeout,omitempty"`    // Maximum run time; zero means only the caller's context applies
+	KillGrace time.Duration `json:"kill_grace,omitempty"` // Time between SIGTERM and SIGKILL; defaults to Timeouts.KillGrace
+}
+
+// StreamName identifies which output stream a line came from
+type StreamName string
+
+const (
+	StreamStdout StreamName = "stdout"
+	StreamStderr StreamName = "stderr"
+)
+
+// StreamOptions configures where a streamed command's output goes.
+// When the context is cancelled the process is killed and StreamOutput
+// returns an error wrapping ctx.Err().
+type StreamOptions struct {
+	Stdout io.Writer // Optional destination for stdout
+	Stderr io.Writer // Optional destination for stderr
+
+	// OnLine is called for every complete output line (without the
+	// trailing newline), in order per stream
+	OnLine func(stream StreamName, line string)
 }
 
 type ExecuteResult struct {
@@ -10,12 +10,15 @@ import (
 // K3dProvider implements ClusterProvider for k3d
 type K3dProvider struct {
 	executor ProcessExecutor
+	runtime  ContainerRuntime // Engine the cluster nodes run in
 }
 
-// NewK3dProvider creates a new k3d provider
-func NewK3dProvider() ClusterProvider {
+// NewK3dProvider creates a new k3d provider running its nodes in a
+// container runtime
+func NewK3dProvider(runtime ContainerRuntime) ClusterProvider {
 	return &K3dProvider{
 		executor: NewProcessExecutor(),
+		runtime:  runtime,
 	}
 }
 
@@ -45,12 +48,18 @@ func (k *K3dProvider) CreateCluster(ctx context.Context, config ClusterConfig) e
 		args = append(args, "--volume", volume)
 	}
 
+	for _, registry := range config.Registries {
+		args = append(args, "--registry-use", registry)
+	}
+
 	// Add additional options
 	args = append(args, config.Options...)
 
 	cmd := Command{
-		Name: "k3d",
-		Args: args,
+		Name:    "k3d",
+		Args:    args,
+		Env:     k.runtime.Env(),
+		Timeout: installTimeout(),
 	}
 
 	_, err := k.executor.Execute(ctx, cmd)
@@ -61,11 +70,33 @@ func (k *K3dProvider) CreateCluster(ctx context.Context, config ClusterConfig) e
 	return nil
 }
 
+// ImportImages copies images from the local docker daemon into a k3d cluster
+func (k *K3dProvider) ImportImages(ctx context.Context, name string, images []string) error {
+	args := append([]string{"image", "import"}, images...)
+	args = append(args, "--cluster", name)
+
+	cmd := Command{
+		Name:    "k3d",
+		Args:    args,
+		Env:     k.runtime.Env(),
+		Timeout: installTimeout(),
+	}
+
+	_, err := k.executor.Execute(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to import images into k3d cluster: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteCluster removes a k3d cluster
 func (k *K3dProvider) DeleteCluster(ctx context.Context, name string) error {
 	cmd := Command{
-		Name: "k3d",
-		Args: []string{"cluster", "delete", name},
+		Name:    "k3d",
+		Args:    []string{"cluster", "delete", name},
+		Env:     k.runtime.Env(),
+		Timeout: mutateTimeout(),
 	}
 
 	_, err := k.executor.Execute(ctx, cmd)
@@ -79,8 +110,10 @@ func (k *K3dProvider) DeleteCluster(ctx context.Context, name string) error {
 // GetClusterStatus returns current cluster information
 func (k *K3dProvider) GetClusterStatus(ctx context.Context, name string) (*ClusterStatus, error) {
 	cmd := Command{
-		Name: "k3d",
-		Args: []string{"cluster", "get", name, "-o", "json"},
+		Name:    "k3d",
+		Args:    []string{"cluster", "get", name, "-o", "json"},
+		Env:     k.runtime.Env(),
+		Timeout: queryTimeout(),
 	}
 
 	result, err := k.executor.Execute(ctx, cmd)
@@ -134,8 +167,10 @@ func (k *K3dProvider) GetClusterStatus(ctx context.Context, name string) (*Clust
 // ListClusters returns all managed clusters
 func (k *K3dProvider) ListClusters(ctx context.Context) ([]ClusterInfo, error) {
 	cmd := Command{
-		Name: "k3d",
-		Args: []string{"cluster", "list", "-o", "json"},
+		Name:    "k3d",
+		Args:    []string{"cluster", "list", "-o", "json"},
+		Env:     k.runtime.Env(),
+		Timeout: queryTimeout(),
 	}
 
 	result, err := k.executor.Execute(ctx, cmd)
@@ -166,6 +201,35 @@ func (k *K3dProvider) ListClusters(ctx context.Context) ([]ClusterInfo, error) {
 	return clusters, nil
 }
 
+// KubeContext returns the kubeconfig context k3d creates for a cluster
+func (k *K3dProvider) KubeContext(name string) string {
+	return "k3d-" + name
+}
+
+// WriteKubeconfig merges a k3d cluster's credentials into the active kubeconfig
+func (k *K3dProvider) WriteKubeconfig(ctx context.Context, name string) error {
+	cmd := Command{
+		Name:    "k3d",
+		Args:    []string{"kubeconfig", "merge", name, "--kubeconfig-merge-default", "--kubeconfig-switch-context"},
+		Env:     k.runtime.Env(),
+		Timeout: queryTimeout(),
+	}
+
+	if _, err := k.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to write kubeconfig for k3d cluster: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that k3d is available and can use the container runtime.
+// k3d needs a docker API, which podman serves and containerd doesn't.
+func (k *K3dProvider) Validate(ctx context.Context) error {
+	if k.runtime.Name() == ContainerRuntimeNerdctl {
+		return fmt.Errorf("k3d needs docker or podman; use clusterProvider kind with nerdctl")
+	}
+	return ValidateK3d(ctx)
+}
+
 // ValidateK3d checks if k3d is available and returns version
 func ValidateK3d(ctx context.Context) error {
 	if err := ValidateCommand("k3d"); err != nil {
@@ -177,6 +241,6 @@ func ValidateK3d(ctx context.Context) error {
 		return fmt.Errorf("failed to get k3d version: %w", err)
 	}
 
-	fmt.Printf("Found k3d: %s\n", version)
+	logger().Debug("found k3d", "version", version)
 	return nil
 }
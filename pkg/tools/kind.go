@@ -0,0 +1,251 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KindProvider implements ClusterProvider for kind
+type KindProvider struct {
+	executor ProcessExecutor
+	runtime  ContainerRuntime // Engine the cluster nodes run in
+}
+
+// NewKindProvider creates a new kind provider running its nodes in a
+// container runtime
+func NewKindProvider(runtime ContainerRuntime) ClusterProvider {
+	return &KindProvider{
+		executor: NewProcessExecutor(),
+		runtime:  runtime,
+	}
+}
+
+// env returns the environment kind runs in: the runtime's socket, and
+// kind's provider selection for runtimes other than docker
+func (k *KindProvider) env() map[string]string {
+	env := map[string]string{}
+	for key, value := range k.runtime.Env() {
+		env[key] = value
+	}
+	if k.runtime.Name() != ContainerRuntimeDocker {
+		env["KIND_EXPERIMENTAL_PROVIDER"] = k.runtime.Name()
+	}
+	return env
+}
+
+// kindConfig is the subset of kind's cluster configuration plat sets
+type kindConfig struct {
+	Kind       string     `yaml:"kind"`
+	APIVersion string     `yaml:"apiVersion"`
+	Nodes      []kindNode `yaml:"nodes"`
+}
+
+type kindNode struct {
+	Role              string            `yaml:"role"`
+	Image             string            `yaml:"image,omitempty"`
+	ExtraPortMappings []kindPortMapping `yaml:"extraPortMappings,omitempty"`
+	ExtraMounts       []kindMount       `yaml:"extraMounts,omitempty"`
+}
+
+type kindPortMapping struct {
+	ContainerPort int `yaml:"containerPort"`
+	HostPort      int `yaml:"hostPort"`
+}
+
+type kindMount struct {
+	HostPath      string `yaml:"hostPath"`
+	ContainerPath string `yaml:"containerPath"`
+}
+
+// CreateCluster creates a new kind cluster. Ports are mapped on the first
+// control-plane node, where the ingress controller runs. k3d-specific
+// settings (labels, registries) don't apply to kind.
+func (k *KindProvider) CreateCluster(ctx context.Context, config ClusterConfig) error {
+	cluster := kindConfig{Kind: "Cluster", APIVersion: "kind.x-k8s.io/v1alpha4"}
+
+	servers := max(config.Servers, 1)
+	for i := 0; i < servers+config.Agents; i++ {
+		node := kindNode{Role: "control-plane", Image: config.Image}
+		if i >= servers {
+			node.Role = "worker"
+		}
+		for _, volume := range config.Volumes {
+			hostPath, containerPath, _ := strings.Cut(volume, ":")
+			containerPath, _, _ = strings.Cut(containerPath, "@")
+			node.ExtraMounts = append(node.ExtraMounts, kindMount{HostPath: hostPath, ContainerPath: containerPath})
+		}
+		cluster.Nodes = append(cluster.Nodes, node)
+	}
+
+	for _, mapping := range config.Ports {
+		host, container := portMapping(mapping)
+		hostPort, err := strconv.Atoi(host)
+		if err != nil {
+			return fmt.Errorf("invalid port mapping %q: %w", mapping, err)
+		}
+		containerPort, err := strconv.Atoi(container)
+		if err != nil {
+			return fmt.Errorf("invalid port mapping %q: %w", mapping, err)
+		}
+		cluster.Nodes[0].ExtraPortMappings = append(cluster.Nodes[0].ExtraPortMappings, kindPortMapping{
+			ContainerPort: containerPort,
+			HostPort:      hostPort,
+		})
+	}
+
+	data, err := yaml.Marshal(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to encode kind config: %w", err)
+	}
+
+	args := append([]string{"create", "cluster", "--name", config.Name, "--config", "-"}, config.Options...)
+	cmd := Command{
+		Name:    "kind",
+		Args:    args,
+		Env:     k.env(),
+		Stdin:   bytes.NewReader(data),
+		Timeout: installTimeout(),
+	}
+
+	if _, err := k.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to create kind cluster: %w", err)
+	}
+	return nil
+}
+
+// DeleteCluster removes a kind cluster
+func (k *KindProvider) DeleteCluster(ctx context.Context, name string) error {
+	cmd := Command{
+		Name:    "kind",
+		Args:    []string{"delete", "cluster", "--name", name},
+		Env:     k.env(),
+		Timeout: mutateTimeout(),
+	}
+
+	if _, err := k.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to delete kind cluster: %w", err)
+	}
+	return nil
+}
+
+// GetClusterStatus returns current cluster information. kind has no status
+// command; the cluster runs when its first control-plane container does.
+func (k *KindProvider) GetClusterStatus(ctx context.Context, name string) (*ClusterStatus, error) {
+	cmd := Command{
+		Name:    "kind",
+		Args:    []string{"get", "nodes", "--name", name},
+		Env:     k.env(),
+		Timeout: queryTimeout(),
+	}
+
+	result, err := k.executor.Execute(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kind cluster status: %w", err)
+	}
+
+	// kind prints "No kind nodes found ..." to stderr for unknown clusters
+	nodes := strings.Fields(result.Stdout)
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("cluster %s not found", name)
+	}
+
+	status := &ClusterStatus{Name: name, Status: "stopped", Network: "kind"}
+	for _, node := range nodes {
+		if strings.Contains(node, "control-plane") {
+			status.Servers++
+		} else {
+			status.Agents++
+		}
+	}
+
+	inspect := Command{
+		Name:    k.runtime.Name(),
+		Args:    []string{"inspect", "--format", "{{.State.Running}}", name + "-control-plane"},
+		Env:     k.runtime.Env(),
+		Timeout: queryTimeout(),
+	}
+	if result, err := k.executor.Execute(ctx, inspect); err == nil && strings.TrimSpace(result.Stdout) == "true" {
+		status.Status = "running"
+	}
+
+	return status, nil
+}
+
+// ListClusters returns all kind clusters
+func (k *KindProvider) ListClusters(ctx context.Context) ([]ClusterInfo, error) {
+	cmd := Command{
+		Name:    "kind",
+		Args:    []string{"get", "clusters"},
+		Env:     k.env(),
+		Timeout: queryTimeout(),
+	}
+
+	result, err := k.executor.Execute(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kind clusters: %w", err)
+	}
+
+	var clusters []ClusterInfo
+	for _, name := range strings.Fields(result.Stdout) {
+		clusters = append(clusters, ClusterInfo{Name: name, Status: "running"})
+	}
+	return clusters, nil
+}
+
+// ImportImages copies images from the container runtime into a kind cluster
+func (k *KindProvider) ImportImages(ctx context.Context, name string, images []string) error {
+	args := append([]string{"load", "docker-image"}, images...)
+	args = append(args, "--name", name)
+
+	cmd := Command{
+		Name:    "kind",
+		Args:    args,
+		Env:     k.env(),
+		Timeout: installTimeout(),
+	}
+
+	if _, err := k.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to import images into kind cluster: %w", err)
+	}
+	return nil
+}
+
+// KubeContext returns the kubeconfig context kind creates for a cluster
+func (k *KindProvider) KubeContext(name string) string {
+	return "kind-" + name
+}
+
+// WriteKubeconfig exports a kind cluster's credentials to the active kubeconfig
+func (k *KindProvider) WriteKubeconfig(ctx context.Context, name string) error {
+	cmd := Command{
+		Name:    "kind",
+		Args:    []string{"export", "kubeconfig", "--name", name},
+		Env:     k.env(),
+		Timeout: queryTimeout(),
+	}
+
+	if _, err := k.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to write kubeconfig for kind cluster: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that kind is available
+func (k *KindProvider) Validate(ctx context.Context) error {
+	if err := ValidateCommand("kind"); err != nil {
+		return err
+	}
+
+	version, err := GetCommandVersion(ctx, "kind", "version")
+	if err != nil {
+		return fmt.Errorf("failed to get kind version: %w", err)
+	}
+
+	logger().Debug("found kind", "version", version)
+	return nil
+}
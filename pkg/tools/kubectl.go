@@ -2,119 +2,218 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // PodStatus represents the status of a Kubernetes pod
 type PodStatus struct {
+	// Of the pod in the worst state, so one crash-looping replica out of
+	// three shows
 	Phase          string
-	Ready          bool
-	PodsReady      string // e.g., "1/1"
 	ContainerState string
 	Reason         string
 	Message        string
+
+	// Across every pod of the release
+	Ready     bool   // Every container of every pod ready
+	PodsReady string // Ready containers, e.g. "2/3"
+	Pods      int
+	ReadyPods int
+	Restarts  int         // Container restarts, summed
+	Summary   string      // Pods counted by state, e.g. "2 ready, 1 CrashLoopBackOff"
+	Details   []PodDetail // Every pod, worst state first
+}
+
+// PodDetail is the state of one pod of a release
+type PodDetail struct {
+	Name           string `json:"name" yaml:"name"`
+	Phase          string `json:"phase" yaml:"phase"`
+	Ready          bool   `json:"ready" yaml:"ready"`
+	ContainerState string `json:"container_state" yaml:"container_state"`
+	Reason         string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message        string `json:"message,omitempty" yaml:"message,omitempty"`
+	Restarts       int    `json:"restarts" yaml:"restarts"`
+}
+
+// JobStatus represents the completion state of a Kubernetes job
+type JobStatus struct {
+	Active    int
+	Succeeded int
+	Failed    int // Failed pods, including ones the job retried
+	Complete  bool
+	GaveUp    bool   // The job's Failed condition: backoff limit or deadline exceeded
+	Reason    string // Why it gave up, e.g. BackoffLimitExceeded
+}
+
+// The helpers below use the default client-go provider, so callers don't
+// need kubectl installed.
+
+// IsNotFound reports whether err means a Kubernetes resource does not exist
+func IsNotFound(err error) bool {
+	return apierrors.IsNotFound(err)
+}
+
+// ListPods returns the pods matching a label selector, oldest first
+func ListPods(ctx context.Context, namespace, selector string) ([]PodInfo, error) {
+	return defaultKubernetes.ListPods(ctx, namespace, selector)
 }
 
 // GetPodStatus gets the status of pods for a given Helm release
 func GetPodStatus(ctx context.Context, releaseName, namespace string) (*PodStatus, error) {
-	executor := NewProcessExecutor()
-
-	// Use label selector to find pods managed by this Helm release
-	cmd := Command{
-		Name: "kubectl",
-		Args: []string{
-			"get", "pods",
-			"-n", namespace,
-			"-l", fmt.Sprintf("app.kubernetes.io/instance=%s", releaseName),
-			"-o", "json",
-		},
-	}
+	return defaultKubernetes.GetPodStatus(ctx, releaseName, namespace)
+}
 
-	result, err := executor.Execute(ctx, cmd)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get pod status: %s", result.Stderr)
-	}
+// GetPodImages returns the resolved image references (with digests) of the
+// containers running for a given Helm release
+func GetPodImages(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	return defaultKubernetes.GetPodImages(ctx, releaseName, namespace)
+}
 
-	var podList struct {
-		Items []struct {
-			Status struct {
-				Phase      string `json:"phase"`
-				Conditions []struct {
-					Type   string `json:"type"`
-					Status string `json:"status"`
-					Reason string `json:"reason"`
-				} `json:"conditions"`
-				ContainerStatuses []struct {
-					Name  string `json:"name"`
-					Ready bool   `json:"ready"`
-					State struct {
-						Running *struct{} `json:"running"`
-						Waiting *struct {
-							Reason  string `json:"reason"`
-							Message string `json:"message"`
-						} `json:"waiting"`
-						Terminated *struct {
-							Reason string `json:"reason"`
-						} `json:"terminated"`
-					} `json:"state"`
-				} `json:"containerStatuses"`
-			} `json:"status"`
-		} `json:"items"`
-	}
+// StreamLogs returns the combined logs of every pod matching a selector
+func StreamLogs(ctx context.Context, namespace, selector string, opts LogOptions) (io.ReadCloser, error) {
+	return defaultKubernetes.StreamLogs(ctx, namespace, selector, opts)
+}
 
-	if err := json.Unmarshal([]byte(result.Stdout), &podList); err != nil {
-		return nil, fmt.Errorf("failed to parse pod status: %w", err)
-	}
+// ListEvents returns the events of a namespace, oldest first
+func ListEvents(ctx context.Context, namespace string) ([]EventInfo, error) {
+	return defaultKubernetes.ListEvents(ctx, namespace)
+}
+
+// DescribePod returns a pod's containers, environment and events
+func DescribePod(ctx context.Context, namespace, name string) (*PodDescription, error) {
+	return defaultKubernetes.DescribePod(ctx, namespace, name)
+}
+
+// PortForward forwards local ports to a pod until ctx is cancelled
+func PortForward(ctx context.Context, namespace, pod string, ports []string, ready chan struct{}) error {
+	return defaultKubernetes.PortForward(ctx, namespace, pod, ports, ready)
+}
 
-	if len(podList.Items) == 0 {
-		return &PodStatus{
-			Phase:     "Unknown",
-			Ready:     false,
-			PodsReady: "0/0",
-			Reason:    "NoPods",
-			Message:   "No pods found for this release",
-		}, nil
+// Exec runs a command in a pod's container with the given streams attached
+func Exec(ctx context.Context, namespace, pod string, command []string, opts ExecOptions) error {
+	return defaultKubernetes.Exec(ctx, namespace, pod, command, opts)
+}
+
+// ListPersistentVolumeClaims returns the names of the PVCs created for a Helm
+// release
+func ListPersistentVolumeClaims(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	return defaultKubernetes.ListPersistentVolumeClaims(ctx, releaseName, namespace)
+}
+
+// DeletePersistentVolumeClaims removes the PVCs created for a Helm release and
+// returns the names of the deleted claims
+func DeletePersistentVolumeClaims(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	return defaultKubernetes.DeletePersistentVolumeClaims(ctx, releaseName, namespace)
+}
+
+// DeleteNetworkPolicies removes the NetworkPolicies matching a label selector
+// and returns the names of the deleted policies
+func DeleteNetworkPolicies(ctx context.Context, namespace, selector string) ([]string, error) {
+	return defaultKubernetes.DeleteNetworkPolicies(ctx, namespace, selector)
+}
+
+// EnsureNamespace creates a namespace if it does not exist yet
+func EnsureNamespace(ctx context.Context, namespace string) error {
+	return defaultKubernetes.EnsureNamespace(ctx, namespace)
+}
+
+// SyncSecrets creates or replaces secrets, and deletes the secrets matching
+// selector that are not among them
+func SyncSecrets(ctx context.Context, namespace, selector string, secrets []SecretSpec) error {
+	return defaultKubernetes.SyncSecrets(ctx, namespace, selector, secrets)
+}
+
+// GetJobStatus gets the status of a Kubernetes job by name
+func GetJobStatus(ctx context.Context, jobName, namespace string) (*JobStatus, error) {
+	return defaultKubernetes.GetJobStatus(ctx, jobName, namespace)
+}
+
+// GetSecret returns the data of a secret. Use IsNotFound to check for a
+// missing secret.
+func GetSecret(ctx context.Context, name, namespace string) (map[string][]byte, error) {
+	return defaultKubernetes.GetSecret(ctx, name, namespace)
+}
+
+// UpdateSecret replaces the data of an opaque secret, creating it if needed
+func UpdateSecret(ctx context.Context, name, namespace string, data map[string][]byte) error {
+	return defaultKubernetes.UpdateSecret(ctx, name, namespace, data)
+}
+
+// DeleteSecret removes a secret; a missing secret is not an error
+func DeleteSecret(ctx context.Context, name, namespace string) error {
+	return defaultKubernetes.DeleteSecret(ctx, name, namespace)
+}
+
+// ListWorkloads returns the deployments, statefulsets and daemonsets of a Helm
+// release as kubectl resource names (e.g. "deployment.apps/api")
+func ListWorkloads(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	return defaultKubernetes.ListWorkloads(ctx, releaseName, namespace)
+}
+
+// ListNamespaceWorkloads returns every deployment, statefulset and daemonset
+// of a namespace with the release or app instance it belongs to
+func ListNamespaceWorkloads(ctx context.Context, namespace string) ([]WorkloadInfo, error) {
+	return defaultKubernetes.ListNamespaceWorkloads(ctx, namespace)
+}
+
+// RolloutRestart triggers a rolling restart of a workload
+func RolloutRestart(ctx context.Context, workload, namespace string) error {
+	return defaultKubernetes.RolloutRestart(ctx, workload, namespace)
+}
+
+// RolloutStatus waits for a workload's rollout to finish, passing each
+// progress message to onProgress
+func RolloutStatus(ctx context.Context, workload, namespace string, timeout time.Duration, onProgress func(string)) error {
+	return defaultKubernetes.RolloutStatus(ctx, workload, namespace, timeout, onProgress)
+}
+
+// UseKubeconfig points plat and every tool it runs at a kubeconfig file by
+// setting $KUBECONFIG for the process. Cluster providers write new clusters'
+// credentials there, leaving the user's ~/.kube/config untouched.
+func UseKubeconfig(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create kubeconfig directory: %w", err)
 	}
+	return os.Setenv(clientcmd.RecommendedConfigPathEnvVar, path)
+}
 
-	// Use the first pod (most releases have a single pod, or we show representative status)
-	pod := podList.Items[0]
-	status := &PodStatus{
-		Phase: pod.Status.Phase,
+// CurrentKubeContext returns the active kubeconfig context, or "" if none is set
+func CurrentKubeContext(ctx context.Context) string {
+	config, err := loadingRules().Load()
+	if err != nil {
+		return ""
 	}
+	return config.CurrentContext
+}
 
-	// Check container readiness
-	totalContainers := len(pod.Status.ContainerStatuses)
-	readyContainers := 0
-	for _, cs := range pod.Status.ContainerStatuses {
-		if cs.Ready {
-			readyContainers++
-		}
+// KubeTarget is the cluster the active kubeconfig context points at
+type KubeTarget struct {
+	Context string
+	Cluster string // Cluster entry of the context
+	Server  string // API server URL
+}
 
-		// Determine container state
-		if cs.State.Running != nil {
-			status.ContainerState = "running"
-		} else if cs.State.Waiting != nil {
-			status.ContainerState = "waiting"
-			status.Reason = cs.State.Waiting.Reason
-			status.Message = cs.State.Waiting.Message
-		} else if cs.State.Terminated != nil {
-			status.ContainerState = "terminated"
-			status.Reason = cs.State.Terminated.Reason
-		}
+// CurrentKubeTarget returns the active kubeconfig context and the API server
+// it points at. Context is empty when none is set.
+func CurrentKubeTarget(ctx context.Context) (*KubeTarget, error) {
+	config, err := loadingRules().Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	status.PodsReady = fmt.Sprintf("%d/%d", readyContainers, totalContainers)
-	status.Ready = readyContainers == totalContainers && totalContainers > 0
-
-	// Check pod conditions for additional info
-	for _, cond := range pod.Status.Conditions {
-		if cond.Type == "Ready" && cond.Status != "True" && cond.Reason != "" {
-			if status.Reason == "" {
-				status.Reason = cond.Reason
-			}
+	target := &KubeTarget{Context: config.CurrentContext}
+	if kubeContext, ok := config.Contexts[config.CurrentContext]; ok {
+		target.Cluster = kubeContext.Cluster
+		if cluster, ok := config.Clusters[kubeContext.Cluster]; ok {
+			target.Server = cluster.Server
 		}
 	}
-
-	return status, nil
+	return target, nil
 }
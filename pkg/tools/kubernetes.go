@@ -0,0 +1,1277 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// ErrNoPods is returned when a label selector matches no pods
+var ErrNoPods = errors.New("no pods found")
+
+// defaultContainerAnnotation names the container kubectl picks by default
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// rolloutPollInterval is how often RolloutStatus checks a workload
+const rolloutPollInterval = 2 * time.Second
+
+// KubeClient implements KubernetesProvider with client-go. The kubeconfig is
+// loaded on every call, so context switches (e.g. a freshly created cluster)
+// are picked up without restarting.
+type KubeClient struct {
+	kubeconfig string // Kubeconfig file; empty follows $KUBECONFIG
+	context    string // Context to use; empty means the current context
+}
+
+// NewKubernetesProvider creates a new Kubernetes API provider
+func NewKubernetesProvider() KubernetesProvider {
+	return &KubeClient{}
+}
+
+// NewKubernetesProviderFor creates a Kubernetes API provider for a context of
+// a given kubeconfig, to reach a cluster other than the active one
+func NewKubernetesProviderFor(kubeconfig, kubeContext string) KubernetesProvider {
+	return &KubeClient{kubeconfig: kubeconfig, context: kubeContext}
+}
+
+// defaultKubernetes backs the package-level helpers in kubectl.go
+var defaultKubernetes = NewKubernetesProvider()
+
+// loadingRules returns the standard kubeconfig search rules ($KUBECONFIG, ~/.kube/config)
+func loadingRules() *clientcmd.ClientConfigLoadingRules {
+	return clientcmd.NewDefaultClientConfigLoadingRules()
+}
+
+// config returns the REST config of the client's kubeconfig context
+func (k *KubeClient) config() (*rest.Config, error) {
+	rules := loadingRules()
+	if k.kubeconfig != "" {
+		rules.ExplicitPath = k.kubeconfig
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: k.context}
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return cfg, nil
+}
+
+// clientset returns a typed client for the current kubeconfig context
+func (k *KubeClient) clientset() (*kubernetes.Clientset, error) {
+	cfg, err := k.config()
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	return client, nil
+}
+
+// releaseSelector selects the resources of a Helm release
+func releaseSelector(releaseName string) string {
+	return fmt.Sprintf("app.kubernetes.io/instance=%s", releaseName)
+}
+
+// listPods returns the pods matching a label selector
+func (k *KubeClient) listPods(ctx context.Context, namespace, selector string) ([]corev1.Pod, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	list, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ListPods returns the pods matching a label selector, oldest first
+func (k *KubeClient) ListPods(ctx context.Context, namespace, selector string) ([]PodInfo, error) {
+	pods, err := k.listPods(ctx, namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	infos := make([]PodInfo, 0, len(pods))
+	for _, pod := range pods {
+		info := PodInfo{
+			Name:    pod.Name,
+			Phase:   string(pod.Status.Phase),
+			Node:    pod.Spec.NodeName,
+			Created: pod.CreationTimestamp.Time,
+		}
+
+		ready := 0
+		for _, cs := range pod.Status.ContainerStatuses {
+			info.Containers = append(info.Containers, cs.Name)
+			info.Restarts += int(cs.RestartCount)
+			if cs.Ready {
+				ready++
+			}
+		}
+		info.Ready = len(pod.Status.ContainerStatuses) > 0 && ready == len(pod.Status.ContainerStatuses)
+
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Created.Before(infos[j].Created) })
+	return infos, nil
+}
+
+// GetPodStatus summarises every pod of a Helm release. The phase, state and
+// reason are those of the pod in the worst state.
+func (k *KubeClient) GetPodStatus(ctx context.Context, releaseName, namespace string) (*PodStatus, error) {
+	pods, err := k.listPods(ctx, namespace, releaseSelector(releaseName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod status: %w", err)
+	}
+
+	if len(pods) == 0 {
+		return &PodStatus{
+			Phase:     "Unknown",
+			Ready:     false,
+			PodsReady: "0/0",
+			Reason:    "NoPods",
+			Message:   "No pods found for this release",
+		}, nil
+	}
+
+	status := &PodStatus{Pods: len(pods)}
+	totalContainers, readyContainers := 0, 0
+	for _, pod := range pods {
+		detail := podDetail(pod)
+		status.Details = append(status.Details, detail)
+		status.Restarts += detail.Restarts
+		if detail.Ready {
+			status.ReadyPods++
+		}
+
+		totalContainers += len(pod.Status.ContainerStatuses)
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				readyContainers++
+			}
+		}
+	}
+
+	sort.SliceStable(status.Details, func(i, j int) bool {
+		return podSeverity(status.Details[i]) > podSeverity(status.Details[j])
+	})
+	worst := status.Details[0]
+	status.Phase = worst.Phase
+	status.ContainerState = worst.ContainerState
+	status.Reason = worst.Reason
+	status.Message = worst.Message
+
+	status.PodsReady = fmt.Sprintf("%d/%d", readyContainers, totalContainers)
+	status.Ready = status.ReadyPods == status.Pods && readyContainers == totalContainers && totalContainers > 0
+	status.Summary = summarizePods(status.Details)
+
+	return status, nil
+}
+
+// podDetail describes one pod: the state of its first container that is not
+// running, or running when they all are
+func podDetail(pod corev1.Pod) PodDetail {
+	detail := PodDetail{
+		Name:  pod.Name,
+		Phase: string(pod.Status.Phase),
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		detail.Restarts += int(cs.RestartCount)
+		if detail.ContainerState != "" && detail.ContainerState != "running" {
+			continue
+		}
+
+		switch {
+		case cs.State.Running != nil:
+			detail.ContainerState = "running"
+		case cs.State.Waiting != nil:
+			detail.ContainerState = "waiting"
+			detail.Reason = cs.State.Waiting.Reason
+			detail.Message = cs.State.Waiting.Message
+		case cs.State.Terminated != nil:
+			detail.ContainerState = "terminated"
+			detail.Reason = cs.State.Terminated.Reason
+		}
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != corev1.PodReady {
+			continue
+		}
+		detail.Ready = cond.Status == corev1.ConditionTrue
+		if !detail.Ready && detail.Reason == "" {
+			detail.Reason = cond.Reason
+		}
+	}
+
+	return detail
+}
+
+// failingPodReasons are container states a pod won't recover from by waiting
+var failingPodReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+	"OOMKilled":                  true,
+	"Error":                      true,
+}
+
+// podSeverity ranks how bad a pod's state is, higher being worse
+func podSeverity(detail PodDetail) int {
+	switch {
+	case detail.Phase == string(corev1.PodFailed) || failingPodReasons[detail.Reason]:
+		return 3
+	case detail.ContainerState == "waiting" || detail.ContainerState == "terminated" || detail.Phase == string(corev1.PodPending):
+		return 2
+	case !detail.Ready:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// summarizePods counts pods by state, ready pods first, e.g.
+// "2 ready, 1 CrashLoopBackOff"
+func summarizePods(details []PodDetail) string {
+	counts := make(map[string]int)
+	var states []string
+	for _, detail := range details {
+		state := "ready"
+		if !detail.Ready {
+			state = detail.Reason
+			if state == "" {
+				state = "not ready"
+			}
+		}
+		if counts[state] == 0 {
+			states = append(states, state)
+		}
+		counts[state]++
+	}
+
+	// Details are sorted worst first; ready pods lead the summary
+	sort.SliceStable(states, func(i, j int) bool { return states[i] == "ready" && states[j] != "ready" })
+
+	parts := make([]string, len(states))
+	for i, state := range states {
+		parts[i] = fmt.Sprintf("%d %s", counts[state], state)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// GetPodImages returns the resolved image references (with digests) of the
+// containers running for a given Helm release
+func (k *KubeClient) GetPodImages(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	pods, err := k.listPods(ctx, namespace, releaseSelector(releaseName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod images: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var images []string
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			// imageID is "docker-pullable://repo@sha256:..." or similar
+			ref := cs.ImageID
+			if idx := strings.Index(ref, "://"); idx != -1 {
+				ref = ref[idx+3:]
+			}
+			if ref == "" {
+				ref = cs.Image
+			}
+			if !seen[ref] {
+				seen[ref] = true
+				images = append(images, ref)
+			}
+		}
+	}
+
+	sort.Strings(images)
+	return images, nil
+}
+
+// StreamLogs returns the combined logs of every pod matching a selector.
+// Lines from different pods are interleaved as they arrive; closing the
+// reader stops all streams.
+func (k *KubeClient) StreamLogs(ctx context.Context, namespace, selector string, opts LogOptions) (io.ReadCloser, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := k.listPods(ctx, namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	if len(pods) == 0 {
+		return nil, ErrNoPods
+	}
+
+	podOpts := corev1.PodLogOptions{
+		Follow:     opts.Follow,
+		Previous:   opts.Previous,
+		Timestamps: opts.Timestamps,
+	}
+	if opts.TailLines >= 0 {
+		tail := int64(opts.TailLines)
+		podOpts.TailLines = &tail
+	}
+	if opts.Since > 0 {
+		since := int64(opts.Since.Seconds())
+		podOpts.SinceSeconds = &since
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var streams []io.ReadCloser
+	var prefixes []string
+	for _, pod := range pods {
+		podOpts.Container = opts.Container
+		if podOpts.Container == "" {
+			podOpts.Container = defaultContainer(pod)
+		}
+
+		stream, err := client.CoreV1().Pods(namespace).GetLogs(pod.Name, &podOpts).Stream(ctx)
+		if err != nil {
+			cancel()
+			for _, s := range streams {
+				s.Close()
+			}
+			return nil, fmt.Errorf("failed to get logs of pod %s: %w", pod.Name, err)
+		}
+
+		streams = append(streams, stream)
+		prefix := ""
+		if opts.Prefix {
+			prefix = fmt.Sprintf("[pod/%s/%s] ", pod.Name, podOpts.Container)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	reader, writer := io.Pipe()
+	var writeMu sync.Mutex
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		wg.Add(1)
+		go func(stream io.ReadCloser, prefix string) {
+			defer wg.Done()
+			defer stream.Close()
+
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				writeMu.Lock()
+				_, err := io.WriteString(writer, prefix+scanner.Text()+"\n")
+				writeMu.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}(stream, prefixes[i])
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		writer.Close()
+	}()
+
+	return &logStream{PipeReader: reader, cancel: cancel}, nil
+}
+
+// logStream cancels the underlying pod log requests when closed
+type logStream struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (s *logStream) Close() error {
+	s.cancel()
+	return s.PipeReader.Close()
+}
+
+// defaultContainer returns the container kubectl would show logs for
+func defaultContainer(pod corev1.Pod) string {
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return name
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// ListEvents returns the events of a namespace, oldest first
+func (k *KubeClient) ListEvents(ctx context.Context, namespace string) ([]EventInfo, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return eventInfos(list.Items), nil
+}
+
+// eventInfos converts events, oldest first
+func eventInfos(items []corev1.Event) []EventInfo {
+	events := make([]EventInfo, 0, len(items))
+	for _, event := range items {
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		if lastSeen.IsZero() {
+			lastSeen = event.CreationTimestamp.Time
+		}
+
+		events = append(events, EventInfo{
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Object:   fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+			Message:  strings.TrimSpace(event.Message),
+			Count:    int(event.Count),
+			LastSeen: lastSeen,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].LastSeen.Before(events[j].LastSeen) })
+	return events
+}
+
+// DescribePod returns a pod's containers with their state and environment,
+// and the pod's events
+func (k *KubeClient) DescribePod(ctx context.Context, namespace, name string) (*PodDescription, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", name, err)
+	}
+
+	description := &PodDescription{
+		Name:    pod.Name,
+		Phase:   string(pod.Status.Phase),
+		Node:    pod.Spec.NodeName,
+		IP:      pod.Status.PodIP,
+		Created: pod.CreationTimestamp.Time,
+	}
+	volumes := volumeSources(pod.Spec.Volumes)
+	for _, container := range pod.Spec.InitContainers {
+		description.Containers = append(description.Containers, containerInfo(container, pod.Status.InitContainerStatuses, volumes, true))
+	}
+	for _, container := range pod.Spec.Containers {
+		description.Containers = append(description.Containers, containerInfo(container, pod.Status.ContainerStatuses, volumes, false))
+	}
+
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s", name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events of pod %s: %w", name, err)
+	}
+	description.Events = eventInfos(events.Items)
+
+	return description, nil
+}
+
+// containerInfo describes a container from its spec and its status, if the
+// kubelet reported one yet. volumes maps the pod's volumes to their sources.
+func containerInfo(container corev1.Container, statuses []corev1.ContainerStatus, volumes map[string]string, init bool) ContainerInfo {
+	info := ContainerInfo{
+		Name:  container.Name,
+		Image: container.Image,
+		Init:  init,
+		State: "waiting",
+		Env:   containerEnv(container),
+	}
+	for _, mount := range container.VolumeMounts {
+		info.Mounts = append(info.Mounts, Mount{Path: mount.MountPath, Source: volumes[mount.Name], ReadOnly: mount.ReadOnly})
+	}
+
+	for _, cs := range statuses {
+		if cs.Name != container.Name {
+			continue
+		}
+		info.Ready = cs.Ready
+		info.Restarts = int(cs.RestartCount)
+		switch {
+		case cs.State.Running != nil:
+			info.State = "running"
+			info.Since = cs.State.Running.StartedAt.Time
+		case cs.State.Waiting != nil:
+			info.Reason = cs.State.Waiting.Reason
+			info.Message = cs.State.Waiting.Message
+		case cs.State.Terminated != nil:
+			info.State = "terminated"
+			info.Reason = cs.State.Terminated.Reason
+			info.Message = cs.State.Terminated.Message
+			info.Since = cs.State.Terminated.FinishedAt.Time
+		}
+		if last := cs.LastTerminationState.Terminated; last != nil {
+			info.LastTermination = fmt.Sprintf("%s (exit %d)", last.Reason, last.ExitCode)
+		}
+	}
+
+	return info
+}
+
+// volumeSources describes where each of a pod's volumes comes from, by name
+func volumeSources(volumes []corev1.Volume) map[string]string {
+	sources := make(map[string]string, len(volumes))
+	for _, volume := range volumes {
+		switch {
+		case volume.Secret != nil:
+			sources[volume.Name] = "secret " + volume.Secret.SecretName
+		case volume.ConfigMap != nil:
+			sources[volume.Name] = "configmap " + volume.ConfigMap.Name
+		case volume.PersistentVolumeClaim != nil:
+			sources[volume.Name] = "pvc " + volume.PersistentVolumeClaim.ClaimName
+		case volume.EmptyDir != nil:
+			sources[volume.Name] = "emptyDir"
+		case volume.HostPath != nil:
+			sources[volume.Name] = "hostPath " + volume.HostPath.Path
+		case volume.Projected != nil:
+			sources[volume.Name] = "projected " + volume.Name
+		default:
+			sources[volume.Name] = "volume " + volume.Name
+		}
+	}
+	return sources
+}
+
+// containerEnv lists a container's environment in spec order, naming the
+// source of values it doesn't set literally
+func containerEnv(container corev1.Container) []EnvVar {
+	var env []EnvVar
+	for _, from := range container.EnvFrom {
+		switch {
+		case from.SecretRef != nil:
+			env = append(env, EnvVar{Source: "secret " + from.SecretRef.Name})
+		case from.ConfigMapRef != nil:
+			env = append(env, EnvVar{Source: "configmap " + from.ConfigMapRef.Name})
+		}
+	}
+
+	for _, variable := range container.Env {
+		entry := EnvVar{Name: variable.Name, Value: variable.Value}
+		if from := variable.ValueFrom; from != nil {
+			switch {
+			case from.SecretKeyRef != nil:
+				entry.Source = fmt.Sprintf("secret %s/%s", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+			case from.ConfigMapKeyRef != nil:
+				entry.Source = fmt.Sprintf("configmap %s/%s", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+			case from.FieldRef != nil:
+				entry.Source = "field " + from.FieldRef.FieldPath
+			case from.ResourceFieldRef != nil:
+				entry.Source = "resource " + from.ResourceFieldRef.Resource
+			}
+		}
+		env = append(env, entry)
+	}
+	return env
+}
+
+// PortForward forwards local ports to a pod until ctx is cancelled. Ports use
+// kubectl's "local:remote" syntax; ready is closed once forwarding is active.
+func (k *KubeClient) PortForward(ctx context.Context, namespace, pod string, ports []string, ready chan struct{}) error {
+	cfg, err := k.config()
+	if err != nil {
+		return err
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create port-forward transport: %w", err)
+	}
+
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stop := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stop)
+	}()
+
+	forwarder, err := portforward.New(dialer, ports, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return fmt.Errorf("failed to forward ports to %s: %w", pod, err)
+	}
+
+	if err := forwarder.ForwardPorts(); err != nil {
+		return fmt.Errorf("port-forward to %s failed: %w", pod, err)
+	}
+	return ctx.Err()
+}
+
+// Exec runs a command in a pod's container. Like kubectl, it talks
+// WebSocket to the API server and falls back to SPDY for older clusters.
+func (k *KubeClient) Exec(ctx context.Context, namespace, pod string, command []string, opts ExecOptions) error {
+	cfg, err := k.config()
+	if err != nil {
+		return err
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	container := opts.Container
+	if container == "" {
+		p, err := client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get pod %s: %w", pod, err)
+		}
+		container = defaultContainer(*p)
+	}
+
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    opts.Stdout != nil,
+			Stderr:    opts.Stderr != nil && !opts.TTY,
+			TTY:       opts.TTY,
+		}, scheme.ParameterCodec).
+		URL()
+
+	websocketExec, err := remotecommand.NewWebSocketExecutor(cfg, http.MethodGet, url.String())
+	if err != nil {
+		return fmt.Errorf("failed to create exec connection: %w", err)
+	}
+	spdyExec, err := remotecommand.NewSPDYExecutor(cfg, http.MethodPost, url)
+	if err != nil {
+		return fmt.Errorf("failed to create exec connection: %w", err)
+	}
+	executor, err := remotecommand.NewFallbackExecutor(websocketExec, spdyExec, httpstream.IsUpgradeFailure)
+	if err != nil {
+		return fmt.Errorf("failed to create exec connection: %w", err)
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Tty:    opts.TTY,
+	}
+	if !opts.TTY {
+		streamOpts.Stderr = opts.Stderr
+	}
+	if opts.Resize != nil {
+		streamOpts.TerminalSizeQueue = terminalSizeQueue(opts.Resize)
+	}
+
+	if err := executor.StreamWithContext(ctx, streamOpts); err != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("command exited with code %d", exitErr.ExitStatus())
+		}
+		return fmt.Errorf("exec in %s failed: %w", pod, err)
+	}
+	return nil
+}
+
+// terminalSizeQueue adapts a channel of sizes to remotecommand's resize queue
+type terminalSizeQueue <-chan TerminalSize
+
+func (q terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+	return &remotecommand.TerminalSize{Width: size.Width, Height: size.Height}
+}
+
+// ListWorkloads returns the deployments, statefulsets and daemonsets of a Helm
+// release as kubectl resource names (e.g. "deployment.apps/api")
+func (k *KubeClient) ListWorkloads(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	opts := metav1.ListOptions{LabelSelector: releaseSelector(releaseName)}
+	apps := client.AppsV1()
+
+	var workloads []string
+	deployments, err := apps.Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, "deployment.apps/"+d.Name)
+	}
+
+	statefulSets, err := apps.StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, "statefulset.apps/"+s.Name)
+	}
+
+	daemonSets, err := apps.DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for _, d := range daemonSets.Items {
+		workloads = append(workloads, "daemonset.apps/"+d.Name)
+	}
+
+	return workloads, nil
+}
+
+// ListNamespaceWorkloads returns every deployment, statefulset and daemonset
+// of a namespace with its app.kubernetes.io/instance label
+func (k *KubeClient) ListNamespaceWorkloads(ctx context.Context, namespace string) ([]WorkloadInfo, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	const instanceLabel = "app.kubernetes.io/instance"
+	apps := client.AppsV1()
+
+	var workloads []WorkloadInfo
+	deployments, err := apps.Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, WorkloadInfo{Name: "deployment.apps/" + d.Name, Instance: d.Labels[instanceLabel]})
+	}
+
+	statefulSets, err := apps.StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, WorkloadInfo{Name: "statefulset.apps/" + s.Name, Instance: s.Labels[instanceLabel]})
+	}
+
+	daemonSets, err := apps.DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for _, d := range daemonSets.Items {
+		workloads = append(workloads, WorkloadInfo{Name: "daemonset.apps/" + d.Name, Instance: d.Labels[instanceLabel]})
+	}
+
+	return workloads, nil
+}
+
+// parseWorkload splits a kubectl resource name like "deployment.apps/api"
+// into its kind and name
+func parseWorkload(workload string) (kind, name string, err error) {
+	resource, name, ok := strings.Cut(workload, "/")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid workload %q", workload)
+	}
+	kind, _, _ = strings.Cut(resource, ".")
+	switch kind {
+	case "deployment", "statefulset", "daemonset":
+		return kind, name, nil
+	}
+	return "", "", fmt.Errorf("unsupported workload kind %q", kind)
+}
+
+// RolloutRestart triggers a rolling restart of a workload the same way
+// 'kubectl rollout restart' does, by stamping its pod template
+func (k *KubeClient) RolloutRestart(ctx context.Context, workload, namespace string) error {
+	kind, name, err := parseWorkload(workload)
+	if err != nil {
+		return err
+	}
+
+	client, err := k.clientset()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	patch := []byte(fmt.Sprintf(
+		`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`,
+		time.Now().Format(time.RFC3339)))
+
+	apps := client.AppsV1()
+	switch kind {
+	case "deployment":
+		_, err = apps.Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "statefulset":
+		_, err = apps.StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "daemonset":
+		_, err = apps.DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restart %s: %w", workload, err)
+	}
+
+	return nil
+}
+
+// RolloutStatus waits for a workload's rollout to finish, passing progress
+// messages in the style of 'kubectl rollout status' to onProgress
+func (k *KubeClient) RolloutStatus(ctx context.Context, workload, namespace string, timeout time.Duration, onProgress func(string)) error {
+	kind, name, err := parseWorkload(workload)
+	if err != nil {
+		return err
+	}
+
+	client, err := k.clientset()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(rolloutPollInterval)
+	defer ticker.Stop()
+
+	lastMessage := ""
+	for {
+		var message string
+		var done bool
+
+		switch kind {
+		case "deployment":
+			var d *appsv1.Deployment
+			if d, err = client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				message, done = deploymentProgress(d)
+			}
+		case "statefulset":
+			var s *appsv1.StatefulSet
+			if s, err = client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				message, done = statefulSetProgress(s)
+			}
+		case "daemonset":
+			var d *appsv1.DaemonSet
+			if d, err = client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				message, done = daemonSetProgress(d)
+			}
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("rollout of %s did not complete: %w", workload, ErrCommandTimeout)
+			}
+			return fmt.Errorf("rollout of %s did not complete: %w", workload, err)
+		}
+
+		if message != lastMessage && onProgress != nil {
+			onProgress(message)
+		}
+		lastMessage = message
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("rollout of %s did not complete: %w", workload, ErrCommandTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+func deploymentProgress(d *appsv1.Deployment) (string, bool) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "Waiting for deployment spec update to be observed...", false
+	}
+
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	switch {
+	case d.Status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", d.Name, d.Status.UpdatedReplicas, replicas), false
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", d.Name, d.Status.Replicas-d.Status.UpdatedReplicas), false
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", d.Name, d.Status.AvailableReplicas, d.Status.UpdatedReplicas), false
+	}
+	return fmt.Sprintf("deployment %q successfully rolled out", d.Name), true
+}
+
+func statefulSetProgress(s *appsv1.StatefulSet) (string, bool) {
+	if s.Generation > s.Status.ObservedGeneration {
+		return "Waiting for statefulset spec update to be observed...", false
+	}
+
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+
+	switch {
+	case s.Status.ReadyReplicas < replicas:
+		return fmt.Sprintf("Waiting for %d pods to be ready...", replicas-s.Status.ReadyReplicas), false
+	case s.Status.UpdateRevision != s.Status.CurrentRevision:
+		return fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s...", s.Status.UpdatedReplicas, s.Status.UpdateRevision), false
+	}
+	return fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...", s.Status.CurrentReplicas, s.Status.CurrentRevision), true
+}
+
+func daemonSetProgress(d *appsv1.DaemonSet) (string, bool) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "Waiting for daemon set spec update to be observed...", false
+	}
+
+	switch {
+	case d.Status.UpdatedNumberScheduled < d.Status.DesiredNumberScheduled:
+		return fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated...", d.Name, d.Status.UpdatedNumberScheduled, d.Status.DesiredNumberScheduled), false
+	case d.Status.NumberAvailable < d.Status.DesiredNumberScheduled:
+		return fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available...", d.Name, d.Status.NumberAvailable, d.Status.DesiredNumberScheduled), false
+	}
+	return fmt.Sprintf("daemon set %q successfully rolled out", d.Name), true
+}
+
+// ListPersistentVolumeClaims returns the names of the PVCs created for a Helm
+// release
+func (k *KubeClient) ListPersistentVolumeClaims(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	list, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{LabelSelector: releaseSelector(releaseName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, claim := range list.Items {
+		names = append(names, claim.Name)
+	}
+	return names, nil
+}
+
+// DeletePersistentVolumeClaims removes the PVCs created for a Helm release and
+// returns the names of the deleted claims
+func (k *KubeClient) DeletePersistentVolumeClaims(ctx context.Context, releaseName, namespace string) ([]string, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mutateTimeout())
+	defer cancel()
+
+	claims := client.CoreV1().PersistentVolumeClaims(namespace)
+	list, err := claims.List(ctx, metav1.ListOptions{LabelSelector: releaseSelector(releaseName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete persistent volume claims: %w", err)
+	}
+
+	var deleted []string
+	for _, claim := range list.Items {
+		if err := claims.Delete(ctx, claim.Name, metav1.DeleteOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete persistent volume claim %s: %w", claim.Name, err)
+		}
+		deleted = append(deleted, claim.Name)
+	}
+
+	return deleted, nil
+}
+
+// GetJobStatus gets the status of a Kubernetes job by name
+func (k *KubeClient) GetJobStatus(ctx context.Context, jobName, namespace string) (*JobStatus, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job status: %w", err)
+	}
+
+	status := &JobStatus{
+		Active:    int(job.Status.Active),
+		Succeeded: int(job.Status.Succeeded),
+		Failed:    int(job.Status.Failed),
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			status.Complete = true
+		case batchv1.JobFailed:
+			status.GaveUp = true
+			status.Reason = cond.Reason
+		}
+	}
+
+	return status, nil
+}
+
+// GetSecret returns the data of a secret
+func (k *KubeClient) GetSecret(ctx context.Context, name, namespace string) (map[string][]byte, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout())
+	defer cancel()
+
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	return secret.Data, nil
+}
+
+// UpdateSecret replaces the data of an opaque secret, creating it if needed
+func (k *KubeClient) UpdateSecret(ctx context.Context, name, namespace string, data map[string][]byte) error {
+	client, err := k.clientset()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mutateTimeout())
+	defer cancel()
+
+	secrets := client.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "plat"},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create secret %s: %w", name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+
+	secret.Data = data
+	secret.StringData = nil
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteSecret removes a secret; a missing secret is not an error
+func (k *KubeClient) DeleteSecret(ctx context.Context, name, namespace string) error {
+	client, err := k.clientset()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mutateTimeout())
+	defer cancel()
+
+	err = client.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete secret %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteNetworkPolicies removes the NetworkPolicies matching a label selector
+// and returns the names of the deleted policies
+func (k *KubeClient) DeleteNetworkPolicies(ctx context.Context, namespace, selector string) ([]string, error) {
+	client, err := k.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mutateTimeout())
+	defer cancel()
+
+	policies := client.NetworkingV1().NetworkPolicies(namespace)
+	list, err := policies.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete network policies: %w", err)
+	}
+
+	var deleted []string
+	for _, policy := range list.Items {
+		if err := policies.Delete(ctx, policy.Name, metav1.DeleteOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete network policy %s: %w", policy.Name, err)
+		}
+		deleted = append(deleted, policy.Name)
+	}
+
+	return deleted, nil
+}
+
+// EnsureNamespace creates a namespace if it does not exist yet
+func (k *KubeClient) EnsureNamespace(ctx context.Context, namespace string) error {
+	client, err := k.clientset()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mutateTimeout())
+	defer cancel()
+
+	_, err = client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+	}
+	return nil
+}
+
+// SyncSecrets creates or replaces secrets, and deletes the secrets matching
+// selector that are not among them. A secret whose type changed is
+// recreated, since the type of a secret can't be updated.
+func (k *KubeClient) SyncSecrets(ctx context.Context, namespace, selector string, specs []SecretSpec) error {
+	client, err := k.clientset()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mutateTimeout())
+	defer cancel()
+
+	secrets := client.CoreV1().Secrets(namespace)
+	wanted := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		wanted[spec.Name] = true
+
+		secretType := corev1.SecretType(spec.Type)
+		if secretType == "" {
+			secretType = corev1.SecretTypeOpaque
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: spec.Name, Namespace: namespace, Labels: spec.Labels},
+			Type:       secretType,
+			Data:       spec.Data,
+		}
+
+		existing, err := secrets.Get(ctx, spec.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return fmt.Errorf("failed to get secret %s: %w", spec.Name, err)
+		case existing.Type != secretType:
+			if err := secrets.Delete(ctx, spec.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to replace secret %s: %w", spec.Name, err)
+			}
+		default:
+			secret.ResourceVersion = existing.ResourceVersion
+			if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("failed to update secret %s: %w", spec.Name, err)
+			}
+			continue
+		}
+
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create secret %s: %w", spec.Name, err)
+		}
+	}
+
+	list, err := secrets.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, secret := range list.Items {
+		if wanted[secret.Name] {
+			continue
+		}
+		if err := secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete secret %s: %w", secret.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"log/slog"
+	"sync"
+
+	"plat/pkg/logging"
+)
+
+var (
+	loggerMu sync.RWMutex
+	toolsLog *slog.Logger // nil uses logging.Default()
+)
+
+// SetLogger sets the logger tools report to, instead of the default one
+func SetLogger(logger *slog.Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	toolsLog = logger
+}
+
+// logger returns the logger tools report to
+func logger() *slog.Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	if toolsLog == nil {
+		return logging.Default()
+	}
+	return toolsLog
+}
@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ApplyManifests applies manifests with kubectl. Resources matching the
+// selector that are no longer part of the manifests are pruned.
+func ApplyManifests(ctx context.Context, manifests, namespace, selector string) error {
+	executor := NewProcessExecutor()
+
+	cmd := Command{
+		Name: "kubectl",
+		Args: []string{
+			"apply",
+			"-n", namespace,
+			"--prune", "-l", selector,
+			"-f", "-",
+		},
+		Stdin:   strings.NewReader(manifests),
+		Timeout: mutateTimeout(),
+	}
+
+	result, err := executor.Execute(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("kubectl apply failed: %s", result.Stderr)
+	}
+
+	return nil
+}
+
+// DeleteManifests deletes the resources described by manifests
+func DeleteManifests(ctx context.Context, manifests, namespace string) error {
+	executor := NewProcessExecutor()
+
+	cmd := Command{
+		Name: "kubectl",
+		Args: []string{
+			"delete",
+			"-n", namespace,
+			"--ignore-not-found",
+			"-f", "-",
+		},
+		Stdin:   strings.NewReader(manifests),
+		Timeout: mutateTimeout(),
+	}
+
+	result, err := executor.Execute(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("kubectl delete failed: %s", result.Stderr)
+	}
+
+	return nil
+}
+
+// DiffManifests reports whether the cluster differs from the manifests
+func DiffManifests(ctx context.Context, manifests, namespace string) (bool, error) {
+	diff, err := ManifestsDiff(ctx, manifests, namespace)
+	return diff != "", err
+}
+
+// ManifestsDiff returns kubectl's unified diff between the cluster and the
+// manifests, empty when they match
+func ManifestsDiff(ctx context.Context, manifests, namespace string) (string, error) {
+	executor := NewProcessExecutor()
+
+	cmd := Command{
+		Name:    "kubectl",
+		Args:    []string{"diff", "-n", namespace, "-f", "-"},
+		Stdin:   strings.NewReader(manifests),
+		Timeout: mutateTimeout(),
+	}
+
+	// kubectl diff exits 1 when there are differences and >1 on error
+	result, err := executor.Execute(ctx, cmd)
+	switch {
+	case err == nil:
+		return "", nil
+	case result.ExitCode == 1:
+		return result.Stdout, nil
+	default:
+		return "", fmt.Errorf("kubectl diff failed: %s", result.Stderr)
+	}
+}
+
+// KustomizeBuild renders a kustomization directory with the standalone
+// kustomize binary when installed, falling back to the version in kubectl
+func KustomizeBuild(ctx context.Context, dir string) (string, error) {
+	executor := NewProcessExecutor()
+
+	cmd := Command{
+		Name:    "kubectl",
+		Args:    []string{"kustomize", dir},
+		Timeout: queryTimeout(),
+	}
+	if ValidateCommand("kustomize") == nil {
+		cmd.Name = "kustomize"
+		cmd.Args = []string{"build", dir}
+	}
+
+	result, err := executor.Execute(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("kustomize build of %s failed: %s", dir, result.Stderr)
+	}
+
+	return result.Stdout, nil
+}
+
+// ListLabeledResources returns the names of workloads, services, config maps
+// and secrets in the namespace matching a label selector
+func ListLabeledResources(ctx context.Context, namespace, selector string) ([]string, error) {
+	executor := NewProcessExecutor()
+
+	cmd := Command{
+		Name: "kubectl",
+		Args: []string{
+			"get", "all,configmaps,secrets,ingresses",
+			"-n", namespace,
+			"-l", selector,
+			"-o", "name",
+		},
+		Timeout: queryTimeout(),
+	}
+
+	result, err := executor.Execute(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %s", result.Stderr)
+	}
+
+	var names []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,210 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MinikubeProvider implements ClusterProvider for minikube. Each cluster is
+// a minikube profile.
+type MinikubeProvider struct {
+	executor ProcessExecutor
+	runtime  ContainerRuntime // Selects the podman driver; minikube picks otherwise
+}
+
+// NewMinikubeProvider creates a new minikube provider
+func NewMinikubeProvider(runtime ContainerRuntime) ClusterProvider {
+	return &MinikubeProvider{
+		executor: NewProcessExecutor(),
+		runtime:  runtime,
+	}
+}
+
+// minikubeNodeStatus is one entry of 'minikube status -o json'
+type minikubeNodeStatus struct {
+	Name      string
+	Host      string
+	APIServer string
+	Worker    bool
+}
+
+// CreateCluster starts a minikube profile. Port mappings need the docker
+// or podman driver; the image and labels are k3d settings and don't apply.
+func (m *MinikubeProvider) CreateCluster(ctx context.Context, config ClusterConfig) error {
+	args := []string{"start", "--profile", config.Name}
+	if m.runtime.Name() == ContainerRuntimePodman {
+		args = append(args, "--driver", ContainerRuntimePodman)
+	}
+
+	if nodes := max(config.Servers, 1) + config.Agents; nodes > 1 {
+		args = append(args, "--nodes", fmt.Sprintf("%d", nodes))
+	}
+
+	for _, mapping := range config.Ports {
+		host, container := portMapping(mapping)
+		args = append(args, "--ports", host+":"+container)
+	}
+
+	for _, volume := range config.Volumes {
+		hostPath, containerPath, _ := strings.Cut(volume, ":")
+		containerPath, _, _ = strings.Cut(containerPath, "@")
+		args = append(args, "--mount", "--mount-string", hostPath+":"+containerPath)
+	}
+
+	args = append(args, config.Options...)
+
+	cmd := Command{
+		Name:    "minikube",
+		Args:    args,
+		Timeout: installTimeout(),
+	}
+
+	if _, err := m.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to start minikube cluster: %w", err)
+	}
+	return nil
+}
+
+// DeleteCluster removes a minikube profile
+func (m *MinikubeProvider) DeleteCluster(ctx context.Context, name string) error {
+	cmd := Command{
+		Name:    "minikube",
+		Args:    []string{"delete", "--profile", name},
+		Timeout: mutateTimeout(),
+	}
+
+	if _, err := m.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to delete minikube cluster: %w", err)
+	}
+	return nil
+}
+
+// GetClusterStatus returns current cluster information
+func (m *MinikubeProvider) GetClusterStatus(ctx context.Context, name string) (*ClusterStatus, error) {
+	cmd := Command{
+		Name:    "minikube",
+		Args:    []string{"status", "--profile", name, "--output", "json"},
+		Timeout: queryTimeout(),
+	}
+
+	// minikube status exits non-zero for stopped clusters but still prints
+	// their status; only a missing profile leaves stdout empty
+	result, err := m.executor.Execute(ctx, cmd)
+	if result == nil || strings.TrimSpace(result.Stdout) == "" {
+		if err == nil {
+			err = fmt.Errorf("no output")
+		}
+		return nil, fmt.Errorf("failed to get minikube cluster status: %w", err)
+	}
+
+	// A single node prints an object, several nodes an array
+	var nodes []minikubeNodeStatus
+	output := strings.TrimSpace(result.Stdout)
+	if strings.HasPrefix(output, "[") {
+		err = json.Unmarshal([]byte(output), &nodes)
+	} else {
+		var node minikubeNodeStatus
+		err = json.Unmarshal([]byte(output), &node)
+		nodes = []minikubeNodeStatus{node}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse minikube cluster status: %w", err)
+	}
+
+	status := &ClusterStatus{Name: name, Status: "stopped", Network: name}
+	for _, node := range nodes {
+		if node.Worker {
+			status.Agents++
+			continue
+		}
+		status.Servers++
+		if node.APIServer == "Running" {
+			status.Status = "running"
+		}
+	}
+	return status, nil
+}
+
+// ListClusters returns all minikube profiles
+func (m *MinikubeProvider) ListClusters(ctx context.Context) ([]ClusterInfo, error) {
+	cmd := Command{
+		Name:    "minikube",
+		Args:    []string{"profile", "list", "--output", "json"},
+		Timeout: queryTimeout(),
+	}
+
+	result, err := m.executor.Execute(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list minikube clusters: %w", err)
+	}
+
+	var profiles struct {
+		Valid []struct {
+			Name   string
+			Status string
+		} `json:"valid"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse minikube profile list: %w", err)
+	}
+
+	clusters := make([]ClusterInfo, 0, len(profiles.Valid))
+	for _, profile := range profiles.Valid {
+		clusters = append(clusters, ClusterInfo{Name: profile.Name, Status: strings.ToLower(profile.Status)})
+	}
+	return clusters, nil
+}
+
+// ImportImages copies images from the local docker daemon into a minikube
+// cluster
+func (m *MinikubeProvider) ImportImages(ctx context.Context, name string, images []string) error {
+	for _, image := range images {
+		cmd := Command{
+			Name:    "minikube",
+			Args:    []string{"image", "load", image, "--profile", name},
+			Timeout: installTimeout(),
+		}
+
+		if _, err := m.executor.Execute(ctx, cmd); err != nil {
+			return fmt.Errorf("failed to import %s into minikube cluster: %w", image, err)
+		}
+	}
+	return nil
+}
+
+// KubeContext returns the kubeconfig context of a minikube profile, which
+// is named after it
+func (m *MinikubeProvider) KubeContext(name string) string {
+	return name
+}
+
+// WriteKubeconfig points the active kubeconfig at a minikube profile
+func (m *MinikubeProvider) WriteKubeconfig(ctx context.Context, name string) error {
+	cmd := Command{
+		Name:    "minikube",
+		Args:    []string{"update-context", "--profile", name},
+		Timeout: queryTimeout(),
+	}
+
+	if _, err := m.executor.Execute(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to write kubeconfig for minikube cluster: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that minikube is available
+func (m *MinikubeProvider) Validate(ctx context.Context) error {
+	if err := ValidateCommand("minikube"); err != nil {
+		return err
+	}
+
+	version, err := GetCommandVersion(ctx, "minikube", "version", "--short")
+	if err != nil {
+		return fmt.Errorf("failed to get minikube version: %w", err)
+	}
+
+	logger().Debug("found minikube", "version", version)
+	return nil
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Container runtimes selectable with defaults.containerRuntime or --runtime
+const (
+	ContainerRuntimeDocker  = "docker"
+	ContainerRuntimePodman  = "podman"
+	ContainerRuntimeNerdctl = "nerdctl" // containerd; builds need buildkitd
+)
+
+// ContainerRuntime is the container engine that builds images, runs the
+// local registry and hosts the nodes of k3d and kind clusters. Its CLI
+// accepts docker's arguments for everything plat runs.
+type ContainerRuntime interface {
+	// Name returns the runtime's CLI
+	Name() string
+
+	// Env returns the environment that points the runtime's CLI, and the
+	// docker API clients k3d and kind, at the engine's socket. Empty means
+	// their defaults.
+	Env() map[string]string
+
+	// Validate checks that the CLI is installed and the engine is running,
+	// and returns the engine version
+	Validate(ctx context.Context) (string, error)
+}
+
+// cliRuntime implements ContainerRuntime for docker-compatible CLIs
+type cliRuntime struct {
+	name   string
+	socket string // Engine socket path; empty for the runtime's default
+}
+
+// NewContainerRuntime returns a container runtime by name. An empty name
+// detects the installed runtime; an empty socket uses the runtime's default.
+func NewContainerRuntime(name, socket string) ContainerRuntime {
+	if name == "" {
+		name = DetectContainerRuntime()
+	}
+	return &cliRuntime{name: name, socket: strings.TrimPrefix(socket, "unix://")}
+}
+
+// DetectContainerRuntime returns the first of docker, podman and nerdctl
+// found in PATH, or docker when none is
+func DetectContainerRuntime() string {
+	for _, name := range []string{ContainerRuntimeDocker, ContainerRuntimePodman, ContainerRuntimeNerdctl} {
+		if _, err := exec.LookPath(name); err == nil {
+			return name
+		}
+	}
+	return ContainerRuntimeDocker
+}
+
+func (c *cliRuntime) Name() string {
+	return c.name
+}
+
+func (c *cliRuntime) Env() map[string]string {
+	socket := c.socket
+	switch c.name {
+	case ContainerRuntimePodman:
+		// k3d and kind talk to podman through its docker-compatible API,
+		// which has no well-known default path
+		if socket == "" {
+			socket = podmanSocket()
+		}
+		return map[string]string{
+			"CONTAINER_HOST": "unix://" + socket,
+			"DOCKER_HOST":    "unix://" + socket,
+			"DOCKER_SOCK":    socket, // Mounted into k3d's tools container
+		}
+	case ContainerRuntimeNerdctl:
+		if socket == "" {
+			return nil
+		}
+		return map[string]string{"CONTAINERD_ADDRESS": socket}
+	default:
+		if socket == "" {
+			return nil
+		}
+		return map[string]string{"DOCKER_HOST": "unix://" + socket}
+	}
+}
+
+func (c *cliRuntime) Validate(ctx context.Context) (string, error) {
+	if err := ValidateCommand(c.name); err != nil {
+		return "", err
+	}
+
+	format := "{{.ServerVersion}}"
+	if c.name == ContainerRuntimePodman {
+		format = "{{.Version.Version}}"
+	}
+	cmd := Command{
+		Name:    c.name,
+		Args:    []string{"info", "--format", format},
+		Env:     c.Env(),
+		Timeout: queryTimeout(),
+	}
+
+	result, err := NewProcessExecutor().Execute(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("%s engine not running: %w", c.name, err)
+	}
+	return result.Stdout, nil
+}
+
+// podmanSocket returns the default socket of podman's API service: the
+// user's socket when running rootless, the system one otherwise
+func podmanSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" && os.Geteuid() != 0 {
+		return filepath.Join(dir, "podman", "podman.sock")
+	}
+	return "/run/podman/podman.sock"
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package tools
+
+import (
+	"os"
+	"syscall"
+)
+
+// terminateProcess asks the process to shut down gracefully
+func terminateProcess(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
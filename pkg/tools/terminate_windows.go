@@ -0,0 +1,10 @@
+//go:build windows
+
+package tools
+
+import "os"
+
+// terminateProcess stops the process; Windows has no SIGTERM equivalent
+func terminateProcess(process *os.Process) error {
+	return process.Kill()
+}
@@ -0,0 +1,40 @@
+package tools
+
+import "sync"
+
+// ProcessTracker is notified when plat spawns and reaps external processes,
+// allowing stray processes from crashed sessions to be detected later
+type ProcessTracker interface {
+	ProcessStarted(pid int, cmd Command)
+	ProcessExited(pid int)
+}
+
+var (
+	trackerMu sync.RWMutex
+	tracker   ProcessTracker
+)
+
+// SetProcessTracker installs the tracker notified for every spawned process
+func SetProcessTracker(t ProcessTracker) {
+	trackerMu.Lock()
+	defer trackerMu.Unlock()
+	tracker = t
+}
+
+// TrackProcess records a process started outside the executor (e.g. log streams)
+func TrackProcess(pid int, cmd Command) {
+	trackerMu.RLock()
+	defer trackerMu.RUnlock()
+	if tracker != nil {
+		tracker.ProcessStarted(pid, cmd)
+	}
+}
+
+// UntrackProcess records that a tracked process has exited
+func UntrackProcess(pid int) {
+	trackerMu.RLock()
+	defer trackerMu.RUnlock()
+	if tracker != nil {
+		tracker.ProcessExited(pid)
+	}
+}
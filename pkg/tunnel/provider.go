@@ -0,0 +1,117 @@
+// Package tunnel exposes a service publicly through a tunnel provider such
+// as cloudflared, ngrok or tailscale funnel, for demos and testing from
+// devices outside the machine.
+package tunnel
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Provider runs a tunnel from a public URL to a local port
+type Provider interface {
+	// Name identifies the provider, e.g. "cloudflared"
+	Name() string
+
+	// Binary is the executable the provider runs
+	Binary() string
+
+	// Args returns the arguments that tunnel to the local port and keep
+	// running in the foreground until interrupted
+	Args(port int) []string
+
+	// PublicURL extracts the tunnel's public URL from a line of the
+	// provider's output
+	PublicURL(line string) (string, bool)
+}
+
+// commandProvider is a provider whose public URL appears in its output
+type commandProvider struct {
+	name    string
+	binary  string
+	args    func(port int) []string
+	pattern *regexp.Regexp // First submatch, or the whole match, is the URL
+}
+
+func (p *commandProvider) Name() string           { return p.name }
+func (p *commandProvider) Binary() string         { return p.binary }
+func (p *commandProvider) Args(port int) []string { return p.args(port) }
+
+func (p *commandProvider) PublicURL(line string) (string, bool) {
+	match := p.pattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	return match[len(match)-1], true
+}
+
+// providers lists the known providers, in detection order
+var providers = []Provider{
+	&commandProvider{
+		name:   "cloudflared",
+		binary: "cloudflared",
+		args: func(port int) []string {
+			return []string{"tunnel", "--no-autoupdate", "--url", fmt.Sprintf("http://127.0.0.1:%d", port)}
+		},
+		pattern: regexp.MustCompile(`https://[a-z0-9-]+\.trycloudflare\.com`),
+	},
+	&commandProvider{
+		name:   "ngrok",
+		binary: "ngrok",
+		args: func(port int) []string {
+			return []string{"http", fmt.Sprintf("127.0.0.1:%d", port), "--log", "stdout", "--log-format", "logfmt"}
+		},
+		pattern: regexp.MustCompile(`url=(https://\S+)`),
+	},
+	&commandProvider{
+		name:   "tailscale",
+		binary: "tailscale",
+		args: func(port int) []string {
+			return []string{"funnel", strconv.Itoa(port)}
+		},
+		pattern: regexp.MustCompile(`https://[^\s/]+\.ts\.net\S*`),
+	},
+}
+
+// Register adds a provider, replacing a known one with the same name
+func Register(provider Provider) {
+	for i, existing := range providers {
+		if existing.Name() == provider.Name() {
+			providers[i] = provider
+			return
+		}
+	}
+	providers = append(providers, provider)
+}
+
+// Names returns the names of the known providers
+func Names() []string {
+	names := make([]string, len(providers))
+	for i, provider := range providers {
+		names[i] = provider.Name()
+	}
+	return names
+}
+
+// Lookup returns the provider with the given name
+func Lookup(name string) (Provider, error) {
+	for _, provider := range providers {
+		if provider.Name() == name {
+			return provider, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown tunnel provider %q, must be one of: %s", name, strings.Join(Names(), ", "))
+}
+
+// Detect returns the first provider whose binary is installed
+func Detect() (Provider, error) {
+	for _, provider := range providers {
+		if _, err := exec.LookPath(provider.Binary()); err == nil {
+			return provider, nil
+		}
+	}
+	return nil, fmt.Errorf("no tunnel provider found, install one of: %s", strings.Join(Names(), ", "))
+}
@@ -0,0 +1,125 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"plat/pkg/tools"
+)
+
+// URLTimeout bounds how long a provider may take to report its public URL
+const URLTimeout = 45 * time.Second
+
+// Tunnel is a running tunnel to a service's ingress
+type Tunnel struct {
+	URL string // Public URL
+
+	proxy   *http.Server
+	cancel  context.CancelFunc
+	done    chan error
+	stopped chan struct{} // Closed once the provider process exited
+}
+
+// Open starts a tunnel exposing the ingress host publicly and waits for the
+// provider to report the public URL. Requests reach the ingress controller
+// through the cluster's load balancer on localhost with their Host set to
+// the ingress host, so neither local DNS for the domain nor host header
+// support in the provider is needed.
+func Open(ctx context.Context, provider Provider, ingressHost string) (*Tunnel, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start tunnel proxy: %w", err)
+	}
+
+	target := &url.URL{Scheme: "http", Host: "127.0.0.1"}
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		req.Host = ingressHost
+	}
+	server := &http.Server{Handler: proxy, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+
+	ctx, cancel := context.WithCancel(ctx)
+	t := &Tunnel{proxy: server, cancel: cancel, done: make(chan error, 1), stopped: make(chan struct{})}
+
+	var (
+		mu       sync.Mutex
+		lastLine string
+	)
+	found := make(chan string, 1)
+	cmd := tools.Command{
+		Name: provider.Binary(),
+		Args: provider.Args(listener.Addr().(*net.TCPAddr).Port),
+	}
+	go func() {
+		defer close(t.stopped)
+		err := tools.NewProcessExecutor().StreamOutput(ctx, cmd, tools.StreamOptions{
+			OnLine: func(_ tools.StreamName, line string) {
+				if publicURL, ok := provider.PublicURL(line); ok {
+					select {
+					case found <- publicURL:
+					default:
+					}
+				}
+				if line = strings.TrimSpace(line); line != "" {
+					mu.Lock()
+					lastLine = line
+					mu.Unlock()
+				}
+			},
+		})
+		if err == nil {
+			err = fmt.Errorf("%s exited", provider.Name())
+		}
+		t.done <- err
+	}()
+
+	fail := func(err error) (*Tunnel, error) {
+		t.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		if lastLine != "" {
+			return nil, fmt.Errorf("%w: %s", err, lastLine)
+		}
+		return nil, err
+	}
+
+	timer := time.NewTimer(URLTimeout)
+	defer timer.Stop()
+	select {
+	case t.URL = <-found:
+		return t, nil
+	case err := <-t.done:
+		return fail(fmt.Errorf("%s stopped before reporting a URL: %w", provider.Name(), err))
+	case <-timer.C:
+		return fail(fmt.Errorf("%s reported no public URL within %s", provider.Name(), URLTimeout))
+	case <-ctx.Done():
+		return fail(ctx.Err())
+	}
+}
+
+// Done returns a channel receiving the error the provider stopped with
+func (t *Tunnel) Done() <-chan error {
+	return t.done
+}
+
+// Close stops the provider, waiting for it to exit, and the proxy
+func (t *Tunnel) Close() {
+	t.cancel()
+	<-t.stopped
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := t.proxy.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		t.proxy.Close()
+	}
+}
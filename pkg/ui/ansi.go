@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Escape sequences services write to their logs. Only SGR (color and style)
+// sequences make sense inside the viewport; cursor movement, line erasing,
+// titles and hyperlinks break its layout.
+var (
+	csiSequence = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]`)
+	oscSequence = regexp.MustCompile(`\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+	escSequence = regexp.MustCompile(`\x1b[@-Z\\-_]`)
+)
+
+// sgrReset ends any color a line left open, so it doesn't bleed into the
+// lines below
+const sgrReset = "\x1b[0m"
+
+// logTabWidth is the number of spaces a tab expands to; the viewport measures
+// widths per cell and can't place tab stops
+const logTabWidth = 4
+
+// sanitizeLogLine removes escape sequences and control characters that would
+// corrupt the viewport. Colors are kept when keepColors is set and stripped
+// otherwise.
+func sanitizeLogLine(line string, keepColors bool) string {
+	if !strings.ContainsAny(line, "\x1b\t\r\b\x07") {
+		return line
+	}
+
+	line = oscSequence.ReplaceAllString(line, "")
+	colored := false
+	line = csiSequence.ReplaceAllStringFunc(line, func(seq string) string {
+		if keepColors && strings.HasSuffix(seq, "m") {
+			colored = true
+			return seq
+		}
+		return ""
+	})
+	line = escSequence.ReplaceAllString(line, "")
+
+	// Progress output redraws with carriage returns; show only the final state
+	if i := strings.LastIndex(line, "\r"); i >= 0 && i < len(line)-1 {
+		line = line[i+1:]
+	}
+
+	line = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return r
+		case r < 0x20 || r == 0x7f:
+			if r == 0x1b && keepColors {
+				return r // Start of a kept color sequence
+			}
+			return -1
+		}
+		return r
+	}, line)
+	line = strings.ReplaceAll(line, "\t", strings.Repeat(" ", logTabWidth))
+
+	if colored {
+		line += sgrReset
+	}
+	return line
+}
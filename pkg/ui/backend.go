@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+
+	"plat/pkg/config"
+	"plat/pkg/orchestrator"
+)
+
+// backend is what the TUI drives: the orchestrator, or synthetic data in
+// demo mode
+type backend interface {
+	Status(ctx context.Context, runtime *config.RuntimeConfig) (*orchestrator.EnvironmentStatus, error)
+	Up(ctx context.Context, runtime *config.RuntimeConfig) error
+	Down(ctx context.Context, runtime *config.RuntimeConfig, deleteCluster bool) error
+	StartService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error
+	StopService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error
+	RestartService(ctx context.Context, runtime *config.RuntimeConfig, serviceName string) error
+	Insights(ctx context.Context, runtime *config.RuntimeConfig) *orchestrator.ServiceInsights
+	ServiceValues(runtime *config.RuntimeConfig, serviceName string) (base, overrides map[string]interface{}, err error)
+	ValueProvenance(runtime *config.RuntimeConfig, serviceName string) ([]config.ValueProvenance, error)
+	ApplyValueOverrides(ctx context.Context, runtime *config.RuntimeConfig, serviceName string, overrides map[string]interface{}) error
+	Subscribe() (<-chan orchestrator.Event, func())
+	SetQuiet(quiet bool)
+	SetLogger(logger *slog.Logger)
+}
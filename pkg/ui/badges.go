@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/orchestrator"
+)
+
+// Nav panel badges: "local", "flapping", "drift" and "update" next to
+// service names
+
+// insightsInterval is how often drift and image updates are re-checked;
+// both query helm and the registry, so they run far less often than status
+const insightsInterval = time.Minute
+
+// serviceBadges returns the badges for a service in display order
+func (m *Model) serviceBadges(serviceName string) []string {
+	var badges []string
+
+	if comp := m.getServiceComponent(serviceName); comp != nil {
+		if svc, ok := comp.StatusDetail.(*orchestrator.ServiceStatus); ok && svc != nil {
+			if svc.IsLocal {
+				badges = append(badges, "local")
+			}
+			if svc.Deployment != nil && svc.Deployment.Flapping {
+				badges = append(badges, "flapping")
+			}
+		}
+	}
+
+	if m.insights != nil {
+		if m.insights.Drift[serviceName] {
+			badges = append(badges, "drift")
+		}
+		if _, ok := m.insights.Updates[serviceName]; ok {
+			badges = append(badges, "update")
+		}
+	}
+
+	return badges
+}
+
+// renderBadges renders badges, abbreviated to their first letter when the
+// full words don't fit in width
+func renderBadges(badges []string, width int) string {
+	if len(badges) == 0 {
+		return ""
+	}
+
+	full := strings.Join(badges, " ")
+	if len(full) > width {
+		short := make([]string, len(badges))
+		for i, badge := range badges {
+			short[i] = strings.ToUpper(badge[:1])
+		}
+		full = strings.Join(short, "")
+	}
+
+	return badgeStyle.Render(full)
+}
+
+// refreshInsights checks drift and image updates in the background
+func (m *Model) refreshInsights() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+		defer cancel()
+
+		return insightsMsg{insights: m.orch.Insights(ctx, m.runtime)}
+	}
+}
+
+func insightsTick() tea.Cmd {
+	return tea.Tick(insightsInterval, func(time.Time) tea.Msg {
+		return insightsTickMsg{}
+	})
+}
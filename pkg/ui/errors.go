@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"plat/pkg/orchestrator"
+)
+
+// maxCollapsedServiceErrors bounds the services listed for a failed
+// operation until the errors are expanded
+const maxCollapsedServiceErrors = 5
+
+// renderOperationError lists an operation's error under it. Failures of
+// several services are grouped by category, one line per service, or with
+// their full messages when expanded.
+func (m *Model) renderOperationError(b *strings.Builder, err error) {
+	var failures *orchestrator.ServiceErrors
+	if !errors.As(err, &failures) {
+		for _, line := range strings.Split(strings.TrimSpace(err.Error()), "\n") {
+			b.WriteString(errorStyle.Render("     " + line))
+			b.WriteString("\n")
+		}
+		return
+	}
+
+	b.WriteString(errorStyle.Render("     " + failures.Error()))
+	b.WriteString("\n")
+
+	listed := 0
+	for _, group := range failures.Groups() {
+		if !m.opsErrorsExpanded && listed >= maxCollapsedServiceErrors {
+			break
+		}
+		b.WriteString(badgeStyle.Render(fmt.Sprintf("       %s (%d)", group.Category, len(group.Errors))))
+		b.WriteString("\n")
+
+		for _, failure := range group.Errors {
+			if !m.opsErrorsExpanded && listed >= maxCollapsedServiceErrors {
+				break
+			}
+			listed++
+
+			lines := strings.Split(strings.TrimSpace(failure.Err.Error()), "\n")
+			if !m.opsErrorsExpanded {
+				lines = lines[:1]
+			}
+			b.WriteString(errorStyle.Render(fmt.Sprintf("         ✗ %s: %s", failure.Service, lines[0])))
+			b.WriteString("\n")
+			for _, line := range lines[1:] {
+				b.WriteString(dimStyle.Render("           " + line))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	if hidden := len(failures.Errors) - listed; hidden > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("         … %d more, e to expand", hidden)))
+		b.WriteString("\n")
+	}
+}
@@ -10,35 +10,17 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"plat/pkg/orchestrator"
 )
 
 // Helper functions
 
-// suppressOutput redirects stdout/stderr to null during execution
-func suppressOutput(fn func() error) error {
-	// Save original stdout/stderr
-	oldStdout := os.Stdout
-	oldStderr := os.Stderr
-
-	// Redirect to null (open for writing)
-	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0666)
-	if err != nil {
-		return fn() // If we can't open null, just run normally
-	}
-	defer null.Close()
-
-	os.Stdout = null
-	os.Stderr = null
-
-	// Restore after execution
-	defer func() {
-		os.Stdout = oldStdout
-		os.Stderr = oldStderr
-	}()
-
-	return fn()
+// copyToClipboard sets the system clipboard with an OSC 52 sequence, which
+// terminals apply without printing anything and which works over SSH
+func copyToClipboard(text string) {
+	fmt.Fprint(os.Stdout, ansi.SetSystemClipboard(text))
 }
 
 func min(a, b int) int {
@@ -55,16 +37,26 @@ func max(a, b int) int {
 	return b
 }
 
-// getSortedServiceNames returns service names in alphabetical order for stable display
+// getSortedServiceNames returns service names in config order for stable
+// display; services unknown to the config follow alphabetically
 func (m *Model) getSortedServiceNames() []string {
 	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, name := range m.runtime.ListServices() {
+		if comp := m.components[name]; comp != nil && comp.Type == ComponentService {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
 	for id, comp := range m.components {
-		if comp.Type == ComponentService {
-			names = append(names, id)
+		if comp.Type == ComponentService && !seen[id] {
+			rest = append(rest, id)
 		}
 	}
-	sort.Strings(names)
-	return names
+	sort.Strings(rest)
+	return append(names, rest...)
 }
 
 func getStatusIcon(status string) string {
@@ -82,12 +74,32 @@ func getStatusIcon(status string) string {
 	}
 }
 
+// truncate cuts s to width runes, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 func tickEvery(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
+// waitForProgress delivers the next event from the orchestrator
+func (m *Model) waitForProgress() tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-m.events
+		if !ok {
+			return nil
+		}
+		return progressMsg{event: event}
+	}
+}
+
 func clearMessageAfter(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return clearMsg{}
@@ -118,12 +130,12 @@ func (m *Model) buildNavItems() []NavItem {
 		})
 	}
 
-	// Add services in alphabetical order
-	serviceNames := m.getSortedServiceNames()
+	// Add services in config order, narrowed and reordered by the nav filter
+	serviceNames := m.filterAndSortServices(m.getSortedServiceNames())
 	for _, name := range serviceNames {
 		items = append(items, NavItem{
 			Type:        NavItemService,
-			Name:        name,
+			Name:        m.serviceLabel(name),
 			ServiceName: name,
 		})
 	}
@@ -131,6 +143,15 @@ func (m *Model) buildNavItems() []NavItem {
 	return items
 }
 
+// serviceLabel returns the name to show for a service: its display name
+// when the config sets one
+func (m *Model) serviceLabel(name string) string {
+	if service, exists := m.runtime.ResolvedServices[name]; exists {
+		return service.Label()
+	}
+	return name
+}
+
 // getSelectedNavItem returns the currently selected navigation item
 func (m *Model) getSelectedNavItem() *NavItem {
 	if m.selectedNav < 0 || m.selectedNav >= len(m.navItems) {
@@ -219,6 +240,9 @@ func (m *Model) syncComponentsFromStatus(status *orchestrator.EnvironmentStatus)
 			}
 		} else {
 			// Update existing service component
+			if existing.Status != svc.Status {
+				existing.LastUpdated = now
+			}
 			existing.Status = svc.Status
 			existing.LastChecked = now
 			existing.StatusDetail = svc
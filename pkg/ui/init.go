@@ -10,7 +10,12 @@ import (
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
-		m.refreshStatus(),
-		tickEvery(3*time.Second),
+		m.autoRefresh(),
+		tickEvery(time.Second),
+		m.waitForProgress(),
+		m.waitForValuesChange(),
+		m.refreshInsights(),
+		m.loadProvenance(),
+		insightsTick(),
 	)
 }
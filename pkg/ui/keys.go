@@ -16,14 +16,46 @@ type keyMap struct {
 	Stop           key.Binding
 	StopAll        key.Binding
 	Refresh        key.Binding
+	Config         key.Binding
+	Operations     key.Binding
+	Progress       key.Binding
+	Filter         key.Binding
+	Sort           key.Binding
+	Mark           key.Binding
 	Logs           key.Binding
+	AllLogs        key.Binding
 	StartService   key.Binding
 	StopService    key.Binding
 	RestartService key.Binding
+	EditValues     key.Binding
+	UpgradeValues  key.Binding
+	CopyDNSName    key.Binding
+	InspectPods    key.Binding
+	Events         key.Binding
+
+	// Pods actions
+	OpenPod key.Binding
+
+	// Events actions
+	WarningsOnly key.Binding
+
+	// Operations actions
+	ExpandErrors key.Binding
+
+	// Values editor actions
+	SaveValues key.Binding
 
 	// Logs actions
 	ToggleTimestamp key.Binding
 	TogglePodName   key.Binding
+	ToggleColors    key.Binding
+	ToggleWrap      key.Binding
+	ToggleSource    key.Binding
+	Search          key.Binding
+	NextMatch       key.Binding
+	PrevMatch       key.Binding
+	FilterMatches   key.Binding
+	ShowAllSources  key.Binding
 	Back            key.Binding
 
 	// Global
@@ -38,12 +70,28 @@ func (m *Model) ShortHelp() []key.Binding {
 		item := m.getSelectedNavItem()
 		if item != nil && item.Type == NavItemCluster {
 			// Cluster selected - show cluster actions
-			return []key.Binding{m.keys.Start, m.keys.Stop, m.keys.Refresh, m.keys.Quit}
+			return []key.Binding{m.keys.Start, m.keys.Stop, m.keys.Refresh, m.keys.AllLogs, m.keys.Events, m.keys.Config, m.keys.Quit}
 		}
 		// Service selected - show service actions
-		return []key.Binding{m.keys.StartService, m.keys.StopService, m.keys.RestartService, m.keys.Logs, m.keys.Quit}
+		return []key.Binding{m.keys.StartService, m.keys.StopService, m.keys.RestartService, m.keys.Logs, m.keys.InspectPods, m.keys.EditValues, m.keys.CopyDNSName, m.keys.Mark, m.keys.Quit}
 	case ServiceLogsView:
-		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.ToggleTimestamp, m.keys.TogglePodName, m.keys.Logs, m.keys.Back, m.keys.Quit}
+		if m.logAllServices {
+			return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Search, m.keys.ToggleSource, m.keys.ShowAllSources, m.keys.Back, m.keys.Quit}
+		}
+		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Search, m.keys.ToggleTimestamp, m.keys.TogglePodName, m.keys.Logs, m.keys.Back, m.keys.Quit}
+	case ValuesEditorView:
+		return []key.Binding{m.keys.SaveValues, m.keys.Back}
+	case OperationsView:
+		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.ExpandErrors, m.keys.Back, m.keys.Quit}
+	case PodsView:
+		if m.podDetail != nil {
+			return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Refresh, m.keys.Back, m.keys.Quit}
+		}
+		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.OpenPod, m.keys.Refresh, m.keys.Back, m.keys.Quit}
+	case EventsView:
+		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.WarningsOnly, m.keys.Refresh, m.keys.Back, m.keys.Quit}
+	case ConfigView, ProgressView:
+		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Back, m.keys.Quit}
 	default:
 		return []key.Binding{}
 	}
@@ -57,24 +105,58 @@ func (m *Model) FullHelp() [][]key.Binding {
 		if item != nil && item.Type == NavItemCluster {
 			// Cluster selected - show cluster actions
 			return [][]key.Binding{
-				{m.keys.Up, m.keys.Down},
+				{m.keys.Up, m.keys.Down, m.keys.Filter, m.keys.Sort},
 				{m.keys.Start, m.keys.Stop, m.keys.StopAll},
-				{m.keys.Refresh},
-				{m.keys.Help, m.keys.Quit},
+				{m.keys.Refresh, m.keys.Config, m.keys.Operations, m.keys.Progress},
+				{m.keys.AllLogs, m.keys.Events, m.keys.UpgradeValues, m.keys.Help, m.keys.Quit},
 			}
 		}
 		// Service selected - show service actions
 		return [][]key.Binding{
-			{m.keys.Up, m.keys.Down},
+			{m.keys.Up, m.keys.Down, m.keys.Filter, m.keys.Sort},
 			{m.keys.StartService, m.keys.StopService, m.keys.RestartService},
-			{m.keys.Logs, m.keys.Refresh},
+			{m.keys.Logs, m.keys.AllLogs, m.keys.InspectPods, m.keys.Events, m.keys.EditValues, m.keys.UpgradeValues, m.keys.CopyDNSName, m.keys.Mark},
+			{m.keys.Refresh, m.keys.Config, m.keys.Operations, m.keys.Progress},
 			{m.keys.Help, m.keys.Quit},
 		}
 	case ServiceLogsView:
+		groups := [][]key.Binding{
+			{m.keys.Up, m.keys.Down},
+			{m.keys.ToggleTimestamp, m.keys.TogglePodName, m.keys.ToggleColors, m.keys.ToggleWrap},
+			{m.keys.Search, m.keys.NextMatch, m.keys.PrevMatch, m.keys.FilterMatches},
+		}
+		if m.logAllServices {
+			groups = append(groups, []key.Binding{m.keys.ToggleSource, m.keys.ShowAllSources})
+		}
+		return append(groups, []key.Binding{m.keys.Logs, m.keys.Back, m.keys.Help, m.keys.Quit})
+	case ValuesEditorView:
+		return [][]key.Binding{
+			{m.keys.SaveValues, m.keys.Back},
+		}
+	case ConfigView:
 		return [][]key.Binding{
 			{m.keys.Up, m.keys.Down},
-			{m.keys.ToggleTimestamp, m.keys.TogglePodName},
-			{m.keys.Logs, m.keys.Back, m.keys.Help, m.keys.Quit},
+			{m.keys.Config, m.keys.Back, m.keys.Help, m.keys.Quit},
+		}
+	case OperationsView:
+		return [][]key.Binding{
+			{m.keys.Up, m.keys.Down, m.keys.ExpandErrors},
+			{m.keys.Operations, m.keys.Back, m.keys.Help, m.keys.Quit},
+		}
+	case ProgressView:
+		return [][]key.Binding{
+			{m.keys.Up, m.keys.Down},
+			{m.keys.Progress, m.keys.Back, m.keys.Help, m.keys.Quit},
+		}
+	case PodsView:
+		return [][]key.Binding{
+			{m.keys.Up, m.keys.Down, m.keys.OpenPod, m.keys.Refresh},
+			{m.keys.Back, m.keys.Help, m.keys.Quit},
+		}
+	case EventsView:
+		return [][]key.Binding{
+			{m.keys.Up, m.keys.Down, m.keys.WarningsOnly, m.keys.Refresh},
+			{m.keys.Back, m.keys.Help, m.keys.Quit},
 		}
 	}
 	return [][]key.Binding{}
@@ -105,10 +187,38 @@ var keys = keyMap{
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "cycle sort"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark service"),
+	),
+	Config: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "view config"),
+	),
+	Operations: key.NewBinding(
+		key.WithKeys("h"),
+		key.WithHelp("h", "operations"),
+	),
+	Progress: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "up/down progress"),
+	),
 	Logs: key.NewBinding(
 		key.WithKeys("l"),
 		key.WithHelp("l", "view logs"),
 	),
+	AllLogs: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "all services logs"),
+	),
 	StartService: key.NewBinding(
 		key.WithKeys("s"),
 		key.WithHelp("s", "start service"),
@@ -121,6 +231,42 @@ var keys = keyMap{
 		key.WithKeys("R"),
 		key.WithHelp("R", "restart service"),
 	),
+	EditValues: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit values"),
+	),
+	UpgradeValues: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "upgrade changed values"),
+	),
+	CopyDNSName: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy DNS name"),
+	),
+	InspectPods: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "inspect pods"),
+	),
+	Events: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "cluster events"),
+	),
+	WarningsOnly: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "warnings only"),
+	),
+	OpenPod: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "inspect pod"),
+	),
+	ExpandErrors: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "expand errors"),
+	),
+	SaveValues: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "apply values"),
+	),
 	ToggleTimestamp: key.NewBinding(
 		key.WithKeys("t"),
 		key.WithHelp("t", "toggle timestamps"),
@@ -129,6 +275,38 @@ var keys = keyMap{
 		key.WithKeys("p"),
 		key.WithHelp("p", "toggle pod names"),
 	),
+	ToggleColors: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "toggle ANSI colors"),
+	),
+	ToggleWrap: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "toggle line wrap"),
+	),
+	ToggleSource: key.NewBinding(
+		key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		key.WithHelp("1-9", "show/hide service"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search logs"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+	FilterMatches: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "only matching lines"),
+	),
+	ShowAllSources: key.NewBinding(
+		key.WithKeys("0"),
+		key.WithHelp("0", "show all services"),
+	),
 	Back: key.NewBinding(
 		key.WithKeys("esc"),
 		key.WithHelp("esc", "back"),
@@ -144,6 +322,31 @@ var keys = keyMap{
 }
 
 func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The nav filter input takes typed keys while focused
+	if m.view == HomeView && m.navFiltering {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleNavFilterKeys(msg)
+	}
+
+	// The log search input takes typed keys while focused
+	if m.view == ServiceLogsView && m.logSearching {
+		if msg.String() == "ctrl+c" {
+			m.stopLogStream()
+			return m, tea.Quit
+		}
+		return m.handleLogSearchKeys(msg)
+	}
+
+	// The values editor takes all typed keys; only ctrl+c quits
+	if m.view == ValuesEditorView {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleValuesKeys(msg)
+	}
+
 	// Global keys (work in all views)
 	switch {
 	case key.Matches(msg, m.keys.Quit):
@@ -161,6 +364,16 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleHomeKeys(msg)
 	case ServiceLogsView:
 		return m.handleLogsKeys(msg)
+	case ConfigView:
+		return m.handleConfigKeys(msg)
+	case OperationsView:
+		return m.handleOperationsKeys(msg)
+	case ProgressView:
+		return m.handleProgressKeys(msg)
+	case PodsView:
+		return m.handlePodsKeys(msg)
+	case EventsView:
+		return m.handleEventsKeys(msg)
 	}
 
 	return m, nil
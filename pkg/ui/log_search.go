@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Log search: '/' highlights a pattern in the logs view, n/N jump between
+// matching lines and f shows only matching lines. Patterns are
+// case-insensitive regular expressions, so "error|warn" finds both levels;
+// a pattern that doesn't compile is searched for literally. Matching runs as
+// raw lines are displayed, so toggles and streaming keep working.
+
+// newLogSearchInput creates the text input used by '/' in the logs view
+func newLogSearchInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search (regex, e.g. error|warn)"
+	input.CharLimit = 128
+	return input
+}
+
+// compileLogPattern compiles a search as a case-insensitive regular
+// expression, or as literal text when it isn't one
+func compileLogPattern(search string) *regexp.Regexp {
+	if search == "" {
+		return nil
+	}
+	if pattern, err := regexp.Compile("(?i)" + search); err == nil {
+		return pattern
+	}
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(search))
+}
+
+// startLogSearch focuses the search input
+func (m *Model) startLogSearch() tea.Cmd {
+	m.logSearching = true
+	m.logSearch.Width = max(10, m.width-4)
+	return m.logSearch.Focus()
+}
+
+// handleLogSearchKeys handles keys while the search input is focused
+func (m *Model) handleLogSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Cancel typing; the search applied before stays
+		m.logSearching = false
+		m.logSearch.Blur()
+		m.logSearch.SetValue(m.logPatternText())
+		return m, nil
+
+	case tea.KeyEnter:
+		m.logSearching = false
+		m.logSearch.Blur()
+		m.setLogPattern(compileLogPattern(strings.TrimSpace(m.logSearch.Value())))
+		// Start at the most recent match
+		if m.logPattern != nil && !m.logFilterOnly {
+			m.jumpToLogMatch(false)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.logSearch, cmd = m.logSearch.Update(msg)
+	return m, cmd
+}
+
+// setLogPattern applies a search, or clears it with nil
+func (m *Model) setLogPattern(pattern *regexp.Regexp) {
+	m.logPattern = pattern
+	m.logMatchRow = -1
+	m.logMatchStatus = ""
+	if pattern == nil {
+		m.logFilterOnly = false
+		m.logSearch.SetValue("")
+	}
+	m.updateLogDisplay()
+	if !m.userScrolled {
+		m.viewport.GotoBottom()
+	}
+}
+
+// logPatternText returns the search as typed, without the case flag
+func (m *Model) logPatternText() string {
+	if m.logPattern == nil {
+		return ""
+	}
+	return strings.TrimPrefix(m.logPattern.String(), "(?i)")
+}
+
+// toggleLogFilter switches between highlighting matches and showing only
+// matching lines
+func (m *Model) toggleLogFilter() {
+	if m.logPattern == nil {
+		return
+	}
+	m.logFilterOnly = !m.logFilterOnly
+	m.logMatchRow = -1
+	m.logMatchStatus = ""
+	m.updateLogDisplay()
+	if !m.userScrolled {
+		m.viewport.GotoBottom()
+	}
+}
+
+// matchLogLine highlights the search in a displayed line. It reports false
+// for a line without a match while only matching lines are shown.
+func (m *Model) matchLogLine(line string) (string, bool) {
+	if m.logPattern == nil {
+		return line, true
+	}
+
+	plain := ansi.Strip(line)
+	matches := m.logPattern.FindAllStringIndex(plain, -1)
+	if len(matches) == 0 {
+		return line, !m.logFilterOnly
+	}
+
+	// Matched lines lose their own colors, which would end the highlight
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		b.WriteString(plain[last:match[0]])
+		b.WriteString(matchStyle.Render(plain[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(plain[last:])
+	return b.String(), true
+}
+
+// logMatchRows returns the viewport rows where matching lines start
+func (m *Model) logMatchRows() []int {
+	var rows []int
+	row := 0
+	m.logs.Each(func(line string) {
+		if m.logPattern.MatchString(ansi.Strip(line)) {
+			rows = append(rows, row)
+		}
+		if m.wrapLogs && m.viewport.Width > 0 {
+			row += strings.Count(ansi.Hardwrap(line, m.viewport.Width, true), "\n") + 1
+		} else {
+			row++
+		}
+	})
+	return rows
+}
+
+// jumpToLogMatch scrolls to the next or previous matching line, wrapping
+// around at either end
+func (m *Model) jumpToLogMatch(forward bool) {
+	if m.logPattern == nil || !m.logsInitialized {
+		return
+	}
+	rows := m.logMatchRows()
+	if len(rows) == 0 {
+		m.logMatchStatus = "no matches"
+		return
+	}
+
+	index := -1
+	if forward {
+		for i, row := range rows {
+			if row > m.logMatchRow {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			index = 0
+		}
+	} else {
+		current := m.logMatchRow
+		if current < 0 {
+			current = rows[len(rows)-1] + 1 // From the bottom: the last match
+		}
+		for i := len(rows) - 1; i >= 0; i-- {
+			if rows[i] < current {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			index = len(rows) - 1
+		}
+	}
+
+	// Show the match a third of the way down, with context above it
+	m.logMatchRow = rows[index]
+	m.viewport.SetYOffset(max(0, m.logMatchRow-m.viewport.Height/3))
+	m.userScrolled = !m.viewport.AtBottom()
+	if !m.userScrolled {
+		m.unseenLogCount = 0
+	}
+	m.logMatchStatus = fmt.Sprintf("match %d of %d", index+1, len(rows))
+}
+
+// renderLogSearch shows the search input while typing, or the applied
+// search with its keys
+func (m *Model) renderLogSearch() string {
+	if m.logSearching {
+		return m.logSearch.View()
+	}
+	if m.logPattern == nil {
+		return ""
+	}
+
+	mode := "highlighting"
+	if m.logFilterOnly {
+		mode = "matching lines only"
+	}
+	status := fmt.Sprintf("🔍 %s (%s)", m.logPatternText(), mode)
+	if m.logMatchStatus != "" {
+		status += " • " + m.logMatchStatus
+	}
+	return activeStyle.Render(status) + dimStyle.Render(" • n/N next/prev • f filter • esc clear")
+}
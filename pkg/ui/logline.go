@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"time"
+)
+
+// logLine is a raw log line split into the parts the log stream adds and the
+// service's own output
+type logLine struct {
+	service   string // Service of a line in the all-services view
+	pod       string // "[pod/<name>/<container>] " prefix of combined logs, with its space
+	timestamp string // Timestamp added by the API server
+	message   string // The line as the service wrote it
+}
+
+// parseLogLine splits a raw log line. The API server prefixes each line with
+// its RFC3339 timestamp in UTC; the leading field is only taken as the
+// timestamp when it parses as one, so lines without it (or starting with the
+// service's own timestamp in another format) are left whole.
+func parseLogLine(line string) logLine {
+	var parsed logLine
+
+	if strings.HasPrefix(line, serviceLogPrefix) {
+		if end := strings.Index(line, "] "); end != -1 {
+			parsed.service, line = line[len(serviceLogPrefix):end], line[end+2:]
+		}
+	}
+	if strings.HasPrefix(line, "[pod/") {
+		if end := strings.Index(line, "] "); end != -1 {
+			parsed.pod, line = line[:end+2], line[end+2:]
+		}
+	}
+
+	field, message, _ := strings.Cut(strings.TrimLeft(line, " "), " ")
+	if isLogTimestamp(field) {
+		parsed.timestamp, line = field, message
+	}
+
+	parsed.message = line
+	return parsed
+}
+
+// isLogTimestamp reports whether a field is an RFC3339 timestamp, with or
+// without fractional seconds and in any zone
+func isLogTimestamp(field string) bool {
+	// Cheap shape check before parsing: "2006-01-02T..."
+	if len(field) < len("2006-01-02T15:04:05Z") || field[4] != '-' || field[7] != '-' {
+		return false
+	}
+	_, err := time.Parse(time.RFC3339Nano, field)
+	return err == nil
+}
@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"plat/pkg/logmux"
+	"plat/pkg/tools"
+)
+
+// All-services logs: every deployed service's logs streamed side by side,
+// interleaved by timestamp and colored by service. Services can be hidden
+// without stopping their streams.
+
+// logMergeWindow is how long lines from different services are held so they
+// can be sorted by timestamp before display
+const logMergeWindow = 250 * time.Millisecond
+
+// serviceLogPrefix marks the service of a line in the all-services view,
+// like the "[pod/...]" prefix kubectl adds
+const serviceLogPrefix = "[service/"
+
+// logSourceColors are the colors services are told apart by, in legend order
+var logSourceColors = []lipgloss.Color{"39", "42", "214", "205", "99", "45", "178", "170", "111", "208"}
+
+// openAllServiceLogs shows the logs of every deployed service
+func (m *Model) openAllServiceLogs() tea.Cmd {
+	services := m.deployedServices()
+	if len(services) == 0 {
+		m.message = "No deployed services"
+		return clearMessageAfter(3 * time.Second)
+	}
+
+	m.logAllServices = true
+	m.logSources = services
+	m.hiddenLogSources = make(map[string]bool)
+	m.logService = "all services"
+	m.view = ServiceLogsView
+	return m.fetchAllLogs(services)
+}
+
+// deployedServices returns the services with a release, in config order
+func (m *Model) deployedServices() []string {
+	var names []string
+	for _, name := range m.runtime.ListServices() {
+		component := m.components[name]
+		if component == nil || component.Status == "not-deployed" || component.Status == "not-found" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// fetchAllLogs loads the recent logs of each service concurrently and
+// interleaves them by timestamp
+func (m *Model) fetchAllLogs(services []string) tea.Cmd {
+	return func() tea.Msg {
+		tails := make([][]string, len(services))
+		errs := make([]error, len(services))
+		var wg sync.WaitGroup
+		for i, service := range services {
+			wg.Add(1)
+			go func(i int, service string) {
+				defer wg.Done()
+				tails[i], errs[i] = m.serviceLogTail(service)
+			}(i, service)
+		}
+		wg.Wait()
+
+		var lines []logmux.Line
+		for i, service := range services {
+			if errs[i] != nil {
+				return logsMsg{services: services, err: fmt.Errorf("failed to get logs of %s: %w", service, errs[i])}
+			}
+			for _, text := range tails[i] {
+				lines = append(lines, logmux.NewLine(service, text))
+			}
+		}
+		logmux.Sort(lines)
+
+		logs := make([]string, len(lines))
+		for i, line := range lines {
+			logs[i] = formatSourceLine(line)
+		}
+		if len(logs) == 0 {
+			logs = []string{"No logs available for these services"}
+		}
+		return logsMsg{services: services, logs: logs}
+	}
+}
+
+// serviceLogTail returns the last lines of one service's logs
+func (m *Model) serviceLogTail(service string) ([]string, error) {
+	if m.demo != nil {
+		return m.demo.Logs([]string{service}, 100), nil
+	}
+
+	reader, err := tools.StreamLogs(context.Background(), m.runtime.Base.Defaults.Namespace,
+		logsSelector([]string{service}), m.sourceLogOptions(service, false))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// sourceLogOptions returns the log options of one service of the
+// all-services view; lines keep their pod so replicas can be told apart
+func (m *Model) sourceLogOptions(service string, follow bool) tools.LogOptions {
+	opts := m.logOptions([]string{service}, follow)
+	opts.Prefix = true
+	return opts
+}
+
+// startAllLogStreams opens a follow stream per service and merges them
+func (m *Model) startAllLogStreams(services []string) (io.ReadCloser, error) {
+	streams := make(map[string]io.ReadCloser, len(services))
+	for _, service := range services {
+		var stream io.ReadCloser
+		if m.demo != nil {
+			stream = m.demo.StreamLogs([]string{service})
+		} else {
+			var err error
+			stream, err = tools.StreamLogs(context.Background(), m.runtime.Base.Defaults.Namespace,
+				logsSelector([]string{service}), m.sourceLogOptions(service, true))
+			if err != nil {
+				for _, opened := range streams {
+					opened.Close()
+				}
+				return nil, fmt.Errorf("failed to start log stream of %s: %w", service, err)
+			}
+		}
+		streams[service] = stream
+	}
+	return newMergedLogStream(logmux.Merge(streams, logMergeWindow)), nil
+}
+
+// mergedLogStream reads merged lines as one stream, marked with their
+// service, for the log reader
+type mergedLogStream struct {
+	*io.PipeReader
+	mux *logmux.Mux
+}
+
+func newMergedLogStream(mux *logmux.Mux) *mergedLogStream {
+	reader, writer := io.Pipe()
+	go func() {
+		for batch := range mux.Batches() {
+			for _, line := range batch {
+				if _, err := io.WriteString(writer, formatSourceLine(line)+"\n"); err != nil {
+					return
+				}
+			}
+		}
+		writer.CloseWithError(mux.Err())
+	}()
+	return &mergedLogStream{PipeReader: reader, mux: mux}
+}
+
+// Close stops every service's stream
+func (s *mergedLogStream) Close() error {
+	s.PipeReader.Close()
+	return s.mux.Close()
+}
+
+// formatSourceLine marks a line with its service
+func formatSourceLine(line logmux.Line) string {
+	return serviceLogPrefix + line.Source + "] " + line.Text
+}
+
+// toggleLogSource hides or shows the nth service of the legend
+func (m *Model) toggleLogSource(n int) {
+	if n < 1 || n > len(m.logSources) {
+		return
+	}
+	service := m.logSources[n-1]
+	m.hiddenLogSources[service] = !m.hiddenLogSources[service]
+	m.updateLogDisplay()
+}
+
+// showAllLogSources shows every service again
+func (m *Model) showAllLogSources() {
+	m.hiddenLogSources = make(map[string]bool)
+	m.updateLogDisplay()
+}
+
+// logSourceStyle returns the color of a service's label
+func (m *Model) logSourceStyle(service string) lipgloss.Style {
+	for i, name := range m.logSources {
+		if name == service {
+			return lipgloss.NewStyle().Foreground(logSourceColors[i%len(logSourceColors)])
+		}
+	}
+	return dimStyle
+}
+
+// renderSourceLabel pads a service name to the legend's widest for the
+// column before each line
+func (m *Model) renderSourceLabel(service string) string {
+	width := 0
+	for _, name := range m.logSources {
+		width = max(width, len(name))
+	}
+	return m.logSourceStyle(service).Render(fmt.Sprintf("%-*s", width, service)) + " │ "
+}
+
+// renderLogLegend numbers the services for the 1-9 toggles, dimming
+// hidden ones
+func (m *Model) renderLogLegend() string {
+	items := make([]string, len(m.logSources))
+	for i, service := range m.logSources {
+		label := service
+		if i < 9 {
+			label = fmt.Sprintf("%d %s", i+1, service)
+		}
+		if m.hiddenLogSources[service] {
+			items[i] = dimStyle.Strikethrough(true).Render(label)
+		} else {
+			items[i] = m.logSourceStyle(service).Render(label)
+		}
+	}
+	return strings.Join(items, "  ") + dimStyle.Render("  (1-9 to show/hide, 0 for all)")
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// logBatchInterval is how long a batch collects lines after the first
+	// arrives, capping view updates at a few dozen per second
+	logBatchInterval = 50 * time.Millisecond
+
+	// maxLogBatch is the most lines delivered in one message
+	maxLogBatch = 1000
+
+	// logReadAhead is how many lines the reader may buffer before it stops
+	// reading, pushing back on the stream until the view catches up
+	logReadAhead = 4 * maxLogBatch
+)
+
+// logReader reads a log stream line by line in the background and hands the
+// lines to the view in batches
+type logReader struct {
+	lines chan string
+	done  chan struct{}
+	err   error // Why reading ended; set before lines is closed
+}
+
+// newLogReader starts reading lines from a stream
+func newLogReader(stream io.Reader) *logReader {
+	lr := &logReader{
+		lines: make(chan string, logReadAhead),
+		done:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(lr.lines)
+		reader := bufio.NewReader(stream)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				select {
+				case lr.lines <- strings.TrimRight(line, "\r\n"):
+				case <-lr.done:
+					return
+				}
+			}
+			if err != nil {
+				lr.err = err
+				return
+			}
+		}
+	}()
+
+	return lr
+}
+
+// stop ends reading; batches already delivered are unaffected
+func (lr *logReader) stop() {
+	close(lr.done)
+}
+
+// nextBatch waits for the next line, then collects whatever else arrives
+// within logBatchInterval into a single message
+func (lr *logReader) nextBatch() tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lr.lines
+		if !ok {
+			return logStreamErrorMsg{reader: lr, err: lr.err}
+		}
+
+		batch := []string{line}
+		timer := time.NewTimer(logBatchInterval)
+		defer timer.Stop()
+
+		for len(batch) < maxLogBatch {
+			select {
+			case line, ok := <-lr.lines:
+				if !ok {
+					// Deliver what we have; the next call reports the end
+					return logStreamMsg{reader: lr, lines: batch}
+				}
+				batch = append(batch, line)
+			case <-timer.C:
+				return logStreamMsg{reader: lr, lines: batch}
+			}
+		}
+		return logStreamMsg{reader: lr, lines: batch}
+	}
+}
@@ -3,7 +3,9 @@ package ui
 import (
 	"time"
 
+	"plat/pkg/config"
 	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
 )
 
 // Messages define all the messages that can be sent to the Update function
@@ -16,27 +18,81 @@ type statusRefreshMsg struct {
 
 // actionCompleteMsg is sent when an action (up/down) completes
 type actionCompleteMsg struct {
+	opID    int // Queued operation the result belongs to
 	message string
 	err     error
 }
 
 // logsMsg is sent when logs are fetched for a service (initial load)
 type logsMsg struct {
-	service string
-	logs    []string
-	err     error
+	services []string
+	logs     []string
+	err      error
 }
 
-// logStreamMsg is sent when a new log line arrives from the stream
+// logStreamMsg is sent when a batch of log lines arrives from the stream
 type logStreamMsg struct {
-	line string
+	reader *logReader
+	lines  []string
 }
 
 // logStreamErrorMsg is sent when the log stream encounters an error
 type logStreamErrorMsg struct {
+	reader *logReader
+	err    error
+}
+
+// valuesLoadedMsg is sent when a service's values are resolved for editing
+type valuesLoadedMsg struct {
+	service   string
+	base      map[string]interface{} // Values from config
+	overrides map[string]interface{} // Current session override layer
+	err       error
+}
+
+// podsMsg carries the pods of the service the pods view shows
+type podsMsg struct {
+	service string
+	pods    []tools.PodInfo
+	err     error
+}
+
+// podDetailMsg carries the pod opened in the pods view
+type podDetailMsg struct {
+	pod *tools.PodDescription
 	err error
 }
 
+// eventsMsg carries the namespace's events for the events view
+type eventsMsg struct {
+	events []tools.EventInfo
+	err    error
+}
+
+// eventsTickMsg reloads the events view while the view opened as
+// generation is still open
+type eventsTickMsg struct {
+	generation int
+}
+
+// provenanceMsg carries where each Helm service's values come from
+type provenanceMsg struct {
+	provenance map[string][]config.ValueProvenance
+}
+
+// progressMsg carries an event of a running operation
+type progressMsg struct {
+	event orchestrator.Event
+}
+
+// insightsMsg carries the latest drift and image update checks
+type insightsMsg struct {
+	insights *orchestrator.ServiceInsights
+}
+
+// insightsTickMsg is sent periodically to re-check drift and updates
+type insightsTickMsg struct{}
+
 // tickMsg is sent periodically for auto-refresh
 type tickMsg time.Time
 
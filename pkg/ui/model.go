@@ -1,19 +1,27 @@
 package ui
 
 import (
-	"bufio"
+	"context"
 	"io"
-	"os/exec"
+	"log/slog"
+	"regexp"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"plat/pkg/config"
+	"plat/pkg/demo"
+	"plat/pkg/logbuf"
+	"plat/pkg/logging"
 	"plat/pkg/orchestrator"
+	"plat/pkg/tools"
+	"plat/pkg/watch"
 )
 
 // NavItem represents an item in the left navigation panel
@@ -34,22 +42,38 @@ const (
 type Model struct {
 	// Shared state
 	runtime *config.RuntimeConfig
-	orch    *orchestrator.Orchestrator
+	orch    backend
+	demo    *demo.Backend // Set in demo mode, which also fakes logs
 
 	// Component-based status
 	components  map[string]*Component // Keyed by component ID
 	envName     string                // Environment name
 	envMode     string                // Environment mode (artifact/source)
 	lastRefresh time.Time
+	nextRefresh time.Time                           // When auto-refresh fetches status next
+	refreshing  bool                                // A status refresh is in flight
+	insights    *orchestrator.ServiceInsights       // Drift and update checks for badges
+	provenance  map[string][]config.ValueProvenance // Value sources of Helm services
+
+	// Consecutive failed status refreshes, which back off auto-refresh
+	refreshFailures int
 
 	// UI state
-	view        ViewMode
-	selectedNav int // Index in navItems slice
-	navItems    []NavItem
-	loading     bool
-	operation   string // Current operation being performed
-	message     string
-	error       error
+	view         ViewMode
+	selectedNav  int // Index in navItems slice
+	navItems     []NavItem
+	navFilter    textinput.Model // '/' filter for the nav panel
+	navFiltering bool            // Whether the filter input has focus
+	navSort      navSortMode
+	marked       map[string]bool // Services marked for bulk actions
+	operations   []*operation    // Queued and running operations, in run order
+	history      []*operation    // Finished operations, most recent first
+	nextOpID     int
+	progress     string                    // Latest progress message of the running operation
+	events       <-chan orchestrator.Event // Events of the running operations
+	logSink      *logging.Sink             // Records logged by the orchestrator, tools and config
+	message      string
+	error        error
 
 	// Shared components
 	spinner spinner.Model
@@ -60,32 +84,112 @@ type Model struct {
 	viewport viewport.Model
 
 	// Log viewer state
-	logService      string
-	logs            []string
-	rawLogs         []string // Original logs before filtering
+	logService      string       // Label of the services being viewed
+	logs            *logbuf.Ring // Logs as displayed
+	rawLogs         *logbuf.Ring // Original logs before filtering
 	logsInitialized bool
 	showTimestamps  bool
 	showPodNames    bool
+	showColors      bool          // Render ANSI colors in logs instead of stripping them
+	wrapLogs        bool          // Wrap long log lines instead of cutting them off
 	logStreaming    bool          // Whether logs are actively streaming
 	userScrolled    bool          // Whether user has scrolled away from bottom
 	unseenLogCount  int           // Number of new logs arrived while user is scrolled up
-	logStreamCmd    *exec.Cmd     // The running kubectl logs command
-	logStreamReader io.ReadCloser // The stdout reader for the stream
-	logBufioReader  *bufio.Reader // Buffered reader for efficient line reading
+	logStreamReader io.ReadCloser // The open log stream
+	logReader       *logReader    // Reads the stream in batches
+
+	// All-services log state
+	logAllServices   bool            // The logs view shows every deployed service
+	logSources       []string        // Services of the all-services view, in legend order
+	hiddenLogSources map[string]bool // Services hidden from the all-services view
+
+	// Log search state
+	logSearch      textinput.Model // '/' search input of the logs view
+	logSearching   bool            // Whether the search input has focus
+	logPattern     *regexp.Regexp  // Applied search; nil when none
+	logFilterOnly  bool            // Show only lines matching logPattern
+	logMatchRow    int             // Viewport row of the match n/N last jumped to, or -1
+	logMatchStatus string          // e.g. "match 3 of 12"
+
+	// Config view state
+	configViewport viewport.Model
+
+	// Operations view state
+	opsViewport       viewport.Model
+	opsErrorsExpanded bool // Show every failed service's full error
+
+	// Pods view state
+	podsService  string
+	pods         []tools.PodInfo
+	podsErr      error
+	selectedPod  int
+	podDetail    *tools.PodDescription // The pod opened, nil while listing
+	podsViewport viewport.Model
+
+	// Events view state
+	kubeEvents         []tools.EventInfo
+	eventsErr          error
+	eventsLoaded       bool // Whether the first load finished
+	eventsWarningsOnly bool
+	eventsGeneration   int // Counts openings, so a closed view's ticks stop
+	eventsViewport     viewport.Model
+
+	// Progress view state, of the latest up or down
+	deployProgress   *deployProgress
+	progressViewport viewport.Model
+
+	// Values watching state
+	reloadConfig   func() (*config.RuntimeConfig, error) // Nil when the config can't be reloaded
+	valuesChanges  <-chan watch.Change
+	deployedValues map[string]map[string]interface{} // Resolved values of the running config
+	pendingValues  *valuesUpdate                     // Changed values awaiting an upgrade
+
+	// Values editor state
+	valuesEditor   textarea.Model
+	valuesService  string
+	valuesBase     map[string]interface{} // Resolved values the edit is diffed against
+	valuesOriginal string                 // Editor content when opened
+	valuesErr      error
 
 	// Dimensions
 	width  int
 	height int
+
+	// First panic recovered from Update or View
+	crash *crashReport
+}
+
+// RunTUI runs the TUI. Crash notifications are recorded in crashes, and
+// reload loads the config again when its files change.
+func RunTUI(runtime *config.RuntimeConfig, crashes orchestrator.CrashLedger, reload func() (*config.RuntimeConfig, error)) error {
+	orch := orchestrator.NewOrchestrator(false)
+	orch.SetCrashLedger(crashes)
+	defer orch.WaitNotifications()
+	return run(runtime, orch, nil, reload)
+}
+
+// RunDemo runs the TUI against synthetic data, without docker, k3d or a
+// config of its own. Sessions are repeatable for screenshots and recordings.
+func RunDemo() error {
+	runtime, cleanup, err := demo.Setup()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	backend := demo.NewBackend(runtime)
+	return run(runtime, backend, backend, nil)
 }
 
-func RunTUI(runtime *config.RuntimeConfig) error {
+func run(runtime *config.RuntimeConfig, orch backend, demoBackend *demo.Backend, reload func() (*config.RuntimeConfig, error)) error {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
 	m := &Model{
 		runtime:        runtime,
-		orch:           orchestrator.NewOrchestrator(false),
+		orch:           orch,
+		demo:           demoBackend,
 		components:     make(map[string]*Component),
 		view:           HomeView,
 		spinner:        s,
@@ -93,13 +197,40 @@ func RunTUI(runtime *config.RuntimeConfig) error {
 		keys:           keys,
 		showTimestamps: false, // Hide timestamps by default to save space
 		showPodNames:   false, // Hide pod names by default to save space
+		showColors:     true,
+		logs:           logbuf.New(runtime.Local.LogMaxLines()),
+		rawLogs:        logbuf.New(runtime.Local.LogMaxLines()),
+		navFilter:      newNavFilterInput(),
+		logSearch:      newLogSearchInput(),
+		logMatchRow:    -1,
+		marked:         make(map[string]bool),
+		logSink:        logging.NewSink(maxLogEntries, tuiLogLevel()),
+		reloadConfig:   reload,
 	}
 
+	// Logs go to memory while the TUI owns the terminal
+	tuiLogger := slog.New(m.logSink)
+	m.orch.SetLogger(tuiLogger)
+	tools.SetLogger(tuiLogger)
+	config.SetLogger(tuiLogger)
+	defer tools.SetLogger(nil)
+	defer config.SetLogger(nil)
+
+	// The TUI renders the orchestrator's events instead of its output
+	events, unsubscribe := m.orch.Subscribe()
+	defer unsubscribe()
+	m.events = events
+	m.orch.SetQuiet(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.watchValues(ctx)
+
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
-	if _, err := p.Run(); err != nil {
-		return err
+	_, err := p.Run()
+	if m.crash != nil {
+		return m.crashError()
 	}
-
-	return nil
+	return err
 }
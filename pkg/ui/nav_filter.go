@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/orchestrator"
+)
+
+// Nav panel filtering and sorting for environments with many services
+
+// navSortMode orders the services in the nav panel
+type navSortMode int
+
+const (
+	navSortConfig navSortMode = iota // Config declaration order
+	navSortName                      // Alphabetical
+	navSortStatus                    // Unhealthy services first
+	navSortRecent                    // Most recently changed first
+)
+
+func (s navSortMode) String() string {
+	switch s {
+	case navSortName:
+		return "name"
+	case navSortStatus:
+		return "status"
+	case navSortRecent:
+		return "recent"
+	default:
+		return "config"
+	}
+}
+
+// newNavFilterInput creates the text input used by '/' filtering
+func newNavFilterInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "filter"
+	input.CharLimit = 64
+	input.Width = navPanelWidth - 6
+	return input
+}
+
+// handleNavFilterKeys handles keys while the filter input is focused
+func (m *Model) handleNavFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Cancel filtering and show everything again
+		m.navFiltering = false
+		m.navFilter.Blur()
+		m.navFilter.SetValue("")
+		m.refreshNavItems()
+		return m, nil
+
+	case tea.KeyEnter:
+		// Keep the filter and return to navigation
+		m.navFiltering = false
+		m.navFilter.Blur()
+		return m, nil
+
+	case tea.KeyUp, tea.KeyDown:
+		// Allow moving through matches while typing
+		if msg.Type == tea.KeyUp {
+			m.selectedNav = max(0, m.selectedNav-1)
+		} else {
+			m.selectedNav = min(len(m.navItems)-1, m.selectedNav+1)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.navFilter, cmd = m.navFilter.Update(msg)
+	m.refreshNavItems()
+	return m, cmd
+}
+
+// startNavFilter focuses the nav filter input
+func (m *Model) startNavFilter() tea.Cmd {
+	m.navFiltering = true
+	return m.navFilter.Focus()
+}
+
+// cycleNavSort switches to the next sort mode
+func (m *Model) cycleNavSort() {
+	m.navSort = (m.navSort + 1) % (navSortRecent + 1)
+	m.refreshNavItems()
+}
+
+// refreshNavItems rebuilds the nav items, keeping the selected item selected
+// even when filtering or sorting moves it to another index
+func (m *Model) refreshNavItems() {
+	var selected *NavItem
+	if item := m.getSelectedNavItem(); item != nil {
+		copied := *item
+		selected = &copied
+	}
+
+	m.navItems = m.buildNavItems()
+
+	if selected != nil {
+		for i, item := range m.navItems {
+			if item.Type == selected.Type && item.Name == selected.Name {
+				m.selectedNav = i
+				return
+			}
+		}
+	}
+	m.selectedNav = max(0, min(m.selectedNav, len(m.navItems)-1))
+}
+
+// filterAndSortServices applies the nav filter and sort mode to service names
+func (m *Model) filterAndSortServices(names []string) []string {
+	pattern := strings.TrimSpace(m.navFilter.Value())
+
+	var result []string
+	for _, name := range names {
+		status := ""
+		if comp := m.components[name]; comp != nil {
+			status = comp.Status
+		}
+		if pattern == "" || fuzzyMatch(pattern, name) || fuzzyMatch(pattern, m.serviceLabel(name)) || fuzzyMatch(pattern, status) {
+			result = append(result, name)
+		}
+	}
+
+	switch m.navSort {
+	case navSortName:
+		sort.Strings(result)
+	case navSortStatus:
+		sort.SliceStable(result, func(i, j int) bool {
+			return m.statusRank(result[i]) < m.statusRank(result[j])
+		})
+	case navSortRecent:
+		sort.SliceStable(result, func(i, j int) bool {
+			return m.lastChanged(result[i]).After(m.lastChanged(result[j]))
+		})
+	}
+
+	return result
+}
+
+// statusRank orders services so the ones needing attention come first
+func (m *Model) statusRank(name string) int {
+	comp := m.components[name]
+	if comp == nil {
+		return 3
+	}
+
+	if svc, ok := comp.StatusDetail.(*orchestrator.ServiceStatus); ok && svc != nil && svc.Deployment != nil && !svc.Deployment.Ready {
+		return 0
+	}
+	switch strings.ToLower(comp.Status) {
+	case "failed", "error":
+		return 0
+	case "pending-install", "pending-upgrade", "starting":
+		return 1
+	case "deployed", "running":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// lastChanged returns when the service was last deployed or changed status
+func (m *Model) lastChanged(name string) time.Time {
+	comp := m.components[name]
+	if comp == nil {
+		return time.Time{}
+	}
+
+	changed := comp.LastUpdated
+	if svc, ok := comp.StatusDetail.(*orchestrator.ServiceStatus); ok && svc != nil {
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999 -0700 MST"} {
+			if deployed, err := time.Parse(layout, svc.Updated); err == nil {
+				if deployed.After(changed) {
+					changed = deployed
+				}
+				break
+			}
+		}
+	}
+	return changed
+}
+
+// renderNavHeader renders the filter input and sort mode above the nav items
+func (m *Model) renderNavHeader() string {
+	var parts []string
+
+	if m.navFiltering {
+		parts = append(parts, m.navFilter.View())
+	} else if value := m.navFilter.Value(); value != "" {
+		parts = append(parts, activeStyle.Render("/"+value))
+	}
+
+	if m.navSort != navSortConfig {
+		parts = append(parts, dimStyle.Render("sort: "+m.navSort.String()))
+	}
+
+	if marked := len(m.markedServices()); marked > 0 {
+		parts = append(parts, markStyle.Render(fmt.Sprintf("%d marked", marked)))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// navFilterActive reports whether the filter narrows the nav items
+func (m *Model) navFilterActive() bool {
+	return m.navFilter.Value() != ""
+}
+
+// clearNavFilter removes the filter if one is set
+func (m *Model) clearNavFilter() bool {
+	if !m.navFilterActive() {
+		return false
+	}
+	m.navFilter.SetValue("")
+	m.refreshNavItems()
+	return true
+}
+
+// fuzzyMatch reports whether the characters of pattern appear in text in
+// order, ignoring case ("usr" matches "user-api")
+func fuzzyMatch(pattern, text string) bool {
+	needle := []rune(strings.ToLower(pattern))
+	i := 0
+	for _, r := range strings.ToLower(text) {
+		if i < len(needle) && needle[i] == r {
+			i++
+		}
+	}
+	return i == len(needle)
+}
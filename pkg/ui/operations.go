@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/logging"
+)
+
+// Operation queue: actions run one at a time in the order they were
+// triggered, and finished operations are kept in a history panel
+
+// maxOperationHistory bounds the finished operations kept for the panel
+const maxOperationHistory = 100
+
+// maxLogEntries bounds the log records kept for the panel
+const maxLogEntries = 200
+
+// tuiLogLevel keeps info records for the panel, or debug ones when the
+// command line asked for them
+func tuiLogLevel() slog.Level {
+	if logging.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// opState is the lifecycle state of a queued operation
+type opState int
+
+const (
+	opQueued opState = iota
+	opRunning
+	opSucceeded
+	opFailed
+)
+
+// operation is an action triggered from the TUI
+type operation struct {
+	id       int
+	label    string
+	cmd      tea.Cmd
+	state    opState
+	queued   time.Time
+	started  time.Time
+	finished time.Time
+	message  string
+	err      error
+}
+
+// duration returns how long the operation ran, or has been running
+func (op *operation) duration() time.Duration {
+	switch {
+	case op.started.IsZero():
+		return 0
+	case op.finished.IsZero():
+		return time.Since(op.started)
+	default:
+		return op.finished.Sub(op.started)
+	}
+}
+
+// enqueue adds an operation to the queue and starts it if nothing is running
+func (m *Model) enqueue(label string, cmd tea.Cmd) tea.Cmd {
+	m.nextOpID++
+	m.operations = append(m.operations, &operation{
+		id:     m.nextOpID,
+		label:  label,
+		cmd:    cmd,
+		state:  opQueued,
+		queued: time.Now(),
+	})
+	m.message = ""
+	m.error = nil
+
+	return m.startNextOperation()
+}
+
+// runningOperation returns the operation in progress, if any
+func (m *Model) runningOperation() *operation {
+	for _, op := range m.operations {
+		if op.state == opRunning {
+			return op
+		}
+	}
+	return nil
+}
+
+// queuedOperations counts operations waiting to run
+func (m *Model) queuedOperations() int {
+	count := 0
+	for _, op := range m.operations {
+		if op.state == opQueued {
+			count++
+		}
+	}
+	return count
+}
+
+// startNextOperation runs the oldest queued operation unless one is running
+func (m *Model) startNextOperation() tea.Cmd {
+	if m.runningOperation() != nil {
+		return nil
+	}
+
+	for _, op := range m.operations {
+		if op.state != opQueued {
+			continue
+		}
+		op.state = opRunning
+		op.started = time.Now()
+		m.progress = ""
+
+		id, cmd := op.id, op.cmd
+		return func() tea.Msg {
+			msg := cmd()
+			done, ok := msg.(actionCompleteMsg)
+			if !ok {
+				done = actionCompleteMsg{}
+			}
+			done.opID = id
+			return done
+		}
+	}
+	return nil
+}
+
+// finishOperation records the result of an operation and moves it to the history
+func (m *Model) finishOperation(msg actionCompleteMsg) {
+	for i, op := range m.operations {
+		if op.id != msg.opID {
+			continue
+		}
+
+		op.finished = time.Now()
+		op.message = msg.message
+		op.err = msg.err
+		op.state = opSucceeded
+		if msg.err != nil {
+			op.state = opFailed
+		}
+		op.cmd = nil
+
+		m.operations = append(m.operations[:i], m.operations[i+1:]...)
+		m.history = append([]*operation{op}, m.history...)
+		if len(m.history) > maxOperationHistory {
+			m.history = m.history[:maxOperationHistory]
+		}
+		return
+	}
+}
+
+// Operations view
+
+func (m *Model) openOperationsView() {
+	m.opsViewport = viewport.New(m.width, max(5, m.height-10))
+	m.opsViewport.SetContent(m.buildOperationsContent())
+	m.view = OperationsView
+}
+
+func (m *Model) renderOperationsView() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	b.WriteString(sectionStyle.Render("🗂  Operations"))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Use ↑/↓ to scroll • e to expand errors • h/ESC to go back"))
+	b.WriteString("\n\n")
+
+	// Durations of running operations tick, so rebuild on every render
+	m.opsViewport.SetContent(m.buildOperationsContent())
+	b.WriteString(m.opsViewport.View())
+
+	b.WriteString("\n\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+func (m *Model) handleOperationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Operations):
+		m.view = HomeView
+		return m, nil
+
+	case key.Matches(msg, m.keys.ExpandErrors):
+		m.opsErrorsExpanded = !m.opsErrorsExpanded
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.opsViewport.ScrollUp(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.opsViewport.ScrollDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.opsViewport, cmd = m.opsViewport.Update(msg)
+	return m, cmd
+}
+
+// buildOperationsContent lists the queue in run order, then the history
+// with the most recent first
+func (m *Model) buildOperationsContent() string {
+	var b strings.Builder
+
+	b.WriteString(sectionStyle.Render("Queue"))
+	b.WriteString("\n")
+	if len(m.operations) == 0 {
+		b.WriteString(dimStyle.Render("  Nothing queued"))
+		b.WriteString("\n")
+	}
+	for i, op := range m.operations {
+		if op.state == opRunning {
+			b.WriteString(activeStyle.Render(fmt.Sprintf("  %d. %s %s", i+1, m.spinner.View(), op.label)))
+			b.WriteString(dimStyle.Render(fmt.Sprintf("  running %s", op.duration().Round(time.Second))))
+		} else {
+			b.WriteString(fmt.Sprintf("  %d. ⏳ %s", i+1, op.label))
+			b.WriteString(dimStyle.Render(fmt.Sprintf("  queued %s", op.queued.Format("15:04:05"))))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("History"))
+	b.WriteString("\n")
+	if len(m.history) == 0 {
+		b.WriteString(dimStyle.Render("  No completed operations"))
+		b.WriteString("\n")
+	}
+	for _, op := range m.history {
+		icon := "✅"
+		if op.state == opFailed {
+			icon = "❌"
+		}
+		b.WriteString(fmt.Sprintf("  %s %s", icon, op.label))
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  %s • took %s", op.finished.Format("15:04:05"), op.duration().Round(100*time.Millisecond))))
+		b.WriteString("\n")
+		if op.err != nil {
+			m.renderOperationError(&b, op.err)
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("Log"))
+	b.WriteString("\n")
+	entries := m.logSink.Entries()
+	if len(entries) == 0 {
+		b.WriteString(dimStyle.Render("  Nothing logged"))
+		b.WriteString("\n")
+	}
+	for _, entry := range entries {
+		line := fmt.Sprintf("  %s %s", entry.Time.Format("15:04:05"), entry.Line)
+		switch {
+		case entry.Level >= slog.LevelWarn:
+			b.WriteString(errorStyle.Render(line))
+		case entry.Level < slog.LevelInfo:
+			b.WriteString(dimStyle.Render(line))
+		default:
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
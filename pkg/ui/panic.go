@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Panic recovery: a panic in Update or View is caught before it reaches
+// bubbletea, the program quits normally so the terminal is restored, and
+// the stack is written to .plat/logs/panic.log
+
+// crashReport is a panic recovered from the TUI
+type crashReport struct {
+	value interface{}
+	stack []byte
+}
+
+// Update handles all incoming messages, quitting cleanly if handling panics
+func (m *Model) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if m.crash != nil {
+		return m, tea.Quit
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.recordCrash(r)
+			model, cmd = m, tea.Quit
+		}
+	}()
+
+	return m.update(msg)
+}
+
+// View renders the current view, rendering nothing once a panic occurred
+func (m *Model) View() (view string) {
+	if m.crash != nil {
+		return ""
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.recordCrash(r)
+			view = ""
+		}
+	}()
+
+	return m.render()
+}
+
+// recordCrash keeps the first panic and stops background work
+func (m *Model) recordCrash(r interface{}) {
+	if m.crash == nil {
+		m.crash = &crashReport{value: r, stack: debug.Stack()}
+	}
+	m.stopLogStream()
+}
+
+// crashError writes the crash report to the panic log and returns the
+// message shown once the terminal is restored
+func (m *Model) crashError() error {
+	path := filepath.Join(m.runtime.ConfigDir(), "logs", "panic.log")
+	if err := writePanicLog(path, m.crash); err != nil {
+		return fmt.Errorf("plat hit an unexpected error and closed: %v\n(failed to write %s: %v)\n\n%s", m.crash.value, path, err, m.crash.stack)
+	}
+	return fmt.Errorf("plat hit an unexpected error and closed: %v\nThe details were saved to %s; please include that file when reporting the problem", m.crash.value, path)
+}
+
+// writePanicLog appends a crash report to the panic log
+func writePanicLog(path string, crash *crashReport) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "=== %s\npanic: %v\n\n%s\n", time.Now().Format(time.RFC3339), crash.value, crash.stack)
+	return err
+}
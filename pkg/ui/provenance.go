@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/config"
+)
+
+// maxProvenanceLines bounds the values listed in the service detail panel
+const maxProvenanceLines = 8
+
+// loadProvenance resolves where each Helm service's values come from
+func (m *Model) loadProvenance() tea.Cmd {
+	return func() tea.Msg {
+		provenance := make(map[string][]config.ValueProvenance)
+		for name, service := range m.runtime.ResolvedServices {
+			if service.Engine() != config.EngineHelm {
+				continue
+			}
+			values, err := m.orch.ValueProvenance(m.runtime, name)
+			if err != nil {
+				continue
+			}
+			provenance[name] = values
+		}
+		return provenanceMsg{provenance: provenance}
+	}
+}
+
+// renderValuesProvenance lists the values a service's configuration sets
+// on top of the chart defaults, with the layer each comes from
+func (m *Model) renderValuesProvenance(serviceName string) string {
+	var set []config.ValueProvenance
+	for _, value := range m.provenance[serviceName] {
+		if value.Source != config.ValuesLayerDefaults {
+			set = append(set, value)
+		}
+	}
+	if len(set) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("Values"))
+	b.WriteString("\n\n")
+
+	for i, value := range set {
+		if i == maxProvenanceLines {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("… %d more (plat config show --explain)", len(set)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(fmt.Sprintf("%s: %s %s", value.Key, value.FormatValue(), dimStyle.Render("("+value.Source+")")))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// refreshInterval is how often status is refreshed while it succeeds
+	refreshInterval = 3 * time.Second
+
+	// maxRefreshBackoff caps the delay between refreshes that keep failing
+	maxRefreshBackoff = time.Minute
+)
+
+// autoRefresh refreshes status when the next refresh is due. Refreshes
+// never overlap, so a slow or hanging cluster doesn't pile up helm and
+// kubectl calls.
+func (m *Model) autoRefresh() tea.Cmd {
+	if m.refreshing || time.Now().Before(m.nextRefresh) {
+		return nil
+	}
+	m.refreshing = true
+	return m.refreshStatus()
+}
+
+// recordRefresh schedules the next refresh: after the regular interval when
+// status was fetched, or after an exponentially growing delay while it keeps
+// failing, typically because the cluster is down
+func (m *Model) recordRefresh(err error) {
+	m.refreshing = false
+	m.lastRefresh = time.Now()
+
+	if err == nil {
+		m.refreshFailures = 0
+		m.nextRefresh = m.lastRefresh.Add(refreshInterval)
+		return
+	}
+
+	m.refreshFailures++
+	m.nextRefresh = m.lastRefresh.Add(refreshBackoff(m.refreshFailures))
+}
+
+// refreshBackoff returns the delay after a number of consecutive failures:
+// the refresh interval doubled for each failure after the first, capped
+func refreshBackoff(failures int) time.Duration {
+	delay := refreshInterval
+	for i := 1; i < failures && delay < maxRefreshBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRefreshBackoff {
+		return maxRefreshBackoff
+	}
+	return delay
+}
+
+// unreachableBanner describes failing refreshes and when the next one runs,
+// or returns "" while status refreshes succeed
+func (m *Model) unreachableBanner() string {
+	if m.refreshFailures == 0 {
+		return ""
+	}
+	if m.refreshing {
+		return "cluster unreachable, retrying..."
+	}
+	wait := time.Until(m.nextRefresh).Round(time.Second)
+	return fmt.Sprintf("cluster unreachable, retrying in %ds (r to retry now)", max(0, int(wait.Seconds())))
+}
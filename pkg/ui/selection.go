@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Multi-select in the nav panel: space marks services, and restart, stop and
+// logs act on every marked service instead of just the highlighted one
+
+// toggleMark marks or unmarks the highlighted service
+func (m *Model) toggleMark() {
+	item := m.getSelectedNavItem()
+	if item == nil || item.Type != NavItemService {
+		return
+	}
+
+	if m.marked[item.ServiceName] {
+		delete(m.marked, item.ServiceName)
+	} else {
+		m.marked[item.ServiceName] = true
+	}
+
+	// Move on so a run of services can be marked by holding space
+	m.selectedNav = min(len(m.navItems)-1, m.selectedNav+1)
+}
+
+// clearMarks unmarks all services, reporting whether any were marked
+func (m *Model) clearMarks() bool {
+	if len(m.marked) == 0 {
+		return false
+	}
+	m.marked = make(map[string]bool)
+	return true
+}
+
+// markedServices returns the marked services in nav order, dropping any that
+// no longer exist
+func (m *Model) markedServices() []string {
+	var names []string
+	for _, name := range m.getSortedServiceNames() {
+		if m.marked[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// actionTargets returns the services an action applies to: the marked
+// services if there are any, otherwise the highlighted one
+func (m *Model) actionTargets() []string {
+	if names := m.markedServices(); len(names) > 0 {
+		return names
+	}
+	if item := m.getSelectedNavItem(); item != nil && item.Type == NavItemService {
+		return []string{item.ServiceName}
+	}
+	return nil
+}
+
+// describeTargets names the services for an operation label
+func describeTargets(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return fmt.Sprintf("%d services", len(names))
+}
+
+// runOnServices runs fn for each service in turn and reports one result; a
+// failure doesn't stop the remaining services
+func (m *Model) runOnServices(names []string, verb string, fn func(ctx context.Context, name string) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute*time.Duration(len(names)))
+		defer cancel()
+
+		var errs []error
+		for _, name := range names {
+			if err := fn(ctx, name); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+
+		if len(errs) > 0 {
+			return actionCompleteMsg{err: errors.Join(errs...)}
+		}
+
+		if len(names) == 1 {
+			return actionCompleteMsg{message: fmt.Sprintf("Service %s %s successfully", names[0], verb)}
+		}
+		return actionCompleteMsg{message: fmt.Sprintf("%s %d services: %s", strings.ToUpper(verb[:1])+verb[1:], len(names), strings.Join(names, ", "))}
+	}
+}
+
+func (m *Model) restartServices(names []string) tea.Cmd {
+	return m.runOnServices(names, "restarted", func(ctx context.Context, name string) error {
+		return m.orch.RestartService(ctx, m.runtime, name)
+	})
+}
+
+func (m *Model) stopSelectedServices(names []string) tea.Cmd {
+	return m.runOnServices(names, "stopped", func(ctx context.Context, name string) error {
+		return m.orch.StopService(ctx, m.runtime, name)
+	})
+}
@@ -10,6 +10,12 @@ type ViewMode int
 const (
 	HomeView ViewMode = iota
 	ServiceLogsView
+	ValuesEditorView
+	ConfigView
+	OperationsView
+	ProgressView
+	PodsView
+	EventsView
 )
 
 // ComponentType identifies the type of component
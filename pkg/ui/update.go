@@ -7,8 +7,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// Update handles all incoming messages and updates the model state
-func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// update handles all incoming messages and updates the model state
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -19,6 +19,32 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.logsInitialized {
 			m.viewport.Width = msg.Width
 			m.viewport.Height = msg.Height - 10
+			if m.wrapLogs {
+				m.viewport.SetContent(m.logContent())
+			}
+		}
+		if m.view == ConfigView {
+			m.configViewport.Width = msg.Width
+			m.configViewport.Height = max(5, msg.Height-10)
+		}
+		if m.view == OperationsView {
+			m.opsViewport.Width = msg.Width
+			m.opsViewport.Height = max(5, msg.Height-10)
+		}
+		if m.view == ProgressView {
+			m.progressViewport.Width = msg.Width
+		}
+		if m.view == PodsView {
+			m.podsViewport.Width = msg.Width
+			m.podsViewport.Height = max(5, msg.Height-10)
+		}
+		if m.view == EventsView {
+			m.eventsViewport.Width = msg.Width
+			m.eventsViewport.Height = max(5, msg.Height-12)
+		}
+		if m.view == ValuesEditorView {
+			m.valuesEditor.SetWidth(msg.Width)
+			m.valuesEditor.SetHeight(max(5, msg.Height-10))
 		}
 		return m, nil
 
@@ -31,46 +57,87 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case statusRefreshMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.error = msg.err
-		} else {
-			m.error = nil
-
+		// Failures show as the unreachable banner rather than an error
+		m.recordRefresh(msg.err)
+		if msg.err == nil {
 			// Sync components from status
 			m.syncComponentsFromStatus(msg.status)
 
 			// Rebuild navigation items when status changes
-			m.navItems = m.buildNavItems()
+			m.refreshNavItems()
 		}
-		m.lastRefresh = time.Now()
 		return m, nil
 
+	case valuesChangedMsg:
+		return m, m.handleValuesChanged(msg)
+
+	case progressMsg:
+		if m.runningOperation() != nil {
+			m.progress = msg.event.String()
+		}
+		m.recordProgress(msg.event)
+		return m, m.waitForProgress()
+
 	case actionCompleteMsg:
-		m.loading = false
-		m.operation = ""
+		m.finishOperation(msg)
+		m.progress = ""
 		m.message = msg.message
 		if msg.err != nil {
 			m.error = msg.err
 		}
+		m.refreshing = true
 		return m, tea.Batch(
 			m.refreshStatus(),
+			m.refreshInsights(),
+			m.loadProvenance(),
 			clearMessageAfter(3*time.Second),
+			m.startNextOperation(),
+		)
+
+	case provenanceMsg:
+		m.provenance = msg.provenance
+		return m, nil
+
+	case insightsMsg:
+		m.insights = msg.insights
+		return m, nil
+
+	case insightsTickMsg:
+		return m, tea.Batch(
+			m.refreshInsights(),
+			insightsTick(),
 		)
 
 	case tickMsg:
+		// Tick every second so the retry countdown stays current; refreshes
+		// themselves run when due
 		return m, tea.Batch(
-			m.refreshStatus(),
-			tickEvery(3*time.Second),
+			m.autoRefresh(),
+			tickEvery(time.Second),
 		)
 
 	case clearMsg:
 		m.message = ""
 		return m, nil
 
+	case valuesLoadedMsg:
+		return m.handleValuesLoadedMsg(msg)
+
 	case logsMsg:
 		return m.handleLogsMsg(msg)
 
+	case podsMsg:
+		return m.handlePodsMsg(msg)
+
+	case podDetailMsg:
+		return m.handlePodDetailMsg(msg)
+
+	case eventsMsg:
+		return m.handleEventsMsg(msg)
+
+	case eventsTickMsg:
+		return m.handleEventsTickMsg(msg)
+
 	case logStreamMsg:
 		return m.handleLogStreamMsg(msg)
 
@@ -78,5 +145,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleLogStreamErrorMsg(msg)
 	}
 
+	// Let focused inputs handle their own messages (cursor blink)
+	if m.view == ValuesEditorView {
+		var cmd tea.Cmd
+		m.valuesEditor, cmd = m.valuesEditor.Update(msg)
+		return m, cmd
+	}
+	if m.navFiltering {
+		var cmd tea.Cmd
+		m.navFilter, cmd = m.navFilter.Update(msg)
+		return m, cmd
+	}
+	if m.logSearching {
+		var cmd tea.Cmd
+		m.logSearch, cmd = m.logSearch.Update(msg)
+		return m, cmd
+	}
+
 	return m, nil
 }
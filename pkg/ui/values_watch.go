@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/config"
+	"plat/pkg/watch"
+)
+
+// Values watching: config.yml, local.yml and values files are watched while
+// the TUI runs. Changes that alter services' resolved values are shown and
+// the affected services upgraded once confirmed with U.
+
+// valuesUpdate is a reloaded config whose values differ from the deployed ones
+type valuesUpdate struct {
+	runtime  *config.RuntimeConfig
+	resolved map[string]map[string]interface{}
+	changes  []config.ServiceValuesChange
+}
+
+// valuesChangedMsg carries the result of reloading the config after its
+// files changed
+type valuesChangedMsg struct {
+	update *valuesUpdate
+	err    error
+}
+
+// watchValues starts watching the files values are read from. The TUI runs
+// without it when the config can't be reloaded.
+func (m *Model) watchValues(ctx context.Context) {
+	if m.reloadConfig == nil {
+		return
+	}
+
+	resolved, err := config.ResolveAllValues(m.runtime)
+	if err != nil {
+		return
+	}
+	watcher, err := watch.NewWatcher(watch.DefaultDebounce)
+	if err != nil {
+		return
+	}
+	for _, path := range m.runtime.ValuesFiles() {
+		if err := watcher.AddFile(path, path); err != nil {
+			return
+		}
+	}
+
+	m.deployedValues = resolved
+	m.valuesChanges = watcher.Run(ctx, nil)
+}
+
+// waitForValuesChange reloads the config once its files change and compares
+// the resolved values with the deployed ones
+func (m *Model) waitForValuesChange() tea.Cmd {
+	if m.valuesChanges == nil {
+		return nil
+	}
+	deployed := m.deployedValues
+	return func() tea.Msg {
+		if _, ok := <-m.valuesChanges; !ok {
+			return nil
+		}
+
+		runtime, err := m.reloadConfig()
+		if err != nil {
+			return valuesChangedMsg{err: err}
+		}
+		resolved, err := config.ResolveAllValues(runtime)
+		if err != nil {
+			return valuesChangedMsg{err: err}
+		}
+		return valuesChangedMsg{update: &valuesUpdate{
+			runtime:  runtime,
+			resolved: resolved,
+			changes:  config.CompareResolvedValues(deployed, resolved),
+		}}
+	}
+}
+
+// handleValuesChanged offers the upgrade of services whose values changed
+func (m *Model) handleValuesChanged(msg valuesChangedMsg) tea.Cmd {
+	switch {
+	case msg.err != nil:
+		m.error = fmt.Errorf("config changed but could not be reloaded: %w", msg.err)
+	case len(msg.update.changes) == 0:
+		m.pendingValues = nil
+	default:
+		m.pendingValues = msg.update
+	}
+	return m.waitForValuesChange()
+}
+
+// upgradeChangedValues switches to the reloaded config and upgrades the
+// services whose values changed
+func (m *Model) upgradeChangedValues() tea.Cmd {
+	update := m.pendingValues
+	if update == nil {
+		return nil
+	}
+	m.pendingValues = nil
+	m.runtime = update.runtime
+	m.deployedValues = update.resolved
+
+	names := make([]string, len(update.changes))
+	for i, change := range update.changes {
+		names[i] = change.Service
+	}
+
+	label := fmt.Sprintf("Upgrading %s with changed values", strings.Join(names, ", "))
+	return m.enqueue(label, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		for _, name := range names {
+			if err := m.orch.StartService(ctx, update.runtime, name); err != nil {
+				return actionCompleteMsg{err: err}
+			}
+		}
+		return actionCompleteMsg{message: fmt.Sprintf("Upgraded %s", strings.Join(names, ", "))}
+	})
+}
+
+// valuesBanner tells which services' values changed on disk
+func (m *Model) valuesBanner() string {
+	if m.pendingValues == nil {
+		return ""
+	}
+	names := make([]string, len(m.pendingValues.changes))
+	for i, change := range m.pendingValues.changes {
+		names[i] = change.Service
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("values changed: %s (U to upgrade)", strings.Join(names, ", "))
+}
+
+// renderPendingValues lists a service's changed keys awaiting an upgrade
+func (m *Model) renderPendingValues(serviceName string) string {
+	if m.pendingValues == nil {
+		return ""
+	}
+
+	for _, change := range m.pendingValues.changes {
+		if change.Service != serviceName {
+			continue
+		}
+
+		var b strings.Builder
+		b.WriteString("\n")
+		b.WriteString(sectionStyle.Render("Changed Values (U to upgrade)"))
+		b.WriteString("\n\n")
+		for _, value := range change.Changes {
+			switch {
+			case value.Old == nil:
+				b.WriteString(successStyle.Render(fmt.Sprintf("+ %s: %v", value.Key, value.New)))
+			case value.New == nil:
+				b.WriteString(errorStyle.Render(fmt.Sprintf("- %s: %v", value.Key, value.Old)))
+			default:
+				b.WriteString(fmt.Sprintf("~ %s: %v → %v", value.Key, value.Old, value.New))
+			}
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+	return ""
+}
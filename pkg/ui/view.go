@@ -1,18 +1,31 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
-// View renders the current view based on the model state
-func (m *Model) View() string {
+// render renders the current view based on the model state
+func (m *Model) render() string {
 	switch m.view {
 	case HomeView:
 		return m.renderHomeView()
 	case ServiceLogsView:
 		return m.renderLogsView()
+	case ValuesEditorView:
+		return m.renderValuesView()
+	case ConfigView:
+		return m.renderConfigView()
+	case OperationsView:
+		return m.renderOperationsView()
+	case ProgressView:
+		return m.renderProgressView()
+	case PodsView:
+		return m.renderPodsView()
+	case EventsView:
+		return m.renderEventsView()
 	default:
 		return "Unknown view"
 	}
@@ -22,15 +35,26 @@ func (m *Model) renderHeader() string {
 	title := headerStyle.Render("🎯 Local Cluster")
 
 	var status string
-	if m.loading && m.operation != "" {
+	if op := m.runningOperation(); op != nil {
 		// Show active operation with spinner
-		status = activeStyle.Render(m.spinner.View() + " " + m.operation + "...")
+		status = activeStyle.Render(m.spinner.View() + " " + op.label + "...")
+		if queued := m.queuedOperations(); queued > 0 {
+			status += " " + dimStyle.Render(fmt.Sprintf("(+%d queued)", queued))
+		}
+		if m.progress != "" {
+			status += " " + dimStyle.Render(m.progress)
+		}
 	} else if m.message != "" {
 		// Show success message
 		status = successStyle.Render("✓ " + m.message)
 	} else if m.error != nil {
 		// Show error
 		status = errorStyle.Render("✗ " + m.error.Error())
+	} else if banner := m.unreachableBanner(); banner != "" {
+		// Show failing refreshes without flashing each error
+		status = badgeStyle.Render("⚠ " + banner)
+	} else if banner := m.valuesBanner(); banner != "" {
+		status = badgeStyle.Render("✎ " + banner)
 	}
 
 	// Pad to fill width
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/quick"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/config"
+)
+
+// Config view: read-only config.yml and local.yml with syntax highlighting,
+// followed by the resolved per-service configuration
+
+func (m *Model) renderConfigView() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	b.WriteString(sectionStyle.Render("⚙️  Configuration"))
+	b.WriteString(" ")
+	b.WriteString(dimStyle.Render(fmt.Sprintf("%3.f%%", m.configViewport.ScrollPercent()*100)))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Use ↑/↓ to scroll • c/ESC to go back"))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.configViewport.View())
+
+	// Footer
+	b.WriteString("\n\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+// Config view key handling
+func (m *Model) handleConfigKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Config):
+		m.view = HomeView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.configViewport.ScrollUp(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.configViewport.ScrollDown(1)
+		return m, nil
+	}
+
+	// Page up/down and other viewport bindings
+	var cmd tea.Cmd
+	m.configViewport, cmd = m.configViewport.Update(msg)
+	return m, cmd
+}
+
+// openConfigView renders the configuration into the config viewport
+func (m *Model) openConfigView() {
+	m.configViewport = viewport.New(m.width, max(5, m.height-10))
+	m.configViewport.SetContent(m.buildConfigContent())
+	m.view = ConfigView
+}
+
+// buildConfigContent renders the config files and the resolved services
+func (m *Model) buildConfigContent() string {
+	var b strings.Builder
+
+	configFile := m.runtime.ConfigFile
+	b.WriteString(sectionStyle.Render(configFile))
+	b.WriteString("\n\n")
+	b.WriteString(highlightFile(configFile))
+	b.WriteString("\n")
+
+	localFile := ""
+	for _, name := range []string{"local.yml", "local.yaml"} {
+		path := filepath.Join(m.runtime.ConfigDir(), name)
+		if _, err := os.Stat(path); err == nil {
+			localFile = path
+			break
+		}
+	}
+
+	if localFile != "" {
+		b.WriteString(sectionStyle.Render(localFile))
+		b.WriteString("\n\n")
+		b.WriteString(highlightFile(localFile))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(dimStyle.Render("No local.yml (local sources and personal settings)"))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(sectionStyle.Render(fmt.Sprintf("Resolved services (%s mode)", m.runtime.Mode)))
+	b.WriteString("\n")
+	for _, service := range m.runtime.OrderedServices() {
+		b.WriteString("\n")
+		b.WriteString(formatResolvedService(service))
+	}
+
+	return b.String()
+}
+
+// formatResolvedService summarizes what plat will deploy for a service
+func formatResolvedService(service *config.ResolvedService) string {
+	var b strings.Builder
+
+	b.WriteString(activeStyle.Render(service.Name))
+	b.WriteString("\n")
+
+	field := func(label, value string) {
+		if value != "" {
+			b.WriteString(fmt.Sprintf("  %s %s\n", dimStyle.Render(label+":"), value))
+		}
+	}
+
+	field("Display name", service.DisplayName)
+	field("Aliases", strings.Join(service.Aliases, ", "))
+	field("Version", service.Version)
+	switch service.Engine() {
+	case config.EngineManifests:
+		field("Manifests", service.Manifests)
+	case config.EngineKustomize:
+		field("Kustomize", service.Kustomize)
+	default:
+		chart := service.Chart.Name
+		if service.Chart.Repository != "" {
+			chart += " (" + service.Chart.Repository + ")"
+		}
+		if service.Chart.Version != "" {
+			chart += " " + service.Chart.Version
+		}
+		field("Chart", chart)
+	}
+
+	if service.IsLocal && service.LocalSource != nil {
+		field("Source", "local "+service.LocalSource.GetPath())
+	}
+	field("Values file", service.ValuesFile)
+	if len(service.Values) > 0 {
+		field("Values", fmt.Sprintf("%d top-level key(s)", len(service.Values)))
+	}
+	if len(service.Ports) > 0 {
+		field("Ports", fmt.Sprint(service.Ports))
+	}
+	if len(service.Dependencies) > 0 {
+		field("Depends on", strings.Join(service.Dependencies, ", "))
+		if len(service.Inferred) > 0 {
+			field("Inferred", strings.Join(service.Inferred, ", ")+" (from environment)")
+		}
+	}
+	if len(service.Patches) > 0 {
+		field("Patches", fmt.Sprintf("%d", len(service.Patches)))
+	}
+	if service.Protected {
+		field("Protected", "yes")
+	}
+	if service.DeletesData() {
+		field("Data", "deleted on down")
+	}
+
+	return b.String()
+}
+
+// highlightFile returns the file's content with terminal syntax highlighting.
+// The lexer is picked from the file name; unknown types fall back to YAML.
+func highlightFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errorStyle.Render(fmt.Sprintf("Failed to read %s: %v", path, err))
+	}
+
+	lexer := "yaml"
+	switch filepath.Ext(path) {
+	case ".cue":
+		lexer = "cue"
+	case ".jsonnet":
+		lexer = "jsonnet"
+	}
+
+	var out bytes.Buffer
+	if err := quick.Highlight(&out, string(data), lexer, "terminal256", "monokai"); err != nil {
+		return string(data)
+	}
+	return out.String()
+}
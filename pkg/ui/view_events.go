@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/tools"
+)
+
+// Events view: the Kubernetes events of the environment's namespace, like
+// 'kubectl get events --watch', with warnings highlighted and summarized so
+// a stuck deployment's cause is visible without leaving plat.
+
+// eventsRefreshInterval is how often the open events view reloads
+const eventsRefreshInterval = 3 * time.Second
+
+// openEventsView shows the namespace's events and keeps them current
+func (m *Model) openEventsView() tea.Cmd {
+	m.kubeEvents = nil
+	m.eventsErr = nil
+	m.eventsLoaded = false
+	m.eventsGeneration++
+	m.eventsViewport = viewport.New(m.width, max(5, m.height-12))
+	m.view = EventsView
+	return tea.Batch(m.fetchEvents(), eventsTick(m.eventsGeneration))
+}
+
+// fetchEvents loads the namespace's events
+func (m *Model) fetchEvents() tea.Cmd {
+	return func() tea.Msg {
+		if m.demo != nil {
+			return eventsMsg{events: m.demo.Events()}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		events, err := tools.ListEvents(ctx, m.runtime.Base.Defaults.Namespace)
+		return eventsMsg{events: events, err: err}
+	}
+}
+
+// eventsTick schedules the next reload of the events view opened as
+// generation
+func eventsTick(generation int) tea.Cmd {
+	return tea.Tick(eventsRefreshInterval, func(time.Time) tea.Msg {
+		return eventsTickMsg{generation: generation}
+	})
+}
+
+func (m *Model) handleEventsMsg(msg eventsMsg) (tea.Model, tea.Cmd) {
+	m.eventsErr = msg.err
+	if msg.err != nil {
+		return m, nil
+	}
+	m.kubeEvents = msg.events
+	m.updateEventsContent()
+	return m, nil
+}
+
+func (m *Model) handleEventsTickMsg(msg eventsTickMsg) (tea.Model, tea.Cmd) {
+	// Ticks stop once the view is closed or reopened
+	if m.view != EventsView || msg.generation != m.eventsGeneration {
+		return m, nil
+	}
+	return m, tea.Batch(m.fetchEvents(), eventsTick(msg.generation))
+}
+
+func (m *Model) handleEventsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.view = HomeView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Refresh):
+		return m, m.fetchEvents()
+
+	case key.Matches(msg, m.keys.WarningsOnly):
+		m.eventsWarningsOnly = !m.eventsWarningsOnly
+		m.updateEventsContent()
+		m.eventsViewport.GotoBottom()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.eventsViewport.ScrollUp(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.eventsViewport.ScrollDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.eventsViewport, cmd = m.eventsViewport.Update(msg)
+	return m, cmd
+}
+
+// updateEventsContent re-renders the events into the viewport, staying at
+// the newest events unless the user scrolled up
+func (m *Model) updateEventsContent() {
+	follow := !m.eventsLoaded || m.eventsViewport.AtBottom()
+	m.eventsLoaded = true
+	m.eventsViewport.SetContent(m.buildEventsContent())
+	if follow {
+		m.eventsViewport.GotoBottom()
+	}
+}
+
+func (m *Model) renderEventsView() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	title := fmt.Sprintf("📰 Events: %s", m.runtime.Base.Defaults.Namespace)
+	if m.eventsWarningsOnly {
+		title += " (warnings only)"
+	}
+	b.WriteString(sectionStyle.Render(title))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Use ↑/↓ to scroll • w for warnings only • r to refresh • ESC to go back"))
+	b.WriteString("\n")
+	b.WriteString(m.renderWarningSummary())
+	b.WriteString("\n\n")
+
+	if !m.eventsLoaded && m.eventsErr == nil {
+		b.WriteString(dimStyle.Render("Loading events..."))
+	} else {
+		b.WriteString(m.eventsViewport.View())
+	}
+
+	if m.eventsErr != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.eventsErr.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+// renderWarningSummary counts the warnings by reason, most frequent first,
+// e.g. "⚠ 9 warnings: BackOff ×7, FailedScheduling ×2"
+func (m *Model) renderWarningSummary() string {
+	counts := make(map[string]int)
+	total := 0
+	for _, event := range m.kubeEvents {
+		if event.Type == "Warning" {
+			counts[event.Reason] += max(1, event.Count)
+			total += max(1, event.Count)
+		}
+	}
+	if total == 0 {
+		return successStyle.Render("✓ No warnings")
+	}
+
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if counts[reasons[i]] != counts[reasons[j]] {
+			return counts[reasons[i]] > counts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%s ×%d", reason, counts[reason])
+	}
+	return badgeStyle.Render(fmt.Sprintf("⚠ %d warning(s): %s", total, strings.Join(parts, ", ")))
+}
+
+// buildEventsContent renders the events as a table, oldest first, warnings
+// highlighted
+func (m *Model) buildEventsContent() string {
+	var events []tools.EventInfo
+	for _, event := range m.kubeEvents {
+		if !m.eventsWarningsOnly || event.Type == "Warning" {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		if m.eventsWarningsOnly {
+			return dimStyle.Render("No warning events")
+		}
+		return dimStyle.Render("No events in this namespace")
+	}
+
+	reasonWidth, objectWidth := len("REASON"), len("OBJECT")
+	for _, event := range events {
+		reasonWidth = max(reasonWidth, len(event.Reason))
+		objectWidth = max(objectWidth, len(event.Object))
+	}
+
+	var b strings.Builder
+	b.WriteString(dimStyle.Render(fmt.Sprintf("  %9s  %-7s  %-*s  %-*s  %s", "LAST SEEN", "TYPE", reasonWidth, "REASON", objectWidth, "OBJECT", "MESSAGE")))
+	b.WriteString("\n")
+	for _, event := range events {
+		line := fmt.Sprintf("  %5s ago  %-7s  %-*s  %-*s  %s", formatPodAge(time.Since(event.LastSeen)), event.Type, reasonWidth, event.Reason, objectWidth, event.Object, event.Message)
+		if event.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", event.Count)
+		}
+		if event.Type == "Warning" {
+			b.WriteString(badgeStyle.Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -9,8 +9,6 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-
-	"plat/pkg/orchestrator"
 )
 
 func (m *Model) renderHomeView() string {
@@ -77,76 +75,130 @@ func (m *Model) handleHomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Refresh - works everywhere
 	case key.Matches(msg, m.keys.Refresh):
-		m.loading = true
+		// A manual refresh retries right away; success ends any backoff
+		m.refreshing = true
 		return m, m.refreshStatus()
 
+	// Filtering and sorting the nav panel
+	case key.Matches(msg, m.keys.Filter):
+		return m, m.startNavFilter()
+
+	case key.Matches(msg, m.keys.Sort):
+		m.cycleNavSort()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Mark):
+		m.toggleMark()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Back):
+		// Clear marks first, then the filter
+		if !m.clearMarks() {
+			m.clearNavFilter()
+		}
+		return m, nil
+
+	// Operation queue and history - works everywhere
+	case key.Matches(msg, m.keys.Operations):
+		m.openOperationsView()
+		return m, nil
+
+	// Progress of the latest up or down
+	case key.Matches(msg, m.keys.Progress):
+		m.openProgressView()
+		return m, nil
+
+	// Config viewer - works everywhere
+	case key.Matches(msg, m.keys.Config):
+		m.openConfigView()
+		return m, nil
+
 	// Cluster-specific actions (only work when cluster is selected)
 	case key.Matches(msg, m.keys.Start):
 		if item != nil && item.Type == NavItemCluster {
-			m.loading = true
-			m.operation = "Starting environment"
-			m.message = ""
-			m.error = nil
-			return m, m.startEnvironment()
+			cmd := m.enqueue("Starting environment", m.startEnvironment())
+			m.startProgress("Starting environment")
+			return m, cmd
 		}
 		return m, nil
 
 	case key.Matches(msg, m.keys.Stop):
 		if item != nil && item.Type == NavItemCluster {
-			m.loading = true
-			m.operation = "Stopping services"
-			m.message = ""
-			m.error = nil
-			return m, m.stopServices(false)
+			cmd := m.enqueue("Stopping services", m.stopServices(false))
+			m.startProgress("Stopping services")
+			return m, cmd
 		}
 		return m, nil
 
 	case key.Matches(msg, m.keys.StopAll):
 		if item != nil && item.Type == NavItemCluster {
-			m.loading = true
-			m.operation = "Stopping services and deleting cluster"
-			m.message = ""
-			m.error = nil
-			return m, m.stopServices(true)
+			cmd := m.enqueue("Stopping services and deleting cluster", m.stopServices(true))
+			m.startProgress("Stopping services and deleting cluster")
+			return m, cmd
 		}
 		return m, nil
 
 	// Service-specific actions (only work when service is selected)
 	case key.Matches(msg, m.keys.Logs):
-		if item != nil && item.Type == NavItemService {
-			m.logService = item.ServiceName
+		if targets := m.actionTargets(); len(targets) > 0 {
+			m.logService = strings.Join(targets, ", ")
 			m.view = ServiceLogsView
-			return m, m.fetchLogs(item.ServiceName)
+			return m, m.fetchLogs(targets)
 		}
 		return m, nil
 
-	case key.Matches(msg, m.keys.StartService):
+	case key.Matches(msg, m.keys.InspectPods):
 		if item != nil && item.Type == NavItemService {
-			m.loading = true
-			m.operation = fmt.Sprintf("Starting service: %s", item.ServiceName)
-			m.message = ""
-			m.error = nil
-			return m, m.startService(item.ServiceName)
+			return m, m.openPodsView(item.ServiceName)
 		}
 		return m, nil
 
-	case key.Matches(msg, m.keys.StopService):
+	// Works with any selection: it shows every deployed service
+	case key.Matches(msg, m.keys.AllLogs):
+		return m, m.openAllServiceLogs()
+
+	// Works with any selection: events cover the whole namespace
+	case key.Matches(msg, m.keys.Events):
+		return m, m.openEventsView()
+
+	case key.Matches(msg, m.keys.EditValues):
 		if item != nil && item.Type == NavItemService {
-			m.loading = true
-			m.operation = fmt.Sprintf("Stopping service: %s", item.ServiceName)
 			m.message = ""
 			m.error = nil
-			return m, m.stopService(item.ServiceName)
+			return m, m.loadServiceValues(item.ServiceName)
 		}
 		return m, nil
 
-	case key.Matches(msg, m.keys.RestartService):
+	// Works with any selection: it upgrades every service whose values changed
+	case key.Matches(msg, m.keys.UpgradeValues):
+		return m, m.upgradeChangedValues()
+
+	case key.Matches(msg, m.keys.CopyDNSName):
 		if item != nil && item.Type == NavItemService {
-			m.loading = true
-			m.operation = fmt.Sprintf("Restarting service: %s", item.ServiceName)
-			m.message = ""
-			m.error = nil
-			return m, m.restartService(item.ServiceName)
+			if service, ok := m.runtime.ResolvedServices[item.ServiceName]; ok {
+				name := m.runtime.ServiceDNSName(service)
+				copyToClipboard(name)
+				m.message = fmt.Sprintf("Copied %s", name)
+				return m, clearMessageAfter(3 * time.Second)
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.StartService):
+		if item != nil && item.Type == NavItemService {
+			return m, m.enqueue(fmt.Sprintf("Starting service: %s", item.ServiceName), m.startService(item.ServiceName))
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.StopService):
+		if targets := m.actionTargets(); len(targets) > 0 {
+			return m, m.enqueue(fmt.Sprintf("Stopping %s", describeTargets(targets)), m.stopSelectedServices(targets))
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.RestartService):
+		if targets := m.actionTargets(); len(targets) > 0 {
+			return m, m.enqueue(fmt.Sprintf("Restarting %s", describeTargets(targets)), m.restartServices(targets))
 		}
 		return m, nil
 	}
@@ -161,15 +213,7 @@ func (m *Model) refreshStatus() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		var status *orchestrator.EnvironmentStatus
-		var err error
-
-		// Suppress output during status check
-		suppressOutput(func() error {
-			status, err = m.orch.Status(ctx, m.runtime)
-			return nil
-		})
-
+		status, err := m.orch.Status(ctx, m.runtime)
 		return statusRefreshMsg{status: status, err: err}
 	}
 }
@@ -179,12 +223,7 @@ func (m *Model) startEnvironment() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 		defer cancel()
 
-		var err error
-		suppressOutput(func() error {
-			err = m.orch.Up(ctx, m.runtime)
-			return nil
-		})
-
+		err := m.orch.Up(ctx, m.runtime)
 		if err != nil {
 			return actionCompleteMsg{err: err}
 		}
@@ -198,12 +237,7 @@ func (m *Model) stopServices(deleteCluster bool) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
-		var err error
-		suppressOutput(func() error {
-			err = m.orch.Down(ctx, m.runtime, deleteCluster)
-			return nil
-		})
-
+		err := m.orch.Down(ctx, m.runtime, deleteCluster)
 		if err != nil {
 			return actionCompleteMsg{err: err}
 		}
@@ -222,12 +256,7 @@ func (m *Model) startService(serviceName string) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
-		var err error
-		suppressOutput(func() error {
-			err = m.orch.StartService(ctx, m.runtime, serviceName)
-			return nil
-		})
-
+		err := m.orch.StartService(ctx, m.runtime, serviceName)
 		if err != nil {
 			return actionCompleteMsg{err: err}
 		}
@@ -235,41 +264,3 @@ func (m *Model) startService(serviceName string) tea.Cmd {
 		return actionCompleteMsg{message: fmt.Sprintf("Service %s started successfully", serviceName)}
 	}
 }
-
-func (m *Model) stopService(serviceName string) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
-
-		var err error
-		suppressOutput(func() error {
-			err = m.orch.StopService(ctx, m.runtime, serviceName)
-			return nil
-		})
-
-		if err != nil {
-			return actionCompleteMsg{err: err}
-		}
-
-		return actionCompleteMsg{message: fmt.Sprintf("Service %s stopped successfully", serviceName)}
-	}
-}
-
-func (m *Model) restartService(serviceName string) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
-
-		var err error
-		suppressOutput(func() error {
-			err = m.orch.RestartService(ctx, m.runtime, serviceName)
-			return nil
-		})
-
-		if err != nil {
-			return actionCompleteMsg{err: err}
-		}
-
-		return actionCompleteMsg{message: fmt.Sprintf("Service %s restarted successfully", serviceName)}
-	}
-}
@@ -2,14 +2,16 @@ package ui
 
 import (
 	"bufio"
-	"bytes"
+	"context"
 	"fmt"
 	"io"
-	"os/exec"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"plat/pkg/tools"
 )
 
 // Logs view rendering and logic
@@ -39,6 +41,10 @@ func (m *Model) renderLogs() string {
 	if m.logStreaming {
 		title += " " + successStyle.Render("● streaming")
 	}
+	if dropped := m.rawLogs.Dropped(); dropped > 0 {
+		// Older lines fell out of the buffer; logs.maxLines in local.yml keeps more
+		title += " " + dimStyle.Render(fmt.Sprintf("(%d older lines truncated, showing last %d)", dropped, m.rawLogs.Len()))
+	}
 	b.WriteString(title)
 	b.WriteString("\n")
 
@@ -54,12 +60,31 @@ func (m *Model) renderLogs() string {
 	} else {
 		toggleInfo = append(toggleInfo, "pod names: off")
 	}
+	if m.showColors {
+		toggleInfo = append(toggleInfo, "colors: on")
+	} else {
+		toggleInfo = append(toggleInfo, "colors: off")
+	}
+	if m.wrapLogs {
+		toggleInfo = append(toggleInfo, "wrap: on")
+	} else {
+		toggleInfo = append(toggleInfo, "wrap: off")
+	}
 
-	b.WriteString(dimStyle.Render(fmt.Sprintf("Use ↑/↓ to scroll • t/p to toggle %s • l/ESC to go back", strings.Join(toggleInfo, " • "))))
-	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render(fmt.Sprintf("Use ↑/↓ to scroll • t/p/a/w to toggle %s • l/ESC to go back", strings.Join(toggleInfo, " • "))))
+	b.WriteString("\n")
+	if m.logAllServices {
+		b.WriteString(m.renderLogLegend())
+		b.WriteString("\n")
+	}
+	if search := m.renderLogSearch(); search != "" {
+		b.WriteString(search)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Show viewport if logs are loaded
-	if m.logsInitialized && len(m.logs) > 0 {
+	if m.logsInitialized && m.logs.Len() > 0 {
 		b.WriteString(m.viewport.View())
 
 		// Show indicator if user is scrolled and there are unseen logs
@@ -71,7 +96,9 @@ func (m *Model) renderLogs() string {
 			}
 			b.WriteString(activeStyle.Render(indicator + " (scroll down to see)"))
 		}
-	} else if len(m.logs) == 0 {
+	} else if m.logs.Len() == 0 && m.logFilterOnly && m.rawLogs.Len() > 0 {
+		b.WriteString(dimStyle.Render("No lines match the search"))
+	} else if m.logs.Len() == 0 {
 		b.WriteString(dimStyle.Render("No logs available"))
 	} else {
 		b.WriteString(fmt.Sprintf("%s Loading logs...", m.spinner.View()))
@@ -84,14 +111,45 @@ func (m *Model) renderLogs() string {
 
 func (m *Model) handleLogsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
-	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Logs):
+	case key.Matches(msg, m.keys.Back) && m.logPattern != nil:
+		// ESC clears the search before leaving the view
+		m.setLogPattern(nil)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Search):
+		return m, m.startLogSearch()
+
+	case key.Matches(msg, m.keys.NextMatch):
+		m.jumpToLogMatch(true)
+		return m, nil
+
+	case key.Matches(msg, m.keys.PrevMatch):
+		m.jumpToLogMatch(false)
+		return m, nil
+
+	case key.Matches(msg, m.keys.FilterMatches):
+		m.toggleLogFilter()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Logs), key.Matches(msg, m.keys.AllLogs):
 		// Stop streaming and go back to home (ESC or L key to toggle)
 		m.stopLogStream()
 		m.view = HomeView
-		m.logs = nil
-		m.rawLogs = nil
+		m.logs.Reset()
+		m.rawLogs.Reset()
 		m.logsInitialized = false
 		m.unseenLogCount = 0
+		m.logAllServices = false
+		m.logSources = nil
+		m.setLogPattern(nil)
+		return m, nil
+
+	case m.logAllServices && key.Matches(msg, m.keys.ToggleSource):
+		m.toggleLogSource(int(msg.Runes[0] - '0'))
+		return m, nil
+
+	case m.logAllServices && key.Matches(msg, m.keys.ShowAllSources):
+		m.showAllLogSources()
 		return m, nil
 
 	case key.Matches(msg, m.keys.Up):
@@ -122,6 +180,16 @@ func (m *Model) handleLogsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.showPodNames = !m.showPodNames
 		m.updateLogDisplay()
 		return m, nil
+
+	case key.Matches(msg, m.keys.ToggleColors):
+		m.showColors = !m.showColors
+		m.updateLogDisplay()
+		return m, nil
+
+	case key.Matches(msg, m.keys.ToggleWrap):
+		m.wrapLogs = !m.wrapLogs
+		m.updateLogDisplay()
+		return m, nil
 	}
 
 	return m, nil
@@ -136,8 +204,11 @@ func (m *Model) handleLogsMsg(msg logsMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	m.rawLogs = msg.logs // Store original logs
-	m.logService = msg.service
+	m.rawLogs.Reset() // Store original logs
+	m.rawLogs.Add(msg.logs...)
+	if !m.logAllServices {
+		m.logService = strings.Join(msg.services, ", ")
+	}
 	m.unseenLogCount = 0   // Reset counter for new log view
 	m.userScrolled = false // Start at bottom, not scrolled
 
@@ -153,42 +224,55 @@ func (m *Model) handleLogsMsg(msg logsMsg) (tea.Model, tea.Cmd) {
 	m.viewport.GotoBottom()
 
 	// Start streaming logs
-	cmd, reader, err := m.startLogStream(msg.service)
+	reader, err := m.startLogStream(msg.services)
 	if err != nil {
 		// If streaming fails, just show the initial logs
 		m.error = err
 		return m, nil
 	}
 
-	m.logStreamCmd = cmd
 	m.logStreamReader = reader
-	m.logBufioReader = bufio.NewReader(reader)
+	m.logReader = newLogReader(reader)
 	m.logStreaming = true
 
-	// Start waiting for the first log line
-	return m, m.waitForLogLine()
+	// Start waiting for the first batch of lines
+	return m, m.logReader.nextBatch()
 }
 
 func (m *Model) handleLogStreamMsg(msg logStreamMsg) (tea.Model, tea.Cmd) {
-	// Append new log line to raw logs
-	m.rawLogs = append(m.rawLogs, msg.line)
+	// Ignore batches from a stream that was stopped since
+	if msg.reader != m.logReader {
+		return m, nil
+	}
 
-	// Update the display with the new line
-	m.updateLogDisplay()
+	// Filter only the new lines; the rest of the display is unchanged
+	m.rawLogs.Add(msg.lines...)
+	added := 0
+	for _, line := range msg.lines {
+		if display, ok := m.filterLogLine(line); ok {
+			m.logs.Add(display)
+			added++
+		}
+	}
+	m.viewport.SetContent(m.logContent())
 
 	// Auto-scroll to bottom if user hasn't scrolled up
 	if !m.userScrolled {
 		m.viewport.GotoBottom()
 	} else {
-		// Increment unseen log counter when user has scrolled up
-		m.unseenLogCount++
+		// Count unseen lines while user has scrolled up
+		m.unseenLogCount += added
 	}
 
-	// Wait for the next line
-	return m, m.waitForLogLine()
+	// Wait for the next batch
+	return m, m.logReader.nextBatch()
 }
 
 func (m *Model) handleLogStreamErrorMsg(msg logStreamErrorMsg) (tea.Model, tea.Cmd) {
+	if msg.reader != m.logReader {
+		return m, nil
+	}
+
 	// Stream ended or error occurred
 	m.stopLogStream()
 
@@ -202,38 +286,25 @@ func (m *Model) handleLogStreamErrorMsg(msg logStreamErrorMsg) (tea.Model, tea.C
 
 // Logs commands
 
-func (m *Model) fetchLogs(serviceName string) tea.Cmd {
+func (m *Model) fetchLogs(services []string) tea.Cmd {
 	return func() tea.Msg {
-		// Build kubectl command to get initial logs
-		namespace := m.runtime.Base.Defaults.Namespace
-		selector := fmt.Sprintf("app.kubernetes.io/instance=%s", serviceName)
-
-		cmd := exec.Command("kubectl", "logs",
-			"-l", selector,
-			"-n", namespace,
-			"--tail=100",
-			"--timestamps")
-
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+		if m.demo != nil {
+			return logsMsg{services: services, logs: m.demo.Logs(services, 100)}
+		}
 
-		err := cmd.Run()
+		reader, err := tools.StreamLogs(context.Background(), m.runtime.Base.Defaults.Namespace,
+			logsSelector(services), m.logOptions(services, false))
 		if err != nil {
-			errorMsg := stderr.String()
-			if errorMsg == "" {
-				errorMsg = err.Error()
-			}
 			return logsMsg{
-				service: serviceName,
-				err:     fmt.Errorf("failed to get logs: %s", errorMsg),
+				services: services,
+				err:      fmt.Errorf("failed to get logs: %w", err),
 			}
 		}
+		defer reader.Close()
 
 		// Split logs into lines
-		output := stdout.String()
 		var logs []string
-		scanner := bufio.NewScanner(strings.NewReader(output))
+		scanner := bufio.NewScanner(reader)
 		for scanner.Scan() {
 			logs = append(logs, scanner.Text())
 		}
@@ -243,119 +314,123 @@ func (m *Model) fetchLogs(serviceName string) tea.Cmd {
 		}
 
 		return logsMsg{
-			service: serviceName,
-			logs:    logs,
+			services: services,
+			logs:     logs,
 		}
 	}
 }
 
-// startLogStream initializes the kubectl log stream process
-func (m *Model) startLogStream(serviceName string) (*exec.Cmd, io.ReadCloser, error) {
-	namespace := m.runtime.Base.Defaults.Namespace
-	selector := fmt.Sprintf("app.kubernetes.io/instance=%s", serviceName)
-
-	cmd := exec.Command("kubectl", "logs",
-		"-l", selector,
-		"-n", namespace,
-		"--follow",
-		"--timestamps")
+// startLogStream opens a follow stream of the services' logs
+func (m *Model) startLogStream(services []string) (io.ReadCloser, error) {
+	if m.logAllServices {
+		return m.startAllLogStreams(services)
+	}
+	if m.demo != nil {
+		return m.demo.StreamLogs(services), nil
+	}
 
-	// Get stdout pipe
-	stdout, err := cmd.StdoutPipe()
+	reader, err := tools.StreamLogs(context.Background(), m.runtime.Base.Defaults.Namespace,
+		logsSelector(services), m.logOptions(services, true))
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+		return nil, fmt.Errorf("failed to start log stream: %w", err)
 	}
+	return reader, nil
+}
 
-	// Start the command
-	if err := cmd.Start(); err != nil {
-		return nil, nil, fmt.Errorf("failed to start log stream: %w", err)
+// logsSelector selects the pods of one or more services
+func logsSelector(services []string) string {
+	if len(services) > 1 {
+		return fmt.Sprintf("app.kubernetes.io/instance in (%s)", strings.Join(services, ","))
 	}
-
-	return cmd, stdout, nil
+	return fmt.Sprintf("app.kubernetes.io/instance=%s", services[0])
 }
 
-// waitForLogLine reads a single line from the stream using the buffered reader
-func (m *Model) waitForLogLine() tea.Cmd {
-	return func() tea.Msg {
-		if m.logBufioReader == nil {
-			return logStreamErrorMsg{err: io.EOF}
-		}
-
-		// Read one line from the buffered reader
-		line, err := m.logBufioReader.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				return logStreamErrorMsg{err: io.EOF}
-			}
-			return logStreamErrorMsg{err: err}
-		}
-
-		// Trim both \n and \r in one pass using strings.TrimRight
-		line = strings.TrimRight(line, "\r\n")
-
-		return logStreamMsg{line: line}
+// logOptions returns the log options for one or more services. The initial
+// fetch shows the last 100 lines; the follow stream only new ones. Combined
+// logs are prefixed with the pod they came from.
+func (m *Model) logOptions(services []string, follow bool) tools.LogOptions {
+	opts := tools.LogOptions{
+		Follow:     follow,
+		TailLines:  100,
+		Timestamps: true,
+		Prefix:     len(services) > 1,
+	}
+	if follow {
+		opts.TailLines = 0
 	}
+	return opts
 }
 
 // stopLogStream stops the running log stream
 func (m *Model) stopLogStream() {
-	if m.logStreamCmd != nil && m.logStreamCmd.Process != nil {
-		m.logStreamCmd.Process.Kill()
-		m.logStreamCmd = nil
-	}
 	if m.logStreamReader != nil {
 		m.logStreamReader.Close()
 		m.logStreamReader = nil
 	}
-	m.logBufioReader = nil
+	if m.logReader != nil {
+		m.logReader.stop()
+		m.logReader = nil
+	}
 	m.logStreaming = false
 }
 
-// updateLogDisplay reprocesses raw logs based on toggle states
+// updateLogDisplay reprocesses all raw logs based on toggle states
 func (m *Model) updateLogDisplay() {
-	if !m.logsInitialized || len(m.rawLogs) == 0 {
+	if !m.logsInitialized || m.rawLogs.Len() == 0 {
 		return
 	}
 
-	// Process rawLogs based on showTimestamps and showPodNames
-	filtered := make([]string, 0, len(m.rawLogs))
-	for _, line := range m.rawLogs {
-		processed := line
-
-		// Strip timestamp if disabled (kubectl --timestamps format: "2025-10-19T18:31:10.831Z message")
-		if !m.showTimestamps {
-			// Find first space after timestamp (timestamps are ISO8601 format)
-			if len(processed) > 20 && processed[10] == 'T' {
-				// Look for space after timestamp
-				if idx := strings.Index(processed, " "); idx != -1 {
-					processed = processed[idx+1:]
-				}
-			}
+	m.logs.Reset()
+	m.rawLogs.Each(func(line string) {
+		if display, ok := m.filterLogLine(line); ok {
+			m.logs.Add(display)
 		}
+	})
+	m.viewport.SetContent(m.logContent())
+}
 
-		// Strip pod name if disabled (kubectl multi-pod format: "[pod-name] message" or "pod-name message")
-		if !m.showPodNames {
-			// Check for bracket format first
-			if strings.HasPrefix(processed, "[") {
-				if idx := strings.Index(processed, "] "); idx != -1 {
-					processed = processed[idx+2:]
-				}
-			} else {
-				// Some logs may have "pod-name " prefix without brackets
-				// Only strip if it looks like a pod name (contains alphanumeric and dashes)
-				parts := strings.SplitN(processed, " ", 2)
-				if len(parts) == 2 {
-					// Check if first part looks like a pod name (contains dash and alphanumeric)
-					if strings.Contains(parts[0], "-") && len(parts[0]) > 5 {
-						processed = parts[1]
-					}
-				}
-			}
+// logContent returns the displayed logs as viewport content, wrapped to the
+// viewport width when wrapping is on
+func (m *Model) logContent() string {
+	if !m.wrapLogs || m.viewport.Width <= 0 {
+		return m.logs.Join("\n")
+	}
+
+	// Wrap by display cells, so wide characters and escape sequences
+	// don't throw off line widths
+	var b strings.Builder
+	first := true
+	m.logs.Each(func(line string) {
+		if !first {
+			b.WriteString("\n")
 		}
+		b.WriteString(ansi.Hardwrap(line, m.viewport.Width, true))
+		first = false
+	})
+	return b.String()
+}
 
-		filtered = append(filtered, processed)
+// filterLogLine processes a raw line based on showTimestamps, showPodNames
+// and showColors. Only the pod prefix and timestamp the log stream adds are
+// removed; the service's output is never cut. Lines of services hidden from
+// the all-services view, and lines not matching the search while only
+// matches are shown, are filtered out.
+func (m *Model) filterLogLine(line string) (string, bool) {
+	parsed := parseLogLine(line)
+	if m.hiddenLogSources[parsed.service] {
+		return "", false
 	}
 
-	m.logs = filtered
-	m.viewport.SetContent(strings.Join(m.logs, "\n"))
+	// Sanitize the message alone, so carriage returns in it can't drop the prefixes
+	processed := sanitizeLogLine(parsed.message, m.showColors)
+	if m.showTimestamps && parsed.timestamp != "" {
+		processed = parsed.timestamp + " " + processed
+	}
+	if m.showPodNames && parsed.pod != "" {
+		processed = parsed.pod + processed
+	}
+	if parsed.service != "" {
+		processed = m.renderSourceLabel(parsed.service) + processed
+	}
+	return m.matchLogLine(processed)
 }
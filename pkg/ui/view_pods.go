@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/tools"
+)
+
+// Pods view: the pods of a service with their readiness, restarts, age and
+// node. Opening a pod shows its containers, recent events and environment.
+
+// maxPodEvents bounds the events shown for a pod, most recent kept
+const maxPodEvents = 20
+
+// openPodsView lists the pods of a service
+func (m *Model) openPodsView(service string) tea.Cmd {
+	m.podsService = service
+	m.pods = nil
+	m.podsErr = nil
+	m.selectedPod = 0
+	m.podDetail = nil
+	m.view = PodsView
+	return m.fetchPods(service)
+}
+
+// fetchPods loads a service's pods
+func (m *Model) fetchPods(service string) tea.Cmd {
+	return func() tea.Msg {
+		if m.demo != nil {
+			return podsMsg{service: service, pods: m.demo.Pods(service)}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		pods, err := tools.ListPods(ctx, m.runtime.Base.Defaults.Namespace, logsSelector([]string{service}))
+		return podsMsg{service: service, pods: pods, err: err}
+	}
+}
+
+// fetchPodDetail loads a pod of the service in depth
+func (m *Model) fetchPodDetail(service, name string) tea.Cmd {
+	return func() tea.Msg {
+		if m.demo != nil {
+			pod, err := m.demo.DescribePod(service, name)
+			return podDetailMsg{pod: pod, err: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		pod, err := tools.DescribePod(ctx, m.runtime.Base.Defaults.Namespace, name)
+		return podDetailMsg{pod: pod, err: err}
+	}
+}
+
+func (m *Model) handlePodsMsg(msg podsMsg) (tea.Model, tea.Cmd) {
+	if msg.service != m.podsService {
+		return m, nil
+	}
+	m.pods = msg.pods
+	m.podsErr = msg.err
+	m.selectedPod = min(m.selectedPod, max(0, len(m.pods)-1))
+	return m, nil
+}
+
+func (m *Model) handlePodDetailMsg(msg podDetailMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.podsErr = msg.err
+		return m, nil
+	}
+	m.podsErr = nil
+	m.podDetail = msg.pod
+	m.podsViewport = viewport.New(m.width, max(5, m.height-10))
+	m.podsViewport.SetContent(m.buildPodDetailContent())
+	return m, nil
+}
+
+func (m *Model) handlePodsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		// Close the pod first, then the view
+		if m.podDetail != nil {
+			m.podDetail = nil
+			return m, m.fetchPods(m.podsService)
+		}
+		m.view = HomeView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Refresh):
+		if m.podDetail != nil {
+			return m, m.fetchPodDetail(m.podsService, m.podDetail.Name)
+		}
+		return m, m.fetchPods(m.podsService)
+
+	case key.Matches(msg, m.keys.Up):
+		if m.podDetail != nil {
+			m.podsViewport.ScrollUp(1)
+		} else {
+			m.selectedPod = max(0, m.selectedPod-1)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.podDetail != nil {
+			m.podsViewport.ScrollDown(1)
+		} else {
+			m.selectedPod = max(0, min(len(m.pods)-1, m.selectedPod+1))
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.OpenPod):
+		if m.podDetail == nil && m.selectedPod < len(m.pods) {
+			return m, m.fetchPodDetail(m.podsService, m.pods[m.selectedPod].Name)
+		}
+		return m, nil
+	}
+
+	if m.podDetail != nil {
+		var cmd tea.Cmd
+		m.podsViewport, cmd = m.podsViewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *Model) renderPodsView() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	if m.podDetail != nil {
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("📦 Pod: %s", m.podDetail.Name)))
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render("Use ↑/↓ to scroll • r to refresh • ESC to go back to the pods"))
+		b.WriteString("\n\n")
+		b.WriteString(m.podsViewport.View())
+	} else {
+		b.WriteString(sectionStyle.Render(fmt.Sprintf("📦 Pods: %s", m.serviceLabel(m.podsService))))
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render("Use ↑/↓ to select • enter to inspect • r to refresh • ESC to go back"))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderPodList())
+	}
+
+	if m.podsErr != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.podsErr.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+// renderPodList renders the pods as a table with the selection highlighted
+func (m *Model) renderPodList() string {
+	if len(m.pods) == 0 {
+		if m.podsErr != nil {
+			return ""
+		}
+		return dimStyle.Render("No pods found for this service")
+	}
+
+	nameWidth := len("NAME")
+	for _, pod := range m.pods {
+		nameWidth = max(nameWidth, len(pod.Name))
+	}
+
+	var b strings.Builder
+	b.WriteString(dimStyle.Render(fmt.Sprintf("  %-*s  %-7s  %-9s  %-8s  %-6s  %s", nameWidth, "NAME", "READY", "PHASE", "RESTARTS", "AGE", "NODE")))
+	b.WriteString("\n")
+	for i, pod := range m.pods {
+		ready := "no"
+		if pod.Ready {
+			ready = "yes"
+		}
+		line := fmt.Sprintf("  %-*s  %-7s  %-9s  %-8d  %-6s  %s", nameWidth, pod.Name, ready, pod.Phase, pod.Restarts, formatPodAge(time.Since(pod.Created)), pod.Node)
+
+		switch {
+		case i == m.selectedPod:
+			b.WriteString(m.navItemStyle(true).Width(0).Padding(0).Render(line))
+		case !pod.Ready:
+			b.WriteString(errorStyle.Render(line))
+		default:
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// buildPodDetailContent renders the open pod's containers, events and
+// environment for the viewport
+func (m *Model) buildPodDetailContent() string {
+	pod := m.podDetail
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Phase: %s\n", pod.Phase))
+	if pod.Node != "" {
+		b.WriteString(fmt.Sprintf("Node: %s\n", pod.Node))
+	}
+	if pod.IP != "" {
+		b.WriteString(fmt.Sprintf("IP: %s\n", pod.IP))
+	}
+	b.WriteString(fmt.Sprintf("Age: %s\n", formatPodAge(time.Since(pod.Created))))
+
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("Containers"))
+	b.WriteString("\n")
+	for _, container := range pod.Containers {
+		name := container.Name
+		if container.Init {
+			name += " (init)"
+		}
+		state := container.State
+		if container.Reason != "" {
+			state += ": " + container.Reason
+		}
+		if !container.Since.IsZero() {
+			state += fmt.Sprintf(" for %s", formatPodAge(time.Since(container.Since)))
+		}
+
+		line := fmt.Sprintf("  %s  %s", name, state)
+		if container.Ready || (container.Init && container.State == "terminated" && container.Reason == "Completed") {
+			b.WriteString(successStyle.Render(line))
+		} else {
+			b.WriteString(errorStyle.Render(line))
+		}
+		b.WriteString("\n")
+
+		b.WriteString(dimStyle.Render(fmt.Sprintf("    image: %s", container.Image)))
+		b.WriteString("\n")
+		restarts := fmt.Sprintf("    restarts: %d", container.Restarts)
+		if container.LastTermination != "" {
+			restarts += ", last ended " + container.LastTermination
+		}
+		b.WriteString(dimStyle.Render(restarts))
+		b.WriteString("\n")
+		if container.Message != "" {
+			b.WriteString(dimStyle.Render("    " + container.Message))
+			b.WriteString("\n")
+		}
+
+		if len(container.Env) > 0 {
+			b.WriteString("    env:\n")
+			for _, env := range container.Env {
+				b.WriteString("      " + formatEnvVar(env))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("Events"))
+	b.WriteString("\n")
+	events := pod.Events
+	if len(events) > maxPodEvents {
+		events = events[len(events)-maxPodEvents:]
+	}
+	if len(events) == 0 {
+		b.WriteString(dimStyle.Render("  No recent events"))
+		b.WriteString("\n")
+	}
+	for _, event := range events {
+		line := fmt.Sprintf("  %4s ago  %-9s %s", formatPodAge(time.Since(event.LastSeen)), event.Reason, event.Message)
+		if event.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", event.Count)
+		}
+		if event.Type == "Warning" {
+			b.WriteString(badgeStyle.Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatEnvVar renders an environment variable; values read from secrets
+// and config maps show where they come from instead
+func formatEnvVar(env tools.EnvVar) string {
+	switch {
+	case env.Name == "":
+		return dimStyle.Render(fmt.Sprintf("(every key of %s)", env.Source))
+	case env.Source != "":
+		return env.Name + dimStyle.Render(fmt.Sprintf(" ← %s", env.Source))
+	default:
+		return fmt.Sprintf("%s=%s", env.Name, env.Value)
+	}
+}
+
+// formatPodAge shortens a duration like kubectl's AGE column: 45s, 12m,
+// 3h, 5d
+func formatPodAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(0, int(d.Seconds())))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
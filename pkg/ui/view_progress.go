@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"plat/pkg/orchestrator"
+)
+
+// Progress view: follows the orchestrator's events while the environment
+// starts or stops, one row per service and the events as they arrived
+
+// maxProgressEvents bounds the events kept for the event log
+const maxProgressEvents = 500
+
+// Phases of a service in the progress view
+const (
+	phaseQueued    = "queued"
+	phaseDeploying = "deploying" // Helm install or upgrade running
+	phaseStarting  = "starting"  // Installed, waiting for pods
+	phaseReady     = "ready"
+	phaseFailed    = "failed"
+	phaseRemoving  = "removing"
+	phaseRemoved   = "removed"
+)
+
+// serviceProgress is a service's row in the progress view
+type serviceProgress struct {
+	name    string
+	level   int // Dependency level, -1 until the service deploys
+	phase   string
+	pods    string // Ready containers, e.g. "1/2"
+	message string // What the service waits on, or why it failed
+}
+
+// deployProgress is the state of the progress view for one operation
+type deployProgress struct {
+	opID     int // Operation whose events are followed
+	title    string
+	cluster  string // Latest cluster event
+	services []*serviceProgress
+	byName   map[string]*serviceProgress
+	events   []orchestrator.Event
+}
+
+func newDeployProgress(opID int, title string, serviceNames []string) *deployProgress {
+	p := &deployProgress{
+		opID:   opID,
+		title:  title,
+		byName: make(map[string]*serviceProgress, len(serviceNames)),
+	}
+	for _, name := range serviceNames {
+		row := &serviceProgress{name: name, level: -1, phase: phaseQueued}
+		p.services = append(p.services, row)
+		p.byName[name] = row
+	}
+	return p
+}
+
+// record applies an event to the service rows and keeps it for the log
+func (p *deployProgress) record(event orchestrator.Event) {
+	p.events = append(p.events, event)
+	if len(p.events) > maxProgressEvents {
+		p.events = p.events[len(p.events)-maxProgressEvents:]
+	}
+
+	switch event.Type {
+	case orchestrator.EventClusterCreating, orchestrator.EventClusterReady,
+		orchestrator.EventIngressInstalling, orchestrator.EventIngressReady:
+		p.cluster = event.String()
+		return
+	}
+
+	row, ok := p.byName[event.Service]
+	if !ok {
+		return
+	}
+	switch event.Type {
+	case orchestrator.EventServiceDeploying:
+		row.level = event.Level
+		row.phase = phaseDeploying
+		row.message = ""
+	case orchestrator.EventServiceReady:
+		row.phase = phaseStarting
+	case orchestrator.EventPodsReady:
+		row.phase = phaseReady
+		row.message = ""
+	case orchestrator.EventServiceRemoving:
+		row.phase = phaseRemoving
+	case orchestrator.EventServiceRemoved:
+		row.phase = phaseRemoved
+		row.pods = ""
+		row.message = ""
+	case orchestrator.EventError:
+		row.phase = phaseFailed
+		row.message = event.Err.Error()
+	case orchestrator.EventProgress, orchestrator.EventInstallSlow:
+		if event.Pods != "" {
+			row.pods = event.Pods
+		}
+		row.message = event.Message
+	}
+}
+
+// startProgress follows the operation just enqueued, an up or down, in the
+// progress view
+func (m *Model) startProgress(title string) {
+	m.deployProgress = newDeployProgress(m.nextOpID, title, m.runtime.ListServices())
+	m.openProgressView()
+}
+
+// recordProgress applies an event to the progress view if it comes from
+// the operation the view follows
+func (m *Model) recordProgress(event orchestrator.Event) {
+	op := m.runningOperation()
+	if m.deployProgress == nil || op == nil || op.id != m.deployProgress.opID {
+		return
+	}
+	m.deployProgress.record(event)
+}
+
+func (m *Model) openProgressView() {
+	m.progressViewport = viewport.New(m.width, max(5, m.height-10))
+	m.view = ProgressView
+}
+
+func (m *Model) renderProgressView() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	p := m.deployProgress
+	if p == nil {
+		b.WriteString(dimStyle.Render("No environment operation yet (u to start, d to stop)"))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderFooter())
+		return b.String()
+	}
+
+	b.WriteString(sectionStyle.Render("🚀 " + p.title))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Use ↑/↓ to scroll the events • ESC to go back"))
+	b.WriteString("\n\n")
+
+	if p.cluster != "" {
+		b.WriteString("  " + p.cluster)
+		b.WriteString("\n\n")
+	}
+
+	// Keep the rows visible and give the events what's left, following
+	// new events unless scrolled up
+	following := m.progressViewport.AtBottom()
+	m.progressViewport.Height = max(3, m.height-len(p.services)-14)
+	m.progressViewport.SetContent(m.buildProgressEvents())
+	if following {
+		m.progressViewport.GotoBottom()
+	}
+
+	nameWidth := 0
+	for _, row := range p.services {
+		nameWidth = max(nameWidth, len(row.name))
+	}
+	for _, row := range p.services {
+		b.WriteString(m.renderProgressRow(row, nameWidth))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("Events"))
+	b.WriteString("\n")
+	b.WriteString(m.progressViewport.View())
+
+	b.WriteString("\n\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+// renderProgressRow shows a service's level, phase, pods and latest message
+func (m *Model) renderProgressRow(row *serviceProgress, nameWidth int) string {
+	level := "  "
+	if row.level >= 0 {
+		level = fmt.Sprintf("L%d", row.level)
+	}
+
+	var phase string
+	switch row.phase {
+	case phaseDeploying, phaseStarting, phaseRemoving:
+		phase = activeStyle.Render(fmt.Sprintf("%s %-9s", m.spinner.View(), row.phase))
+	case phaseReady, phaseRemoved:
+		phase = successStyle.Render(fmt.Sprintf("✓ %-9s", row.phase))
+	case phaseFailed:
+		phase = errorStyle.Render(fmt.Sprintf("✗ %-9s", row.phase))
+	default:
+		phase = dimStyle.Render(fmt.Sprintf("· %-9s", row.phase))
+	}
+
+	line := fmt.Sprintf("  %s  %-*s  %s  %-5s", dimStyle.Render(level), nameWidth, row.name, phase, row.pods)
+	if row.message != "" {
+		message := truncate(row.message, max(10, m.width-nameWidth-30))
+		if row.phase == phaseFailed {
+			line += " " + errorStyle.Render(message)
+		} else {
+			line += " " + dimStyle.Render(message)
+		}
+	}
+	return line
+}
+
+// buildProgressEvents lists the events of the operation, oldest first
+func (m *Model) buildProgressEvents() string {
+	var b strings.Builder
+	for _, event := range m.deployProgress.events {
+		text := event.String()
+		if event.Type == orchestrator.EventProgress && event.Service != "" {
+			text = event.Service + ": " + text
+		}
+
+		line := fmt.Sprintf("  %s %s", event.Time.Format("15:04:05"), text)
+		if event.Type == orchestrator.EventError {
+			b.WriteString(errorStyle.Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m *Model) handleProgressKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Progress):
+		m.view = HomeView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.progressViewport.ScrollUp(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.progressViewport.ScrollDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.progressViewport, cmd = m.progressViewport.Update(msg)
+	return m, cmd
+}
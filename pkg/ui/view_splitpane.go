@@ -23,8 +23,17 @@ const navPanelWidth = 30
 func (m *Model) renderNavPanel() string {
 	var b strings.Builder
 
+	if header := m.renderNavHeader(); header != "" {
+		b.WriteString(" " + header)
+		b.WriteString("\n")
+	}
+
 	if len(m.navItems) == 0 {
-		return dimStyle.Render("No items")
+		if m.navFilterActive() {
+			b.WriteString(dimStyle.Render(" No matching services"))
+		} else {
+			b.WriteString(dimStyle.Render("No items"))
+		}
 	}
 
 	for i, item := range m.navItems {
@@ -61,22 +70,36 @@ func (m *Model) formatNavItem(item NavItem) string {
 		if svc := m.getServiceComponent(item.ServiceName); svc != nil {
 			icon := getStatusIcon(svc.Status)
 			name := icon + " " + item.Name
+			if len(m.marked) > 0 {
+				if m.marked[item.ServiceName] {
+					name = markStyle.Render("●") + " " + name
+				} else {
+					name = "  " + name
+				}
+			}
+
+			// Pods readiness is right-aligned; badges share the remaining space
+			podsReady := ""
+			if svcStatus, ok := svc.StatusDetail.(*orchestrator.ServiceStatus); ok && svcStatus != nil && svcStatus.Deployment != nil {
+				podsReady = svcStatus.Deployment.PodsReady
+			}
+			availableWidth := navPanelWidth - 2 - 2 // width - borders - padding
+			if badges := m.serviceBadges(item.ServiceName); len(badges) > 0 {
+				space := availableWidth - lipgloss.Width(name) - lipgloss.Width(podsReady) - 2
+				name += " " + renderBadges(badges, space)
+			}
 
 			// Add pod readiness if available (right-aligned)
-			if svcStatus, ok := svc.StatusDetail.(*orchestrator.ServiceStatus); ok && svcStatus != nil {
-				if svcStatus.Deployment != nil {
-					podsReady := svcStatus.Deployment.PodsReady
-					// Calculate padding to right-align (account for nav width, padding, icon, space)
-					nameLen := lipgloss.Width(name)
-					podsLen := lipgloss.Width(podsReady)
-					availableWidth := navPanelWidth - 2 - 2 // width - borders - padding
-					padding := availableWidth - nameLen - podsLen
-					if padding > 0 {
-						name += strings.Repeat(" ", padding) + dimStyle.Render(podsReady)
-					} else {
-						// If not enough space, just append with single space
-						name += " " + dimStyle.Render(podsReady)
-					}
+			if podsReady != "" {
+				// Calculate padding to right-align (account for nav width, padding, icon, space)
+				nameLen := lipgloss.Width(name)
+				podsLen := lipgloss.Width(podsReady)
+				padding := availableWidth - nameLen - podsLen
+				if padding > 0 {
+					name += strings.Repeat(" ", padding) + dimStyle.Render(podsReady)
+				} else {
+					// If not enough space, just append with single space
+					name += " " + dimStyle.Render(podsReady)
 				}
 			}
 
@@ -189,7 +212,11 @@ func (m *Model) renderClusterDetail() string {
 func (m *Model) renderServiceDetail(serviceName string) string {
 	var b strings.Builder
 
-	b.WriteString(sectionStyle.Render(fmt.Sprintf("Service: %s", serviceName)))
+	title := fmt.Sprintf("Service: %s", serviceName)
+	if label := m.serviceLabel(serviceName); label != serviceName {
+		title = fmt.Sprintf("Service: %s (%s)", label, serviceName)
+	}
+	b.WriteString(sectionStyle.Render(title))
 	b.WriteString("\n\n")
 
 	comp := m.getServiceComponent(serviceName)
@@ -227,12 +254,30 @@ func (m *Model) renderServiceDetail(serviceName string) string {
 			b.WriteString("\n")
 		}
 
+		// In-cluster address, for other services' config
+		if svcStatus.DNSName != "" {
+			b.WriteString(fmt.Sprintf("DNS: %s %s", svcStatus.DNSName, dimStyle.Render("(y to copy)")))
+			b.WriteString("\n")
+		}
+
 		// Chart info (for Helm services)
 		if svcStatus.Chart != "" {
 			b.WriteString(fmt.Sprintf("Chart: %s", svcStatus.Chart))
 			b.WriteString("\n")
 		}
 
+		// Badges with what to do about them
+		if m.insights != nil {
+			if m.insights.Drift[serviceName] {
+				b.WriteString(badgeStyle.Render("Drift: deployed values differ from config (s to redeploy)"))
+				b.WriteString("\n")
+			}
+			if newer, ok := m.insights.Updates[serviceName]; ok {
+				b.WriteString(badgeStyle.Render(fmt.Sprintf("Update: %s is available in the registry", newer)))
+				b.WriteString("\n")
+			}
+		}
+
 		// Updated timestamp
 		if svcStatus.Updated != "" {
 			b.WriteString(dimStyle.Render(fmt.Sprintf("Updated: %s", svcStatus.Updated)))
@@ -259,6 +304,18 @@ func (m *Model) renderServiceDetail(serviceName string) string {
 			b.WriteString(fmt.Sprintf("Containers: %s", readyStyle.Render(dep.PodsReady)))
 			b.WriteString("\n")
 
+			// Replicas and restarts across all pods
+			if dep.Replicas != "" {
+				b.WriteString(fmt.Sprintf("Pods ready: %s %s", dep.Replicas, dimStyle.Render("(i to inspect)")))
+				b.WriteString("\n")
+			}
+			restarts := fmt.Sprintf("Restarts: %d", dep.Restarts)
+			if dep.Flapping {
+				restarts = badgeStyle.Render(restarts + " (flapping: restarted since the last refresh)")
+			}
+			b.WriteString(restarts)
+			b.WriteString("\n")
+
 			// Container state
 			b.WriteString(fmt.Sprintf("State: %s", dep.ContainerState))
 			b.WriteString("\n")
@@ -274,8 +331,35 @@ func (m *Model) renderServiceDetail(serviceName string) string {
 				b.WriteString(fmt.Sprintf("Message: %s", dimStyle.Render(dep.Message)))
 				b.WriteString("\n")
 			}
+
+			// Each replica, worst first, when there are several
+			if len(dep.Pods) > 1 {
+				b.WriteString(fmt.Sprintf("Pods: %s", dep.Summary))
+				b.WriteString("\n")
+				for _, pod := range dep.Pods {
+					line := "  " + pod.Name
+					if pod.Reason != "" {
+						line += " " + pod.Reason
+					}
+					if pod.Restarts > 0 {
+						line += fmt.Sprintf(" (%d restarts)", pod.Restarts)
+					}
+					if pod.Ready {
+						b.WriteString(successStyle.Render(line))
+					} else {
+						b.WriteString(errorStyle.Render(line))
+					}
+					b.WriteString("\n")
+				}
+			}
 		}
 	}
 
+	// Values changed on disk since the services were deployed
+	b.WriteString(m.renderPendingValues(serviceName))
+
+	// Where the configured values come from
+	b.WriteString(m.renderValuesProvenance(serviceName))
+
 	return b.String()
 }
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"plat/pkg/config"
+)
+
+// Values editor view: edit a service's resolved values and apply the changes
+// as an override layer with an immediate release upgrade
+
+func (m *Model) renderValuesView() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	title := sectionStyle.Render(fmt.Sprintf("✏️  Values: %s", m.valuesService))
+	if m.valuesDirty() {
+		title += " " + activeStyle.Render("● modified")
+	}
+	b.WriteString(title)
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Changes are applied as an override layer for this session • ctrl+s to apply • esc to cancel"))
+	b.WriteString("\n\n")
+
+	if m.valuesErr != nil {
+		b.WriteString(errorStyle.Render(m.valuesErr.Error()))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(m.valuesEditor.View())
+
+	// Footer
+	b.WriteString("\n\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+// Values editor key handling. Printable keys go to the editor, so global
+// shortcuts like q are not handled here.
+func (m *Model) handleValuesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.closeValuesEditor()
+		return m, nil
+
+	case key.Matches(msg, m.keys.SaveValues):
+		var edited map[string]interface{}
+		if err := yaml.Unmarshal([]byte(m.valuesEditor.Value()), &edited); err != nil {
+			m.valuesErr = fmt.Errorf("invalid YAML: %w", err)
+			return m, nil
+		}
+		if edited == nil {
+			edited = map[string]interface{}{}
+		}
+
+		serviceName := m.valuesService
+		overrides := config.DiffValues(m.valuesBase, edited)
+		m.closeValuesEditor()
+
+		return m, m.enqueue(fmt.Sprintf("Upgrading %s with edited values", serviceName), m.applyValueOverrides(serviceName, overrides))
+	}
+
+	var cmd tea.Cmd
+	m.valuesEditor, cmd = m.valuesEditor.Update(msg)
+	return m, cmd
+}
+
+// handleValuesLoadedMsg opens the editor once a service's values are resolved
+func (m *Model) handleValuesLoadedMsg(msg valuesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.error = msg.err
+		return m, nil
+	}
+
+	// Show the values the release currently runs with, overrides included
+	current := make(map[string]interface{})
+	values := config.NewValuesManager("")
+	values.ApplyOverrides(current, msg.base)
+	values.ApplyOverrides(current, msg.overrides)
+	pruneNilValues(current)
+
+	data, err := yaml.Marshal(current)
+	if err != nil {
+		m.error = fmt.Errorf("failed to encode values: %w", err)
+		return m, nil
+	}
+
+	editor := textarea.New()
+	editor.ShowLineNumbers = true
+	editor.MaxHeight = 0
+	editor.CharLimit = 0
+	editor.SetWidth(m.width)
+	editor.SetHeight(max(5, m.height-10))
+	editor.SetValue(string(data))
+	editor.CursorStart()
+	for editor.Line() > 0 {
+		editor.CursorUp()
+	}
+
+	m.valuesEditor = editor
+	m.valuesService = msg.service
+	m.valuesBase = msg.base
+	m.valuesOriginal = string(data)
+	m.valuesErr = nil
+	m.view = ValuesEditorView
+
+	return m, m.valuesEditor.Focus()
+}
+
+// closeValuesEditor discards the editor state and returns home
+func (m *Model) closeValuesEditor() {
+	m.valuesEditor.Blur()
+	m.view = HomeView
+	m.valuesService = ""
+	m.valuesBase = nil
+	m.valuesOriginal = ""
+	m.valuesErr = nil
+}
+
+// valuesDirty reports whether the editor content differs from what was loaded
+func (m *Model) valuesDirty() bool {
+	return m.valuesEditor.Value() != m.valuesOriginal
+}
+
+// pruneNilValues removes keys an override layer deleted, so the editor
+// shows the values as helm will see them
+func pruneNilValues(values map[string]interface{}) {
+	for key, value := range values {
+		switch v := value.(type) {
+		case nil:
+			delete(values, key)
+		case map[string]interface{}:
+			pruneNilValues(v)
+		}
+	}
+}
+
+// Values editor commands
+
+func (m *Model) loadServiceValues(serviceName string) tea.Cmd {
+	return func() tea.Msg {
+		base, overrides, err := m.orch.ServiceValues(m.runtime, serviceName)
+		return valuesLoadedMsg{service: serviceName, base: base, overrides: overrides, err: err}
+	}
+}
+
+func (m *Model) applyValueOverrides(serviceName string, overrides map[string]interface{}) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		err := m.orch.ApplyValueOverrides(ctx, m.runtime, serviceName, overrides)
+		if err != nil {
+			return actionCompleteMsg{err: err}
+		}
+
+		if len(overrides) == 0 {
+			return actionCompleteMsg{message: fmt.Sprintf("Service %s upgraded with configured values", serviceName)}
+		}
+		return actionCompleteMsg{message: fmt.Sprintf("Service %s upgraded with edited values", serviceName)}
+	}
+}
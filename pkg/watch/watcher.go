@@ -0,0 +1,245 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long a source tree must stay quiet before a change
+// is reported, so saving many files at once triggers one rebuild
+const DefaultDebounce = 500 * time.Millisecond
+
+// ignoredDirs are never watched: VCS metadata, dependency caches and editor
+// state change often without affecting the image
+var ignoredDirs = map[string]bool{
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+	".idea":        true,
+	".vscode":      true,
+	".plat":        true,
+	"node_modules": true,
+	"__pycache__":  true,
+}
+
+// Change reports that files of a service's source changed
+type Change struct {
+	Service string   // Or the name given to AddFile
+	Paths   []string // Changed files, sorted
+}
+
+// Watcher watches the local source trees of services, and single files,
+// and reports debounced changes per service
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	debounce time.Duration
+
+	mu    sync.Mutex
+	roots map[string]string // Absolute source root -> service
+	files map[string]string // Absolute file path -> name
+}
+
+// NewWatcher creates a watcher that reports changes after the given quiet period
+func NewWatcher(debounce time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	return &Watcher{
+		fs:       fsw,
+		debounce: debounce,
+		roots:    make(map[string]string),
+		files:    make(map[string]string),
+	}, nil
+}
+
+// Add watches a service's source tree recursively
+func (w *Watcher) Add(service, root string) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("invalid source path %s: %w", root, err)
+	}
+
+	w.mu.Lock()
+	w.roots[abs] = service
+	w.mu.Unlock()
+
+	return w.addTree(abs)
+}
+
+// AddFile watches a single file, reporting its changes under name. The
+// file's directory is watched, so editors replacing the file on save and
+// files created later are seen.
+func (w *Watcher) AddFile(name, path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid path %s: %w", path, err)
+	}
+
+	w.mu.Lock()
+	w.files[abs] = name
+	w.mu.Unlock()
+
+	if err := w.fs.Add(filepath.Dir(abs)); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", filepath.Dir(abs), err)
+	}
+	return nil
+}
+
+// addTree adds a directory and its subdirectories to the fsnotify watcher
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != dir && ignoredDirs[entry.Name()] {
+			return filepath.SkipDir
+		}
+		if err := w.fs.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// Run reports changes on the returned channel until ctx is done; the channel
+// is closed when Run stops. Watch errors are passed to onError.
+func (w *Watcher) Run(ctx context.Context, onError func(error)) <-chan Change {
+	changes := make(chan Change, 16)
+
+	go func() {
+		defer close(changes)
+		defer w.fs.Close()
+
+		pending := make(map[string]map[string]bool) // service -> changed paths
+		timer := time.NewTimer(w.debounce)
+		timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event, ok := <-w.fs.Events:
+				if !ok {
+					return
+				}
+				service := w.handleEvent(event)
+				if service == "" {
+					continue
+				}
+				if pending[service] == nil {
+					pending[service] = make(map[string]bool)
+				}
+				pending[service][event.Name] = true
+				timer.Reset(w.debounce)
+
+			case err, ok := <-w.fs.Errors:
+				if !ok {
+					return
+				}
+				if onError != nil {
+					onError(err)
+				}
+
+			case <-timer.C:
+				for _, service := range sortedKeys(pending) {
+					change := Change{Service: service, Paths: sortedKeys(pending[service])}
+					select {
+					case changes <- change:
+					case <-ctx.Done():
+						return
+					}
+				}
+				pending = make(map[string]map[string]bool)
+			}
+		}
+	}()
+
+	return changes
+}
+
+// handleEvent starts watching new directories and returns the service a
+// relevant change belongs to, or "" for changes to ignore
+func (w *Watcher) handleEvent(event fsnotify.Event) string {
+	if event.Op == fsnotify.Chmod {
+		return ""
+	}
+
+	// Single files are watched wherever they are, .plat included
+	if name := w.fileName(event.Name); name != "" {
+		return name
+	}
+
+	if ignoredFile(filepath.Base(event.Name)) {
+		return ""
+	}
+
+	for _, part := range strings.Split(filepath.ToSlash(event.Name), "/") {
+		if ignoredDirs[part] {
+			return ""
+		}
+	}
+
+	if event.Op.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			w.addTree(event.Name)
+		}
+	}
+
+	return w.serviceFor(event.Name)
+}
+
+// fileName returns the name a single watched file was added under, or ""
+func (w *Watcher) fileName(path string) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.files[path]
+}
+
+// serviceFor returns the service whose source root contains path; with
+// nested roots the innermost wins
+func (w *Watcher) serviceFor(path string) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	best, service := "", ""
+	for root, name := range w.roots {
+		if (path == root || strings.HasPrefix(path, root+string(filepath.Separator))) && len(root) > len(best) {
+			best, service = root, name
+		}
+	}
+	return service
+}
+
+// ignoredFile reports editor swap and backup files
+func ignoredFile(name string) bool {
+	return strings.HasSuffix(name, "~") ||
+		strings.HasSuffix(name, ".swp") ||
+		strings.HasSuffix(name, ".swx") ||
+		strings.HasPrefix(name, ".#") ||
+		name == ".DS_Store" ||
+		name == "4913" // vim's write test file
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}